@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"github.com/emochka2007/block-accounting/internal/pkg/config"
+	"github.com/urfave/cli/v2"
+)
+
+// Config builds service config from the global flags
+func Config(c *cli.Context) config.Config {
+	return config.Config{
+		Common: config.CommonConfig{
+			LogLevel:     c.String("log-level"),
+			LogLocal:     c.Bool("log-local"),
+			LogFile:      c.String("log-file"),
+			LogAddSource: c.Bool("log-add-source"),
+			JWTSecret:    []byte(c.String("jwt-secret")),
+
+			JWTSigningKey:       c.String("jwt-signing-key"),
+			JWTVerificationKeys: c.StringSlice("jwt-verification-keys"),
+		},
+		Rest: config.RestConfig{
+			Address: c.String("rest-address"),
+			TLS:     c.Bool("rest-enable-tls"),
+		},
+		DB: config.DBConfig{
+			Host:      c.String("db-host"),
+			EnableSSL: c.Bool("db-enable-ssl"),
+			Database:  c.String("db-database"),
+			User:      c.String("db-user"),
+			Secret:    c.String("db-secret"),
+
+			CacheHost:   c.String("cache-host"),
+			CacheUser:   c.String("cache-user"),
+			CacheSecret: c.String("cache-secret"),
+		},
+		ChainAPI: config.ChainAPIConfig{
+			Host:    c.String("chain-api-url"),
+			ChainID: c.Int64("chain-id"),
+			Timeout: c.Duration("chain-api-timeout"),
+			Signer:  c.String("signer"),
+			RPCURL:  c.String("chain-rpc-url"),
+		},
+		Jobs: config.JobsConfig{
+			Workers:      c.Int("jobs-workers"),
+			PollInterval: c.Duration("jobs-poll-interval"),
+			MaxAttempts:  c.Int("jobs-max-attempts"),
+		},
+		Secrets: config.SecretsConfig{
+			MasterKey:              c.String("master-key"),
+			MasterKeyFile:          c.String("master-key-file"),
+			PreviousMasterKeys:     c.StringSlice("previous-master-keys"),
+			PreviousMasterKeyFiles: c.StringSlice("previous-master-key-files"),
+		},
+		Prices: config.PricesConfig{
+			Source:      c.String("prices-source"),
+			FeedAddress: c.String("prices-feed-address"),
+			Fixture:     c.String("prices-fixture"),
+		},
+	}
+}
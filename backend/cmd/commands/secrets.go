@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/emochka2007/block-accounting/internal/factory"
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	Register(&cli.Command{
+		Name:  "secrets",
+		Usage: "manage encryption of mnemonics and seeds at rest",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "generate-key",
+				Usage:  "print new base64 encoded master key",
+				Action: generateKey,
+			},
+			{
+				Name: "migrate",
+				Usage: "encrypt plaintext secrets and rewrap secrets encrypted with previous master keys. " +
+					"Run before starting the service after upgrade or master key rotation",
+				Action: migrateSecrets,
+			},
+		},
+	})
+}
+
+func generateKey(c *cli.Context) error {
+	key := make([]byte, 32)
+
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("error generate master key. %w", err)
+	}
+
+	fmt.Fprintln(c.App.Writer, base64.StdEncoding.EncodeToString(key))
+
+	return nil
+}
+
+func migrateSecrets(c *cli.Context) error {
+	migrator, cleanup, err := factory.ProvideSecretsMigrator(Config(c))
+	if err != nil {
+		return fmt.Errorf("error create secrets migrator. %w", err)
+	}
+
+	defer cleanup()
+
+	result, err := migrator.Migrate(c.Context)
+	if err != nil {
+		return fmt.Errorf("error migrate secrets. %w", err)
+	}
+
+	fmt.Fprintf(c.App.Writer, "users updated: %d, organizations updated: %d\n", result.Users, result.Organizations)
+
+	return nil
+}
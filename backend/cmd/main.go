@@ -5,10 +5,10 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/emochka2007/block-accounting/cmd/commands"
 	"github.com/emochka2007/block-accounting/internal/factory"
-	"github.com/emochka2007/block-accounting/internal/pkg/config"
 
 	cli "github.com/urfave/cli/v2"
 )
@@ -37,10 +37,67 @@ func main() {
 			&cli.StringFlag{
 				Name: "jwt-secret",
 			},
+			&cli.StringFlag{
+				Name:  "jwt-signing-key",
+				Usage: "path to Ed25519 or ECDSA P-256 private key in PEM, overrides jwt-secret for signing",
+			},
+			&cli.StringSliceFlag{
+				Name:  "jwt-verification-keys",
+				Usage: "paths to retired JWT keys in PEM still accepted for verification",
+			},
 			&cli.StringFlag{
 				Name:  "chain-api-url",
 				Value: "http://localhost:3000",
 			},
+			&cli.DurationFlag{
+				Name:  "chain-api-timeout",
+				Value: 5 * time.Minute,
+			},
+			&cli.StringFlag{
+				Name:    "master-key",
+				Usage:   "base64 encoded 32 bytes key encrypting mnemonics and seeds at rest",
+				EnvVars: []string{"BLOCKD_MASTER_KEY"},
+			},
+			&cli.StringFlag{
+				Name:  "master-key-file",
+				Usage: "path to file with base64 encoded master key",
+			},
+			&cli.StringSliceFlag{
+				Name:    "previous-master-keys",
+				Usage:   "rotated out master keys still used for decryption",
+				EnvVars: []string{"BLOCKD_PREVIOUS_MASTER_KEYS"},
+			},
+			&cli.StringSliceFlag{
+				Name:  "previous-master-key-files",
+				Usage: "paths to rotated out master keys",
+			},
+			&cli.StringFlag{
+				Name:  "signer",
+				Usage: "remote to sign multisig transactions by the chain-api or local to sign them in process",
+				Value: "remote",
+			},
+			&cli.StringFlag{
+				Name:  "chain-rpc-url",
+				Usage: "ethereum node url, required by the local signer",
+			},
+			&cli.Int64Flag{
+				Name:  "chain-id",
+				Usage: "chain id of the network multisigs are deployed to and wallet sign in messages are issued for",
+				Value: 80002,
+			},
+			&cli.StringFlag{
+				Name:  "prices-source",
+				Usage: "chain-api to read ETH price from the payroll price feed or fixture to serve rates from a file",
+				Value: "chain-api",
+			},
+			&cli.StringFlag{
+				Name:  "prices-feed-address",
+				Usage: "payroll contract address whose price feed is read by the chain-api prices source",
+			},
+			&cli.StringFlag{
+				Name:  "prices-fixture",
+				Usage: "path to JSON file with rates keyed by currency pair, e.g. {\"ETH/USD\": \"3000\"}",
+			},
 
 			// rest
 			&cli.StringFlag{
@@ -83,40 +140,26 @@ func main() {
 			&cli.StringFlag{
 				Name: "cache-secret",
 			},
+
+			// jobs
+			&cli.IntFlag{
+				Name:  "jobs-workers",
+				Value: 4,
+			},
+			&cli.DurationFlag{
+				Name:  "jobs-poll-interval",
+				Value: 2 * time.Second,
+			},
+			&cli.IntFlag{
+				Name:  "jobs-max-attempts",
+				Value: 5,
+			},
 		},
 		Action: func(c *cli.Context) error {
 			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 			defer stop()
 
-			config := config.Config{
-				Common: config.CommonConfig{
-					LogLevel:     c.String("log-level"),
-					LogLocal:     c.Bool("log-local"),
-					LogFile:      c.String("log-file"),
-					LogAddSource: c.Bool("log-add-source"),
-					JWTSecret:    []byte(c.String("jwt-secret")),
-				},
-				Rest: config.RestConfig{
-					Address: c.String("rest-address"),
-					TLS:     c.Bool("rest-enable-tls"),
-				},
-				DB: config.DBConfig{
-					Host:      c.String("db-host"),
-					EnableSSL: c.Bool("db-enable-ssl"),
-					Database:  c.String("db-database"),
-					User:      c.String("db-user"),
-					Secret:    c.String("db-secret"),
-
-					CacheHost:   c.String("cache-host"),
-					CacheUser:   c.String("cache-user"),
-					CacheSecret: c.String("cache-secret"),
-				},
-				ChainAPI: config.ChainAPIConfig{
-					Host: c.String("chain-api-url"),
-				},
-			}
-
-			service, cleanup, err := factory.ProvideService(config)
+			service, cleanup, err := factory.ProvideService(commands.Config(c))
 			if err != nil {
 				panic(err)
 			}
@@ -1,19 +1,46 @@
 package factory
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 
+	"github.com/emochka2007/block-accounting/internal/pkg/chainapi"
 	"github.com/emochka2007/block-accounting/internal/pkg/config"
+	"github.com/emochka2007/block-accounting/internal/pkg/jwks"
+	pricesource "github.com/emochka2007/block-accounting/internal/pkg/prices"
+	"github.com/emochka2007/block-accounting/internal/pkg/signer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/agreements"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/assets"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/invites"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jwt"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/ledger"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/licenses"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/payouts"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/prices"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/reports"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/siwe"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/transactions"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/users"
+	arepo "github.com/emochka2007/block-accounting/internal/usecase/repository/agreements"
+	assetsrepo "github.com/emochka2007/block-accounting/internal/usecase/repository/assets"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/auth"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/cache"
+	jrepo "github.com/emochka2007/block-accounting/internal/usecase/repository/jobs"
+	ledgerrepo "github.com/emochka2007/block-accounting/internal/usecase/repository/ledger"
+	lrepo "github.com/emochka2007/block-accounting/internal/usecase/repository/licenses"
 	orepo "github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	prepo "github.com/emochka2007/block-accounting/internal/usecase/repository/payouts"
+	pricesrepo "github.com/emochka2007/block-accounting/internal/usecase/repository/prices"
 	txRepo "github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
 	urepo "github.com/emochka2007/block-accounting/internal/usecase/repository/users"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 func provideUsersInteractor(
@@ -24,40 +51,348 @@ func provideUsersInteractor(
 	return users.NewUsersInteractor(log.WithGroup("users-interactor"), usersRepo, chainInteractor)
 }
 
+func provideJWTKeyRing(c config.Config) (*jwks.KeyRing, error) {
+	var (
+		signing *jwks.Key
+		err     error
+	)
+
+	if c.Common.JWTSigningKey != "" {
+		if signing, err = jwks.LoadKey(c.Common.JWTSigningKey); err != nil {
+			return nil, fmt.Errorf("error load jwt signing key. %w", err)
+		}
+	}
+
+	verification := make([]*jwks.Key, len(c.Common.JWTVerificationKeys))
+
+	for i, path := range c.Common.JWTVerificationKeys {
+		if verification[i], err = jwks.LoadKey(path); err != nil {
+			return nil, fmt.Errorf("error load jwt verification key. %w", err)
+		}
+	}
+
+	if signing == nil && len(c.Common.JWTSecret) == 0 {
+		return nil, errors.New("error either jwt-signing-key or jwt-secret required")
+	}
+
+	return jwks.NewKeyRing(signing, verification...)
+}
+
 func provideJWTInteractor(
+	log *slog.Logger,
 	c config.Config,
+	keys *jwks.KeyRing,
+	cache cache.Cache,
 	usersInteractor users.UsersInteractor,
 	authRepository auth.Repository,
 ) jwt.JWTInteractor {
-	return jwt.NewJWT(c.Common.JWTSecret, usersInteractor, authRepository)
+	return jwt.NewJWT(
+		log.WithGroup("jwt-interactor"),
+		c.Common.JWTSecret,
+		keys,
+		cache,
+		usersInteractor,
+		authRepository,
+	)
+}
+
+func provideSIWEInteractor(
+	log *slog.Logger,
+	c config.Config,
+	authRepo auth.Repository,
+) siwe.SIWEInteractor {
+	return siwe.NewSIWEInteractor(log.WithGroup("siwe-interactor"), c.ChainAPI.ChainID, authRepo)
+}
+
+func provideAuthorizer(
+	log *slog.Logger,
+	orgRepo orepo.Repository,
+) authorizer.Authorizer {
+	return authorizer.NewAuthorizer(log.WithGroup("authorizer"), orgRepo)
+}
+
+func provideInvitesInteractor(
+	log *slog.Logger,
+	authRepo auth.Repository,
+	orgRepo orepo.Repository,
+	usersRepo urepo.Repository,
+	authorizer authorizer.Authorizer,
+) invites.InvitesInteractor {
+	return invites.NewInvitesInteractor(
+		log.WithGroup("invites-interactor"),
+		authRepo,
+		orgRepo,
+		usersRepo,
+		authorizer,
+	)
 }
 
 func provideOrganizationsInteractor(
 	log *slog.Logger,
 	orgRepo orepo.Repository,
 	cache cache.Cache,
+	authorizer authorizer.Authorizer,
 ) organizations.OrganizationsInteractor {
-	return organizations.NewOrganizationsInteractor(log, orgRepo, cache)
+	return organizations.NewOrganizationsInteractor(log, orgRepo, cache, authorizer)
 }
 
 func provideTxInteractor(
 	log *slog.Logger,
 	txRepo txRepo.Repository,
+	usersRepo urepo.Repository,
 	orgInteractor organizations.OrganizationsInteractor,
 	chainInteractor chain.ChainInteractor,
+	jobsInteractor jobs.JobsInteractor,
+	confirmationsInteractor confirmations.ConfirmationsInteractor,
+	ledgerInteractor ledger.LedgerInteractor,
+	assetsInteractor assets.AssetsInteractor,
+	pricesInteractor prices.PricesInteractor,
+	authorizer authorizer.Authorizer,
 ) transactions.TransactionsInteractor {
 	return transactions.NewTransactionsInteractor(
 		log.WithGroup("transaction-interactor"),
 		txRepo,
+		usersRepo,
 		orgInteractor,
 		chainInteractor,
+		jobsInteractor,
+		confirmationsInteractor,
+		ledgerInteractor,
+		assetsInteractor,
+		pricesInteractor,
+		authorizer,
 	)
 }
 
+func provideConfirmationsInteractor(
+	log *slog.Logger,
+	txRepository txRepo.Repository,
+	authorizer authorizer.Authorizer,
+) confirmations.ConfirmationsInteractor {
+	return confirmations.NewConfirmationsInteractor(
+		log.WithGroup("confirmations-interactor"),
+		txRepository,
+		authorizer,
+	)
+}
+
+func provideLedgerInteractor(
+	log *slog.Logger,
+	ledgerRepo ledgerrepo.Repository,
+	pricesInteractor prices.PricesInteractor,
+	authorizer authorizer.Authorizer,
+) ledger.LedgerInteractor {
+	return ledger.NewLedgerInteractor(
+		log.WithGroup("ledger-interactor"),
+		ledgerRepo,
+		pricesInteractor,
+		authorizer,
+	)
+}
+
+func providePricesSource(c config.Config, client *chainapi.Client) (pricesource.Source, error) {
+	switch c.Prices.Source {
+	case "", pricesource.KindChainAPI:
+		if c.Prices.FeedAddress != "" && !common.IsHexAddress(c.Prices.FeedAddress) {
+			return nil, fmt.Errorf("error invalid prices feed address %s", c.Prices.FeedAddress)
+		}
+
+		return pricesource.NewChainAPISource(client, common.HexToAddress(c.Prices.FeedAddress)), nil
+	case pricesource.KindFixture:
+		if c.Prices.Fixture == "" {
+			return nil, errors.New("error prices-fixture required by the fixture prices source")
+		}
+
+		return pricesource.LoadFixture(c.Prices.Fixture)
+	default:
+		return nil, fmt.Errorf("error unknown prices source %s", c.Prices.Source)
+	}
+}
+
+func providePricesInteractor(
+	log *slog.Logger,
+	source pricesource.Source,
+	pricesRepo pricesrepo.Repository,
+) prices.PricesInteractor {
+	return prices.NewPricesInteractor(
+		log.WithGroup("prices-interactor"),
+		source,
+		pricesRepo,
+	)
+}
+
+func provideAssetsInteractor(
+	log *slog.Logger,
+	c config.Config,
+	assetsRepo assetsrepo.Repository,
+	authorizer authorizer.Authorizer,
+) assets.AssetsInteractor {
+	return assets.NewAssetsInteractor(
+		log.WithGroup("assets-interactor"),
+		c.ChainAPI.ChainID,
+		assetsRepo,
+		authorizer,
+	)
+}
+
+func provideReportsInteractor(
+	log *slog.Logger,
+	ledgerRepo ledgerrepo.Repository,
+	authorizer authorizer.Authorizer,
+) reports.ReportsInteractor {
+	return reports.NewReportsInteractor(
+		log.WithGroup("reports-interactor"),
+		ledgerRepo,
+		authorizer,
+	)
+}
+
+func provideChainAPIClient(c config.Config, log *slog.Logger) *chainapi.Client {
+	return chainapi.NewClient(
+		c.ChainAPI.Host,
+		chainapi.WithLogger(log.WithGroup("chain-api")),
+		chainapi.WithTimeout(c.ChainAPI.Timeout),
+	)
+}
+
+func provideSigners(
+	log *slog.Logger,
+	c config.Config,
+	client *chainapi.Client,
+) (signer.Provider, func(), error) {
+	switch c.ChainAPI.Signer {
+	case "", signer.KindRemote:
+		return signer.NewRemoteProvider(client), func() {}, nil
+	case signer.KindLocal:
+		if c.ChainAPI.RPCURL == "" {
+			return nil, nil, errors.New("error chain-rpc-url required by the local signer")
+		}
+
+		backend, err := ethclient.Dial(c.ChainAPI.RPCURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error connect to ethereum node. %w", err)
+		}
+
+		return signer.NewLocalProvider(
+			log.WithGroup("local-signer"),
+			backend,
+			c.ChainAPI.ChainID,
+		), backend.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("error unknown signer %s", c.ChainAPI.Signer)
+	}
+}
+
 func provideChainInteractor(
 	log *slog.Logger,
-	config config.Config,
+	client *chainapi.Client,
+	signers signer.Provider,
 	txRepository txRepo.Repository,
+	usersRepo urepo.Repository,
+	orgRepo orepo.Repository,
+	jobsInteractor jobs.JobsInteractor,
+	confirmationsInteractor confirmations.ConfirmationsInteractor,
+	ledgerInteractor ledger.LedgerInteractor,
+	authorizer authorizer.Authorizer,
 ) chain.ChainInteractor {
-	return chain.NewChainInteractor(log, config, txRepository)
+	return chain.NewChainInteractor(
+		log.WithGroup("chain-interactor"),
+		client,
+		signers,
+		txRepository,
+		usersRepo,
+		orgRepo,
+		jobsInteractor,
+		confirmationsInteractor,
+		ledgerInteractor,
+		authorizer,
+	)
+}
+
+func provideJobsInteractor(
+	log *slog.Logger,
+	c config.Config,
+	jobsRepo jrepo.Repository,
+	orgInteractor organizations.OrganizationsInteractor,
+) jobs.JobsInteractor {
+	return jobs.NewJobsInteractor(
+		log.WithGroup("jobs-interactor"),
+		c.Jobs,
+		jobsRepo,
+		orgInteractor,
+	)
+}
+
+func providePayoutsInteractor(
+	log *slog.Logger,
+	payoutsRepo prepo.Repository,
+	txRepository txRepo.Repository,
+	usersRepo urepo.Repository,
+	orgInteractor organizations.OrganizationsInteractor,
+	chainInteractor chain.ChainInteractor,
+	jobsInteractor jobs.JobsInteractor,
+	confirmationsInteractor confirmations.ConfirmationsInteractor,
+	ledgerInteractor ledger.LedgerInteractor,
+	authorizer authorizer.Authorizer,
+) payouts.PayoutsInteractor {
+	return payouts.NewPayoutsInteractor(
+		log.WithGroup("payouts-interactor"),
+		payoutsRepo,
+		txRepository,
+		usersRepo,
+		orgInteractor,
+		chainInteractor,
+		jobsInteractor,
+		confirmationsInteractor,
+		ledgerInteractor,
+		authorizer,
+	)
+}
+
+func provideLicensesInteractor(
+	log *slog.Logger,
+	licensesRepo lrepo.Repository,
+	txRepository txRepo.Repository,
+	usersRepo urepo.Repository,
+	orgInteractor organizations.OrganizationsInteractor,
+	chainInteractor chain.ChainInteractor,
+	jobsInteractor jobs.JobsInteractor,
+	confirmationsInteractor confirmations.ConfirmationsInteractor,
+	authorizer authorizer.Authorizer,
+) licenses.LicenseInteractor {
+	return licenses.NewLicenseInteractor(
+		log.WithGroup("licenses-interactor"),
+		licensesRepo,
+		txRepository,
+		usersRepo,
+		orgInteractor,
+		chainInteractor,
+		jobsInteractor,
+		confirmationsInteractor,
+		authorizer,
+	)
+}
+
+func provideAgreementsInteractor(
+	log *slog.Logger,
+	agreementsRepo arepo.Repository,
+	txRepository txRepo.Repository,
+	usersRepo urepo.Repository,
+	orgInteractor organizations.OrganizationsInteractor,
+	chainInteractor chain.ChainInteractor,
+	jobsInteractor jobs.JobsInteractor,
+	confirmationsInteractor confirmations.ConfirmationsInteractor,
+	authorizer authorizer.Authorizer,
+) agreements.AgreementsInteractor {
+	return agreements.NewAgreementsInteractor(
+		log.WithGroup("agreements-interactor"),
+		agreementsRepo,
+		txRepository,
+		usersRepo,
+		orgInteractor,
+		chainInteractor,
+		jobsInteractor,
+		confirmationsInteractor,
+		authorizer,
+	)
 }
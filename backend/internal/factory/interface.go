@@ -11,12 +11,21 @@ import (
 	"github.com/emochka2007/block-accounting/internal/interface/rest/presenters"
 	"github.com/emochka2007/block-accounting/internal/pkg/config"
 	"github.com/emochka2007/block-accounting/internal/pkg/logger"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/agreements"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/assets"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/invites"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jwt"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/ledger"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/licenses"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/payouts"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/reports"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/siwe"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/transactions"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/users"
-	"github.com/emochka2007/block-accounting/internal/usecase/repository/auth"
 )
 
 var interfaceSet wire.ProviderSet = wire.NewSet(
@@ -25,9 +34,25 @@ var interfaceSet wire.ProviderSet = wire.NewSet(
 	provideControllers,
 	provideTxController,
 	provideParticipantsController,
+	provideJobsController,
+	providePayoutsController,
+	provideLicensesController,
+	provideAgreementsController,
+	provideConfirmationsController,
+	provideLedgerController,
+	provideAssetsController,
+	provideReportsController,
 
 	provideAuthPresenter,
 	provideOrganizationsPresenter,
+	provideJobsPresenter,
+	providePayoutsPresenter,
+	provideLicensesPresenter,
+	provideAgreementsPresenter,
+	provideConfirmationsPresenter,
+	provideLedgerPresenter,
+	provideAssetsPresenter,
+	provideReportsPresenter,
 )
 
 func provideLogger(c config.Config) *slog.Logger {
@@ -63,21 +88,55 @@ func provideOrganizationsPresenter() presenters.OrganizationsPresenter {
 	return presenters.NewOrganizationsPresenter()
 }
 
+func provideJobsPresenter() presenters.JobsPresenter {
+	return presenters.NewJobsPresenter()
+}
+
+func providePayoutsPresenter() presenters.PayoutsPresenter {
+	return presenters.NewPayoutsPresenter()
+}
+
+func provideLicensesPresenter() presenters.LicensesPresenter {
+	return presenters.NewLicensesPresenter()
+}
+
+func provideAgreementsPresenter() presenters.AgreementsPresenter {
+	return presenters.NewAgreementsPresenter()
+}
+
+func provideConfirmationsPresenter() presenters.ConfirmationsPresenter {
+	return presenters.NewConfirmationsPresenter()
+}
+
+func provideLedgerPresenter() presenters.LedgerPresenter {
+	return presenters.NewLedgerPresenter()
+}
+
+func provideAssetsPresenter() presenters.AssetsPresenter {
+	return presenters.NewAssetsPresenter()
+}
+
+func provideReportsPresenter() presenters.ReportsPresenter {
+	return presenters.NewReportsPresenter()
+}
+
 func provideAuthController(
 	log *slog.Logger,
 	usersInteractor users.UsersInteractor,
 	authPresenter presenters.AuthPresenter,
 	jwtInteractor jwt.JWTInteractor,
-	repo auth.Repository,
 	orgInteractor organizations.OrganizationsInteractor,
+	invitesInteractor invites.InvitesInteractor,
+	siweInteractor siwe.SIWEInteractor,
 ) controllers.AuthController {
 	return controllers.NewAuthController(
 		log.WithGroup("auth-controller"),
 		authPresenter,
 		usersInteractor,
 		jwtInteractor,
-		repo,
 		orgInteractor,
+		invitesInteractor,
+		siweInteractor,
 	)
 }
 
@@ -98,11 +157,13 @@ func provideTxController(
 	txInteractor transactions.TransactionsInteractor,
 	chainInteractor chain.ChainInteractor,
 	organizationsInteractor organizations.OrganizationsInteractor,
+	jobsPresenter presenters.JobsPresenter,
 ) controllers.TransactionsController {
 	return controllers.NewTransactionsController(
 		log.WithGroup("transactions-controller"),
 		txInteractor,
 		presenters.NewTransactionsPresenter(),
+		jobsPresenter,
 		chainInteractor,
 		organizationsInteractor,
 	)
@@ -121,12 +182,118 @@ func provideParticipantsController(
 	)
 }
 
+func provideJobsController(
+	log *slog.Logger,
+	jobsInteractor jobs.JobsInteractor,
+	presenter presenters.JobsPresenter,
+) controllers.JobsController {
+	return controllers.NewJobsController(
+		log.WithGroup("jobs-controller"),
+		jobsInteractor,
+		presenter,
+	)
+}
+
+func providePayoutsController(
+	log *slog.Logger,
+	payoutsInteractor payouts.PayoutsInteractor,
+	presenter presenters.PayoutsPresenter,
+	jobsPresenter presenters.JobsPresenter,
+) controllers.PayoutsController {
+	return controllers.NewPayoutsController(
+		log.WithGroup("payouts-controller"),
+		payoutsInteractor,
+		presenter,
+		jobsPresenter,
+	)
+}
+
+func provideLicensesController(
+	log *slog.Logger,
+	licensesInteractor licenses.LicenseInteractor,
+	presenter presenters.LicensesPresenter,
+) controllers.LicensesController {
+	return controllers.NewLicensesController(
+		log.WithGroup("licenses-controller"),
+		licensesInteractor,
+		presenter,
+	)
+}
+
+func provideAgreementsController(
+	log *slog.Logger,
+	agreementsInteractor agreements.AgreementsInteractor,
+	presenter presenters.AgreementsPresenter,
+) controllers.AgreementsController {
+	return controllers.NewAgreementsController(
+		log.WithGroup("agreements-controller"),
+		agreementsInteractor,
+		presenter,
+	)
+}
+
+func provideConfirmationsController(
+	log *slog.Logger,
+	confirmationsInteractor confirmations.ConfirmationsInteractor,
+	presenter presenters.ConfirmationsPresenter,
+) controllers.ConfirmationsController {
+	return controllers.NewConfirmationsController(
+		log.WithGroup("confirmations-controller"),
+		confirmationsInteractor,
+		presenter,
+	)
+}
+
+func provideLedgerController(
+	log *slog.Logger,
+	ledgerInteractor ledger.LedgerInteractor,
+	presenter presenters.LedgerPresenter,
+) controllers.LedgerController {
+	return controllers.NewLedgerController(
+		log.WithGroup("ledger-controller"),
+		ledgerInteractor,
+		presenter,
+	)
+}
+
+func provideAssetsController(
+	log *slog.Logger,
+	assetsInteractor assets.AssetsInteractor,
+	presenter presenters.AssetsPresenter,
+) controllers.AssetsController {
+	return controllers.NewAssetsController(
+		log.WithGroup("assets-controller"),
+		assetsInteractor,
+		presenter,
+	)
+}
+
+func provideReportsController(
+	log *slog.Logger,
+	reportsInteractor reports.ReportsInteractor,
+	presenter presenters.ReportsPresenter,
+) controllers.ReportsController {
+	return controllers.NewReportsController(
+		log.WithGroup("reports-controller"),
+		reportsInteractor,
+		presenter,
+	)
+}
+
 func provideControllers(
 	log *slog.Logger,
 	authController controllers.AuthController,
 	orgController controllers.OrganizationsController,
 	txController controllers.TransactionsController,
 	participantsController controllers.ParticipantsController,
+	jobsController controllers.JobsController,
+	payoutsController controllers.PayoutsController,
+	licensesController controllers.LicensesController,
+	agreementsController controllers.AgreementsController,
+	confirmationsController controllers.ConfirmationsController,
+	ledgerController controllers.LedgerController,
+	assetsController controllers.AssetsController,
+	reportsController controllers.ReportsController,
 ) *controllers.RootController {
 	return controllers.NewRootController(
 		controllers.NewPingController(log.WithGroup("ping-controller")),
@@ -134,6 +301,14 @@ func provideControllers(
 		orgController,
 		txController,
 		participantsController,
+		jobsController,
+		payoutsController,
+		licensesController,
+		agreementsController,
+		confirmationsController,
+		ledgerController,
+		assetsController,
+		reportsController,
 	)
 }
 
@@ -2,36 +2,131 @@ package factory
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"log/slog"
 
 	"github.com/emochka2007/block-accounting/internal/pkg/config"
+	"github.com/emochka2007/block-accounting/internal/pkg/secrets"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/agreements"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/assets"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/auth"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/cache"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/encryption"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/jobs"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/ledger"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/licenses"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/payouts"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/prices"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/users"
 	"github.com/redis/go-redis/v9"
 )
 
-func provideUsersRepository(db *sql.DB) users.Repository {
-	return users.NewRepository(db)
+func provideSecretsKeeper(c config.Config) (*secrets.Keeper, error) {
+	var (
+		active *secrets.MasterKey
+		err    error
+	)
+
+	switch {
+	case c.Secrets.MasterKey != "" && c.Secrets.MasterKeyFile != "":
+		return nil, errors.New("error either master-key or master-key-file expected, not both")
+	case c.Secrets.MasterKey != "":
+		active, err = secrets.ParseMasterKey(c.Secrets.MasterKey)
+	case c.Secrets.MasterKeyFile != "":
+		active, err = secrets.LoadMasterKey(c.Secrets.MasterKeyFile)
+	default:
+		return nil, errors.New("error master-key or master-key-file required")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error load master key. %w", err)
+	}
+
+	previous := make([]*secrets.MasterKey, 0, len(c.Secrets.PreviousMasterKeys)+len(c.Secrets.PreviousMasterKeyFiles))
+
+	for _, v := range c.Secrets.PreviousMasterKeys {
+		mk, err := secrets.ParseMasterKey(v)
+		if err != nil {
+			return nil, fmt.Errorf("error load previous master key. %w", err)
+		}
+
+		previous = append(previous, mk)
+	}
+
+	for _, path := range c.Secrets.PreviousMasterKeyFiles {
+		mk, err := secrets.LoadMasterKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("error load previous master key. %w", err)
+		}
+
+		previous = append(previous, mk)
+	}
+
+	return secrets.NewKeeper(active, previous...)
+}
+
+func provideSecretsMigrator(
+	log *slog.Logger,
+	db *sql.DB,
+	keeper *secrets.Keeper,
+) encryption.Migrator {
+	return encryption.NewMigrator(log.WithGroup("secrets-migrator"), db, keeper)
+}
+
+func provideUsersRepository(db *sql.DB, keeper *secrets.Keeper) users.Repository {
+	return users.NewRepository(db, keeper)
 }
 
 func provideOrganizationsRepository(
 	db *sql.DB,
 	uRepo users.Repository,
+	keeper *secrets.Keeper,
 ) organizations.Repository {
-	return organizations.NewRepository(db, uRepo)
+	return organizations.NewRepository(db, uRepo, keeper)
 }
 
-func provideTxRepository(db *sql.DB, or organizations.Repository) transactions.Repository {
-	return transactions.NewRepository(db, or)
+func provideTxRepository(
+	db *sql.DB,
+	or organizations.Repository,
+	keeper *secrets.Keeper,
+) transactions.Repository {
+	return transactions.NewRepository(db, or, keeper)
 }
 
 func provideAuthRepository(db *sql.DB) auth.Repository {
 	return auth.NewRepository(db)
 }
 
+func provideJobsRepository(db *sql.DB) jobs.Repository {
+	return jobs.NewRepository(db)
+}
+
+func providePayoutsRepository(db *sql.DB) payouts.Repository {
+	return payouts.NewRepository(db)
+}
+
+func provideLicensesRepository(db *sql.DB) licenses.Repository {
+	return licenses.NewRepository(db)
+}
+
+func provideAgreementsRepository(db *sql.DB) agreements.Repository {
+	return agreements.NewRepository(db)
+}
+
+func provideLedgerRepository(db *sql.DB) ledger.Repository {
+	return ledger.NewRepository(db)
+}
+
+func provideAssetsRepository(db *sql.DB) assets.Repository {
+	return assets.NewRepository(db)
+}
+
+func providePricesRepository(db *sql.DB) prices.Repository {
+	return prices.NewRepository(db)
+}
+
 func provideRedisConnection(c config.Config) (*redis.Client, func()) {
 	r := redis.NewClient(&redis.Options{
 		Addr:     c.DB.CacheHost,
@@ -7,6 +7,7 @@ import (
 	"github.com/emochka2007/block-accounting/internal/pkg/config"
 	"github.com/emochka2007/block-accounting/internal/service"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/encryption"
 	"github.com/google/wire"
 )
 
@@ -16,15 +17,39 @@ func ProvideService(c config.Config) (service.Service, func(), error) {
 		provideRedisConnection,
 		provideLogger,
 		provideRedisCache,
+		provideSecretsKeeper,
 		provideUsersRepository,
 		provideUsersInteractor,
 		provideTxRepository,
 		provideOrganizationsRepository,
+		provideAuthorizer,
+		provideInvitesInteractor,
 		provideOrganizationsInteractor,
+		provideConfirmationsInteractor,
 		provideTxInteractor,
+		provideChainAPIClient,
+		provideSigners,
 		provideChainInteractor,
+		provideJobsRepository,
+		provideJobsInteractor,
+		providePayoutsRepository,
+		providePayoutsInteractor,
+		provideLicensesRepository,
+		provideLicensesInteractor,
+		provideAgreementsRepository,
+		provideAgreementsInteractor,
+		provideLedgerRepository,
+		provideLedgerInteractor,
+		provideAssetsRepository,
+		provideAssetsInteractor,
+		providePricesRepository,
+		providePricesSource,
+		providePricesInteractor,
+		provideReportsInteractor,
 		provideAuthRepository,
+		provideJWTKeyRing,
 		provideJWTInteractor,
+		provideSIWEInteractor,
 		interfaceSet,
 		provideRestServer,
 		service.NewService,
@@ -32,3 +57,14 @@ func ProvideService(c config.Config) (service.Service, func(), error) {
 
 	return &service.ServiceImpl{}, func() {}, nil
 }
+
+func ProvideSecretsMigrator(c config.Config) (encryption.Migrator, func(), error) {
+	wire.Build(
+		repository.ProvideDatabaseConnection,
+		provideLogger,
+		provideSecretsKeeper,
+		provideSecretsMigrator,
+	)
+
+	return nil, func() {}, nil
+}
@@ -10,38 +10,113 @@ import (
 	"github.com/emochka2007/block-accounting/internal/pkg/config"
 	"github.com/emochka2007/block-accounting/internal/service"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/encryption"
 )
 
 // Injectors from wire.go:
 
 func ProvideService(c config.Config) (service.Service, func(), error) {
 	logger := provideLogger(c)
+	keyRing, err := provideJWTKeyRing(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	keeper, err := provideSecretsKeeper(c)
+	if err != nil {
+		return nil, nil, err
+	}
 	db, cleanup, err := repository.ProvideDatabaseConnection(c)
 	if err != nil {
 		return nil, nil, err
 	}
-	usersRepository := provideUsersRepository(db)
-	organizationsRepository := provideOrganizationsRepository(db, usersRepository)
-	transactionsRepository := provideTxRepository(db, organizationsRepository)
-	chainInteractor := provideChainInteractor(logger, c, transactionsRepository)
+	usersRepository := provideUsersRepository(db, keeper)
+	organizationsRepository := provideOrganizationsRepository(db, usersRepository, keeper)
+	transactionsRepository := provideTxRepository(db, organizationsRepository, keeper)
+	jobsRepository := provideJobsRepository(db)
+	payoutsRepository := providePayoutsRepository(db)
+	licensesRepository := provideLicensesRepository(db)
+	agreementsRepository := provideAgreementsRepository(db)
+	ledgerRepository := provideLedgerRepository(db)
+	assetsRepository := provideAssetsRepository(db)
+	pricesRepository := providePricesRepository(db)
+	client, cleanup2 := provideRedisConnection(c)
+	cache := provideRedisCache(client, logger)
+	authorizerAuthorizer := provideAuthorizer(logger, organizationsRepository)
+	organizationsInteractor := provideOrganizationsInteractor(logger, organizationsRepository, cache, authorizerAuthorizer)
+	jobsInteractor := provideJobsInteractor(logger, c, jobsRepository, organizationsInteractor)
+	chainapiClient := provideChainAPIClient(c, logger)
+	confirmationsInteractor := provideConfirmationsInteractor(logger, transactionsRepository, authorizerAuthorizer)
+	provider, cleanup3, err := provideSigners(logger, c, chainapiClient)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	source, err := providePricesSource(c, chainapiClient)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	pricesInteractor := providePricesInteractor(logger, source, pricesRepository)
+	ledgerInteractor := provideLedgerInteractor(logger, ledgerRepository, pricesInteractor, authorizerAuthorizer)
+	chainInteractor := provideChainInteractor(logger, chainapiClient, provider, transactionsRepository, usersRepository, organizationsRepository, jobsInteractor, confirmationsInteractor, ledgerInteractor, authorizerAuthorizer)
 	usersInteractor := provideUsersInteractor(logger, usersRepository, chainInteractor)
 	authRepository := provideAuthRepository(db)
-	jwtInteractor := provideJWTInteractor(c, usersInteractor, authRepository)
+	jwtInteractor := provideJWTInteractor(logger, c, keyRing, cache, usersInteractor, authRepository)
 	authPresenter := provideAuthPresenter(jwtInteractor)
-	client, cleanup2 := provideRedisConnection(c)
-	cache := provideRedisCache(client, logger)
-	organizationsInteractor := provideOrganizationsInteractor(logger, organizationsRepository, cache)
-	authController := provideAuthController(logger, usersInteractor, authPresenter, jwtInteractor, authRepository, organizationsInteractor)
+	invitesInteractor := provideInvitesInteractor(logger, authRepository, organizationsRepository, usersRepository, authorizerAuthorizer)
+	siweInteractor := provideSIWEInteractor(logger, c, authRepository)
+	authController := provideAuthController(logger, usersInteractor, authPresenter, jwtInteractor, organizationsInteractor, invitesInteractor, siweInteractor)
 	organizationsPresenter := provideOrganizationsPresenter()
 	organizationsController := provideOrganizationsController(logger, organizationsInteractor, organizationsPresenter)
-	transactionsInteractor := provideTxInteractor(logger, transactionsRepository, organizationsInteractor, chainInteractor)
-	transactionsController := provideTxController(logger, transactionsInteractor, chainInteractor, organizationsInteractor)
+	assetsInteractor := provideAssetsInteractor(logger, c, assetsRepository, authorizerAuthorizer)
+	transactionsInteractor := provideTxInteractor(logger, transactionsRepository, usersRepository, organizationsInteractor, chainInteractor, jobsInteractor, confirmationsInteractor, ledgerInteractor, assetsInteractor, pricesInteractor, authorizerAuthorizer)
+	jobsPresenter := provideJobsPresenter()
+	transactionsController := provideTxController(logger, transactionsInteractor, chainInteractor, organizationsInteractor, jobsPresenter)
 	participantsController := provideParticipantsController(logger, organizationsInteractor, usersInteractor)
-	rootController := provideControllers(logger, authController, organizationsController, transactionsController, participantsController)
+	jobsController := provideJobsController(logger, jobsInteractor, jobsPresenter)
+	payoutsInteractor := providePayoutsInteractor(logger, payoutsRepository, transactionsRepository, usersRepository, organizationsInteractor, chainInteractor, jobsInteractor, confirmationsInteractor, ledgerInteractor, authorizerAuthorizer)
+	payoutsPresenter := providePayoutsPresenter()
+	payoutsController := providePayoutsController(logger, payoutsInteractor, payoutsPresenter, jobsPresenter)
+	licensesInteractor := provideLicensesInteractor(logger, licensesRepository, transactionsRepository, usersRepository, organizationsInteractor, chainInteractor, jobsInteractor, confirmationsInteractor, authorizerAuthorizer)
+	licensesPresenter := provideLicensesPresenter()
+	licensesController := provideLicensesController(logger, licensesInteractor, licensesPresenter)
+	agreementsInteractor := provideAgreementsInteractor(logger, agreementsRepository, transactionsRepository, usersRepository, organizationsInteractor, chainInteractor, jobsInteractor, confirmationsInteractor, authorizerAuthorizer)
+	agreementsPresenter := provideAgreementsPresenter()
+	agreementsController := provideAgreementsController(logger, agreementsInteractor, agreementsPresenter)
+	confirmationsPresenter := provideConfirmationsPresenter()
+	confirmationsController := provideConfirmationsController(logger, confirmationsInteractor, confirmationsPresenter)
+	ledgerPresenter := provideLedgerPresenter()
+	ledgerController := provideLedgerController(logger, ledgerInteractor, ledgerPresenter)
+	assetsPresenter := provideAssetsPresenter()
+	assetsController := provideAssetsController(logger, assetsInteractor, assetsPresenter)
+	reportsInteractor := provideReportsInteractor(logger, ledgerRepository, authorizerAuthorizer)
+	reportsPresenter := provideReportsPresenter()
+	reportsController := provideReportsController(logger, reportsInteractor, reportsPresenter)
+	rootController := provideControllers(logger, authController, organizationsController, transactionsController, participantsController, jobsController, payoutsController, licensesController, agreementsController, confirmationsController, ledgerController, assetsController, reportsController)
 	server := provideRestServer(logger, rootController, c, jwtInteractor)
-	serviceService := service.NewService(logger, server)
+	serviceService := service.NewService(logger, server, jobsInteractor)
 	return serviceService, func() {
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
 }
+
+func ProvideSecretsMigrator(c config.Config) (encryption.Migrator, func(), error) {
+	keeper, err := provideSecretsKeeper(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	db, cleanup, err := repository.ProvideDatabaseConnection(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := provideLogger(c)
+	migrator := provideSecretsMigrator(logger, db, keeper)
+	return migrator, func() {
+		cleanup()
+	}, nil
+}
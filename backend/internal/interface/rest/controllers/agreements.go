@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/presenters"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/agreements"
+)
+
+type AgreementsController interface {
+	NewAgreement(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ListAgreements(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Request(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Resolve(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ListOperations(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ConfirmOperation(w http.ResponseWriter, r *http.Request) ([]byte, error)
+}
+
+type agreementsController struct {
+	log                  *slog.Logger
+	agreementsInteractor agreements.AgreementsInteractor
+	presenter            presenters.AgreementsPresenter
+}
+
+func NewAgreementsController(
+	log *slog.Logger,
+	agreementsInteractor agreements.AgreementsInteractor,
+	presenter presenters.AgreementsPresenter,
+) AgreementsController {
+	return &agreementsController{
+		log:                  log,
+		agreementsInteractor: agreementsInteractor,
+		presenter:            presenter,
+	}
+}
+
+func (c *agreementsController) NewAgreement(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.NewAgreementRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	multisigID, err := uuid.Parse(req.MultisigID)
+	if err != nil {
+		return nil, fmt.Errorf("error parse multisig id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	op, err := c.agreementsInteractor.Deploy(ctx, agreements.DeployParams{
+		Title:      req.Title,
+		MultisigID: multisigID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error deploy agreement. %w", err)
+	}
+
+	return c.presenter.ResponseOperation(ctx, op)
+}
+
+func (c *agreementsController) ListAgreements(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.ListAgreementsRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	ids, err := parseUUIDs(req.IDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse agreement id. %w", err)
+	}
+
+	list, err := c.agreementsInteractor.List(ctx, agreements.ListParams{
+		OrganizationID: organizationID,
+		IDs:            ids,
+		Limit:          int64(req.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch agreements. %w", err)
+	}
+
+	return c.presenter.ResponseAgreements(ctx, list)
+}
+
+func (c *agreementsController) Request(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.AgreementRequestRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	agreementID, err := uuid.Parse(chi.URLParam(r, "agreement_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse agreement id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	op, err := c.agreementsInteractor.Request(ctx, agreements.RequestParams{
+		AgreementID: agreementID,
+		URL:         req.URL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error request agreement outcome. %w", err)
+	}
+
+	return c.presenter.ResponseOperation(ctx, op)
+}
+
+func (c *agreementsController) Resolve(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	agreementID, err := uuid.Parse(chi.URLParam(r, "agreement_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse agreement id. %w", err)
+	}
+
+	// agreement response is read from the chain
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	agreement, err := c.agreementsInteractor.Resolve(ctx, agreements.ResolveParams{
+		AgreementID:    agreementID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error resolve agreement. %w", err)
+	}
+
+	return c.presenter.ResponseAgreement(ctx, agreement)
+}
+
+func (c *agreementsController) ListOperations(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.ListAgreementOperationsRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	agreementID, err := uuid.Parse(chi.URLParam(r, "agreement_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse agreement id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	ids, err := parseUUIDs(req.IDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse operation id. %w", err)
+	}
+
+	ops, err := c.agreementsInteractor.ListOperations(ctx, agreements.ListOperationsParams{
+		OrganizationID: organizationID,
+		IDs:            ids,
+		AgreementIDs:   uuid.UUIDs{agreementID},
+		Limit:          int64(req.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch agreement operations. %w", err)
+	}
+
+	return c.presenter.ResponseOperations(ctx, ops)
+}
+
+func (c *agreementsController) ConfirmOperation(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	operationID, err := uuid.Parse(chi.URLParam(r, "operation_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse operation id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	op, err := c.agreementsInteractor.ConfirmOperation(ctx, agreements.ConfirmOperationParams{
+		ID:             operationID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error confirm agreement operation. %w", err)
+	}
+
+	return c.presenter.ResponseOperation(ctx, op)
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/presenters"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/assets"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+type AssetsController interface {
+	NewAsset(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ListAssets(w http.ResponseWriter, r *http.Request) ([]byte, error)
+}
+
+type assetsController struct {
+	log              *slog.Logger
+	assetsInteractor assets.AssetsInteractor
+	presenter        presenters.AssetsPresenter
+}
+
+func NewAssetsController(
+	log *slog.Logger,
+	assetsInteractor assets.AssetsInteractor,
+	presenter presenters.AssetsPresenter,
+) AssetsController {
+	return &assetsController{
+		log:              log,
+		assetsInteractor: assetsInteractor,
+		presenter:        presenter,
+	}
+}
+
+func (c *assetsController) NewAsset(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.NewAssetRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	if !common.IsHexAddress(req.ContractAddress) {
+		return nil, presenters.ErrorInvalidHexAddress
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	asset, err := c.assetsInteractor.Create(ctx, assets.CreateParams{
+		Symbol:          req.Symbol,
+		ContractAddress: common.HexToAddress(req.ContractAddress).Bytes(),
+		Decimals:        req.Decimals,
+		ChainID:         req.ChainID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error create asset. %w", err)
+	}
+
+	return c.presenter.ResponseAsset(ctx, asset)
+}
+
+func (c *assetsController) ListAssets(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.ListAssetsRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	ids := make(uuid.UUIDs, len(req.IDs))
+
+	for i, id := range req.IDs {
+		if ids[i], err = uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("error parse asset id. %w", err)
+		}
+	}
+
+	list, err := c.assetsInteractor.List(ctx, assets.ListParams{
+		OrganizationID: organizationID,
+		IDs:            ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch assets. %w", err)
+	}
+
+	return c.presenter.ResponseAssets(ctx, list)
+}
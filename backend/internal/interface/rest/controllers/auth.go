@@ -2,8 +2,7 @@ package controllers
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -16,34 +15,53 @@ import (
 	"github.com/emochka2007/block-accounting/internal/pkg/bip39"
 	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
 	"github.com/emochka2007/block-accounting/internal/pkg/hdwallet"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/invites"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jwt"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/siwe"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/users"
-	"github.com/emochka2007/block-accounting/internal/usecase/repository/auth"
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
 var (
 	ErrorAuthInvalidMnemonic = errors.New("invalid mnemonic")
 	ErrorTokenRequired       = errors.New("token required")
+	ErrorWalletRegistered    = errors.New("wallet already registered")
+	ErrorWalletNotRegistered = errors.New("wallet not registered")
 )
 
 type AuthController interface {
 	Join(w http.ResponseWriter, req *http.Request) ([]byte, error)
 	JoinWithInvite(w http.ResponseWriter, req *http.Request) ([]byte, error)
 	Login(w http.ResponseWriter, req *http.Request) ([]byte, error)
+	WalletNonce(w http.ResponseWriter, req *http.Request) ([]byte, error)
+	WalletLogin(w http.ResponseWriter, req *http.Request) ([]byte, error)
+	WalletJoin(w http.ResponseWriter, req *http.Request) ([]byte, error)
 	Invite(w http.ResponseWriter, req *http.Request) ([]byte, error)
 	Refresh(w http.ResponseWriter, req *http.Request) ([]byte, error)
 	InviteGet(w http.ResponseWriter, req *http.Request) ([]byte, error)
+	ListInvites(w http.ResponseWriter, req *http.Request) ([]byte, error)
+	RevokeInvite(w http.ResponseWriter, req *http.Request) ([]byte, error)
+	AcceptInvite(w http.ResponseWriter, req *http.Request) ([]byte, error)
+	ReceivedInvites(w http.ResponseWriter, req *http.Request) ([]byte, error)
+	JWKS(w http.ResponseWriter, req *http.Request) ([]byte, error)
+
+	Logout(w http.ResponseWriter, req *http.Request) ([]byte, error)
+	Sessions(w http.ResponseWriter, req *http.Request) ([]byte, error)
+	RevokeSession(w http.ResponseWriter, req *http.Request) ([]byte, error)
+	RevokeOtherSessions(w http.ResponseWriter, req *http.Request) ([]byte, error)
 }
 
 type authController struct {
-	log             *slog.Logger
-	presenter       presenters.AuthPresenter
-	usersInteractor users.UsersInteractor
-	jwtInteractor   jwt.JWTInteractor
-	repo            auth.Repository
-	orgInteractor   organizations.OrganizationsInteractor
+	log               *slog.Logger
+	presenter         presenters.AuthPresenter
+	usersInteractor   users.UsersInteractor
+	jwtInteractor     jwt.JWTInteractor
+	orgInteractor     organizations.OrganizationsInteractor
+	invitesInteractor invites.InvitesInteractor
+	siweInteractor    siwe.SIWEInteractor
 }
 
 func NewAuthController(
@@ -51,16 +69,18 @@ func NewAuthController(
 	presenter presenters.AuthPresenter,
 	usersInteractor users.UsersInteractor,
 	jwtInteractor jwt.JWTInteractor,
-	repo auth.Repository,
 	orgInteractor organizations.OrganizationsInteractor,
+	invitesInteractor invites.InvitesInteractor,
+	siweInteractor siwe.SIWEInteractor,
 ) AuthController {
 	return &authController{
-		log:             log,
-		presenter:       presenter,
-		usersInteractor: usersInteractor,
-		jwtInteractor:   jwtInteractor,
-		repo:            repo,
-		orgInteractor:   orgInteractor,
+		log:               log,
+		presenter:         presenter,
+		usersInteractor:   usersInteractor,
+		jwtInteractor:     jwtInteractor,
+		orgInteractor:     orgInteractor,
+		invitesInteractor: invitesInteractor,
+		siweInteractor:    siweInteractor,
 	}
 }
 
@@ -70,7 +90,7 @@ func (c *authController) Join(w http.ResponseWriter, req *http.Request) ([]byte,
 		return nil, fmt.Errorf("error create join request. %w", err)
 	}
 
-	c.log.Debug("join request", slog.String("mnemonic", request.Mnemonic))
+	c.log.Debug("join request", slog.String("name", request.Name))
 
 	if !bip39.IsMnemonicValid(request.Mnemonic) {
 		return nil, fmt.Errorf("error invalid mnemonic. %w", ErrorAuthInvalidMnemonic)
@@ -95,7 +115,7 @@ func (c *authController) Join(w http.ResponseWriter, req *http.Request) ([]byte,
 
 	c.log.Debug("join request", slog.String("user id", user.ID.String()))
 
-	return c.presenter.ResponseJoin(user)
+	return c.presenter.ResponseJoin(ctx, user)
 }
 
 // NIT: wrap with idempotent action handler
@@ -105,7 +125,7 @@ func (c *authController) Login(w http.ResponseWriter, req *http.Request) ([]byte
 		return nil, fmt.Errorf("error create login request. %w", err)
 	}
 
-	c.log.Debug("login request", slog.String("mnemonic", request.Mnemonic))
+	c.log.Debug("login request")
 
 	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
 	defer cancel()
@@ -128,7 +148,108 @@ func (c *authController) Login(w http.ResponseWriter, req *http.Request) ([]byte
 
 	c.log.Debug("login request", slog.String("user id", users[0].ID.String()))
 
-	return c.presenter.ResponseLogin(users[0])
+	return c.presenter.ResponseLogin(ctx, users[0])
+}
+
+// WalletNonce issues sign in message for the wallet. The wallet signs it with personal_sign
+func (c *authController) WalletNonce(w http.ResponseWriter, req *http.Request) ([]byte, error) {
+	request, err := presenters.CreateRequest[domain.WalletNonceRequest](req)
+	if err != nil {
+		return nil, fmt.Errorf("error create wallet nonce request. %w", err)
+	}
+
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
+	defer cancel()
+
+	nonce, err := c.siweInteractor.Nonce(ctx, siwe.NonceParams{
+		Address: request.Address,
+		Domain:  req.Host,
+		URI:     scheme + "://" + req.Host,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error issue wallet nonce. %w", err)
+	}
+
+	return c.presenter.ResponseWalletNonce(nonce)
+}
+
+func (c *authController) WalletLogin(w http.ResponseWriter, req *http.Request) ([]byte, error) {
+	request, err := presenters.CreateRequest[domain.WalletLoginRequest](req)
+	if err != nil {
+		return nil, fmt.Errorf("error create wallet login request. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
+	defer cancel()
+
+	address, err := c.siweInteractor.Verify(ctx, siwe.VerifyParams{
+		Address:   request.Address,
+		Nonce:     request.Nonce,
+		Signature: request.Signature,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error verify wallet signature. %w", err)
+	}
+
+	users, err := c.usersInteractor.Get(ctx, users.GetParams{
+		PKs: [][]byte{address.Bytes()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user by wallet. %w", ErrorWalletNotRegistered)
+	}
+
+	c.log.Debug("wallet login request", slog.String("user id", users[0].ID.String()))
+
+	return c.presenter.ResponseLogin(ctx, users[0])
+}
+
+// WalletJoin creates new account owned by the wallet. The account has no custodial seed
+func (c *authController) WalletJoin(w http.ResponseWriter, req *http.Request) ([]byte, error) {
+	request, err := presenters.CreateRequest[domain.WalletJoinRequest](req)
+	if err != nil {
+		return nil, fmt.Errorf("error create wallet join request. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
+	defer cancel()
+
+	address, err := c.siweInteractor.Verify(ctx, siwe.VerifyParams{
+		Address:   request.Address,
+		Nonce:     request.Nonce,
+		Signature: request.Signature,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error verify wallet signature. %w", err)
+	}
+
+	if registered, err := c.usersInteractor.Get(ctx, users.GetParams{
+		PKs: [][]byte{address.Bytes()},
+	}); err == nil && len(registered) > 0 {
+		return nil, ErrorWalletRegistered
+	}
+
+	user, err := c.usersInteractor.Create(ctx, users.CreateParams{
+		Name:      request.Name,
+		Email:     request.Credentals.Email,
+		Phone:     request.Credentals.Phone,
+		Tg:        request.Credentals.Telegram,
+		PublicKey: address.Bytes(),
+		Activate:  true,
+		Owner:     true,
+		Admin:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error create new wallet user. %w", err)
+	}
+
+	c.log.Debug("wallet join request", slog.String("user id", user.ID.String()))
+
+	return c.presenter.ResponseJoin(ctx, user)
 }
 
 func (c *authController) Refresh(w http.ResponseWriter, req *http.Request) ([]byte, error) {
@@ -154,12 +275,68 @@ func (c *authController) Refresh(w http.ResponseWriter, req *http.Request) ([]by
 	return c.presenter.ResponseRefresh(newTokens)
 }
 
+// JWKS publishes public keys accepted for tokens verification
+func (c *authController) JWKS(w http.ResponseWriter, req *http.Request) ([]byte, error) {
+	return c.presenter.ResponseJWKS(c.jwtInteractor.JWKS())
+}
+
+func (c *authController) Logout(w http.ResponseWriter, req *http.Request) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
+	defer cancel()
+
+	if err := c.jwtInteractor.Logout(ctx); err != nil {
+		return nil, fmt.Errorf("error logout. %w", err)
+	}
+
+	return presenters.ResponseOK()
+}
+
+func (c *authController) Sessions(w http.ResponseWriter, req *http.Request) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
+	defer cancel()
+
+	sessions, err := c.jwtInteractor.Sessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch sessions. %w", err)
+	}
+
+	return c.presenter.ResponseSessions(sessions)
+}
+
+func (c *authController) RevokeSession(w http.ResponseWriter, req *http.Request) ([]byte, error) {
+	sessionID, err := uuid.Parse(chi.URLParam(req, "session_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse session id. %w", jwt.ErrorSessionNotFound)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
+	defer cancel()
+
+	if err = c.jwtInteractor.RevokeSession(ctx, sessionID); err != nil {
+		return nil, fmt.Errorf("error revoke session. %w", err)
+	}
+
+	return presenters.ResponseOK()
+}
+
+func (c *authController) RevokeOtherSessions(w http.ResponseWriter, req *http.Request) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
+	defer cancel()
+
+	revoked, err := c.jwtInteractor.RevokeOtherSessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error revoke other sessions. %w", err)
+	}
+
+	return c.presenter.ResponseRevokedSessions(revoked)
+}
+
 // const mnemonicEntropyBitSize int = 256
 
 func (c *authController) Invite(w http.ResponseWriter, r *http.Request) ([]byte, error) {
 	request, err := presenters.CreateRequest[domain.NewInviteLinkRequest](r)
 	if err != nil {
-		return nil, fmt.Errorf("error create refresh request. %w", err)
+		return nil, fmt.Errorf("error create invite request. %w", err)
 	}
 
 	organizationID, err := ctxmeta.OrganizationId(r.Context())
@@ -167,61 +344,132 @@ func (c *authController) Invite(w http.ResponseWriter, r *http.Request) ([]byte,
 		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
 	}
 
-	user, err := ctxmeta.User(r.Context())
-	if err != nil {
-		return nil, fmt.Errorf("error fetch user from context. %w", err)
-	}
-
 	c.log.Debug(
 		"invite request",
 		slog.Int("exp at", request.ExpirationDate),
 		slog.String("org id", organizationID.String()),
-		slog.String("inviter id", user.Id().String()),
+		slog.String("role", request.Role),
+		slog.Int("max uses", request.MaxUses),
 	)
 
+	params := invites.CreateParams{
+		OrganizationID: organizationID,
+		Position:       request.Position,
+		MaxUses:        request.MaxUses,
+	}
+
+	if request.Role != "" {
+		if params.Role = models.ParseRole(request.Role); params.Role == models.RoleUnknown {
+			return nil, fmt.Errorf("error parse role. %w", organizations.ErrorInvalidRole)
+		}
+	}
+
+	if request.PublicKey != "" {
+		if params.PublicKey, err = hex.DecodeString(strings.TrimPrefix(request.PublicKey, "0x")); err != nil {
+			return nil, fmt.Errorf("error decode public key. %w", invites.ErrorInvalidInvite)
+		}
+	}
+
+	if request.ExpirationDate > 0 {
+		params.ExpiredAt = time.UnixMilli(int64(request.ExpirationDate))
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
 	defer cancel()
 
-	linkHash := sha256.New()
-
-	linkHash.Write([]byte(
-		user.Id().String() + organizationID.String() + time.Now().String(),
-	))
-
-	linkHashString := strings.ReplaceAll(
-		strings.ReplaceAll(
-			strings.ReplaceAll(
-				base64.StdEncoding.EncodeToString(linkHash.Sum(nil)),
-				"/", "$",
-			),
-			"?", "@",
-		),
-		"&", "#",
-	)
+	invite, err := c.invitesInteractor.Create(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error create invite link. %w", err)
+	}
 
-	createdAt := time.Now()
-	expDate := createdAt.Add(time.Hour * 24 * 7)
+	return c.presenter.ResponseInvite(invite)
+}
 
-	if request.ExpirationDate > 0 {
-		expDate = time.UnixMilli(int64(request.ExpirationDate))
+func (c *authController) ListInvites(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	request, err := presenters.CreateRequest[domain.ListInvitesRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error create list invites request. %w", err)
 	}
 
-	c.log.Debug(
-		"",
-		slog.String("link", linkHashString),
-	)
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
 
-	if err := c.repo.AddInvite(ctx, auth.AddInviteParams{
-		LinkHash:       linkHashString,
+	invitesList, err := c.invitesInteractor.List(ctx, invites.ListParams{
 		OrganizationID: organizationID,
-		CreatedBy:      *user,
-		CreatedAt:      createdAt,
-		ExpiredAt:      expDate,
-	}); err != nil {
-		return nil, fmt.Errorf("error add new invite link. %w", err)
+		All:            request.All,
+		Limit:          request.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch invites. %w", err)
+	}
+
+	return c.presenter.ResponseInvites(organizationID, invitesList)
+}
+
+func (c *authController) RevokeInvite(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	hash := chi.URLParam(r, "hash")
+	if hash == "" {
+		return nil, fmt.Errorf("error fetch invite hash from request")
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	invite, err := c.invitesInteractor.Revoke(ctx, invites.RevokeParams{
+		OrganizationID: organizationID,
+		LinkHash:       hash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error revoke invite. %w", err)
 	}
 
-	return c.presenter.ResponseNewInvite(ctx, organizationID, linkHashString)
+	return c.presenter.ResponseInvite(invite)
+}
+
+func (c *authController) AcceptInvite(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	hash := chi.URLParam(r, "hash")
+	if hash == "" {
+		return nil, fmt.Errorf("error fetch invite hash from request")
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	invite, err := c.invitesInteractor.Accept(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("error accept invite. %w", err)
+	}
+
+	return c.presenter.ResponseInvite(invite)
+}
+
+func (c *authController) ReceivedInvites(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	request, err := presenters.CreateRequest[domain.ListReceivedInvitesRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error create received invites request. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	invitesList, err := c.invitesInteractor.Received(ctx, invites.ReceivedParams{
+		Limit: request.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch received invites. %w", err)
+	}
+
+	return c.presenter.ResponseReceivedInvites(invitesList)
 }
 
 func (c *authController) JoinWithInvite(w http.ResponseWriter, r *http.Request) ([]byte, error) {
@@ -244,14 +492,12 @@ func (c *authController) JoinWithInvite(w http.ResponseWriter, r *http.Request)
 		return nil, fmt.Errorf("error fetch invite hash from request")
 	}
 
-	usedAt := time.Now()
-
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	organizationID, err := c.repo.MarkAsUsedLink(ctx, hash, usedAt)
+	invite, err := c.invitesInteractor.Use(ctx, hash)
 	if err != nil {
-		return nil, fmt.Errorf("error mark invite link as used. %w", err)
+		return nil, fmt.Errorf("error use invite link. %w", err)
 	}
 
 	user, err := c.usersInteractor.Create(ctx, users.CreateParams{
@@ -268,12 +514,14 @@ func (c *authController) JoinWithInvite(w http.ResponseWriter, r *http.Request)
 
 	if err = c.orgInteractor.AddUser(ctx, organizations.AddUserParams{
 		User:           user,
-		OrganizationID: organizationID,
+		Role:           invite.Role,
+		Position:       invite.Position,
+		OrganizationID: invite.OrganizationID,
 		SkipRights:     true,
 	}); err != nil {
 		c.log.Error(
 			"error add user into organization",
-			slog.String("organization id", organizationID.String()),
+			slog.String("organization id", invite.OrganizationID.String()),
 			slog.String("user id", user.Id().String()),
 			slog.String("invire hash", hash),
 		)
@@ -281,9 +529,22 @@ func (c *authController) JoinWithInvite(w http.ResponseWriter, r *http.Request)
 		return nil, fmt.Errorf("error add user into organization. %w", err)
 	}
 
-	return c.presenter.ResponseJoin(user)
+	return c.presenter.ResponseJoin(ctx, user)
 }
 
 func (c *authController) InviteGet(w http.ResponseWriter, r *http.Request) ([]byte, error) {
-	return presenters.ResponseOK()
+	hash := chi.URLParam(r, "hash")
+	if hash == "" {
+		return nil, fmt.Errorf("error fetch invite hash from request")
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	info, err := c.invitesInteractor.Get(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch invite. %w", err)
+	}
+
+	return c.presenter.ResponseInviteInfo(info)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math/big"
 	"net/http"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/emochka2007/block-accounting/internal/interface/rest/presenters"
 	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
 	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/transactions"
@@ -30,16 +32,24 @@ type TransactionsController interface {
 
 	NewPayroll(w http.ResponseWriter, r *http.Request) ([]byte, error)
 	ConfirmPayroll(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	// ConfirmPayrollDeprecated confirms payroll by id from the request body.
+	// Deprecated: kept for clients of PUT /payrolls, use ConfirmPayroll
+	ConfirmPayrollDeprecated(w http.ResponseWriter, r *http.Request) ([]byte, error)
 	ListPayrolls(w http.ResponseWriter, r *http.Request) ([]byte, error)
 
+	SetSalary(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ListSalaries(w http.ResponseWriter, r *http.Request) ([]byte, error)
+
 	NewMultisig(w http.ResponseWriter, r *http.Request) ([]byte, error)
 	ListMultisigs(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	MultisigDeposit(w http.ResponseWriter, r *http.Request) ([]byte, error)
 }
 
 type transactionsController struct {
 	log                     *slog.Logger
 	txInteractor            transactions.TransactionsInteractor
 	txPresenter             presenters.TransactionsPresenter
+	jobsPresenter           presenters.JobsPresenter
 	chainInteractor         chain.ChainInteractor
 	organizationsInteractor organizations.OrganizationsInteractor
 }
@@ -48,6 +58,7 @@ func NewTransactionsController(
 	log *slog.Logger,
 	txInteractor transactions.TransactionsInteractor,
 	txPresenter presenters.TransactionsPresenter,
+	jobsPresenter presenters.JobsPresenter,
 	chainInteractor chain.ChainInteractor,
 	organizationsInteractor organizations.OrganizationsInteractor,
 ) TransactionsController {
@@ -55,6 +66,7 @@ func NewTransactionsController(
 		log:                     log,
 		txInteractor:            txInteractor,
 		txPresenter:             txPresenter,
+		jobsPresenter:           jobsPresenter,
 		chainInteractor:         chainInteractor,
 		organizationsInteractor: organizationsInteractor,
 	}
@@ -164,6 +176,22 @@ func (c *transactionsController) UpdateStatus(w http.ResponseWriter, r *http.Req
 		if err != nil {
 			return nil, fmt.Errorf("error cancel transaction. %w", err)
 		}
+	} else if req.Revoke {
+		tx, err = c.txInteractor.Revoke(ctx, transactions.RevokeParams{
+			TxID:           txID,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error revoke transaction confirmation. %w", err)
+		}
+	} else if req.Reject {
+		tx, err = c.txInteractor.Reject(ctx, transactions.RejectParams{
+			TxID:           txID,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reject transaction. %w", err)
+		}
 	} else {
 		return nil, fmt.Errorf("error new status required")
 	}
@@ -211,15 +239,41 @@ func (c *transactionsController) NewMultisig(w http.ResponseWriter, r *http.Requ
 		return nil, fmt.Errorf("error fetch participants by pks. %w", err)
 	}
 
-	if err := c.chainInteractor.NewMultisig(ctx, chain.NewMultisigParams{
+	job, err := c.chainInteractor.NewMultisig(ctx, chain.NewMultisigParams{
 		Title:         req.Title,
 		Owners:        participants,
 		Confirmations: req.Confirmations,
-	}); err != nil {
+	})
+	if err != nil {
 		return nil, fmt.Errorf("error deploy multisig. %w", err)
 	}
 
-	return presenters.ResponseOK()
+	return c.jobsPresenter.ResponseJob(job)
+}
+
+func (c *transactionsController) MultisigDeposit(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.NewMultisigDepositRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	multisigID, err := uuid.Parse(req.MultisigID)
+	if err != nil {
+		return nil, fmt.Errorf("error parse multisig id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	job, err := c.chainInteractor.MultisigDeposit(ctx, chain.MultisigDepositParams{
+		MultisigID: multisigID,
+		Amount:     money.New(req.Amount, money.ETH),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error deposit multisig. %w", err)
+	}
+
+	return c.jobsPresenter.ResponseJob(job)
 }
 
 func (s *transactionsController) ListMultisigs(w http.ResponseWriter, r *http.Request) ([]byte, error) {
@@ -268,19 +322,6 @@ func (c *transactionsController) NewPayroll(w http.ResponseWriter, r *http.Reque
 		return nil, fmt.Errorf("error fetch user from context. %w", err)
 	}
 
-	userParticipant, err := c.organizationsInteractor.Participant(ctx, organizations.ParticipantParams{
-		ID:             user.Id(),
-		OrganizationID: organizationID,
-		// TODO fetch REAL first admin
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error fetch user protocpant. %w", err)
-	}
-
-	if !userParticipant.IsOwner() {
-		return nil, fmt.Errorf("only owner can create payrolls")
-	}
-
 	firstAdmin, err := c.organizationsInteractor.Participant(ctx, organizations.ParticipantParams{
 		ID:             user.Id(),
 		OrganizationID: organizationID,
@@ -290,11 +331,7 @@ func (c *transactionsController) NewPayroll(w http.ResponseWriter, r *http.Reque
 		return nil, fmt.Errorf("error fetch first admin. %w", err)
 	}
 
-	if !firstAdmin.IsOwner() {
-		return nil, fmt.Errorf("invalid first admin. not owner")
-	}
-
-	err = c.chainInteractor.PayrollDeploy(ctx, chain.PayrollDeployParams{
+	payroll, err := c.chainInteractor.PayrollDeploy(ctx, chain.PayrollDeployParams{
 		MultisigID: multisigID,
 		FirstAdmin: firstAdmin,
 		Title:      req.Title,
@@ -303,7 +340,7 @@ func (c *transactionsController) NewPayroll(w http.ResponseWriter, r *http.Reque
 		return nil, fmt.Errorf("error create new payroll contract. %w", err)
 	}
 
-	return presenters.ResponseOK()
+	return c.txPresenter.ResponsePayroll(ctx, payroll)
 }
 
 func (c *transactionsController) ListPayrolls(w http.ResponseWriter, r *http.Request) ([]byte, error) {
@@ -343,12 +380,212 @@ func (c *transactionsController) ListPayrolls(w http.ResponseWriter, r *http.Req
 }
 
 func (c *transactionsController) SetSalary(w http.ResponseWriter, r *http.Request) ([]byte, error) {
-	// req, err := presenters.CreateRequest[domain.]()
+	req, err := presenters.CreateRequest[domain.SetSalaryRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
 
-	return presenters.ResponseOK()
+	payrollID, err := uuid.Parse(req.PayrollID)
+	if err != nil {
+		return nil, fmt.Errorf("error parse payroll id. %w", err)
+	}
+
+	employeeID, err := uuid.Parse(req.EmployeeID)
+	if err != nil {
+		return nil, fmt.Errorf("error parse employee id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	salary, err := c.chainInteractor.NewSalary(ctx, chain.NewSalaryParams{
+		PayrollID:  payrollID,
+		EmployeeID: employeeID,
+		Amount:     money.New(req.Salary, money.USD),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error set salary. %w", err)
+	}
+
+	return c.txPresenter.ResponseSalary(ctx, salary)
+}
+
+func (c *transactionsController) ListSalaries(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.ListSalariesRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	ids, err := parseUUIDs(req.IDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse salary id. %w", err)
+	}
+
+	payrollIDs, err := parseUUIDs(req.PayrollIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse payroll id. %w", err)
+	}
+
+	employeeIDs, err := parseUUIDs(req.EmployeeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse employee id. %w", err)
+	}
+
+	salaries, err := c.chainInteractor.ListSalaries(ctx, chain.ListSalariesParams{
+		OrganizationID: organizationID,
+		IDs:            ids,
+		PayrollIDs:     payrollIDs,
+		EmployeeIDs:    employeeIDs,
+		Limit:          int(req.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch salaries. %w", err)
+	}
+
+	if !req.OnChain || len(salaries) == 0 {
+		return c.txPresenter.ResponseSalaries(ctx, salaries, nil)
+	}
+
+	onChain, err := c.onChainSalaries(r.Context(), organizationID, salaries)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.txPresenter.ResponseSalaries(ctx, salaries, onChain)
+}
+
+// onChainSalaries reads salaries from payroll contracts. Every payroll-employee pair is requested once
+func (c *transactionsController) onChainSalaries(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	salaries []models.Salary,
+) (map[uuid.UUID]*big.Int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	payrollIDs := make(uuid.UUIDs, 0)
+	seen := make(map[uuid.UUID]struct{})
+
+	for _, s := range salaries {
+		if _, ok := seen[s.PayrollID]; !ok {
+			seen[s.PayrollID] = struct{}{}
+			payrollIDs = append(payrollIDs, s.PayrollID)
+		}
+	}
+
+	payrolls, err := c.chainInteractor.ListPayrolls(ctx, chain.ListPayrollsParams{
+		OrganizationID: organizationID,
+		IDs:            payrollIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch payrolls. %w", err)
+	}
+
+	payrollAddresses := make(map[uuid.UUID][]byte, len(payrolls))
+
+	for _, p := range payrolls {
+		payrollAddresses[p.ID] = p.Address
+	}
+
+	type pair struct {
+		payrollID uuid.UUID
+		employee  string
+	}
+
+	fetched := make(map[pair]*big.Int)
+	out := make(map[uuid.UUID]*big.Int, len(salaries))
+
+	for _, s := range salaries {
+		// payroll contract is not deployed yet
+		if len(payrollAddresses[s.PayrollID]) == 0 {
+			continue
+		}
+
+		key := pair{s.PayrollID, common.Bytes2Hex(s.EmployeeAddress)}
+
+		amount, ok := fetched[key]
+		if !ok {
+			amount, err = c.chainInteractor.OnChainSalary(ctx, chain.OnChainSalaryParams{
+				PayrollAddress:  payrollAddresses[s.PayrollID],
+				EmployeeAddress: s.EmployeeAddress,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error fetch on-chain salary. %w", err)
+			}
+
+			fetched[key] = amount
+		}
+
+		out[s.ID] = amount
+	}
+
+	return out, nil
+}
+
+func parseUUIDs(strs []string) (uuid.UUIDs, error) {
+	ids := make(uuid.UUIDs, len(strs))
+
+	for i, str := range strs {
+		id, err := uuid.Parse(str)
+		if err != nil {
+			return nil, err
+		}
+
+		ids[i] = id
+	}
+
+	return ids, nil
 }
 
 func (c *transactionsController) ConfirmPayroll(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	payrollID, err := uuid.Parse(chi.URLParam(r, "payroll_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse payroll id. %w", err)
+	}
+
+	return c.confirmPayroll(r, payrollID)
+}
+
+func (c *transactionsController) ConfirmPayrollDeprecated(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.ConfirmPayrollRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	payrollID, err := uuid.Parse(req.PayrollID)
+	if err != nil {
+		return nil, fmt.Errorf("error parse payroll id. %w", err)
+	}
+
+	w.Header().Set("Deprecation", "true")
+
+	return c.confirmPayroll(r, payrollID)
+}
+
+func (c *transactionsController) confirmPayroll(r *http.Request, payrollID uuid.UUID) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	payroll, err := c.chainInteractor.ConfirmPayroll(ctx, chain.ConfirmPayrollParams{
+		ID:             payrollID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error confirm payroll. %w", err)
+	}
 
-	return nil, nil
+	return c.txPresenter.ResponsePayroll(ctx, payroll)
 }
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/presenters"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+)
+
+type ConfirmationsController interface {
+	Get(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Confirm(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Reject(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Revoke(w http.ResponseWriter, r *http.Request) ([]byte, error)
+}
+
+type confirmationsController struct {
+	log                     *slog.Logger
+	confirmationsInteractor confirmations.ConfirmationsInteractor
+	presenter               presenters.ConfirmationsPresenter
+}
+
+func NewConfirmationsController(
+	log *slog.Logger,
+	confirmationsInteractor confirmations.ConfirmationsInteractor,
+	presenter presenters.ConfirmationsPresenter,
+) ConfirmationsController {
+	return &confirmationsController{
+		log:                     log,
+		confirmationsInteractor: confirmationsInteractor,
+		presenter:               presenter,
+	}
+}
+
+type confirmationEntity struct {
+	organizationID uuid.UUID
+	entityType     models.MultisigConfirmationEntityType
+	entityID       uuid.UUID
+}
+
+func parseConfirmationEntity(r *http.Request) (*confirmationEntity, error) {
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization ID from context. %w", err)
+	}
+
+	entityType := models.ParseMultisigConfirmationEntityType(chi.URLParam(r, "entity_type"))
+	if entityType == models.MultisigConfirmationEntityTypeUnknown {
+		return nil, confirmations.ErrorUnknownEntityType
+	}
+
+	entityID, err := uuid.Parse(chi.URLParam(r, "entity_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse entity id. %w", err)
+	}
+
+	return &confirmationEntity{
+		organizationID: organizationID,
+		entityType:     entityType,
+		entityID:       entityID,
+	}, nil
+}
+
+func (c *confirmationsController) Get(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	entity, err := parseConfirmationEntity(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	confirmation, err := c.confirmationsInteractor.Get(ctx, confirmations.GetParams{
+		OrganizationID: entity.organizationID,
+		EntityType:     entity.entityType,
+		EntityID:       entity.entityID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch confirmation. %w", err)
+	}
+
+	return c.presenter.ResponseConfirmation(ctx, confirmation)
+}
+
+func (c *confirmationsController) Confirm(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	entity, err := parseConfirmationEntity(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	confirmation, err := c.confirmationsInteractor.Confirm(ctx, confirmations.ConfirmParams{
+		OrganizationID: entity.organizationID,
+		EntityType:     entity.entityType,
+		EntityID:       entity.entityID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error confirm entity. %w", err)
+	}
+
+	return c.presenter.ResponseConfirmation(ctx, confirmation)
+}
+
+func (c *confirmationsController) Reject(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	entity, err := parseConfirmationEntity(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	confirmation, err := c.confirmationsInteractor.Reject(ctx, confirmations.RejectParams{
+		OrganizationID: entity.organizationID,
+		EntityType:     entity.entityType,
+		EntityID:       entity.entityID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reject entity. %w", err)
+	}
+
+	return c.presenter.ResponseConfirmation(ctx, confirmation)
+}
+
+func (c *confirmationsController) Revoke(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	entity, err := parseConfirmationEntity(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	confirmation, err := c.confirmationsInteractor.Revoke(ctx, confirmations.RevokeParams{
+		OrganizationID: entity.organizationID,
+		EntityType:     entity.entityType,
+		EntityID:       entity.entityID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error revoke confirmation. %w", err)
+	}
+
+	return c.presenter.ResponseConfirmation(ctx, confirmation)
+}
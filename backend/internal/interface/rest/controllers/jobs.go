@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/presenters"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
+)
+
+type JobsController interface {
+	Get(w http.ResponseWriter, r *http.Request) ([]byte, error)
+}
+
+type jobsController struct {
+	log            *slog.Logger
+	jobsInteractor jobs.JobsInteractor
+	presenter      presenters.JobsPresenter
+}
+
+func NewJobsController(
+	log *slog.Logger,
+	jobsInteractor jobs.JobsInteractor,
+	presenter presenters.JobsPresenter,
+) JobsController {
+	return &jobsController{
+		log:            log,
+		jobsInteractor: jobsInteractor,
+		presenter:      presenter,
+	}
+}
+
+func (c *jobsController) Get(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization ID from context. %w", err)
+	}
+
+	jobID, err := uuid.Parse(chi.URLParam(r, "job_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse job id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	job, err := c.jobsInteractor.Get(ctx, jobs.GetParams{
+		ID:             jobID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch job. %w", err)
+	}
+
+	return c.presenter.ResponseJob(job)
+}
@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/presenters"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/ledger"
+)
+
+var (
+	ErrorInvalidQueryParams = errors.New("invalid query params")
+)
+
+type LedgerController interface {
+	// Ledger accepts as_of, from, limit and source_type query params
+	Ledger(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Fetch(w http.ResponseWriter, r *http.Request) ([]byte, error)
+
+	NewAccount(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ListAccounts(w http.ResponseWriter, r *http.Request) ([]byte, error)
+
+	NewEntry(w http.ResponseWriter, r *http.Request) ([]byte, error)
+}
+
+type ledgerController struct {
+	log              *slog.Logger
+	ledgerInteractor ledger.LedgerInteractor
+	presenter        presenters.LedgerPresenter
+}
+
+func NewLedgerController(
+	log *slog.Logger,
+	ledgerInteractor ledger.LedgerInteractor,
+	presenter presenters.LedgerPresenter,
+) LedgerController {
+	return &ledgerController{
+		log:              log,
+		ledgerInteractor: ledgerInteractor,
+		presenter:        presenter,
+	}
+}
+
+func (c *ledgerController) Ledger(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	query := r.URL.Query()
+
+	req := &domain.LedgerRequest{
+		SourceTypes: query["source_type"],
+	}
+
+	for name, dst := range map[string]*int64{"as_of": &req.AsOf, "from": &req.From} {
+		if v := query.Get(name); v != "" {
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parse %s. %w", name, ErrorInvalidQueryParams)
+			}
+
+			*dst = parsed
+		}
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseUint(v, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("error parse limit. %w", ErrorInvalidQueryParams)
+		}
+
+		req.Limit = uint8(limit)
+	}
+
+	return c.ledger(r.Context(), req)
+}
+
+func (c *ledgerController) Fetch(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.LedgerRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	return c.ledger(r.Context(), req)
+}
+
+func (c *ledgerController) ledger(ctx context.Context, req *domain.LedgerRequest) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	params := ledger.LedgerParams{
+		OrganizationID: organizationID,
+		SourceTypes:    make([]models.LedgerEntrySource, len(req.SourceTypes)),
+		Limit:          int64(req.Limit),
+	}
+
+	for i, st := range req.SourceTypes {
+		params.SourceTypes[i] = models.LedgerEntrySource(st)
+	}
+
+	if req.AsOf > 0 {
+		params.AsOf = time.UnixMilli(req.AsOf)
+	}
+
+	if req.From > 0 {
+		params.From = time.UnixMilli(req.From)
+	}
+
+	l, err := c.ledgerInteractor.Ledger(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ledger. %w", err)
+	}
+
+	return c.presenter.ResponseLedger(ctx, l)
+}
+
+func (c *ledgerController) NewAccount(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.NewLedgerAccountRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	account, err := c.ledgerInteractor.NewAccount(ctx, ledger.NewAccountParams{
+		Code: req.Code,
+		Name: req.Name,
+		Type: models.ParseLedgerAccountType(req.Type),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error create ledger account. %w", err)
+	}
+
+	return c.presenter.ResponseAccount(ctx, account)
+}
+
+func (c *ledgerController) ListAccounts(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	accounts, err := c.ledgerInteractor.ListAccounts(ctx, ledger.ListAccountsParams{
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ledger accounts. %w", err)
+	}
+
+	return c.presenter.ResponseAccounts(ctx, accounts)
+}
+
+func (c *ledgerController) NewEntry(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.NewLedgerEntryRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	params := ledger.NewEntryParams{
+		Description: req.Description,
+		Lines:       make([]ledger.PostLine, len(req.Lines)),
+	}
+
+	if req.OccurredAt > 0 {
+		params.OccurredAt = time.UnixMilli(req.OccurredAt)
+	}
+
+	for i, l := range req.Lines {
+		params.Lines[i] = ledger.PostLine{
+			AccountCode: l.AccountCode,
+			Currency:    l.Currency,
+			Debit:       l.Debit,
+			Credit:      l.Credit,
+		}
+	}
+
+	entry, err := c.ledgerInteractor.NewEntry(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error post ledger entry. %w", err)
+	}
+
+	return c.presenter.ResponseEntry(ctx, entry)
+}
@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/presenters"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/licenses"
+)
+
+type LicensesController interface {
+	NewLicense(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ListLicenses(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Info(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Request(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	SetPayoutContract(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Payout(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ListOperations(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ConfirmOperation(w http.ResponseWriter, r *http.Request) ([]byte, error)
+}
+
+type licensesController struct {
+	log                *slog.Logger
+	licensesInteractor licenses.LicenseInteractor
+	presenter          presenters.LicensesPresenter
+}
+
+func NewLicensesController(
+	log *slog.Logger,
+	licensesInteractor licenses.LicenseInteractor,
+	presenter presenters.LicensesPresenter,
+) LicensesController {
+	return &licensesController{
+		log:                log,
+		licensesInteractor: licensesInteractor,
+		presenter:          presenter,
+	}
+}
+
+func (c *licensesController) NewLicense(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.NewLicenseRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	multisigID, err := uuid.Parse(req.MultisigID)
+	if err != nil {
+		return nil, fmt.Errorf("error parse multisig id. %w", err)
+	}
+
+	shareholders := make([]licenses.Shareholder, len(req.Shareholders))
+
+	for i, sh := range req.Shareholders {
+		shareholders[i].Share = sh.Share
+
+		if sh.ParticipantID != "" {
+			if shareholders[i].ParticipantID, err = uuid.Parse(sh.ParticipantID); err != nil {
+				return nil, fmt.Errorf("error parse participant id. %w", err)
+			}
+
+			continue
+		}
+
+		if !common.IsHexAddress(sh.Address) {
+			return nil, presenters.ErrorInvalidHexAddress
+		}
+
+		shareholders[i].Address = common.HexToAddress(sh.Address).Bytes()
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	op, err := c.licensesInteractor.Deploy(ctx, licenses.DeployParams{
+		Title:        req.Title,
+		MultisigID:   multisigID,
+		Shareholders: shareholders,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error deploy license. %w", err)
+	}
+
+	return c.presenter.ResponseOperation(ctx, op)
+}
+
+func (c *licensesController) ListLicenses(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.ListLicensesRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	ids, err := parseUUIDs(req.IDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse license id. %w", err)
+	}
+
+	list, err := c.licensesInteractor.List(ctx, licenses.ListParams{
+		OrganizationID: organizationID,
+		IDs:            ids,
+		Limit:          int64(req.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch licenses. %w", err)
+	}
+
+	return c.presenter.ResponseLicenses(ctx, list)
+}
+
+func (c *licensesController) Info(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	licenseID, err := uuid.Parse(chi.URLParam(r, "license_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse license id. %w", err)
+	}
+
+	// license state is read from the chain
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	info, err := c.licensesInteractor.Info(ctx, licenses.InfoParams{
+		LicenseID:      licenseID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch license info. %w", err)
+	}
+
+	return c.presenter.ResponseLicenseInfo(ctx, licenseID, info)
+}
+
+func (c *licensesController) Request(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.LicenseRequestRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	licenseID, err := uuid.Parse(chi.URLParam(r, "license_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse license id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	op, err := c.licensesInteractor.Request(ctx, licenses.RequestParams{
+		LicenseID: licenseID,
+		URL:       req.URL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error request license payout. %w", err)
+	}
+
+	return c.presenter.ResponseOperation(ctx, op)
+}
+
+func (c *licensesController) SetPayoutContract(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.SetLicensePayoutContractRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	licenseID, err := uuid.Parse(chi.URLParam(r, "license_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse license id. %w", err)
+	}
+
+	payrollID, err := uuid.Parse(req.PayrollID)
+	if err != nil {
+		return nil, fmt.Errorf("error parse payroll id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	op, err := c.licensesInteractor.SetPayoutContract(ctx, licenses.SetPayoutContractParams{
+		LicenseID: licenseID,
+		PayrollID: payrollID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error set license payout contract. %w", err)
+	}
+
+	return c.presenter.ResponseOperation(ctx, op)
+}
+
+func (c *licensesController) Payout(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	licenseID, err := uuid.Parse(chi.URLParam(r, "license_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse license id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	op, err := c.licensesInteractor.Payout(ctx, licenses.PayoutParams{
+		LicenseID: licenseID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error license payout. %w", err)
+	}
+
+	return c.presenter.ResponseOperation(ctx, op)
+}
+
+func (c *licensesController) ListOperations(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.ListLicenseOperationsRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	licenseID, err := uuid.Parse(chi.URLParam(r, "license_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse license id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	ids, err := parseUUIDs(req.IDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse operation id. %w", err)
+	}
+
+	ops, err := c.licensesInteractor.ListOperations(ctx, licenses.ListOperationsParams{
+		OrganizationID: organizationID,
+		IDs:            ids,
+		LicenseIDs:     uuid.UUIDs{licenseID},
+		Limit:          int64(req.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch license operations. %w", err)
+	}
+
+	return c.presenter.ResponseOperations(ctx, ops)
+}
+
+func (c *licensesController) ConfirmOperation(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	operationID, err := uuid.Parse(chi.URLParam(r, "operation_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse operation id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	op, err := c.licensesInteractor.ConfirmOperation(ctx, licenses.ConfirmOperationParams{
+		ID:             operationID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error confirm license operation. %w", err)
+	}
+
+	return c.presenter.ResponseOperation(ctx, op)
+}
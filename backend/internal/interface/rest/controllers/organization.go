@@ -9,14 +9,23 @@ import (
 
 	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
 	"github.com/emochka2007/block-accounting/internal/interface/rest/presenters"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
 type OrganizationsController interface {
 	NewOrganization(w http.ResponseWriter, r *http.Request) ([]byte, error)
 	ListOrganizations(w http.ResponseWriter, r *http.Request) ([]byte, error)
-	// todo delete
-	// todo update
+	UpdateOrganization(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Archive(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Unarchive(w http.ResponseWriter, r *http.Request) ([]byte, error)
+
+	TransferOwnership(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ListOwnershipTransfers(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ConfirmOwnershipTransfer(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	CancelOwnershipTransfer(w http.ResponseWriter, r *http.Request) ([]byte, error)
 }
 
 type organizationsController struct {
@@ -77,3 +86,173 @@ func (c *organizationsController) ListOrganizations(w http.ResponseWriter, r *ht
 
 	return c.presenter.ResponseList(resp.Organizations, resp.NextCursor)
 }
+
+func (c *organizationsController) UpdateOrganization(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.UpdateOrganizationRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	org, err := c.orgInteractor.Update(ctx, organizations.UpdateParams{
+		OrganizationID: organizationID,
+		Name:           req.Name,
+		Address:        req.Address,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error update organization. %w", err)
+	}
+
+	return c.presenter.ResponseCreate(org)
+}
+
+func (c *organizationsController) Archive(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	return c.archive(r, true)
+}
+
+func (c *organizationsController) Unarchive(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	return c.archive(r, false)
+}
+
+func (c *organizationsController) archive(r *http.Request, archived bool) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	org, err := c.orgInteractor.Archive(ctx, organizations.ArchiveParams{
+		OrganizationID: organizationID,
+		Archived:       archived,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error change organization archive state. %w", err)
+	}
+
+	return c.presenter.ResponseCreate(org)
+}
+
+func (c *organizationsController) TransferOwnership(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.NewOwnershipTransferRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	toUserID, err := uuid.Parse(req.ToUserID)
+	if err != nil {
+		return nil, fmt.Errorf("error parse new owner id. %w", err)
+	}
+
+	var fromUserID uuid.UUID
+
+	if req.FromUserID != "" {
+		if fromUserID, err = uuid.Parse(req.FromUserID); err != nil {
+			return nil, fmt.Errorf("error parse current owner id. %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	transfer, err := c.orgInteractor.TransferOwnership(ctx, organizations.TransferOwnershipParams{
+		OrganizationID: organizationID,
+		FromUserID:     fromUserID,
+		ToUserID:       toUserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error create ownership transfer. %w", err)
+	}
+
+	return c.presenter.ResponseOwnershipTransfer(transfer)
+}
+
+func (c *organizationsController) ListOwnershipTransfers(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.ListOwnershipTransfersRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	transfers, err := c.orgInteractor.ListOwnershipTransfers(ctx, organizations.ListOwnershipTransfersParams{
+		OrganizationID: organizationID,
+		PendingOnly:    req.PendingOnly,
+		Limit:          req.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ownership transfers. %w", err)
+	}
+
+	return c.presenter.ResponseOwnershipTransfers(organizationID.String(), transfers)
+}
+
+func (c *organizationsController) ConfirmOwnershipTransfer(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	params, err := ownershipTransferParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	transfer, err := c.orgInteractor.ConfirmOwnershipTransfer(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error confirm ownership transfer. %w", err)
+	}
+
+	return c.presenter.ResponseOwnershipTransfer(transfer)
+}
+
+func (c *organizationsController) CancelOwnershipTransfer(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	params, err := ownershipTransferParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	transfer, err := c.orgInteractor.CancelOwnershipTransfer(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error cancel ownership transfer. %w", err)
+	}
+
+	return c.presenter.ResponseOwnershipTransfer(transfer)
+}
+
+func ownershipTransferParams(r *http.Request) (organizations.OwnershipTransferParams, error) {
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return organizations.OwnershipTransferParams{}, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	transferID, err := uuid.Parse(chi.URLParam(r, "transfer_id"))
+	if err != nil {
+		return organizations.OwnershipTransferParams{}, fmt.Errorf("error parse ownership transfer id. %w", err)
+	}
+
+	return organizations.OwnershipTransferParams{
+		OrganizationID: organizationID,
+		ID:             transferID,
+	}, nil
+}
@@ -10,14 +10,21 @@ import (
 	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
 	"github.com/emochka2007/block-accounting/internal/interface/rest/presenters"
 	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/users"
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
 type ParticipantsController interface {
 	List(w http.ResponseWriter, r *http.Request) ([]byte, error)
 	New(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Get(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Update(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Delete(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Restore(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	UpdateRole(w http.ResponseWriter, r *http.Request) ([]byte, error)
 }
 
 type participantsController struct {
@@ -48,11 +55,6 @@ func (c *participantsController) List(w http.ResponseWriter, r *http.Request) ([
 		return nil, fmt.Errorf("error build list participants request. %w", err)
 	}
 
-	user, err := ctxmeta.User(r.Context())
-	if err != nil {
-		return nil, fmt.Errorf("error fetch user from context. %w", err)
-	}
-
 	organizationID, err := ctxmeta.OrganizationId(r.Context())
 	if err != nil {
 		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
@@ -61,18 +63,6 @@ func (c *participantsController) List(w http.ResponseWriter, r *http.Request) ([
 	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
 	defer cancel()
 
-	participant, err := c.orgInteractor.Participant(ctx, organizations.ParticipantParams{
-		ID:             user.Id(),
-		OrganizationID: organizationID,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error fetch user participant. %w", err)
-	}
-
-	if !participant.IsActive() {
-		return nil, fmt.Errorf("error participant is inactive")
-	}
-
 	ids := make(uuid.UUIDs, len(req.IDs))
 	for i, id := range req.IDs {
 		uid, err := uuid.Parse(id)
@@ -83,15 +73,27 @@ func (c *participantsController) List(w http.ResponseWriter, r *http.Request) ([
 		ids[i] = uid
 	}
 
-	participants, err := c.orgInteractor.Participants(ctx, organizations.ParticipantsParams{
-		IDs:            ids,
-		OrganizationID: organizationID,
+	result, err := c.orgInteractor.ListParticipants(ctx, organizations.ListParticipantsParams{
+		ParticipantsParams: organizations.ParticipantsParams{
+			IDs:            ids,
+			OrganizationID: organizationID,
+			UsersOnly:      req.UsersOnly,
+			EmployeesOnly:  req.EmployeesOnly,
+			ActiveOnly:     req.ActiveOnly,
+			OwnerOnly:      req.OwnerOnly,
+		},
+		Search: req.Search,
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error fetch participants. %w", err)
 	}
 
-	return c.presenter.ResponseListParticipants(ctx, participants)
+	return c.presenter.ResponseListParticipants(ctx, result.Participants, domain.Pagination{
+		NextCursor: result.NextCursor,
+		TotalItems: uint32(result.TotalItems),
+	})
 }
 
 func (c *participantsController) New(w http.ResponseWriter, r *http.Request) ([]byte, error) {
@@ -120,3 +122,150 @@ func (c *participantsController) New(w http.ResponseWriter, r *http.Request) ([]
 
 	return c.presenter.ResponseParticipant(ctx, participant)
 }
+
+func (c *participantsController) UpdateRole(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.UpdateParticipantRoleRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build update participant role request. %w", err)
+	}
+
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	participantID, err := uuid.Parse(chi.URLParam(r, "participant_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse participant id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	participant, err := c.orgInteractor.UpdateRole(ctx, organizations.UpdateRoleParams{
+		OrganizationID: organizationID,
+		UserID:         participantID,
+		Role:           models.ParseRole(req.Role),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error update participant role. %w", err)
+	}
+
+	return c.presenter.ResponseParticipant(ctx, participant)
+}
+
+func (c *participantsController) Get(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	participantID, err := uuid.Parse(chi.URLParam(r, "participant_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse participant id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	participant, err := c.orgInteractor.Participant(ctx, organizations.ParticipantParams{
+		ID:             participantID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch participant. %w", err)
+	}
+
+	return c.presenter.ResponseParticipant(ctx, participant)
+}
+
+func (c *participantsController) Update(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.UpdateParticipantRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build update participant request. %w", err)
+	}
+
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	participantID, err := uuid.Parse(chi.URLParam(r, "participant_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse participant id. %w", err)
+	}
+
+	params := organizations.UpdateParticipantParams{
+		OrganizationID: organizationID,
+		ID:             participantID,
+		Name:           req.Name,
+		Position:       req.Position,
+		WalletAddress:  req.WalletAddress,
+	}
+
+	if req.Role != "" {
+		if params.Role = models.ParseRole(req.Role); params.Role == models.RoleUnknown {
+			return nil, fmt.Errorf("error parse role. %w", organizations.ErrorInvalidRole)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	participant, err := c.orgInteractor.UpdateParticipant(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error update participant. %w", err)
+	}
+
+	return c.presenter.ResponseParticipant(ctx, participant)
+}
+
+func (c *participantsController) Delete(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	participantID, err := uuid.Parse(chi.URLParam(r, "participant_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse participant id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	participant, err := c.orgInteractor.DeleteParticipant(ctx, organizations.DeleteParticipantParams{
+		OrganizationID: organizationID,
+		ID:             participantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error delete participant. %w", err)
+	}
+
+	return c.presenter.ResponseParticipant(ctx, participant)
+}
+
+func (c *participantsController) Restore(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	participantID, err := uuid.Parse(chi.URLParam(r, "participant_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse participant id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	participant, err := c.orgInteractor.RestoreParticipant(ctx, organizations.DeleteParticipantParams{
+		OrganizationID: organizationID,
+		ID:             participantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error restore participant. %w", err)
+	}
+
+	return c.presenter.ResponseParticipant(ctx, participant)
+}
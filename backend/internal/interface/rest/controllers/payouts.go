@@ -0,0 +1,208 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/presenters"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/payouts"
+)
+
+type PayoutsController interface {
+	NewPayout(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ListPayouts(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ConfirmPayout(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	ListPayments(w http.ResponseWriter, r *http.Request) ([]byte, error)
+	Deposit(w http.ResponseWriter, r *http.Request) ([]byte, error)
+}
+
+type payoutsController struct {
+	log               *slog.Logger
+	payoutsInteractor payouts.PayoutsInteractor
+	presenter         presenters.PayoutsPresenter
+	jobsPresenter     presenters.JobsPresenter
+}
+
+func NewPayoutsController(
+	log *slog.Logger,
+	payoutsInteractor payouts.PayoutsInteractor,
+	presenter presenters.PayoutsPresenter,
+	jobsPresenter presenters.JobsPresenter,
+) PayoutsController {
+	return &payoutsController{
+		log:               log,
+		payoutsInteractor: payoutsInteractor,
+		presenter:         presenter,
+		jobsPresenter:     jobsPresenter,
+	}
+}
+
+func (c *payoutsController) NewPayout(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.NewPayoutRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	payrollID, err := uuid.Parse(req.PayrollID)
+	if err != nil {
+		return nil, fmt.Errorf("error parse payroll id. %w", err)
+	}
+
+	employeeIDs, err := parseUUIDs(req.EmployeeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse employee id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	run, err := c.payoutsInteractor.CreateRun(ctx, payouts.CreateRunParams{
+		PayrollID:     payrollID,
+		EmployeeIDs:   employeeIDs,
+		DepositAmount: money.New(req.DepositAmount, money.ETH),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error create payout run. %w", err)
+	}
+
+	return c.presenter.ResponsePayoutRun(ctx, run)
+}
+
+func (c *payoutsController) ListPayouts(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.ListPayoutsRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	ids, err := parseUUIDs(req.IDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse payout id. %w", err)
+	}
+
+	payrollIDs, err := parseUUIDs(req.PayrollIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse payroll id. %w", err)
+	}
+
+	runs, err := c.payoutsInteractor.ListRuns(ctx, payouts.ListRunsParams{
+		OrganizationID: organizationID,
+		IDs:            ids,
+		PayrollIDs:     payrollIDs,
+		Limit:          int64(req.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch payout runs. %w", err)
+	}
+
+	return c.presenter.ResponsePayoutRuns(ctx, runs)
+}
+
+func (c *payoutsController) ConfirmPayout(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	payoutID, err := uuid.Parse(chi.URLParam(r, "payout_id"))
+	if err != nil {
+		return nil, fmt.Errorf("error parse payout id. %w", err)
+	}
+
+	run, err := c.payoutsInteractor.ConfirmRun(ctx, payouts.ConfirmRunParams{
+		ID:             payoutID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error confirm payout run. %w", err)
+	}
+
+	return c.presenter.ResponsePayoutRun(ctx, run)
+}
+
+func (c *payoutsController) ListPayments(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.ListPaymentsRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	payoutIDs, err := parseUUIDs(req.PayoutIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse payout id. %w", err)
+	}
+
+	payrollIDs, err := parseUUIDs(req.PayrollIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse payroll id. %w", err)
+	}
+
+	employeeIDs, err := parseUUIDs(req.EmployeeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error parse employee id. %w", err)
+	}
+
+	payments, err := c.payoutsInteractor.ListPayments(ctx, payouts.ListPaymentsParams{
+		OrganizationID: organizationID,
+		PayoutRunIDs:   payoutIDs,
+		PayrollIDs:     payrollIDs,
+		EmployeeIDs:    employeeIDs,
+		Limit:          int64(req.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch payments. %w", err)
+	}
+
+	return c.presenter.ResponsePayments(ctx, payments)
+}
+
+func (c *payoutsController) Deposit(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	req, err := presenters.CreateRequest[domain.NewDepositRequest](r)
+	if err != nil {
+		return nil, fmt.Errorf("error build request. %w", err)
+	}
+
+	payrollID, err := uuid.Parse(req.PayrollID)
+	if err != nil {
+		return nil, fmt.Errorf("error parse payroll id. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	job, err := c.payoutsInteractor.Deposit(ctx, payouts.DepositParams{
+		PayrollID: payrollID,
+		Amount:    money.New(req.Amount, money.ETH),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error deposit payroll. %w", err)
+	}
+
+	return c.jobsPresenter.ResponseJob(job)
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/presenters"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/reports"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+	reportFormatPDF  = "pdf"
+)
+
+type ReportsController interface {
+	// Report accepts from, to and format query params. Format is one of json, csv and pdf, json by default
+	Report(w http.ResponseWriter, r *http.Request) ([]byte, error)
+}
+
+type reportsController struct {
+	log               *slog.Logger
+	reportsInteractor reports.ReportsInteractor
+	presenter         presenters.ReportsPresenter
+}
+
+func NewReportsController(
+	log *slog.Logger,
+	reportsInteractor reports.ReportsInteractor,
+	presenter presenters.ReportsPresenter,
+) ReportsController {
+	return &reportsController{
+		log:               log,
+		reportsInteractor: reportsInteractor,
+		presenter:         presenter,
+	}
+}
+
+func (c *reportsController) Report(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = reportFormatJSON
+	}
+
+	if format != reportFormatJSON && format != reportFormatCSV && format != reportFormatPDF {
+		return nil, fmt.Errorf("error unknown format %s. %w", format, ErrorInvalidQueryParams)
+	}
+
+	var from, to int64
+
+	for name, dst := range map[string]*int64{"from": &from, "to": &to} {
+		if v := query.Get(name); v != "" {
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parse %s. %w", name, ErrorInvalidQueryParams)
+			}
+
+			*dst = parsed
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	params := reports.ReportParams{
+		OrganizationID: organizationID,
+	}
+
+	if statement := chi.URLParam(r, "statement"); statement != "" {
+		params.Statements = []reports.Statement{reports.Statement(statement)}
+	}
+
+	if from > 0 {
+		params.From = time.UnixMilli(from)
+	}
+
+	if to > 0 {
+		params.To = time.UnixMilli(to)
+	}
+
+	report, err := c.reportsInteractor.Report(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error build report. %w", err)
+	}
+
+	filename := fmt.Sprintf("report-%s-%s.%s", organizationID, report.To.UTC().Format("20060102"), format)
+
+	switch format {
+	case reportFormatCSV:
+		out, err := c.presenter.ResponseReportCSV(ctx, report)
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+		return out, nil
+	case reportFormatPDF:
+		out, err := c.presenter.ResponseReportPDF(ctx, report)
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+		return out, nil
+	}
+
+	return c.presenter.ResponseReport(ctx, report)
+}
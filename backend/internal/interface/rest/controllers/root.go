@@ -6,6 +6,14 @@ type RootController struct {
 	Organizations OrganizationsController
 	Transactions  TransactionsController
 	Participants  ParticipantsController
+	Jobs          JobsController
+	Payouts       PayoutsController
+	Licenses      LicensesController
+	Agreements    AgreementsController
+	Confirmations ConfirmationsController
+	Ledger        LedgerController
+	Assets        AssetsController
+	Reports       ReportsController
 }
 
 func NewRootController(
@@ -14,6 +22,14 @@ func NewRootController(
 	organizations OrganizationsController,
 	transactions TransactionsController,
 	participants ParticipantsController,
+	jobs JobsController,
+	payouts PayoutsController,
+	licenses LicensesController,
+	agreements AgreementsController,
+	confirmations ConfirmationsController,
+	ledger LedgerController,
+	assets AssetsController,
+	reports ReportsController,
 ) *RootController {
 	return &RootController{
 		Ping:          ping,
@@ -21,5 +37,13 @@ func NewRootController(
 		Organizations: organizations,
 		Transactions:  transactions,
 		Participants:  participants,
+		Jobs:          jobs,
+		Payouts:       payouts,
+		Licenses:      licenses,
+		Agreements:    agreements,
+		Confirmations: confirmations,
+		Ledger:        ledger,
+		Assets:        assets,
+		Reports:       reports,
 	}
 }
@@ -0,0 +1,33 @@
+package domain
+
+type Agreement struct {
+	Id             string `json:"id"`
+	OrganizationId string `json:"organization_id"`
+	MultisigId     string `json:"multisig_id"`
+	Title          string `json:"title"`
+	Address        string `json:"address,omitempty"`
+	Status         string `json:"status"`
+	RequestURL     string `json:"request_url,omitempty"`
+	Outcome        *bool  `json:"outcome,omitempty"`
+	ResolvedAt     int64  `json:"resolved_at,omitempty"`
+	CreatedBy      string `json:"created_by"`
+	CreatedAt      int64  `json:"created_at"`
+	UpdatedAt      int64  `json:"updated_at"`
+}
+
+type AgreementOperation struct {
+	Id            string   `json:"id"`
+	AgreementId   string   `json:"agreement_id"`
+	MultisigId    string   `json:"multisig_id"`
+	Kind          string   `json:"kind"`
+	Status        string   `json:"status"`
+	URL           string   `json:"url,omitempty"`
+	TxIndex       int64    `json:"tx_index,omitempty"`
+	TxHash        string   `json:"tx_hash,omitempty"`
+	ConfirmedBy   []string `json:"confirmed_by"`
+	Confirmations int      `json:"confirmations"`
+	CreatedBy     string   `json:"created_by"`
+	CreatedAt     int64    `json:"created_at"`
+	UpdatedAt     int64    `json:"updated_at"`
+	ExecutedAt    int64    `json:"executed_at,omitempty"`
+}
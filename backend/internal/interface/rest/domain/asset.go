@@ -0,0 +1,12 @@
+package domain
+
+type Asset struct {
+	Id              string `json:"id"`
+	OrganizationId  string `json:"organization_id"`
+	Symbol          string `json:"symbol"`
+	ContractAddress string `json:"contract_address"`
+	Decimals        int32  `json:"decimals"`
+	ChainId         int64  `json:"chain_id"`
+	CreatedBy       string `json:"created_by"`
+	CreatedAt       int64  `json:"created_at"`
+}
@@ -0,0 +1,12 @@
+package domain
+
+type Confirmation struct {
+	EntityId              string   `json:"entity_id"`
+	EntityType            string   `json:"entity_type"`
+	MultisigId            string   `json:"multisig_id"`
+	Pending               bool     `json:"pending"`
+	ConfirmationsRequired int      `json:"confirmations_required"`
+	Confirmations         int      `json:"confirmations"`
+	ConfirmedBy           []string `json:"confirmed_by,omitempty"`
+	RejectedBy            []string `json:"rejected_by,omitempty"`
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "github.com/emochka2007/block-accounting/internal/pkg/money"
+
 // Generic
 
 type Collection[T any] struct {
@@ -33,6 +35,34 @@ type LoginRequest struct {
 	Mnemonic string `json:"mnemonic"`
 }
 
+type WalletNonceRequest struct {
+	Address string `json:"address"`
+}
+
+type WalletNonceResponse struct {
+	Nonce     string `json:"nonce"`
+	Message   string `json:"message"`
+	ExpiredAt int64  `json:"expired_at"`
+}
+
+type WalletLoginRequest struct {
+	Address string `json:"address"`
+	Nonce   string `json:"nonce"`
+	// Signature is the hex encoded personal_sign signature of the nonce message
+	Signature string `json:"signature"`
+}
+
+type WalletJoinRequest struct {
+	Name       string `json:"name,omitempty"`
+	Credentals struct {
+		Email    string `json:"email,omitempty"`
+		Phone    string `json:"phone,omitempty"`
+		Telegram string `json:"telegram,omitempty"`
+	} `json:"credentals,omitempty"`
+
+	WalletLoginRequest
+}
+
 type RefreshRequest struct {
 	Token        string `json:"token"`
 	RefreshToken string `json:"refresh_token"`
@@ -45,8 +75,29 @@ type LoginResponse struct {
 	RTExpiredAt  int64  `json:"refresh_token_expired_at"`
 }
 
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
 type NewInviteLinkRequest struct {
 	ExpirationDate int `json:"expiration_date"`
+	// Role is one of viewer, approver, accountant, admin, owner. Default: viewer
+	Role     string `json:"role,omitempty"`
+	Position string `json:"position,omitempty"`
+	// MaxUses is the number of users allowed to join with the link. Default: 1, Max: 1000
+	MaxUses int `json:"max_uses,omitempty"`
+	// PublicKey binds invite to the existing user wallet, hex encoded
+	PublicKey string `json:"public_key,omitempty"`
+}
+
+type ListReceivedInvitesRequest struct {
+	Limit uint8 `json:"limit,omitempty"` // Default: 50, Max: 50
+}
+
+type ListInvitesRequest struct {
+	// All includes revoked, expired and exhausted invites
+	All   bool  `json:"all,omitempty"`
+	Limit uint8 `json:"limit,omitempty"` // Default: 50, Max: 50
 }
 
 // Organizations
@@ -62,12 +113,30 @@ type ListOrganizationsRequest struct {
 	Limit  uint8  `json:"limit,omitempty"` // Default: 50, Max: 50
 }
 
+type UpdateOrganizationRequest struct {
+	Name    string `json:"name,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+type NewOwnershipTransferRequest struct {
+	// FromUserID is the current owner, caller if empty
+	FromUserID string `json:"from_user_id,omitempty"`
+	ToUserID   string `json:"to_user_id"`
+}
+
+type ListOwnershipTransfersRequest struct {
+	PendingOnly bool  `json:"pending_only,omitempty"`
+	Limit       uint8 `json:"limit,omitempty"` // Default: 50, Max: 50
+}
+
 // Transactions
 
 type NewTransactionRequest struct {
-	Description string  `json:"description,omitempty"`
-	Amount      float64 `json:"amount,omitempty"`
-	ToAddr      string  `json:"to,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Amount      money.Decimal `json:"amount,omitempty"`
+	ToAddr      string        `json:"to,omitempty"`
+	// AssetID of the ERC-20 token to transfer. Native ETH is sent if empty
+	AssetID string `json:"asset_id,omitempty"`
 
 	MultisigID            string `json:"multisig_id"`
 	ConfirmationsRequired int    `json:"confirmations_required"`
@@ -84,14 +153,24 @@ type ListTransactionsRequest struct {
 type UpdateTransactionStatusRequest struct {
 	Cancel  bool `json:"cancel,omitempty"`
 	Confirm bool `json:"confirm,omitempty"`
+	Revoke  bool `json:"revoke,omitempty"`
+	Reject  bool `json:"reject,omitempty"`
 }
 
 // Participants
 
 type ListParticipantsRequest struct {
 	IDs    []string `json:"ids,omitempty"`
-	Cursor string   `json:"cursor,omitempty"` // not implemented
-	Limit  uint8    `json:"limit,omitempty"`  // not implemented
+	Cursor string   `json:"cursor,omitempty"`
+	Limit  uint8    `json:"limit,omitempty"`
+
+	// Filters
+	UsersOnly     bool `json:"users_only,omitempty"`
+	EmployeesOnly bool `json:"employees_only,omitempty"`
+	ActiveOnly    bool `json:"active_only,omitempty"`
+	OwnerOnly     bool `json:"owner_only,omitempty"`
+	// Search matches participant name or position
+	Search string `json:"search,omitempty"`
 }
 
 type AddEmployeeRequest struct {
@@ -100,6 +179,20 @@ type AddEmployeeRequest struct {
 	WalletAddress string `json:"wallet_address"`
 }
 
+type UpdateParticipantRequest struct {
+	// Name and WalletAddress are updated for employees only
+	Name          string `json:"name,omitempty"`
+	Position      string `json:"position,omitempty"`
+	WalletAddress string `json:"wallet_address,omitempty"`
+	// Role is updated for users only
+	Role string `json:"role,omitempty"`
+}
+
+type UpdateParticipantRoleRequest struct {
+	// Role is one of viewer, approver, accountant, admin, owner
+	Role string `json:"role"`
+}
+
 // Chain
 
 type NewMultisigRequest struct {
@@ -113,8 +206,8 @@ type NewMultisigRequest struct {
 type ListMultisigsRequest struct{}
 
 type NewMultisigDepositRequest struct {
-	MultisigID string  `json:"multisig_id"`
-	Amount     float64 `json:"amount"`
+	MultisigID string        `json:"multisig_id"`
+	Amount     money.Decimal `json:"amount"`
 }
 
 // Payrolls and salaries
@@ -129,22 +222,153 @@ type ListPayrollsRequest struct {
 	Limit uint8    `json:"limit"`
 }
 
+// ConfirmPayrollRequest is the body of the deprecated PUT /payrolls
+type ConfirmPayrollRequest struct {
+	PayrollID string `json:"payroll_id"`
+}
+
 type SetSalaryRequest struct {
-	EmployeeID string  `json:"employee_id"`
-	Salary     float64 `json:"salary"`
-	PayrollID  string  `json:"payroll_id"`
+	EmployeeID string        `json:"employee_id"`
+	Salary     money.Decimal `json:"salary"`
+	PayrollID  string        `json:"payroll_id"`
 }
 
+type ListSalariesRequest struct {
+	IDs         []string `json:"ids"`
+	PayrollIDs  []string `json:"payroll_ids"`
+	EmployeeIDs []string `json:"employee_ids"`
+	Limit       uint8    `json:"limit"`
+	// OnChain adds salary stored in the payroll contract to the response
+	OnChain bool `json:"on_chain"`
+}
+
+// Payouts
+
 type NewPayoutRequest struct {
-	EmployeeID string `json:"employee_id"`
-	SalaryID   string `json:"salary_id"`
+	PayrollID string `json:"payroll_id"`
+	// EmployeeIDs limits payout run to the given employees. All employees with salaries are paid if empty
+	EmployeeIDs []string `json:"employee_ids"`
+	// DepositAmount in ETH is sent to the payroll contract before payout
+	DepositAmount money.Decimal `json:"deposit_amount"`
+}
+
+type ListPayoutsRequest struct {
+	IDs        []string `json:"ids"`
+	PayrollIDs []string `json:"payroll_ids"`
+	Limit      uint8    `json:"limit"`
+}
+
+type ListPaymentsRequest struct {
+	PayoutIDs   []string `json:"payout_ids"`
+	PayrollIDs  []string `json:"payroll_ids"`
+	EmployeeIDs []string `json:"employee_ids"`
+	Limit       uint8    `json:"limit"`
 }
 
 type NewDepositRequest struct {
-	PayrollID string  `json:"payroll_id"`
-	Amount    float64 `json:"amount"`
+	PayrollID string        `json:"payroll_id"`
+	Amount    money.Decimal `json:"amount"`
 }
 
 type ConfirmSalaryRequest struct {
 	SalaryID string `json:"salary_id"`
 }
+
+// Licenses
+
+type NewLicenseRequest struct {
+	Title        string `json:"title"`
+	MultisigID   string `json:"multisig_id"`
+	Shareholders []struct {
+		// ParticipantID if set, participant wallet address is used
+		ParticipantID string `json:"participant_id"`
+		Address       string `json:"address"`
+		// Share in percents, shares of all shareholders sum up to 100
+		Share int `json:"share"`
+	} `json:"shareholders"`
+}
+
+type ListLicensesRequest struct {
+	IDs   []string `json:"ids"`
+	Limit uint8    `json:"limit"`
+}
+
+type LicenseRequestRequest struct {
+	URL string `json:"url"`
+}
+
+type SetLicensePayoutContractRequest struct {
+	PayrollID string `json:"payroll_id"`
+}
+
+type ListLicenseOperationsRequest struct {
+	IDs   []string `json:"ids"`
+	Limit uint8    `json:"limit"`
+}
+
+// Agreements
+
+type NewAgreementRequest struct {
+	Title      string `json:"title"`
+	MultisigID string `json:"multisig_id"`
+}
+
+type ListAgreementsRequest struct {
+	IDs   []string `json:"ids"`
+	Limit uint8    `json:"limit"`
+}
+
+type AgreementRequestRequest struct {
+	URL string `json:"url"`
+}
+
+type ListAgreementOperationsRequest struct {
+	IDs   []string `json:"ids"`
+	Limit uint8    `json:"limit"`
+}
+
+// Ledger
+
+type LedgerRequest struct {
+	// AsOf unix milli timestamp, entries and balances include everything occurred before it. Defaults to now
+	AsOf int64 `json:"as_of"`
+	// From unix milli timestamp, entries occurred before it are omitted. Does not affect balances
+	From        int64    `json:"from"`
+	SourceTypes []string `json:"source_types"`
+	Limit       uint8    `json:"limit"`
+}
+
+type NewLedgerAccountRequest struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// Type is one of asset, liability, equity, income, expense
+	Type string `json:"type"`
+}
+
+type NewLedgerEntryRequest struct {
+	Description string `json:"description"`
+	// OccurredAt unix milli timestamp. Defaults to now
+	OccurredAt int64                       `json:"occurred_at"`
+	Lines      []NewLedgerEntryLineRequest `json:"lines"`
+}
+
+type NewLedgerEntryLineRequest struct {
+	AccountCode string        `json:"account_code"`
+	Currency    string        `json:"currency"`
+	Debit       money.Decimal `json:"debit"`
+	Credit      money.Decimal `json:"credit"`
+}
+
+// Assets
+
+type NewAssetRequest struct {
+	Symbol          string `json:"symbol"`
+	ContractAddress string `json:"contract_address"`
+	Decimals        int32  `json:"decimals"`
+	// ChainID defaults to the network multisigs are deployed to
+	ChainID int64 `json:"chain_id,omitempty"`
+}
+
+type ListAssetsRequest struct {
+	IDs []string `json:"ids"`
+}
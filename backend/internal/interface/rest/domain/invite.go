@@ -0,0 +1,33 @@
+package domain
+
+type Invite struct {
+	Link           string `json:"link"`
+	Hash           string `json:"hash"`
+	OrganizationId string `json:"organization_id"`
+	CreatedBy      string `json:"created_by"`
+	Role           string `json:"role"`
+	Position       string `json:"position,omitempty"`
+	PublicKey      string `json:"public_key,omitempty"`
+	MaxUses        int    `json:"max_uses"`
+	Uses           int    `json:"uses"`
+	Active         bool   `json:"active"`
+	CreatedAt      int64  `json:"created_at"`
+	ExpiredAt      int64  `json:"expired_at"`
+	UsedAt         int64  `json:"used_at,omitempty"`
+	RevokedAt      int64  `json:"revoked_at,omitempty"`
+}
+
+// InviteInfo is a public invite view rendered on the join page
+type InviteInfo struct {
+	OrganizationId   string `json:"organization_id"`
+	OrganizationName string `json:"organization_name"`
+	InviterId        string `json:"inviter_id,omitempty"`
+	InviterName      string `json:"inviter_name,omitempty"`
+	Role             string `json:"role"`
+	Position         string `json:"position,omitempty"`
+	// Bound invites are accepted by the addressed user only
+	Bound     bool  `json:"bound"`
+	UsesLeft  int   `json:"uses_left"`
+	Active    bool  `json:"active"`
+	ExpiredAt int64 `json:"expired_at"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "encoding/json"
+
+type Job struct {
+	Id             string          `json:"id"`
+	OrganizationId string          `json:"organization_id"`
+	CreatedBy      string          `json:"created_by"`
+	Kind           string          `json:"kind"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	MaxAttempts    int             `json:"max_attempts"`
+	Result         json.RawMessage `json:"result,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	RunAt          int64           `json:"run_at"`
+	CreatedAt      int64           `json:"created_at"`
+	UpdatedAt      int64           `json:"updated_at"`
+	StartedAt      int64           `json:"started_at,omitempty"`
+	FinishedAt     int64           `json:"finished_at,omitempty"`
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "github.com/emochka2007/block-accounting/internal/pkg/money"
+
+type LedgerAccount struct {
+	Id             string `json:"id"`
+	OrganizationId string `json:"organization_id"`
+	Code           string `json:"code"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type LedgerEntry struct {
+	Id             string       `json:"id"`
+	OrganizationId string       `json:"organization_id"`
+	Description    string       `json:"description,omitempty"`
+	SourceType     string       `json:"source_type"`
+	SourceId       string       `json:"source_id"`
+	Lines          []LedgerLine `json:"lines"`
+	OccurredAt     int64        `json:"occurred_at"`
+	CreatedBy      string       `json:"created_by,omitempty"`
+	CreatedAt      int64        `json:"created_at"`
+}
+
+type LedgerLine struct {
+	AccountId   string        `json:"account_id"`
+	AccountCode string        `json:"account_code"`
+	Currency    string        `json:"currency"`
+	Debit       money.Decimal `json:"debit"`
+	Credit      money.Decimal `json:"credit"`
+	// Rate and fiat amounts are omitted if the entry has no price snapshot
+	Rate       *money.Decimal `json:"rate,omitempty"`
+	FiatDebit  *money.Decimal `json:"fiat_debit,omitempty"`
+	FiatCredit *money.Decimal `json:"fiat_credit,omitempty"`
+}
+
+type LedgerBalance struct {
+	AccountId   string        `json:"account_id"`
+	AccountCode string        `json:"account_code"`
+	AccountName string        `json:"account_name"`
+	AccountType string        `json:"account_type"`
+	Currency    string        `json:"currency"`
+	Debit       money.Decimal `json:"debit"`
+	Credit      money.Decimal `json:"credit"`
+	Balance     money.Decimal `json:"balance"`
+
+	FiatCurrency  string        `json:"fiat_currency"`
+	FiatDebit     money.Decimal `json:"fiat_debit"`
+	FiatCredit    money.Decimal `json:"fiat_credit"`
+	FiatBalance   money.Decimal `json:"fiat_balance"`
+	UnpricedLines int64         `json:"unpriced_lines"`
+}
@@ -0,0 +1,47 @@
+package domain
+
+type License struct {
+	Id             string               `json:"id"`
+	OrganizationId string               `json:"organization_id"`
+	MultisigId     string               `json:"multisig_id"`
+	Title          string               `json:"title"`
+	Address        string               `json:"address,omitempty"`
+	Status         string               `json:"status"`
+	OracleURL      string               `json:"oracle_url,omitempty"`
+	PayrollId      string               `json:"payroll_id,omitempty"`
+	Shareholders   []LicenseShareholder `json:"shareholders"`
+	CreatedBy      string               `json:"created_by"`
+	CreatedAt      int64                `json:"created_at"`
+	UpdatedAt      int64                `json:"updated_at"`
+}
+
+type LicenseShareholder struct {
+	ParticipantId string `json:"participant_id,omitempty"`
+	Address       string `json:"address"`
+	Share         int    `json:"share"`
+}
+
+type LicenseOperation struct {
+	Id            string   `json:"id"`
+	LicenseId     string   `json:"license_id"`
+	MultisigId    string   `json:"multisig_id"`
+	Kind          string   `json:"kind"`
+	Status        string   `json:"status"`
+	URL           string   `json:"url,omitempty"`
+	PayrollId     string   `json:"payroll_id,omitempty"`
+	TxIndex       int64    `json:"tx_index,omitempty"`
+	TxHash        string   `json:"tx_hash,omitempty"`
+	ConfirmedBy   []string `json:"confirmed_by"`
+	Confirmations int      `json:"confirmations"`
+	CreatedBy     string   `json:"created_by"`
+	CreatedAt     int64    `json:"created_at"`
+	UpdatedAt     int64    `json:"updated_at"`
+	ExecutedAt    int64    `json:"executed_at,omitempty"`
+}
+
+type LicenseInfo struct {
+	LicenseId        string               `json:"license_id"`
+	Shareholders     []LicenseShareholder `json:"shareholders"`
+	TotalPayoutInUSD string               `json:"total_payout_in_usd"`
+	PayoutContract   string               `json:"payout_contract,omitempty"`
+}
@@ -6,4 +6,19 @@ type Organization struct {
 	Address   string `json:"address"`
 	CreatedAt uint64 `json:"created_at"`
 	UpdatedAt uint64 `json:"updated_at"`
+	// ArchivedAt is set for read only organizations
+	ArchivedAt uint64 `json:"archived_at,omitempty"`
+}
+
+type OwnershipTransfer struct {
+	Id             string `json:"id"`
+	OrganizationId string `json:"organization_id"`
+	FromUserId     string `json:"from_user_id"`
+	ToUserId       string `json:"to_user_id"`
+	Status         string `json:"status"`
+	CreatedBy      string `json:"created_by"`
+	CreatedAt      int64  `json:"created_at"`
+	UpdatedAt      int64  `json:"updated_at"`
+	ConfirmedAt    int64  `json:"confirmed_at,omitempty"`
+	CancelledAt    int64  `json:"cancelled_at,omitempty"`
 }
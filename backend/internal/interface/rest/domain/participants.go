@@ -18,6 +18,10 @@ type Participant struct {
 	IsAdmin  bool `json:"is_admin"`
 	IsOwner  bool `json:"is_owner"`
 	IsActive bool `json:"is_active"`
+
+	// Role and Permissions are filled for users only
+	Role        string   `json:"role,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
 }
 
 type UserParticipantCredentials struct {
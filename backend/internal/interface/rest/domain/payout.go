@@ -0,0 +1,35 @@
+package domain
+
+import "github.com/emochka2007/block-accounting/internal/pkg/money"
+
+type PayoutRun struct {
+	Id             string        `json:"id"`
+	OrganizationId string        `json:"organization_id"`
+	PayrollId      string        `json:"payroll_id"`
+	MultisigId     string        `json:"multisig_id"`
+	Status         string        `json:"status"`
+	DepositAmount  money.Decimal `json:"deposit_amount"`
+	DepositTxHash  string        `json:"deposit_tx_hash,omitempty"`
+	ConfirmedBy    []string      `json:"confirmed_by"`
+	Confirmations  int           `json:"confirmations"`
+	CreatedBy      string        `json:"created_by"`
+	CreatedAt      int64         `json:"created_at"`
+	UpdatedAt      int64         `json:"updated_at"`
+	ExecutedAt     int64         `json:"executed_at,omitempty"`
+}
+
+type Payment struct {
+	Id              string        `json:"id"`
+	PayoutRunId     string        `json:"payout_id"`
+	PayrollId       string        `json:"payroll_id"`
+	SalaryId        string        `json:"salary_id"`
+	EmployeeId      string        `json:"employee_id"`
+	EmployeeAddress string        `json:"employee_address"`
+	Amount          money.Decimal `json:"amount"`
+	Status          string        `json:"status"`
+	TxIndex         int64         `json:"tx_index,omitempty"`
+	TxHash          string        `json:"tx_hash,omitempty"`
+	CreatedAt       int64         `json:"created_at"`
+	UpdatedAt       int64         `json:"updated_at"`
+	PaidAt          int64         `json:"paid_at,omitempty"`
+}
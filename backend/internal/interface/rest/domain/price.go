@@ -0,0 +1,11 @@
+package domain
+
+import "github.com/emochka2007/block-accounting/internal/pkg/money"
+
+// FiatValue is the fiat equivalent of the amount at the rate snapshotted when funds were moved
+type FiatValue struct {
+	Currency string        `json:"currency"`
+	Amount   money.Decimal `json:"amount"`
+	Rate     money.Decimal `json:"rate"`
+	PricedAt int64         `json:"priced_at"`
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "github.com/emochka2007/block-accounting/internal/pkg/money"
+
+type Report struct {
+	OrganizationId string            `json:"organization_id"`
+	From           int64             `json:"from,omitempty"`
+	To             int64             `json:"to"`
+	FiatCurrency   string            `json:"fiat_currency"`
+	Statements     []ReportStatement `json:"statements"`
+	Unposted       []ReportUnposted  `json:"unposted"`
+}
+
+type ReportUnposted struct {
+	SourceType  string        `json:"source_type"`
+	SourceId    string        `json:"source_id"`
+	Description string        `json:"description"`
+	Currency    string        `json:"currency"`
+	Amount      money.Decimal `json:"amount"`
+	OccurredAt  int64         `json:"occurred_at"`
+}
+
+type ReportStatement struct {
+	Statement string          `json:"statement"`
+	Title     string          `json:"title"`
+	Sections  []ReportSection `json:"sections"`
+	Summary   []ReportLine    `json:"summary"`
+}
+
+type ReportSection struct {
+	Name      string        `json:"name"`
+	Lines     []ReportLine  `json:"lines"`
+	Totals    []ReportLine  `json:"totals"`
+	FiatTotal money.Decimal `json:"fiat_total"`
+}
+
+type ReportLine struct {
+	Category      string        `json:"category"`
+	Name          string        `json:"name"`
+	Currency      string        `json:"currency"`
+	Amount        money.Decimal `json:"amount"`
+	Fiat          money.Decimal `json:"fiat"`
+	UnpricedLines int64         `json:"unpriced_lines,omitempty"`
+}
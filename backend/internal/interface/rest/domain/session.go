@@ -0,0 +1,10 @@
+package domain
+
+type Session struct {
+	Id         string `json:"id"`
+	RemoteAddr string `json:"remote_addr,omitempty"`
+	UserAgent  string `json:"user_agent,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+	ExpiredAt  int64  `json:"expired_at"`
+	Current    bool   `json:"current"`
+}
@@ -1,18 +1,50 @@
 package domain
 
+import "github.com/emochka2007/block-accounting/internal/pkg/money"
+
 type Transaction struct {
-	Id             string  `json:"id"`
-	Description    string  `json:"description"`
-	OrganizationId string  `json:"organization_id"`
-	CreatedBy      string  `json:"created_by"`
-	Amount         float64 `json:"amount"`
-	ToAddr         string  `json:"to"`
-	MaxFeeAllowed  float64 `json:"max_fee_allowed"`
-	Deadline       int64   `json:"deadline,omitempty"`
-	Status         int     `json:"status"`
-	CreatedAt      int64   `json:"created_at"`
-	UpdatedAt      int64   `json:"updated_at"`
-	ConfirmedAt    int64   `json:"confirmed_at,omitempty"`
-	CancelledAt    int64   `json:"cancelled_at,omitempty"`
-	CommitedAt     int64   `json:"commited_at,omitempty"`
+	Id             string        `json:"id"`
+	Description    string        `json:"description"`
+	OrganizationId string        `json:"organization_id"`
+	CreatedBy      string        `json:"created_by"`
+	Amount         money.Decimal `json:"amount"`
+	Asset          string        `json:"asset"`
+	AssetId        string        `json:"asset_id,omitempty"`
+	Fiat           *FiatValue    `json:"fiat,omitempty"`
+	ToAddr         string        `json:"to"`
+	MaxFeeAllowed  money.Decimal `json:"max_fee_allowed"`
+	Deadline       int64         `json:"deadline,omitempty"`
+	Status         int           `json:"status"`
+
+	MultisigId            string   `json:"multisig_id,omitempty"`
+	ConfirmationsRequired int      `json:"confirmations_required"`
+	Confirmations         int      `json:"confirmations"`
+	ConfirmedBy           []string `json:"confirmed_by,omitempty"`
+	RejectedBy            []string `json:"rejected_by,omitempty"`
+	TxIndex               int64    `json:"tx_index,omitempty"`
+	TxHash                string   `json:"tx_hash,omitempty"`
+
+	CreatedAt   int64 `json:"created_at"`
+	UpdatedAt   int64 `json:"updated_at"`
+	ConfirmedAt int64 `json:"confirmed_at,omitempty"`
+	CancelledAt int64 `json:"cancelled_at,omitempty"`
+	CommitedAt  int64 `json:"commited_at,omitempty"`
+}
+
+type Salary struct {
+	Id              string        `json:"id"`
+	OrganizationId  string        `json:"organization_id"`
+	PayrollId       string        `json:"payroll_id"`
+	EmployeeId      string        `json:"employee_id"`
+	EmployeeAddress string        `json:"employee_address"`
+	Amount          money.Decimal `json:"amount"`
+	OnChainAmount   string        `json:"on_chain_amount,omitempty"`
+	Status          string        `json:"status"`
+	Confirmations   int           `json:"confirmations"`
+	ConfirmedBy     []string      `json:"confirmed_by,omitempty"`
+	TxIndex         int64         `json:"tx_index,omitempty"`
+	TxHash          string        `json:"tx_hash,omitempty"`
+	CreatedBy       string        `json:"created_by"`
+	CreatedAt       int64         `json:"created_at"`
+	UpdatedAt       int64         `json:"updated_at"`
 }
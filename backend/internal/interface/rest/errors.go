@@ -5,7 +5,22 @@ import (
 	"net/http"
 
 	"github.com/emochka2007/block-accounting/internal/interface/rest/controllers"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/agreements"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/assets"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/invites"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jwt"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/ledger"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/licenses"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/payouts"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/reports"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/siwe"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/transactions"
 )
 
 var (
@@ -29,17 +44,205 @@ func mapError(err error) apiError {
 	// server error
 	case errors.Is(err, ErrorBadPathParams):
 		return buildApiError(http.StatusBadRequest, "Invalid Path Params")
+	case errors.Is(err, controllers.ErrorInvalidQueryParams):
+		return buildApiError(http.StatusBadRequest, "Invalid Query Params")
+	case errors.Is(err, money.ErrorInvalidAmount):
+		return buildApiError(http.StatusBadRequest, "Invalid Amount")
 	// auth controller errors
 	case errors.Is(err, controllers.ErrorAuthInvalidMnemonic):
 		return buildApiError(http.StatusBadRequest, "Invalid Mnemonic")
 	case errors.Is(err, controllers.ErrorTokenRequired):
 		return buildApiError(http.StatusUnauthorized, "Token Required")
+	case errors.Is(err, controllers.ErrorWalletRegistered):
+		return buildApiError(http.StatusConflict, "Wallet Already Registered")
+	case errors.Is(err, controllers.ErrorWalletNotRegistered):
+		return buildApiError(http.StatusUnauthorized, "Wallet Not Registered")
+
+	// wallet sign in errors
+	case errors.Is(err, siwe.ErrorNonceNotFound):
+		return buildApiError(http.StatusUnauthorized, "Invalid Or Expired Nonce")
+	case errors.Is(err, siwe.ErrorInvalidAddress):
+		return buildApiError(http.StatusBadRequest, "Invalid Address")
+	case errors.Is(err, siwe.ErrorInvalidSig):
+		return buildApiError(http.StatusUnauthorized, "Invalid Signature")
 
 	// jwt-related errors
 	case errors.Is(err, jwt.ErrorTokenExpired):
 		return buildApiError(http.StatusUnauthorized, "Token Expired")
 	case errors.Is(err, jwt.ErrorInvalidTokenClaims):
 		return buildApiError(http.StatusUnauthorized, "Invalid Token")
+	case errors.Is(err, jwt.ErrorSessionRevoked):
+		return buildApiError(http.StatusUnauthorized, "Session Revoked")
+	case errors.Is(err, jwt.ErrorTokenReplaced):
+		return buildApiError(http.StatusUnauthorized, "Token Replaced")
+	case errors.Is(err, jwt.ErrorSessionNotFound):
+		return buildApiError(http.StatusNotFound, "Session Not Found")
+
+	// authorization errors
+	case errors.Is(err, authorizer.ErrorUnauthorizedAccess):
+		return buildApiError(http.StatusForbidden, "Unauthorized Access")
+	case errors.Is(err, authorizer.ErrorPermissionDenied):
+		return buildApiError(http.StatusForbidden, "Permission Denied")
+	case errors.Is(err, authorizer.ErrorOrganizationArchived):
+		return buildApiError(http.StatusConflict, "Organization Is Archived")
+
+	// organizations errors
+	case errors.Is(err, organizations.ErrorInvalidRole):
+		return buildApiError(http.StatusBadRequest, "Invalid Role")
+	case errors.Is(err, organizations.ErrorParticipantNotFound):
+		return buildApiError(http.StatusNotFound, "Participant Not Found")
+	case errors.Is(err, organizations.ErrorLastOwner):
+		return buildApiError(http.StatusConflict, "Organization Must Have An Owner")
+	case errors.Is(err, organizations.ErrorInvalidUpdate):
+		return buildApiError(http.StatusBadRequest, "Invalid Participant Update")
+	case errors.Is(err, organizations.ErrorOrganizationNotFound):
+		return buildApiError(http.StatusNotFound, "Organization Not Found")
+	case errors.Is(err, organizations.ErrorOwnershipTransferNotFound):
+		return buildApiError(http.StatusNotFound, "Ownership Transfer Not Found")
+	case errors.Is(err, organizations.ErrorOwnershipTransferNotPending):
+		return buildApiError(http.StatusConflict, "Ownership Transfer Is Not Pending")
+	case errors.Is(err, organizations.ErrorOwnershipTransferExists):
+		return buildApiError(http.StatusConflict, "Organization Has Pending Ownership Transfer")
+	case errors.Is(err, organizations.ErrorWalletMnemonicRequired):
+		return buildApiError(http.StatusBadRequest, "Organization Wallet Mnemonic Required")
+	case errors.Is(err, organizations.ErrorInvalidOwnershipTransfer):
+		return buildApiError(http.StatusBadRequest, "Invalid Ownership Transfer")
+
+	// invites errors
+	case errors.Is(err, invites.ErrorInviteNotFound):
+		return buildApiError(http.StatusNotFound, "Invite Not Found")
+	case errors.Is(err, invites.ErrorInviteExpired):
+		return buildApiError(http.StatusGone, "Invite Expired")
+	case errors.Is(err, invites.ErrorInviteRevoked):
+		return buildApiError(http.StatusGone, "Invite Revoked")
+	case errors.Is(err, invites.ErrorInviteUsesExhausted):
+		return buildApiError(http.StatusGone, "Invite Uses Exhausted")
+	case errors.Is(err, invites.ErrorInvalidInvite):
+		return buildApiError(http.StatusBadRequest, "Invalid Invite")
+	case errors.Is(err, invites.ErrorUnknownPublicKey):
+		return buildApiError(http.StatusNotFound, "User With Public Key Not Found")
+	case errors.Is(err, invites.ErrorAlreadyParticipant):
+		return buildApiError(http.StatusConflict, "User Is Already Participant")
+	case errors.Is(err, invites.ErrorInviteNotAddressed):
+		return buildApiError(http.StatusForbidden, "Invite Is Addressed To Another User")
+	case errors.Is(err, invites.ErrorInviteAccountBound):
+		return buildApiError(http.StatusConflict, "Invite Is Bound To Existing Account")
+
+	// chain errors
+	case errors.Is(err, chain.ErrorPayrollNotFound):
+		return buildApiError(http.StatusNotFound, "Payroll Not Found")
+	case errors.Is(err, chain.ErrorPayrollNotDeployed):
+		return buildApiError(http.StatusConflict, "Payroll Is Not Deployed")
+	case errors.Is(err, chain.ErrorPayrollNotPending):
+		return buildApiError(http.StatusConflict, "Payroll Is Not Pending")
+	case errors.Is(err, chain.ErrorSalaryNotFound):
+		return buildApiError(http.StatusNotFound, "Salary Not Found")
+	case errors.Is(err, chain.ErrorEmployeeNotFound):
+		return buildApiError(http.StatusNotFound, "Employee Not Found")
+	case errors.Is(err, chain.ErrorInvalidSalary):
+		return buildApiError(http.StatusBadRequest, "Invalid Salary Amount")
+	case errors.Is(err, chain.ErrorNotMultisigOwner):
+		return buildApiError(http.StatusForbidden, "Not A Multisig Owner")
+	case errors.Is(err, chain.ErrorNoCustodialSeed):
+		return buildApiError(http.StatusConflict, "Wallet Has No Custodial Seed")
+	case errors.Is(err, chain.ErrorTxReverted):
+		return buildApiError(http.StatusConflict, "Transaction Reverted")
+	case errors.Is(err, chain.ErrorSignerUnsupported):
+		return buildApiError(http.StatusNotImplemented, "Not Supported By Signer")
+
+	// confirmations errors
+	case errors.Is(err, confirmations.ErrorUnknownEntityType):
+		return buildApiError(http.StatusBadRequest, "Unknown Confirmation Entity Type")
+	case errors.Is(err, confirmations.ErrorRejectNotSupported):
+		return buildApiError(http.StatusBadRequest, "Entity Type Can Not Be Rejected")
+	case errors.Is(err, confirmations.ErrorEntityNotPending):
+		return buildApiError(http.StatusConflict, "Entity Is Not Pending Confirmation")
+	case errors.Is(err, confirmations.ErrorConfirmationNotFound):
+		return buildApiError(http.StatusNotFound, "Confirmation Not Found")
+
+	// transactions errors
+	case errors.Is(err, transactions.ErrorTransactionNotFound):
+		return buildApiError(http.StatusNotFound, "Transaction Not Found")
+	case errors.Is(err, transactions.ErrorTransactionNotPending):
+		return buildApiError(http.StatusConflict, "Transaction Is Not Pending")
+	case errors.Is(err, transactions.ErrorMultisigNotFound):
+		return buildApiError(http.StatusNotFound, "Multisig Not Found")
+	case errors.Is(err, transactions.ErrorInvalidAmount):
+		return buildApiError(http.StatusBadRequest, "Invalid Transaction Amount")
+
+	// assets errors
+	case errors.Is(err, assets.ErrorAssetNotFound):
+		return buildApiError(http.StatusNotFound, "Asset Not Found")
+	case errors.Is(err, assets.ErrorAssetExists):
+		return buildApiError(http.StatusConflict, "Asset Already Exists")
+	case errors.Is(err, assets.ErrorInvalidAsset):
+		return buildApiError(http.StatusBadRequest, "Invalid Asset")
+	case errors.Is(err, assets.ErrorAssetNetworkMismatch):
+		return buildApiError(http.StatusBadRequest, "Asset Is Deployed To Another Network")
+
+	// payouts errors
+	case errors.Is(err, payouts.ErrorPayoutRunNotFound):
+		return buildApiError(http.StatusNotFound, "Payout Not Found")
+	case errors.Is(err, payouts.ErrorPayoutRunNotPending):
+		return buildApiError(http.StatusConflict, "Payout Is Not Pending")
+	case errors.Is(err, payouts.ErrorNoSalariesDue):
+		return buildApiError(http.StatusBadRequest, "No Salaries Due")
+	case errors.Is(err, payouts.ErrorInvalidDepositAmount):
+		return buildApiError(http.StatusBadRequest, "Invalid Deposit Amount")
+
+	// licenses errors
+	case errors.Is(err, licenses.ErrorLicenseNotFound):
+		return buildApiError(http.StatusNotFound, "License Not Found")
+	case errors.Is(err, licenses.ErrorLicenseNotDeployed):
+		return buildApiError(http.StatusConflict, "License Is Not Deployed")
+	case errors.Is(err, licenses.ErrorLicenseOperationNotFound):
+		return buildApiError(http.StatusNotFound, "License Operation Not Found")
+	case errors.Is(err, licenses.ErrorLicenseOperationNotPending):
+		return buildApiError(http.StatusConflict, "License Operation Is Not Pending")
+	case errors.Is(err, licenses.ErrorInvalidShares):
+		return buildApiError(http.StatusBadRequest, "Invalid License Shares")
+	case errors.Is(err, licenses.ErrorInvalidOracleURL):
+		return buildApiError(http.StatusBadRequest, "Invalid Oracle URL")
+	case errors.Is(err, licenses.ErrorPayoutContractNotSet):
+		return buildApiError(http.StatusConflict, "License Payout Contract Is Not Set")
+
+	// agreements errors
+	case errors.Is(err, agreements.ErrorAgreementNotFound):
+		return buildApiError(http.StatusNotFound, "Agreement Not Found")
+	case errors.Is(err, agreements.ErrorAgreementNotDeployed):
+		return buildApiError(http.StatusConflict, "Agreement Is Not Deployed")
+	case errors.Is(err, agreements.ErrorAgreementNotRequested):
+		return buildApiError(http.StatusConflict, "Agreement Outcome Is Not Requested")
+	case errors.Is(err, agreements.ErrorAgreementOperationNotFound):
+		return buildApiError(http.StatusNotFound, "Agreement Operation Not Found")
+	case errors.Is(err, agreements.ErrorAgreementOperationNotPending):
+		return buildApiError(http.StatusConflict, "Agreement Operation Is Not Pending")
+	case errors.Is(err, agreements.ErrorInvalidRequestURL):
+		return buildApiError(http.StatusBadRequest, "Invalid Request URL")
+
+	// ledger errors
+	case errors.Is(err, ledger.ErrorEntryUnbalanced):
+		return buildApiError(http.StatusBadRequest, "Ledger Entry Is Unbalanced")
+	case errors.Is(err, ledger.ErrorInvalidEntryLine):
+		return buildApiError(http.StatusBadRequest, "Invalid Ledger Entry Line")
+	case errors.Is(err, ledger.ErrorAccountNotFound):
+		return buildApiError(http.StatusNotFound, "Ledger Account Not Found")
+	case errors.Is(err, ledger.ErrorAccountExists):
+		return buildApiError(http.StatusConflict, "Ledger Account Already Exists")
+	case errors.Is(err, ledger.ErrorInvalidAccount):
+		return buildApiError(http.StatusBadRequest, "Invalid Ledger Account")
+	case errors.Is(err, ledger.ErrorEntryExists):
+		return buildApiError(http.StatusConflict, "Ledger Entry Already Posted")
+
+	// reports errors
+	case errors.Is(err, reports.ErrorInvalidPeriod):
+		return buildApiError(http.StatusBadRequest, "Invalid Report Period")
+	case errors.Is(err, reports.ErrorUnknownStatement):
+		return buildApiError(http.StatusBadRequest, "Unknown Report Statement")
+
+	// jobs errors
+	case errors.Is(err, jobs.ErrorJobNotFound):
+		return buildApiError(http.StatusNotFound, "Job Not Found")
 	default:
 		return buildApiError(http.StatusInternalServerError, "Internal Server Error")
 	}
@@ -0,0 +1,166 @@
+package presenters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain/hal"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+)
+
+type AgreementsPresenter interface {
+	ResponseAgreement(ctx context.Context, agreement *models.Agreement) ([]byte, error)
+	ResponseAgreements(ctx context.Context, agreements []*models.Agreement) ([]byte, error)
+	ResponseOperation(ctx context.Context, op *models.AgreementOperation) ([]byte, error)
+	ResponseOperations(ctx context.Context, ops []*models.AgreementOperation) ([]byte, error)
+}
+
+type agreementsPresenter struct{}
+
+func NewAgreementsPresenter() AgreementsPresenter {
+	return &agreementsPresenter{}
+}
+
+func (p *agreementsPresenter) Agreement(agreement *models.Agreement) *hal.Resource {
+	r := &domain.Agreement{
+		Id:             agreement.ID.String(),
+		OrganizationId: agreement.OrganizationID.String(),
+		MultisigId:     agreement.MultisigID.String(),
+		Title:          agreement.Title,
+		Status:         agreement.Status.String(),
+		RequestURL:     agreement.RequestURL,
+		CreatedBy:      agreement.CreatedBy.String(),
+		CreatedAt:      agreement.CreatedAt.UnixMilli(),
+		UpdatedAt:      agreement.UpdatedAt.UnixMilli(),
+	}
+
+	if len(agreement.Address) > 0 {
+		r.Address = common.BytesToAddress(agreement.Address).Hex()
+	}
+
+	if !agreement.ResolvedAt.IsZero() {
+		outcome := agreement.Outcome
+
+		r.Outcome = &outcome
+		r.ResolvedAt = agreement.ResolvedAt.UnixMilli()
+	}
+
+	return hal.NewResource(
+		r,
+		"/organizations/"+r.OrganizationId+"/agreements/"+r.Id,
+		hal.WithType("agreement"),
+	)
+}
+
+func (p *agreementsPresenter) ResponseAgreement(ctx context.Context, agreement *models.Agreement) ([]byte, error) {
+	out, err := json.Marshal(p.Agreement(agreement))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal agreement to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *agreementsPresenter) ResponseAgreements(
+	ctx context.Context,
+	agreements []*models.Agreement,
+) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	outArray := make([]*hal.Resource, len(agreements))
+
+	for i, agreement := range agreements {
+		outArray[i] = p.Agreement(agreement)
+	}
+
+	r := hal.NewResource(
+		map[string]any{"agreements": outArray},
+		"/organizations/"+organizationID.String()+"/agreements",
+		hal.WithType("agreements"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal agreements to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *agreementsPresenter) Operation(op *models.AgreementOperation) *hal.Resource {
+	r := &domain.AgreementOperation{
+		Id:            op.ID.String(),
+		AgreementId:   op.AgreementID.String(),
+		MultisigId:    op.MultisigID.String(),
+		Kind:          op.Kind.String(),
+		Status:        op.Status.String(),
+		URL:           op.URL,
+		TxIndex:       op.TxIndex,
+		TxHash:        op.TxHash,
+		ConfirmedBy:   make([]string, len(op.ConfirmedBy)),
+		Confirmations: op.Confirmations,
+		CreatedBy:     op.CreatedBy.String(),
+		CreatedAt:     op.CreatedAt.UnixMilli(),
+		UpdatedAt:     op.UpdatedAt.UnixMilli(),
+	}
+
+	for i, id := range op.ConfirmedBy {
+		r.ConfirmedBy[i] = id.String()
+	}
+
+	if !op.ExecutedAt.IsZero() {
+		r.ExecutedAt = op.ExecutedAt.UnixMilli()
+	}
+
+	return hal.NewResource(
+		r,
+		"/organizations/"+op.OrganizationID.String()+"/agreements/operations/"+r.Id,
+		hal.WithType("agreement_operation"),
+	)
+}
+
+func (p *agreementsPresenter) ResponseOperation(ctx context.Context, op *models.AgreementOperation) ([]byte, error) {
+	out, err := json.Marshal(p.Operation(op))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal agreement operation to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *agreementsPresenter) ResponseOperations(
+	ctx context.Context,
+	ops []*models.AgreementOperation,
+) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	outArray := make([]*hal.Resource, len(ops))
+
+	for i, op := range ops {
+		outArray[i] = p.Operation(op)
+	}
+
+	r := hal.NewResource(
+		map[string]any{"operations": outArray},
+		"/organizations/"+organizationID.String()+"/agreements/operations",
+		hal.WithType("agreement_operations"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal agreement operations to hal resource. %w", err)
+	}
+
+	return out, nil
+}
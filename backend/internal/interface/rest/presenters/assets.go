@@ -0,0 +1,78 @@
+package presenters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain/hal"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type AssetsPresenter interface {
+	ResponseAsset(ctx context.Context, asset *models.Asset) ([]byte, error)
+	ResponseAssets(ctx context.Context, assets []*models.Asset) ([]byte, error)
+}
+
+type assetsPresenter struct{}
+
+func NewAssetsPresenter() AssetsPresenter {
+	return &assetsPresenter{}
+}
+
+func (p *assetsPresenter) Asset(asset *models.Asset) *hal.Resource {
+	r := &domain.Asset{
+		Id:              asset.ID.String(),
+		OrganizationId:  asset.OrganizationID.String(),
+		Symbol:          asset.Symbol,
+		ContractAddress: common.BytesToAddress(asset.ContractAddress).Hex(),
+		Decimals:        asset.Decimals,
+		ChainId:         asset.ChainID,
+		CreatedBy:       asset.CreatedBy.String(),
+		CreatedAt:       asset.CreatedAt.UnixMilli(),
+	}
+
+	return hal.NewResource(
+		r,
+		"/organizations/"+r.OrganizationId+"/assets/"+r.Id,
+		hal.WithType("asset"),
+	)
+}
+
+func (p *assetsPresenter) ResponseAsset(ctx context.Context, asset *models.Asset) ([]byte, error) {
+	out, err := json.Marshal(p.Asset(asset))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal asset to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *assetsPresenter) ResponseAssets(ctx context.Context, assets []*models.Asset) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	outArray := make([]*hal.Resource, len(assets))
+
+	for i, asset := range assets {
+		outArray[i] = p.Asset(asset)
+	}
+
+	r := hal.NewResource(
+		map[string]any{"assets": outArray},
+		"/organizations/"+organizationID.String()+"/assets",
+		hal.WithType("assets"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal assets to hal resource. %w", err)
+	}
+
+	return out, nil
+}
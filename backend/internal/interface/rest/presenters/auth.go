@@ -7,20 +7,27 @@ import (
 	"time"
 
 	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain/hal"
+	"github.com/emochka2007/block-accounting/internal/pkg/jwks"
 	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/invites"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jwt"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 )
 
 type AuthPresenter interface {
-	ResponseJoin(user *models.User) ([]byte, error)
-	ResponseLogin(user *models.User) ([]byte, error)
+	ResponseJoin(ctx context.Context, user *models.User) ([]byte, error)
+	ResponseLogin(ctx context.Context, user *models.User) ([]byte, error)
 	ResponseRefresh(tokens jwt.AccessToken) ([]byte, error)
-	ResponseNewInvite(
-		ctx context.Context,
-		organizationID uuid.UUID,
-		link string,
-	) ([]byte, error)
+	ResponseWalletNonce(nonce *models.AuthNonce) ([]byte, error)
+	ResponseJWKS(set jwks.Set) ([]byte, error)
+	ResponseSessions(sessions []*models.Session) ([]byte, error)
+	ResponseRevokedSessions(revoked int) ([]byte, error)
+	ResponseInvite(invite *models.Invite) ([]byte, error)
+	ResponseInvites(organizationID uuid.UUID, invites []*models.Invite) ([]byte, error)
+	ResponseReceivedInvites(invites []*models.Invite) ([]byte, error)
+	ResponseInviteInfo(info *invites.InviteInfo) ([]byte, error)
 }
 
 type authPresenter struct {
@@ -35,8 +42,8 @@ func NewAuthPresenter(
 	}
 }
 
-func (p *authPresenter) ResponseJoin(user *models.User) ([]byte, error) {
-	tokens, err := p.jwtInteractor.NewToken(user, 24*time.Hour*30, "")
+func (p *authPresenter) ResponseJoin(ctx context.Context, user *models.User) ([]byte, error) {
+	tokens, err := p.jwtInteractor.NewToken(ctx, user, 24*time.Hour*30)
 	if err != nil {
 		return nil, fmt.Errorf("error create access token. %w", err)
 	}
@@ -54,8 +61,8 @@ func (p *authPresenter) ResponseJoin(user *models.User) ([]byte, error) {
 	return out, nil
 }
 
-func (p *authPresenter) ResponseLogin(user *models.User) ([]byte, error) {
-	tokens, err := p.jwtInteractor.NewToken(user, 24*time.Hour*30, "")
+func (p *authPresenter) ResponseLogin(ctx context.Context, user *models.User) ([]byte, error) {
+	tokens, err := p.jwtInteractor.NewToken(ctx, user, 24*time.Hour*30)
 	if err != nil {
 		return nil, fmt.Errorf("error create access token. %w", err)
 	}
@@ -87,16 +94,165 @@ func (p *authPresenter) ResponseRefresh(tokens jwt.AccessToken) ([]byte, error)
 	return out, nil
 }
 
-func (p *authPresenter) ResponseNewInvite(
-	ctx context.Context,
-	organizationID uuid.UUID,
-	link string,
-) ([]byte, error) {
-	out, err := json.Marshal(map[string]string{
-		"link": "/invite/" + link + "/join",
+func (p *authPresenter) ResponseWalletNonce(nonce *models.AuthNonce) ([]byte, error) {
+	out, err := json.Marshal(domain.WalletNonceResponse{
+		Nonce:     nonce.Nonce,
+		Message:   nonce.Message,
+		ExpiredAt: nonce.ExpiredAt.UnixMilli(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error marshal refresh response. %w", err)
+		return nil, fmt.Errorf("error marshal wallet nonce. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *authPresenter) ResponseJWKS(set jwks.Set) ([]byte, error) {
+	out, err := json.Marshal(set)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal jwks. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *authPresenter) ResponseSessions(sessions []*models.Session) ([]byte, error) {
+	resources := make([]*hal.Resource, len(sessions))
+
+	for i, s := range sessions {
+		session := domain.Session{
+			Id:         s.ID.String(),
+			RemoteAddr: s.RemoteAddr,
+			UserAgent:  s.UserAgent,
+			CreatedAt:  s.CreatedAt.UnixMilli(),
+			ExpiredAt:  s.ExpiredAt.UnixMilli(),
+			Current:    s.Current,
+		}
+
+		resources[i] = hal.NewResource(session, "/sessions/"+session.Id, hal.WithType("session"))
+	}
+
+	r := hal.NewResource(
+		map[string][]*hal.Resource{
+			"sessions": resources,
+		},
+		"/sessions",
+		hal.WithType("sessions"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal sessions. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *authPresenter) ResponseRevokedSessions(revoked int) ([]byte, error) {
+	out, err := json.Marshal(domain.RevokeSessionsResponse{
+		Revoked: revoked,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshal revoked sessions. %w", err)
+	}
+
+	return out, nil
+}
+
+func inviteResource(i *models.Invite) *hal.Resource {
+	invite := domain.Invite{
+		Link:           "/invite/" + i.LinkHash + "/join",
+		Hash:           i.LinkHash,
+		OrganizationId: i.OrganizationID.String(),
+		CreatedBy:      i.CreatedBy.String(),
+		Role:           i.Role.String(),
+		Position:       i.Position,
+		MaxUses:        i.MaxUses,
+		Uses:           i.Uses,
+		Active:         i.IsActive(time.Now()),
+		CreatedAt:      i.CreatedAt.UnixMilli(),
+		ExpiredAt:      i.ExpiredAt.UnixMilli(),
+	}
+
+	if i.IsBound() {
+		invite.PublicKey = common.Bytes2Hex(i.PublicKey)
+	}
+
+	if !i.UsedAt.IsZero() {
+		invite.UsedAt = i.UsedAt.UnixMilli()
+	}
+
+	if !i.RevokedAt.IsZero() {
+		invite.RevokedAt = i.RevokedAt.UnixMilli()
+	}
+
+	return hal.NewResource(
+		invite,
+		"/organizations/"+invite.OrganizationId+"/participants/invites/"+invite.Hash,
+		hal.WithType("invite"),
+	)
+}
+
+func (p *authPresenter) ResponseInvite(invite *models.Invite) ([]byte, error) {
+	out, err := json.Marshal(inviteResource(invite))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal invite. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *authPresenter) ResponseInvites(organizationID uuid.UUID, invites []*models.Invite) ([]byte, error) {
+	return responseInvites("/organizations/"+organizationID.String()+"/participants/invites", invites)
+}
+
+func (p *authPresenter) ResponseReceivedInvites(invites []*models.Invite) ([]byte, error) {
+	return responseInvites("/invites", invites)
+}
+
+func responseInvites(href string, invites []*models.Invite) ([]byte, error) {
+	resources := make([]*hal.Resource, len(invites))
+
+	for i, invite := range invites {
+		resources[i] = inviteResource(invite)
+	}
+
+	r := hal.NewResource(
+		map[string][]*hal.Resource{
+			"invites": resources,
+		},
+		href,
+		hal.WithType("invites"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal invites. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *authPresenter) ResponseInviteInfo(info *invites.InviteInfo) ([]byte, error) {
+	resp := domain.InviteInfo{
+		OrganizationId:   info.Organization.ID.String(),
+		OrganizationName: info.Organization.Name,
+		Role:             info.Invite.Role.String(),
+		Position:         info.Invite.Position,
+		Bound:            info.Invite.IsBound(),
+		UsesLeft:         max(info.Invite.MaxUses-info.Invite.Uses, 0),
+		Active:           info.Invite.IsActive(time.Now()) && !info.Organization.IsArchived(),
+		ExpiredAt:        info.Invite.ExpiredAt.UnixMilli(),
+	}
+
+	if info.Inviter != nil {
+		resp.InviterId = info.Inviter.Id().String()
+		resp.InviterName = info.Inviter.Name
+	}
+
+	out, err := json.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal invite info. %w", err)
 	}
 
 	return out, nil
@@ -0,0 +1,60 @@
+package presenters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain/hal"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+)
+
+type ConfirmationsPresenter interface {
+	ResponseConfirmation(ctx context.Context, confirmation *models.EntityConfirmation) ([]byte, error)
+}
+
+type confirmationsPresenter struct{}
+
+func NewConfirmationsPresenter() ConfirmationsPresenter {
+	return &confirmationsPresenter{}
+}
+
+func (p *confirmationsPresenter) ResponseConfirmation(
+	ctx context.Context,
+	confirmation *models.EntityConfirmation,
+) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	r := &domain.Confirmation{
+		EntityId:              confirmation.EntityID.String(),
+		EntityType:            confirmation.EntityType.String(),
+		MultisigId:            confirmation.MultisigID.String(),
+		Pending:               confirmation.Pending,
+		ConfirmationsRequired: confirmation.ConfirmationsRequired,
+		Confirmations:         confirmation.Confirmations,
+	}
+
+	for _, id := range confirmation.ConfirmedBy {
+		r.ConfirmedBy = append(r.ConfirmedBy, id.String())
+	}
+
+	for _, id := range confirmation.RejectedBy {
+		r.RejectedBy = append(r.RejectedBy, id.String())
+	}
+
+	out, err := json.Marshal(hal.NewResource(
+		r,
+		"/organizations/"+organizationID.String()+"/confirmations/"+r.EntityType+"/"+r.EntityId,
+		hal.WithType("confirmation"),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal confirmation to hal resource. %w", err)
+	}
+
+	return out, nil
+}
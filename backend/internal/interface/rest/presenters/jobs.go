@@ -0,0 +1,64 @@
+package presenters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain/hal"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+)
+
+type JobsPresenter interface {
+	ResponseJob(job *models.Job) ([]byte, error)
+	Job(job *models.Job) *hal.Resource
+}
+
+type jobsPresenter struct{}
+
+func NewJobsPresenter() JobsPresenter {
+	return &jobsPresenter{}
+}
+
+func (p *jobsPresenter) Job(job *models.Job) *hal.Resource {
+	r := &domain.Job{
+		Id:             job.ID.String(),
+		OrganizationId: job.OrganizationID.String(),
+		CreatedBy:      job.CreatedBy.String(),
+		Kind:           job.Kind,
+		Status:         job.Status.String(),
+		Attempts:       job.Attempts,
+		MaxAttempts:    job.MaxAttempts,
+		Error:          job.LastError,
+		RunAt:          job.RunAt.UnixMilli(),
+		CreatedAt:      job.CreatedAt.UnixMilli(),
+		UpdatedAt:      job.UpdatedAt.UnixMilli(),
+	}
+
+	if len(job.Result) > 0 {
+		r.Result = job.Result
+	}
+
+	if !job.StartedAt.IsZero() {
+		r.StartedAt = job.StartedAt.UnixMilli()
+	}
+
+	if !job.FinishedAt.IsZero() {
+		r.FinishedAt = job.FinishedAt.UnixMilli()
+	}
+
+	return hal.NewResource(
+		r,
+		"/organizations/"+r.OrganizationId+"/jobs/"+r.Id,
+		hal.WithType("job"),
+	)
+}
+
+func (p *jobsPresenter) ResponseJob(job *models.Job) ([]byte, error) {
+	out, err := json.Marshal(p.Job(job))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal job to hal resource. %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,185 @@
+package presenters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain/hal"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/ledger"
+	"github.com/google/uuid"
+)
+
+type LedgerPresenter interface {
+	ResponseLedger(ctx context.Context, l *ledger.Ledger) ([]byte, error)
+	ResponseAccount(ctx context.Context, account *models.LedgerAccount) ([]byte, error)
+	ResponseAccounts(ctx context.Context, accounts []*models.LedgerAccount) ([]byte, error)
+	ResponseEntry(ctx context.Context, entry *models.LedgerEntry) ([]byte, error)
+}
+
+type ledgerPresenter struct{}
+
+func NewLedgerPresenter() LedgerPresenter {
+	return &ledgerPresenter{}
+}
+
+func (p *ledgerPresenter) Account(account *models.LedgerAccount) *hal.Resource {
+	r := &domain.LedgerAccount{
+		Id:             account.ID.String(),
+		OrganizationId: account.OrganizationID.String(),
+		Code:           account.Code,
+		Name:           account.Name,
+		Type:           account.Type.String(),
+		CreatedAt:      account.CreatedAt.UnixMilli(),
+	}
+
+	return hal.NewResource(
+		r,
+		"/organizations/"+r.OrganizationId+"/ledger/accounts/"+r.Id,
+		hal.WithType("ledger_account"),
+	)
+}
+
+func (p *ledgerPresenter) Entry(entry *models.LedgerEntry) *hal.Resource {
+	r := &domain.LedgerEntry{
+		Id:             entry.ID.String(),
+		OrganizationId: entry.OrganizationID.String(),
+		Description:    entry.Description,
+		SourceType:     string(entry.SourceType),
+		SourceId:       entry.SourceID.String(),
+		Lines:          make([]domain.LedgerLine, len(entry.Lines)),
+		OccurredAt:     entry.OccurredAt.UnixMilli(),
+		CreatedAt:      entry.CreatedAt.UnixMilli(),
+	}
+
+	for i, l := range entry.Lines {
+		r.Lines[i] = domain.LedgerLine{
+			AccountId:   l.AccountID.String(),
+			AccountCode: l.AccountCode,
+			Currency:    l.Currency,
+			Debit:       l.Debit,
+			Credit:      l.Credit,
+		}
+
+		if debit, credit, ok := l.Fiat(); ok {
+			r.Lines[i].Rate = l.Rate
+			r.Lines[i].FiatDebit = &debit
+			r.Lines[i].FiatCredit = &credit
+		}
+	}
+
+	if entry.CreatedBy != uuid.Nil {
+		r.CreatedBy = entry.CreatedBy.String()
+	}
+
+	return hal.NewResource(
+		r,
+		"/organizations/"+r.OrganizationId+"/ledger/entries/"+r.Id,
+		hal.WithType("ledger_entry"),
+	)
+}
+
+func (p *ledgerPresenter) Balance(balance models.LedgerBalance) *domain.LedgerBalance {
+	return &domain.LedgerBalance{
+		AccountId:   balance.Account.ID.String(),
+		AccountCode: balance.Account.Code,
+		AccountName: balance.Account.Name,
+		AccountType: balance.Account.Type.String(),
+		Currency:    balance.Currency,
+		Debit:       balance.Debit,
+		Credit:      balance.Credit,
+		Balance:     balance.Balance(),
+
+		FiatCurrency:  models.FiatCurrency.Code,
+		FiatDebit:     balance.FiatDebit.Round(models.FiatCurrency.Decimals),
+		FiatCredit:    balance.FiatCredit.Round(models.FiatCurrency.Decimals),
+		FiatBalance:   balance.FiatBalance(),
+		UnpricedLines: balance.UnpricedLines,
+	}
+}
+
+func (p *ledgerPresenter) ResponseLedger(ctx context.Context, l *ledger.Ledger) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	entries := make([]*hal.Resource, len(l.Entries))
+
+	for i, entry := range l.Entries {
+		entries[i] = p.Entry(entry)
+	}
+
+	balances := make([]*domain.LedgerBalance, len(l.Balances))
+
+	for i, balance := range l.Balances {
+		balances[i] = p.Balance(balance)
+	}
+
+	r := hal.NewResource(
+		map[string]any{
+			"as_of":    l.AsOf.UnixMilli(),
+			"entries":  entries,
+			"balances": balances,
+		},
+		"/organizations/"+organizationID.String()+"/ledger",
+		hal.WithType("ledger"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal ledger to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *ledgerPresenter) ResponseAccount(ctx context.Context, account *models.LedgerAccount) ([]byte, error) {
+	out, err := json.Marshal(p.Account(account))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal ledger account to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *ledgerPresenter) ResponseAccounts(
+	ctx context.Context,
+	accounts []*models.LedgerAccount,
+) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	outArray := make([]*hal.Resource, len(accounts))
+
+	for i, account := range accounts {
+		outArray[i] = p.Account(account)
+	}
+
+	r := hal.NewResource(
+		map[string]any{"accounts": outArray},
+		"/organizations/"+organizationID.String()+"/ledger/accounts",
+		hal.WithType("ledger_accounts"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal ledger accounts to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *ledgerPresenter) ResponseEntry(ctx context.Context, entry *models.LedgerEntry) ([]byte, error) {
+	out, err := json.Marshal(p.Entry(entry))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal ledger entry to hal resource. %w", err)
+	}
+
+	return out, nil
+}
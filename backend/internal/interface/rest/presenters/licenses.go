@@ -0,0 +1,206 @@
+package presenters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain/hal"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+)
+
+type LicensesPresenter interface {
+	ResponseLicenses(ctx context.Context, licenses []*models.License) ([]byte, error)
+	ResponseLicenseInfo(ctx context.Context, licenseID uuid.UUID, info *models.LicenseInfo) ([]byte, error)
+	ResponseOperation(ctx context.Context, op *models.LicenseOperation) ([]byte, error)
+	ResponseOperations(ctx context.Context, ops []*models.LicenseOperation) ([]byte, error)
+}
+
+type licensesPresenter struct{}
+
+func NewLicensesPresenter() LicensesPresenter {
+	return &licensesPresenter{}
+}
+
+func (p *licensesPresenter) License(license *models.License) *hal.Resource {
+	r := &domain.License{
+		Id:             license.ID.String(),
+		OrganizationId: license.OrganizationID.String(),
+		MultisigId:     license.MultisigID.String(),
+		Title:          license.Title,
+		Status:         license.Status.String(),
+		OracleURL:      license.OracleURL,
+		Shareholders:   p.shareholders(license.Shareholders),
+		CreatedBy:      license.CreatedBy.String(),
+		CreatedAt:      license.CreatedAt.UnixMilli(),
+		UpdatedAt:      license.UpdatedAt.UnixMilli(),
+	}
+
+	if len(license.Address) > 0 {
+		r.Address = common.BytesToAddress(license.Address).Hex()
+	}
+
+	if license.PayrollID != uuid.Nil {
+		r.PayrollId = license.PayrollID.String()
+	}
+
+	return hal.NewResource(
+		r,
+		"/organizations/"+r.OrganizationId+"/license/"+r.Id,
+		hal.WithType("license"),
+	)
+}
+
+func (p *licensesPresenter) shareholders(shareholders []models.LicenseShareholder) []domain.LicenseShareholder {
+	out := make([]domain.LicenseShareholder, len(shareholders))
+
+	for i, sh := range shareholders {
+		out[i] = domain.LicenseShareholder{
+			Address: common.BytesToAddress(sh.Address).Hex(),
+			Share:   sh.Share,
+		}
+
+		if sh.ParticipantID != uuid.Nil {
+			out[i].ParticipantId = sh.ParticipantID.String()
+		}
+	}
+
+	return out
+}
+
+func (p *licensesPresenter) ResponseLicenses(ctx context.Context, licenses []*models.License) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	outArray := make([]*hal.Resource, len(licenses))
+
+	for i, license := range licenses {
+		outArray[i] = p.License(license)
+	}
+
+	r := hal.NewResource(
+		map[string]any{"licenses": outArray},
+		"/organizations/"+organizationID.String()+"/license",
+		hal.WithType("licenses"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal licenses to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *licensesPresenter) ResponseLicenseInfo(
+	ctx context.Context,
+	licenseID uuid.UUID,
+	info *models.LicenseInfo,
+) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	r := &domain.LicenseInfo{
+		LicenseId:        licenseID.String(),
+		Shareholders:     p.shareholders(info.Shareholders),
+		TotalPayoutInUSD: info.TotalPayoutInUSD,
+	}
+
+	if len(info.PayoutContract) > 0 {
+		r.PayoutContract = common.BytesToAddress(info.PayoutContract).Hex()
+	}
+
+	out, err := json.Marshal(hal.NewResource(
+		r,
+		"/organizations/"+organizationID.String()+"/license/"+r.LicenseId+"/info",
+		hal.WithType("license_info"),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal license info to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *licensesPresenter) Operation(op *models.LicenseOperation) *hal.Resource {
+	r := &domain.LicenseOperation{
+		Id:            op.ID.String(),
+		LicenseId:     op.LicenseID.String(),
+		MultisigId:    op.MultisigID.String(),
+		Kind:          op.Kind.String(),
+		Status:        op.Status.String(),
+		URL:           op.URL,
+		TxIndex:       op.TxIndex,
+		TxHash:        op.TxHash,
+		ConfirmedBy:   make([]string, len(op.ConfirmedBy)),
+		Confirmations: op.Confirmations,
+		CreatedBy:     op.CreatedBy.String(),
+		CreatedAt:     op.CreatedAt.UnixMilli(),
+		UpdatedAt:     op.UpdatedAt.UnixMilli(),
+	}
+
+	for i, id := range op.ConfirmedBy {
+		r.ConfirmedBy[i] = id.String()
+	}
+
+	if op.PayrollID != uuid.Nil {
+		r.PayrollId = op.PayrollID.String()
+	}
+
+	if !op.ExecutedAt.IsZero() {
+		r.ExecutedAt = op.ExecutedAt.UnixMilli()
+	}
+
+	return hal.NewResource(
+		r,
+		"/organizations/"+op.OrganizationID.String()+"/license/operations/"+r.Id,
+		hal.WithType("license_operation"),
+	)
+}
+
+func (p *licensesPresenter) ResponseOperation(ctx context.Context, op *models.LicenseOperation) ([]byte, error) {
+	out, err := json.Marshal(p.Operation(op))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal license operation to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *licensesPresenter) ResponseOperations(
+	ctx context.Context,
+	ops []*models.LicenseOperation,
+) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	outArray := make([]*hal.Resource, len(ops))
+
+	for i, op := range ops {
+		outArray[i] = p.Operation(op)
+	}
+
+	r := hal.NewResource(
+		map[string]any{"operations": outArray},
+		"/organizations/"+organizationID.String()+"/license/operations",
+		hal.WithType("license_operations"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal license operations to hal resource. %w", err)
+	}
+
+	return out, nil
+}
@@ -13,6 +13,8 @@ type OrganizationsPresenter interface {
 	ResponseCreate(organization *models.Organization) ([]byte, error)
 	ResponseList(orgs []*models.Organization, nextCursor string) ([]byte, error)
 	Organizations(orgs []*models.Organization) []*hal.Resource
+	ResponseOwnershipTransfer(transfer *models.OwnershipTransfer) ([]byte, error)
+	ResponseOwnershipTransfers(organizationID string, transfers []models.OwnershipTransfer) ([]byte, error)
 }
 
 type organizationsPresenter struct {
@@ -23,13 +25,7 @@ func NewOrganizationsPresenter() OrganizationsPresenter {
 }
 
 func (p *organizationsPresenter) ResponseCreate(o *models.Organization) ([]byte, error) {
-	org := domain.Organization{
-		Id:        o.ID.String(),
-		Name:      o.Name,
-		Address:   o.Address,
-		CreatedAt: uint64(o.CreatedAt.UnixMilli()),
-		UpdatedAt: uint64(o.UpdatedAt.UnixMilli()),
-	}
+	org := organization(o)
 
 	r := hal.NewResource(
 		org,
@@ -72,13 +68,7 @@ func (p *organizationsPresenter) Organizations(orgs []*models.Organization) []*h
 	out := make([]*hal.Resource, len(orgs))
 
 	for i, o := range orgs {
-		org := domain.Organization{
-			Id:        o.ID.String(),
-			Name:      o.Name,
-			Address:   o.Address,
-			CreatedAt: uint64(o.CreatedAt.UnixMilli()),
-			UpdatedAt: uint64(o.UpdatedAt.UnixMilli()),
-		}
+		org := organization(o)
 
 		r := hal.NewResource(org, "/organizations/"+org.Id)
 
@@ -87,3 +77,81 @@ func (p *organizationsPresenter) Organizations(orgs []*models.Organization) []*h
 
 	return out
 }
+
+func organization(o *models.Organization) domain.Organization {
+	org := domain.Organization{
+		Id:        o.ID.String(),
+		Name:      o.Name,
+		Address:   o.Address,
+		CreatedAt: uint64(o.CreatedAt.UnixMilli()),
+		UpdatedAt: uint64(o.UpdatedAt.UnixMilli()),
+	}
+
+	if o.IsArchived() {
+		org.ArchivedAt = uint64(o.ArchivedAt.UnixMilli())
+	}
+
+	return org
+}
+
+func ownershipTransferResource(t *models.OwnershipTransfer) *hal.Resource {
+	transfer := domain.OwnershipTransfer{
+		Id:             t.ID.String(),
+		OrganizationId: t.OrganizationID.String(),
+		FromUserId:     t.FromUserID.String(),
+		ToUserId:       t.ToUserID.String(),
+		Status:         t.Status.String(),
+		CreatedBy:      t.CreatedBy.String(),
+		CreatedAt:      t.CreatedAt.UnixMilli(),
+		UpdatedAt:      t.UpdatedAt.UnixMilli(),
+	}
+
+	if !t.ConfirmedAt.IsZero() {
+		transfer.ConfirmedAt = t.ConfirmedAt.UnixMilli()
+	}
+
+	if !t.CancelledAt.IsZero() {
+		transfer.CancelledAt = t.CancelledAt.UnixMilli()
+	}
+
+	return hal.NewResource(
+		transfer,
+		"/organizations/"+transfer.OrganizationId+"/ownership-transfers/"+transfer.Id,
+		hal.WithType("ownership_transfer"),
+	)
+}
+
+func (p *organizationsPresenter) ResponseOwnershipTransfer(t *models.OwnershipTransfer) ([]byte, error) {
+	out, err := json.Marshal(ownershipTransferResource(t))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal ownership transfer. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *organizationsPresenter) ResponseOwnershipTransfers(
+	organizationID string,
+	transfers []models.OwnershipTransfer,
+) ([]byte, error) {
+	resources := make([]*hal.Resource, len(transfers))
+
+	for i := range transfers {
+		resources[i] = ownershipTransferResource(&transfers[i])
+	}
+
+	r := hal.NewResource(
+		map[string][]*hal.Resource{
+			"ownership_transfers": resources,
+		},
+		"/organizations/"+organizationID+"/ownership-transfers",
+		hal.WithType("ownership_transfers"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal ownership transfers. %w", err)
+	}
+
+	return out, nil
+}
@@ -16,6 +16,7 @@ type ParticipantsPresenter interface {
 	ResponseListParticipants(
 		ctx context.Context,
 		participants []models.OrganizationParticipant,
+		pagination domain.Pagination,
 	) ([]byte, error)
 	ResponseParticipant(
 		ctx context.Context,
@@ -68,10 +69,16 @@ func (p *participantsPresenter) ResponseParticipantHal(
 		domainParticipant.IsAdmin = user.IsAdmin()
 		domainParticipant.IsOwner = user.IsOwner()
 		domainParticipant.IsActive = user.Activated
+		domainParticipant.Role = user.Role().String()
+
+		for _, p := range user.Role().Permissions() {
+			domainParticipant.Permissions = append(domainParticipant.Permissions, string(p))
+		}
 
 	} else if employee := participant.GetEmployee(); employee != nil {
 		domainParticipant.Name = employee.EmployeeName
 		domainParticipant.Position = employee.Position()
+		domainParticipant.IsActive = employee.IsActive()
 	}
 
 	organizationID, err := ctxmeta.OrganizationId(ctx)
@@ -122,12 +129,33 @@ func (p *participantsPresenter) ResponseParticipantsHal(
 func (p *participantsPresenter) ResponseListParticipants(
 	ctx context.Context,
 	participants []models.OrganizationParticipant,
+	pagination domain.Pagination,
 ) ([]byte, error) {
-	r, err := p.ResponseParticipantsHal(ctx, participants)
+	resources := make([]*hal.Resource, len(participants))
+
+	for i, pt := range participants {
+		r, err := p.ResponseParticipantHal(ctx, pt)
+		if err != nil {
+			return nil, fmt.Errorf("error map participant to hal resource. %w", err)
+		}
+
+		resources[i] = r
+	}
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error map participants to hal. %w", err)
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
 	}
 
+	r := hal.NewResource(
+		map[string]any{
+			"participants": resources,
+			"pagination":   pagination,
+		},
+		"/organizations/"+organizationID.String()+"/participants",
+		hal.WithType("participants"),
+	)
+
 	out, err := json.Marshal(r)
 	if err != nil {
 		return nil, fmt.Errorf("error marshal organization create response. %w", err)
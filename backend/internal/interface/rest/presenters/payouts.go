@@ -0,0 +1,149 @@
+package presenters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain/hal"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+)
+
+type PayoutsPresenter interface {
+	ResponsePayoutRun(ctx context.Context, run *models.PayoutRun) ([]byte, error)
+	ResponsePayoutRuns(ctx context.Context, runs []*models.PayoutRun) ([]byte, error)
+	ResponsePayments(ctx context.Context, payments []models.Payment) ([]byte, error)
+}
+
+type payoutsPresenter struct{}
+
+func NewPayoutsPresenter() PayoutsPresenter {
+	return &payoutsPresenter{}
+}
+
+func (p *payoutsPresenter) PayoutRun(run *models.PayoutRun) *hal.Resource {
+	r := &domain.PayoutRun{
+		Id:             run.ID.String(),
+		OrganizationId: run.OrganizationID.String(),
+		PayrollId:      run.PayrollID.String(),
+		MultisigId:     run.MultisigID.String(),
+		Status:         run.Status.String(),
+		DepositAmount:  run.DepositAmount.Value,
+		DepositTxHash:  run.DepositTxHash,
+		ConfirmedBy:    make([]string, len(run.ConfirmedBy)),
+		Confirmations:  run.Confirmations,
+		CreatedBy:      run.CreatedBy.String(),
+		CreatedAt:      run.CreatedAt.UnixMilli(),
+		UpdatedAt:      run.UpdatedAt.UnixMilli(),
+	}
+
+	for i, id := range run.ConfirmedBy {
+		r.ConfirmedBy[i] = id.String()
+	}
+
+	if !run.ExecutedAt.IsZero() {
+		r.ExecutedAt = run.ExecutedAt.UnixMilli()
+	}
+
+	res := hal.NewResource(
+		r,
+		"/organizations/"+r.OrganizationId+"/payrolls/payouts/"+r.Id,
+		hal.WithType("payout"),
+	)
+
+	if len(run.Payments) > 0 {
+		res.Embed("payments", p.payments(run.OrganizationID.String(), run.Payments))
+	}
+
+	return res
+}
+
+func (p *payoutsPresenter) ResponsePayoutRun(ctx context.Context, run *models.PayoutRun) ([]byte, error) {
+	out, err := json.Marshal(p.PayoutRun(run))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal payout run to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *payoutsPresenter) ResponsePayoutRuns(ctx context.Context, runs []*models.PayoutRun) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	outArray := make([]*hal.Resource, len(runs))
+
+	for i, run := range runs {
+		outArray[i] = p.PayoutRun(run)
+	}
+
+	r := hal.NewResource(
+		map[string]any{"payouts": outArray},
+		"/organizations/"+organizationID.String()+"/payrolls/payouts",
+		hal.WithType("payouts"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal payout runs to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *payoutsPresenter) ResponsePayments(ctx context.Context, payments []models.Payment) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	out, err := json.Marshal(p.payments(organizationID.String(), payments))
+	if err != nil {
+		return nil, fmt.Errorf("error marshal payments to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *payoutsPresenter) payments(organizationID string, payments []models.Payment) *hal.Resource {
+	outArray := make([]*hal.Resource, len(payments))
+
+	for i, payment := range payments {
+		r := &domain.Payment{
+			Id:              payment.ID.String(),
+			PayoutRunId:     payment.PayoutRunID.String(),
+			PayrollId:       payment.PayrollID.String(),
+			SalaryId:        payment.SalaryID.String(),
+			EmployeeId:      payment.EmployeeID.String(),
+			EmployeeAddress: common.BytesToAddress(payment.EmployeeAddress).Hex(),
+			Amount:          payment.Amount.Value,
+			Status:          payment.Status.String(),
+			TxIndex:         payment.TxIndex,
+			TxHash:          payment.TxHash,
+			CreatedAt:       payment.CreatedAt.UnixMilli(),
+			UpdatedAt:       payment.UpdatedAt.UnixMilli(),
+		}
+
+		if !payment.PaidAt.IsZero() {
+			r.PaidAt = payment.PaidAt.UnixMilli()
+		}
+
+		outArray[i] = hal.NewResource(
+			r,
+			"/organizations/"+organizationID+"/payrolls/payouts/payments/"+r.Id,
+			hal.WithType("payment"),
+		)
+	}
+
+	return hal.NewResource(
+		map[string]any{"payments": outArray},
+		"/organizations/"+organizationID+"/payrolls/payouts/payments",
+		hal.WithType("payments"),
+	)
+}
@@ -0,0 +1,252 @@
+package presenters
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
+	"github.com/emochka2007/block-accounting/internal/interface/rest/domain/hal"
+	"github.com/emochka2007/block-accounting/internal/pkg/pdf"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/reports"
+)
+
+type ReportsPresenter interface {
+	ResponseReport(ctx context.Context, report *reports.Report) ([]byte, error)
+	// ResponseReportCSV renders report as one table, section totals and statement summary included
+	ResponseReportCSV(ctx context.Context, report *reports.Report) ([]byte, error)
+	ResponseReportPDF(ctx context.Context, report *reports.Report) ([]byte, error)
+}
+
+type reportsPresenter struct{}
+
+func NewReportsPresenter() ReportsPresenter {
+	return &reportsPresenter{}
+}
+
+func (p *reportsPresenter) Line(line reports.Line) domain.ReportLine {
+	return domain.ReportLine{
+		Category:      line.Category,
+		Name:          line.Name,
+		Currency:      line.Currency,
+		Amount:        line.Amount,
+		Fiat:          line.Fiat,
+		UnpricedLines: line.UnpricedLines,
+	}
+}
+
+func (p *reportsPresenter) Lines(lines []reports.Line) []domain.ReportLine {
+	out := make([]domain.ReportLine, len(lines))
+
+	for i, l := range lines {
+		out[i] = p.Line(l)
+	}
+
+	return out
+}
+
+func (p *reportsPresenter) Report(report *reports.Report) *domain.Report {
+	r := &domain.Report{
+		OrganizationId: report.OrganizationID.String(),
+		To:             report.To.UnixMilli(),
+		FiatCurrency:   report.FiatCurrency,
+		Statements:     make([]domain.ReportStatement, len(report.Statements)),
+		Unposted:       make([]domain.ReportUnposted, len(report.Unposted)),
+	}
+
+	if !report.From.IsZero() {
+		r.From = report.From.UnixMilli()
+	}
+
+	for i, s := range report.Statements {
+		statement := domain.ReportStatement{
+			Statement: string(s.Statement),
+			Title:     s.Statement.Title(),
+			Sections:  make([]domain.ReportSection, len(s.Sections)),
+			Summary:   p.Lines(s.Summary),
+		}
+
+		for j, section := range s.Sections {
+			statement.Sections[j] = domain.ReportSection{
+				Name:      section.Name,
+				Lines:     p.Lines(section.Lines),
+				Totals:    p.Lines(section.Totals),
+				FiatTotal: section.FiatTotal,
+			}
+		}
+
+		r.Statements[i] = statement
+	}
+
+	for i, u := range report.Unposted {
+		r.Unposted[i] = domain.ReportUnposted{
+			SourceType:  string(u.SourceType),
+			SourceId:    u.SourceID.String(),
+			Description: u.Description,
+			Currency:    u.Currency,
+			Amount:      u.Amount,
+			OccurredAt:  u.OccurredAt.UnixMilli(),
+		}
+	}
+
+	return r
+}
+
+func (p *reportsPresenter) ResponseReport(ctx context.Context, report *reports.Report) ([]byte, error) {
+	r := hal.NewResource(
+		p.Report(report),
+		"/organizations/"+report.OrganizationID.String()+"/reports",
+		hal.WithType("report"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal report to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (p *reportsPresenter) ResponseReportCSV(ctx context.Context, report *reports.Report) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{{
+		"statement",
+		"section",
+		"category",
+		"name",
+		"currency",
+		"amount",
+		"fiat_amount_" + report.FiatCurrency,
+		"unpriced_lines",
+	}}
+
+	row := func(statement reports.Statement, section string, l reports.Line) []string {
+		return []string{
+			string(statement),
+			section,
+			l.Category,
+			l.Name,
+			l.Currency,
+			l.Amount.String(),
+			l.Fiat.String(),
+			strconv.FormatInt(l.UnpricedLines, 10),
+		}
+	}
+
+	for _, s := range report.Statements {
+		for _, section := range s.Sections {
+			for _, l := range section.Lines {
+				rows = append(rows, row(s.Statement, section.Name, l))
+			}
+
+			for _, l := range section.Totals {
+				rows = append(rows, row(s.Statement, section.Name, l))
+			}
+		}
+
+		for _, l := range s.Summary {
+			rows = append(rows, row(s.Statement, "Summary", l))
+		}
+	}
+
+	// unposted rows are categorized by source type and named by source id, they have no fiat amount
+	for _, u := range report.Unposted {
+		rows = append(rows, []string{
+			"unposted",
+			u.Description,
+			string(u.SourceType),
+			u.SourceID.String(),
+			u.Currency,
+			u.Amount.String(),
+			"",
+			"",
+		})
+	}
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("error write report csv. %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// reportRowFormat columns are category, name, currency, amount and fiat amount
+const reportRowFormat = "  %-16.16s %-28.28s %-6.6s %22s %14s"
+
+func (p *reportsPresenter) ResponseReportPDF(ctx context.Context, report *reports.Report) ([]byte, error) {
+	doc := pdf.New("Financial report " + report.OrganizationID.String())
+
+	period := "as of " + report.To.UTC().Format(time.DateTime)
+	if !report.From.IsZero() {
+		period = report.From.UTC().Format(time.DateTime) + " - " + period
+	}
+
+	doc.Line("Organization: %s", report.OrganizationID)
+	doc.Line("Period (UTC): %s", period)
+	doc.Line("Fiat amounts in %s at the rates of the moments funds were moved", report.FiatCurrency)
+
+	row := func(l reports.Line) {
+		line := fmt.Sprintf(reportRowFormat, l.Category, l.Name, l.Currency, l.Amount, l.Fiat)
+
+		if l.UnpricedLines > 0 {
+			line += " *"
+		}
+
+		doc.Line("%s", line)
+	}
+
+	for _, s := range report.Statements {
+		doc.Line("")
+		doc.Line(s.Statement.Title())
+		doc.Line(reportRowFormat, "category", "name", "curr.", "amount", report.FiatCurrency)
+
+		for _, section := range s.Sections {
+			doc.Line("")
+			doc.Line(" %s", section.Name)
+
+			for _, l := range section.Lines {
+				row(l)
+			}
+
+			for _, l := range section.Totals {
+				row(l)
+			}
+
+			doc.Line(reportRowFormat, "", "Total in "+report.FiatCurrency, "", "", section.FiatTotal)
+		}
+
+		doc.Line("")
+
+		for _, l := range s.Summary {
+			row(l)
+		}
+	}
+
+	if len(report.Unposted) > 0 {
+		doc.Line("")
+		doc.Line("Executed but not posted to the ledger, not included above")
+
+		for _, u := range report.Unposted {
+			doc.Line(
+				"  %-10s %-12.12s %-36s %-6.6s %s",
+				u.OccurredAt.UTC().Format(time.DateOnly),
+				u.SourceType,
+				u.SourceID,
+				u.Currency,
+				u.Amount,
+			)
+		}
+	}
+
+	doc.Line("")
+	doc.Line("* fiat amount excludes ledger lines without price snapshot")
+
+	return doc.Bytes(), nil
+}
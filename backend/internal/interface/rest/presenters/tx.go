@@ -5,13 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/emochka2007/block-accounting/internal/interface/rest/domain"
 	"github.com/emochka2007/block-accounting/internal/interface/rest/domain/hal"
 	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
 	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
 )
 
 var (
@@ -27,7 +30,12 @@ type TransactionsPresenter interface {
 
 	ResponseMultisigs(ctx context.Context, msgs []models.Multisig) ([]byte, error)
 
+	ResponsePayroll(ctx context.Context, payroll *models.Payroll) ([]byte, error)
 	ResponsePayrolls(ctx context.Context, payrolls []models.Payroll) ([]byte, error)
+
+	ResponseSalary(ctx context.Context, salary *models.Salary) ([]byte, error)
+	// onChain maps salary id to the salary stored in the payroll contract. May be nil
+	ResponseSalaries(ctx context.Context, salaries []models.Salary, onChain map[uuid.UUID]*big.Int) ([]byte, error)
 }
 
 type transactionsPresenter struct {
@@ -56,12 +64,28 @@ func (p *transactionsPresenter) RequestTransaction(
 		return models.Transaction{}, fmt.Errorf("error fetch organization id from context. %w", err)
 	}
 
+	multisigID, err := uuid.Parse(r.MultisigID)
+	if err != nil {
+		return models.Transaction{}, fmt.Errorf("error parse multisig id. %w", err)
+	}
+
+	var assetID uuid.UUID
+
+	if r.AssetID != "" {
+		if assetID, err = uuid.Parse(r.AssetID); err != nil {
+			return models.Transaction{}, fmt.Errorf("error parse asset id. %w", err)
+		}
+	}
+
 	tx := models.Transaction{
-		OrganizationId: organizationID,
-		Description:    r.Description,
-		Amount:         r.Amount,
-		ToAddr:         toAddress.Bytes(),
-		CreatedAt:      time.Now(),
+		OrganizationId:        organizationID,
+		Description:           r.Description,
+		Amount:                money.New(r.Amount, money.ETH),
+		AssetID:               assetID,
+		ToAddr:                toAddress.Bytes(),
+		MultisigID:            multisigID,
+		ConfirmationsRequired: r.ConfirmationsRequired,
+		CreatedAt:             time.Now(),
 	}
 
 	return tx, nil
@@ -76,11 +100,46 @@ func (c *transactionsPresenter) ResponseTransaction(
 		Description:    tx.Description,
 		OrganizationId: tx.OrganizationId.String(),
 		CreatedBy:      tx.CreatedBy.Id().String(),
-		Amount:         tx.Amount,
-		MaxFeeAllowed:  tx.MaxFeeAllowed,
-		Status:         tx.Status,
+		Amount:         tx.Amount.Value,
+		Asset:          tx.Amount.Currency.Code,
+		MaxFeeAllowed:  tx.MaxFeeAllowed.Value,
+		Status:         int(tx.Status),
 		CreatedAt:      tx.CreatedAt.UnixMilli(),
 		UpdatedAt:      tx.UpdatedAt.UnixMilli(),
+
+		ConfirmationsRequired: tx.ConfirmationsRequired,
+		Confirmations:         tx.Confirmations,
+		TxHash:                tx.TxHash,
+	}
+
+	if tx.MultisigID != uuid.Nil {
+		r.MultisigId = tx.MultisigID.String()
+	}
+
+	if tx.AssetID != uuid.Nil {
+		r.AssetId = tx.AssetID.String()
+	}
+
+	if tx.Price != nil {
+		r.Fiat = &domain.FiatValue{
+			Currency: tx.Price.Quote,
+			Amount:   tx.Price.Convert(tx.Amount.Value).Value,
+			Rate:     tx.Price.Rate,
+			PricedAt: tx.Price.ObservedAt.UnixMilli(),
+		}
+	}
+
+	for _, id := range tx.ConfirmedBy {
+		r.ConfirmedBy = append(r.ConfirmedBy, id.String())
+	}
+
+	for _, id := range tx.RejectedBy {
+		r.RejectedBy = append(r.RejectedBy, id.String())
+	}
+
+	// transaction returned to pending after revocation keeps its multisig index
+	if tx.Status >= models.TransactionStatusSubmitted || !tx.SubmittedAt.IsZero() {
+		r.TxIndex = tx.TxIndex
 	}
 
 	addr := common.BytesToAddress(tx.ToAddr)
@@ -184,6 +243,8 @@ type Multisig struct {
 	ID     string        `json:"id"`
 	Title  string        `json:"title"`
 	Owners *hal.Resource `json:"owners"`
+	// RotationRequiredAt is set if any owner was removed from the organization
+	RotationRequiredAt int64 `json:"rotation_required_at,omitempty"`
 }
 
 func (c *transactionsPresenter) ResponseMultisigs(ctx context.Context, msgs []models.Multisig) ([]byte, error) {
@@ -200,6 +261,10 @@ func (c *transactionsPresenter) ResponseMultisigs(ctx context.Context, msgs []mo
 			Title: m.Title,
 		}
 
+		if !m.RotationRequiredAt.IsZero() {
+			mout.RotationRequiredAt = m.RotationRequiredAt.UnixMilli()
+		}
+
 		partOut, err := c.participantsPresenter.ResponseParticipantsHal(ctx, m.Owners)
 		if err != nil {
 			return nil, err
@@ -227,10 +292,60 @@ func (c *transactionsPresenter) ResponseMultisigs(ctx context.Context, msgs []mo
 }
 
 type Payroll struct {
-	ID        string `json:"id"`
-	Title     string `json:"title"`
-	CreatedAt int64  `json:"created_at"`
-	UpdatedAt int64  `json:"updated_at"`
+	ID            string   `json:"id"`
+	Title         string   `json:"title"`
+	Address       string   `json:"address,omitempty"`
+	MultisigID    string   `json:"multisig_id"`
+	Status        string   `json:"status"`
+	Confirmations int      `json:"confirmations"`
+	ConfirmedBy   []string `json:"confirmed_by,omitempty"`
+	CreatedAt     int64    `json:"created_at"`
+	UpdatedAt     int64    `json:"updated_at"`
+}
+
+func newPayroll(pr *models.Payroll) Payroll {
+	out := Payroll{
+		ID:            pr.ID.String(),
+		Title:         pr.Title,
+		MultisigID:    pr.MultisigID.String(),
+		Status:        pr.Status.String(),
+		Confirmations: pr.Confirmations,
+		CreatedAt:     pr.CreatedAt.UnixMilli(),
+		UpdatedAt:     pr.UpdatedAt.UnixMilli(),
+	}
+
+	if len(pr.Address) > 0 {
+		out.Address = common.BytesToAddress(pr.Address).Hex()
+	}
+
+	for _, id := range pr.ConfirmedBy {
+		out.ConfirmedBy = append(out.ConfirmedBy, id.String())
+	}
+
+	return out
+}
+
+func (c *transactionsPresenter) ResponsePayroll(
+	ctx context.Context,
+	payroll *models.Payroll,
+) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	r := hal.NewResource(
+		newPayroll(payroll),
+		"/organizations/"+organizationID.String()+"/payrolls/"+payroll.ID.String(),
+		hal.WithType("payroll"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal payroll to hal resource. %w", err)
+	}
+
+	return out, nil
 }
 
 func (c *transactionsPresenter) ResponsePayrolls(
@@ -244,13 +359,8 @@ func (c *transactionsPresenter) ResponsePayrolls(
 
 	outArray := make([]Payroll, len(payrolls))
 
-	for i, pr := range payrolls {
-		outArray[i] = Payroll{
-			ID:        pr.ID.String(),
-			Title:     pr.Title,
-			CreatedAt: pr.CreatedAt.UnixMilli(),
-			UpdatedAt: pr.UpdatedAt.UnixMilli(),
-		}
+	for i := range payrolls {
+		outArray[i] = newPayroll(&payrolls[i])
 	}
 
 	txsResource := map[string]any{"payrolls": outArray}
@@ -268,3 +378,92 @@ func (c *transactionsPresenter) ResponsePayrolls(
 
 	return out, nil
 }
+
+func newSalary(s *models.Salary) *domain.Salary {
+	r := &domain.Salary{
+		Id:              s.ID.String(),
+		OrganizationId:  s.OrganizationID.String(),
+		PayrollId:       s.PayrollID.String(),
+		EmployeeId:      s.EmployeeID.String(),
+		EmployeeAddress: common.BytesToAddress(s.EmployeeAddress).Hex(),
+		Amount:          s.Amount.Value,
+		Status:          s.Status.String(),
+		Confirmations:   s.Confirmations,
+		TxIndex:         s.TxIndex,
+		TxHash:          s.TxHash,
+		CreatedBy:       s.CreatedBy.String(),
+		CreatedAt:       s.CreatedAt.UnixMilli(),
+		UpdatedAt:       s.UpdatedAt.UnixMilli(),
+	}
+
+	for _, id := range s.ConfirmedBy {
+		r.ConfirmedBy = append(r.ConfirmedBy, id.String())
+	}
+
+	return r
+}
+
+func (c *transactionsPresenter) ResponseSalary(
+	ctx context.Context,
+	salary *models.Salary,
+) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	r := hal.NewResource(
+		newSalary(salary),
+		"/organizations/"+organizationID.String()+"/payrolls/salaries/"+salary.ID.String(),
+		hal.WithType("salary"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal salary to hal resource. %w", err)
+	}
+
+	return out, nil
+}
+
+func (c *transactionsPresenter) ResponseSalaries(
+	ctx context.Context,
+	salaries []models.Salary,
+	onChain map[uuid.UUID]*big.Int,
+) ([]byte, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	outArray := make([]*hal.Resource, len(salaries))
+
+	for i, s := range salaries {
+		r := newSalary(&s)
+
+		if amount, ok := onChain[s.ID]; ok && amount != nil {
+			r.OnChainAmount = amount.String()
+		}
+
+		outArray[i] = hal.NewResource(
+			r,
+			"/organizations/"+organizationID.String()+"/payrolls/salaries/"+r.Id,
+			hal.WithType("salary"),
+		)
+	}
+
+	txsResource := map[string]any{"salaries": outArray}
+
+	r := hal.NewResource(
+		txsResource,
+		"/organizations/"+organizationID.String()+"/payrolls/salaries",
+		hal.WithType("salaries"),
+	)
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal salaries to hal resource. %w", err)
+	}
+
+	return out, nil
+}
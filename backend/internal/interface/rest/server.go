@@ -106,12 +106,31 @@ func (s *Server) buildRouter() {
 
 	router.Post("/join", s.handle(s.controllers.Auth.Join, "join"))
 	router.Post("/login", s.handle(s.controllers.Auth.Login, "login"))
+	// wallet signature sign in, EIP-4361
+	router.Post("/login/nonce", s.handle(s.controllers.Auth.WalletNonce, "wallet_nonce"))
+	router.Post("/login/wallet", s.handle(s.controllers.Auth.WalletLogin, "wallet_login"))
+	router.Post("/join/wallet", s.handle(s.controllers.Auth.WalletJoin, "wallet_join"))
 	router.Post("/refresh", s.handle(s.controllers.Auth.Refresh, "refresh"))
+	router.Get("/.well-known/jwks.json", s.handle(s.controllers.Auth.JWKS, "jwks"))
+
+	router.With(s.withAuthorization).Post("/logout", s.handle(s.controllers.Auth.Logout, "logout"))
+
+	router.Route("/sessions", func(r chi.Router) {
+		r = r.With(s.withAuthorization)
+
+		r.Get("/", s.handle(s.controllers.Auth.Sessions, "list_sessions"))
+		r.Post("/revoke-others", s.handle(s.controllers.Auth.RevokeOtherSessions, "revoke_other_sessions"))
+		r.Delete("/{session_id}", s.handle(s.controllers.Auth.RevokeSession, "revoke_session"))
+	})
 
 	// open invite link
 	router.Get("/invite/{hash}", s.handle(s.controllers.Auth.InviteGet, "invite_open"))
 	// join via invite link
 	router.Post("/invite/{hash}/join", s.handle(s.controllers.Auth.JoinWithInvite, "invite_join"))
+	// accept invite with existing account
+	router.With(s.withAuthorization).Post("/invite/{hash}/accept", s.handle(s.controllers.Auth.AcceptInvite, "invite_accept"))
+	// invites bound to the current user wallet
+	router.With(s.withAuthorization).Post("/invites/fetch", s.handle(s.controllers.Auth.ReceivedInvites, "received_invites"))
 
 	router.Route("/organizations", func(r chi.Router) {
 		r = r.With(s.withAuthorization)
@@ -120,23 +139,91 @@ func (s *Server) buildRouter() {
 		r.Post("/", s.handle(s.controllers.Organizations.NewOrganization, "new_organization"))
 
 		r.Route("/{organization_id}", func(r chi.Router) {
+			r.Put("/", s.handle(s.controllers.Organizations.UpdateOrganization, "update_organization"))
+			r.Put("/archive", s.handle(s.controllers.Organizations.Archive, "archive_organization"))
+			r.Put("/unarchive", s.handle(s.controllers.Organizations.Unarchive, "unarchive_organization"))
+
+			r.Route("/ownership-transfers", func(r chi.Router) {
+				r.Post("/", s.handle(s.controllers.Organizations.TransferOwnership, "new_ownership_transfer"))
+				r.Post("/fetch", s.handle(s.controllers.Organizations.ListOwnershipTransfers, "list_ownership_transfers"))
+				r.Put("/{transfer_id}/confirm", s.handle(s.controllers.Organizations.ConfirmOwnershipTransfer, "confirm_ownership_transfer"))
+				r.Put("/{transfer_id}/cancel", s.handle(s.controllers.Organizations.CancelOwnershipTransfer, "cancel_ownership_transfer"))
+			})
+
 			r.Route("/payrolls", func(r chi.Router) {
 				r.Post("/fetch", s.handle(s.controllers.Transactions.ListPayrolls, "list_payrolls"))
 				r.Post("/", s.handle(s.controllers.Transactions.NewPayroll, "new_payroll"))
-				r.Put("/", s.handle(s.controllers.Transactions.ConfirmPayroll, "confirm_payroll"))
+				r.Put("/{payroll_id}/confirm", s.handle(s.controllers.Transactions.ConfirmPayroll, "confirm_payroll"))
+				// deprecated, payroll id is passed in the body
+				r.Put("/", s.handle(s.controllers.Transactions.ConfirmPayrollDeprecated, "confirm_payroll_deprecated"))
+
+				r.Post("/salaries", s.handle(s.controllers.Transactions.SetSalary, "set_salary"))
+				r.Post("/salaries/fetch", s.handle(s.controllers.Transactions.ListSalaries, "get_salaries"))
+
+				r.Post("/payouts", s.handle(s.controllers.Payouts.NewPayout, "new_payout"))
+				r.Post("/payouts/fetch", s.handle(s.controllers.Payouts.ListPayouts, "list_payouts"))
+				r.Put("/payouts/{payout_id}/confirm", s.handle(s.controllers.Payouts.ConfirmPayout, "confirm_payout"))
+				r.Post("/payouts/payments/fetch", s.handle(s.controllers.Payouts.ListPayments, "list_payments"))
 
-				r.Post("/salaries", s.handle(nil, "set_salary"))
-				r.Post("/salaries/fetch", s.handle(nil, "get_salaries"))
+				r.Post("/deposit", s.handle(s.controllers.Payouts.Deposit, "payroll_deposit"))
 			})
 
 			r.Route("/multisig", func(r chi.Router) {
 				r.Post("/", s.handle(s.controllers.Transactions.NewMultisig, "new_multisig"))
 				r.Post("/fetch", s.handle(s.controllers.Transactions.ListMultisigs, "list_multisig"))
+				r.Post("/deposit", s.handle(s.controllers.Transactions.MultisigDeposit, "multisig_deposit"))
 			})
 
 			r.Route("/license", func(r chi.Router) {
-				r.Post("/fetch", nil) // list license
-				r.Post("/", nil)      // deploy contract
+				r.Post("/fetch", s.handle(s.controllers.Licenses.ListLicenses, "list_licenses"))
+				r.Post("/", s.handle(s.controllers.Licenses.NewLicense, "new_license"))
+
+				r.Put(
+					"/operations/{operation_id}/confirm",
+					s.handle(s.controllers.Licenses.ConfirmOperation, "confirm_license_operation"),
+				)
+
+				r.Route("/{license_id}", func(r chi.Router) {
+					r.Get("/info", s.handle(s.controllers.Licenses.Info, "license_info"))
+					r.Post("/request", s.handle(s.controllers.Licenses.Request, "license_request"))
+					r.Post("/payout-contract", s.handle(s.controllers.Licenses.SetPayoutContract, "license_set_payout_contract"))
+					r.Post("/payout", s.handle(s.controllers.Licenses.Payout, "license_payout"))
+					r.Post("/operations/fetch", s.handle(s.controllers.Licenses.ListOperations, "list_license_operations"))
+				})
+			})
+
+			r.Route("/agreements", func(r chi.Router) {
+				r.Post("/fetch", s.handle(s.controllers.Agreements.ListAgreements, "list_agreements"))
+				r.Post("/", s.handle(s.controllers.Agreements.NewAgreement, "new_agreement"))
+
+				r.Put(
+					"/operations/{operation_id}/confirm",
+					s.handle(s.controllers.Agreements.ConfirmOperation, "confirm_agreement_operation"),
+				)
+
+				r.Route("/{agreement_id}", func(r chi.Router) {
+					r.Post("/request", s.handle(s.controllers.Agreements.Request, "agreement_request"))
+					r.Post("/resolve", s.handle(s.controllers.Agreements.Resolve, "agreement_resolve"))
+					r.Post("/operations/fetch", s.handle(s.controllers.Agreements.ListOperations, "list_agreement_operations"))
+				})
+			})
+
+			r.Route("/ledger", func(r chi.Router) {
+				r.Get("/", s.handle(s.controllers.Ledger.Ledger, "ledger"))
+				r.Post("/fetch", s.handle(s.controllers.Ledger.Fetch, "fetch_ledger"))
+				r.Post("/entries", s.handle(s.controllers.Ledger.NewEntry, "new_ledger_entry"))
+				r.Post("/accounts", s.handle(s.controllers.Ledger.NewAccount, "new_ledger_account"))
+				r.Post("/accounts/fetch", s.handle(s.controllers.Ledger.ListAccounts, "list_ledger_accounts"))
+			})
+
+			r.Route("/assets", func(r chi.Router) {
+				r.Post("/", s.handle(s.controllers.Assets.NewAsset, "new_asset"))
+				r.Post("/fetch", s.handle(s.controllers.Assets.ListAssets, "list_assets"))
+			})
+
+			r.Route("/reports", func(r chi.Router) {
+				r.Get("/", s.handle(s.controllers.Reports.Report, "report"))
+				r.Get("/{statement}", s.handle(s.controllers.Reports.Report, "report_statement"))
 			})
 
 			r.Route("/participants", func(r chi.Router) {
@@ -145,12 +232,29 @@ func (s *Server) buildRouter() {
 
 				// generate new invite link
 				r.Post("/invite", s.handle(s.controllers.Auth.Invite, "invite"))
+				r.Post("/invites/fetch", s.handle(s.controllers.Auth.ListInvites, "list_invites"))
+				r.Delete("/invites/{hash}", s.handle(s.controllers.Auth.RevokeInvite, "revoke_invite"))
 
 				r.Route("/{participant_id}", func(r chi.Router) {
-					r.Get("/", nil) // todo если успею
+					r.Get("/", s.handle(s.controllers.Participants.Get, "get_participant"))
+					r.Put("/", s.handle(s.controllers.Participants.Update, "update_participant"))
+					r.Delete("/", s.handle(s.controllers.Participants.Delete, "delete_participant"))
+					r.Put("/restore", s.handle(s.controllers.Participants.Restore, "restore_participant"))
+					r.Put("/role", s.handle(s.controllers.Participants.UpdateRole, "update_participant_role"))
 				})
 			})
 
+			r.Route("/confirmations/{entity_type}/{entity_id}", func(r chi.Router) {
+				r.Get("/", s.handle(s.controllers.Confirmations.Get, "get_confirmation"))
+				r.Put("/", s.handle(s.controllers.Confirmations.Confirm, "confirm_entity"))
+				r.Delete("/", s.handle(s.controllers.Confirmations.Revoke, "revoke_confirmation"))
+				r.Put("/reject", s.handle(s.controllers.Confirmations.Reject, "reject_entity"))
+			})
+
+			r.Route("/jobs", func(r chi.Router) {
+				r.Get("/{job_id}", s.handle(s.controllers.Jobs.Get, "get_job"))
+			})
+
 			r.Route("/transactions", func(r chi.Router) {
 				r.Post("/fetch", s.handle(s.controllers.Transactions.List, "tx_list"))
 				r.Post("/", s.handle(s.controllers.Transactions.New, "new_tx"))
@@ -193,7 +297,11 @@ func (s *Server) handle(
 			return
 		}
 
-		w.Header().Add("Content-Type", "application/json")
+		// controllers rendering other formats than json set the content type themselves
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+
 		w.WriteHeader(http.StatusOK)
 
 		if _, err = w.Write(out); err != nil {
@@ -233,10 +341,15 @@ func (s *Server) handleMw(next http.Handler) http.Handler {
 			return
 		}
 
-		w.Header().Add("Content-Type", "application/json")
+		w.Header().Set("Content-Type", "application/json")
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 
-		next.ServeHTTP(w, r)
+		ctx := ctxmeta.ClientContext(r.Context(), ctxmeta.ClientInfo{
+			RemoteAddr: r.RemoteAddr,
+			UserAgent:  r.UserAgent(),
+		})
+
+		next.ServeHTTP(w, r.WithContext(ctx))
 	}
 
 	return http.HandlerFunc(fn)
@@ -273,6 +386,7 @@ func (s *Server) withAuthorization(next http.Handler) http.Handler {
 		}
 
 		ctx := ctxmeta.UserContext(r.Context(), user)
+		ctx = ctxmeta.AccessTokenContext(ctx, tokenString)
 
 		if organizationID := chi.URLParam(r, "organization_id"); organizationID != "" {
 			organizationUUID, err := uuid.Parse(organizationID)
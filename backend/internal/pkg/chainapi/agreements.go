@@ -0,0 +1,45 @@
+package chainapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AgreementDeploy submits agreement contract deploy to the multisig
+func (c *Client) AgreementDeploy(
+	ctx context.Context,
+	seed []byte,
+	req AgreementDeployRequest,
+) (*SubmitTransactionResponse, error) {
+	resp := new(SubmitTransactionResponse)
+
+	if err := c.do(ctx, http.MethodPost, "/agreements/deploy", seed, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// AgreementResponse reads the last oracle response stored in the agreement contract
+func (c *Client) AgreementResponse(
+	ctx context.Context,
+	seed []byte,
+	agreement common.Address,
+) (bool, error) {
+	var response string
+
+	if err := c.do(ctx, http.MethodGet, "/agreements/"+agreement.Hex(), seed, nil, &response); err != nil {
+		return false, err
+	}
+
+	outcome, err := strconv.ParseBool(response)
+	if err != nil {
+		return false, fmt.Errorf("error parse agreement response %s. %w", response, err)
+	}
+
+	return outcome, nil
+}
@@ -0,0 +1,99 @@
+package chainapi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Contract methods called through the multisig. chain-api encodes the same calldata in its salaries, license
+// and agreements endpoints, the backend encodes it itself, so the calls are submitted by any signer
+const (
+	MethodSetSalary         = "setSalary"
+	MethodPayoutInETH       = "payoutInETH"
+	MethodRequest           = "request"
+	MethodPayout            = "payout"
+	MethodSetPayoutContract = "setPayoutContract"
+)
+
+var ErrorUnknownCall = errors.New("unknown contract call")
+
+// callsABI holds Payroll, StreamingRightsManagement and Agreement methods. License and agreement share request
+const callsABI = `[
+	{"type":"function","name":"setSalary","stateMutability":"nonpayable","inputs":[
+		{"name":"employee","type":"address"},{"name":"salaryInUSDT","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"payoutInETH","stateMutability":"nonpayable","inputs":[
+		{"name":"employee","type":"address"}],"outputs":[]},
+	{"type":"function","name":"request","stateMutability":"nonpayable","inputs":[
+		{"name":"url","type":"string"}],"outputs":[]},
+	{"type":"function","name":"payout","stateMutability":"nonpayable","inputs":[],"outputs":[]},
+	{"type":"function","name":"setPayoutContract","stateMutability":"nonpayable","inputs":[
+		{"name":"payoutContract","type":"address"}],"outputs":[]}
+]`
+
+var calls = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(callsABI))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}()
+
+func pack(method string, args ...any) []byte {
+	data, err := calls.Pack(method, args...)
+	if err != nil {
+		// arguments are typed by the callers, so packing can not fail
+		panic(fmt.Sprintf("error pack %s call. %s", method, err))
+	}
+
+	return data
+}
+
+// SetSalaryCall is the payroll setSalary calldata. Salary in USD
+func SetSalaryCall(employee common.Address, salary *big.Int) []byte {
+	return pack(MethodSetSalary, employee, salary)
+}
+
+// PayoutInETHCall is the payroll payoutInETH calldata
+func PayoutInETHCall(employee common.Address) []byte {
+	return pack(MethodPayoutInETH, employee)
+}
+
+// RequestCall is the license and agreement oracle request calldata
+func RequestCall(url string) []byte {
+	return pack(MethodRequest, url)
+}
+
+// PayoutCall is the license payout calldata
+func PayoutCall() []byte {
+	return pack(MethodPayout)
+}
+
+// SetPayoutContractCall is the license setPayoutContract calldata
+func SetPayoutContractCall(payoutContract common.Address) []byte {
+	return pack(MethodSetPayoutContract, payoutContract)
+}
+
+// UnpackCall decodes calldata of the methods above. Returns ErrorUnknownCall for other calldata
+func UnpackCall(data []byte) (string, []any, error) {
+	if len(data) < 4 {
+		return "", nil, ErrorUnknownCall
+	}
+
+	method, err := calls.MethodById(data[:4])
+	if err != nil {
+		return "", nil, ErrorUnknownCall
+	}
+
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return "", nil, fmt.Errorf("error unpack %s call. %w", method.Name, err)
+	}
+
+	return method.Name, args, nil
+}
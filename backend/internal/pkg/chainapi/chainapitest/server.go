@@ -0,0 +1,991 @@
+// Package chainapitest provides an in-process fake of the chain-api service.
+// Fake keeps contracts state in memory and mimics chain-api responses and errors format,
+// so code using chainapi.Client can be run without a chain
+package chainapitest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/chainapi"
+	"github.com/emochka2007/block-accounting/internal/pkg/hdwallet"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultUSDTPrice is ETH price in USD returned by the payroll price feed unless changed with SetUSDTPrice
+var DefaultUSDTPrice = money.NewFromInt(3000)
+
+var (
+	errNotOwner           = errors.New("not owner")
+	errTxDoesNotExist     = errors.New("tx does not exist")
+	errTxAlreadyConfirmed = errors.New("tx already confirmed")
+	errTxNotConfirmed     = errors.New("tx not confirmed")
+	errCannotExecute      = errors.New("cannot execute tx")
+)
+
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+
+	// nonce is used to generate contract and transaction addresses
+	nonce     uint64
+	usdtPrice money.Decimal
+
+	multisigs  map[common.Address]*multisig
+	payrolls   map[common.Address]*payroll
+	licenses   map[common.Address]*license
+	agreements map[common.Address]*agreement
+
+	signers  map[string]common.Address
+	failures map[string][]failure
+	calls    map[string]int
+}
+
+type multisig struct {
+	owners   []common.Address
+	required int
+	txs      []*transaction
+}
+
+func (m *multisig) isOwner(address common.Address) bool {
+	for _, owner := range m.owners {
+		if owner == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+type transaction struct {
+	to            common.Address
+	value         *big.Int
+	data          []byte
+	executed      bool
+	confirmations map[common.Address]bool
+
+	// effect is applied on execution. Deploy transactions effect registers a contract at the given address
+	effect func(deployed common.Address)
+	deploy bool
+}
+
+type payroll struct {
+	authorizedWallet common.Address
+	salaries         map[common.Address]int64
+}
+
+type license struct {
+	multisig       common.Address
+	owners         []common.Address
+	shares         map[common.Address]int
+	totalPayout    string
+	payoutContract common.Address
+	url            string
+}
+
+type agreement struct {
+	multisig common.Address
+	url      string
+	response bool
+}
+
+type failure struct {
+	statusCode int
+	message    string
+}
+
+// NewServer starts new fake chain-api server. Server must be closed by the caller
+func NewServer() *Server {
+	s := &Server{
+		usdtPrice:  DefaultUSDTPrice,
+		multisigs:  make(map[common.Address]*multisig),
+		payrolls:   make(map[common.Address]*payroll),
+		licenses:   make(map[common.Address]*license),
+		agreements: make(map[common.Address]*agreement),
+		signers:    make(map[string]common.Address),
+		failures:   make(map[string][]failure),
+		calls:      make(map[string]int),
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /multi-sig/deploy", s.handle(s.multisigDeploy))
+	mux.HandleFunc("GET /multi-sig/owners/{address}", s.handle(s.multisigOwners))
+	mux.HandleFunc("POST /multi-sig/submit-transaction", s.handle(s.submitTransaction))
+	mux.HandleFunc("POST /multi-sig/confirm-transaction", s.handle(s.confirmTransaction))
+	mux.HandleFunc("POST /multi-sig/execute-transaction", s.handle(s.executeTransaction))
+	mux.HandleFunc("POST /multi-sig/revoke-confirmation", s.handle(s.revokeConfirmation))
+	mux.HandleFunc("GET /multi-sig/transaction-count/{address}", s.handle(s.transactionCount))
+	mux.HandleFunc("GET /multi-sig/transaction", s.handle(s.transaction))
+	mux.HandleFunc("POST /multi-sig/deposit", s.handle(s.multisigDeposit))
+
+	mux.HandleFunc("POST /salaries/deploy", s.handle(s.payrollDeploy))
+	mux.HandleFunc("GET /salaries/usdt-price/{address}", s.handle(s.usdtPriceHandler))
+	mux.HandleFunc("GET /salaries/salary", s.handle(s.salary))
+	mux.HandleFunc("POST /salaries/deposit", s.handle(s.payrollDeposit))
+
+	mux.HandleFunc("POST /license/deploy", s.handle(s.licenseDeploy))
+	mux.HandleFunc("GET /license/total-payout", s.handle(s.licenseTotalPayout))
+	mux.HandleFunc("GET /license/shares", s.handle(s.licenseShares))
+	mux.HandleFunc("GET /license/owners", s.handle(s.licenseOwners))
+	mux.HandleFunc("GET /license/payout-contract", s.handle(s.licensePayoutContract))
+
+	mux.HandleFunc("POST /agreements/deploy", s.handle(s.agreementDeploy))
+	mux.HandleFunc("GET /agreements/{address}", s.handle(s.agreementResponse))
+
+	mux.HandleFunc("GET /address/{privateKey}", s.handle(s.addressFromPrivateKey))
+	mux.HandleFunc("POST /address-from-seed", s.handle(s.addressFromSeed))
+
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Client returns chain-api client sending requests to the fake
+func (s *Server) Client(opts ...chainapi.Option) *chainapi.Client {
+	return chainapi.NewClient(
+		s.URL,
+		append([]chainapi.Option{chainapi.WithHTTPClient(s.Server.Client())}, opts...)...,
+	)
+}
+
+// FailNext makes the next request to the path fail with given status code and error message.
+// Failures are queued, so a path can be failed several times in a row
+func (s *Server) FailNext(path string, statusCode int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures[path] = append(s.failures[path], failure{
+		statusCode: statusCode,
+		message:    message,
+	})
+}
+
+// Calls returns number of requests received by the path
+func (s *Server) Calls(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[path]
+}
+
+func (s *Server) SetUSDTPrice(price money.Decimal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.usdtPrice = price
+}
+
+// SetLicenseTotalPayout sets payout figure in USD, as if the oracle fulfilled license request
+func (s *Server) SetLicenseTotalPayout(address common.Address, total string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l, ok := s.licenses[address]; ok {
+		l.totalPayout = total
+	}
+}
+
+// SetAgreementResponse sets agreement outcome, as if the oracle fulfilled agreement request
+func (s *Server) SetAgreementResponse(address common.Address, response bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, ok := s.agreements[address]; ok {
+		a.response = response
+	}
+}
+
+// Transaction returns multisig transaction state
+func (s *Server) Transaction(multisigAddress common.Address, index int64) (*chainapi.MultisigTransaction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.tx(multisigAddress, index)
+	if err != nil {
+		return nil, false
+	}
+
+	return tx.view(), true
+}
+
+// Salary returns employee salary stored in the payroll contract
+func (s *Server) Salary(payrollAddress, employee common.Address) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.payrolls[payrollAddress]
+	if !ok {
+		return 0, false
+	}
+
+	salary, ok := p.salaries[employee]
+
+	return salary, ok
+}
+
+// AddressFromSeed returns address of the wallet chain-api derives from the X-Seed header
+func AddressFromSeed(seed []byte) (common.Address, error) {
+	wallet, err := hdwallet.NewFromSeed(seed)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error create wallet from seed. %w", err)
+	}
+
+	account, err := wallet.Derive(hdwallet.DefaultBaseDerivationPath, false)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error derive account. %w", err)
+	}
+
+	return account.Address, nil
+}
+
+// request is a parsed request passed to the handlers. Handlers are called with the server lock held
+type request struct {
+	r      *http.Request
+	signer common.Address
+}
+
+func (r *request) decode(v any) error {
+	if err := json.NewDecoder(r.r.Body).Decode(v); err != nil {
+		return &httpError{
+			statusCode: http.StatusBadRequest,
+			message:    "BadRequestException: Bad Request Exception",
+		}
+	}
+
+	return nil
+}
+
+type httpError struct {
+	statusCode int
+	message    string
+}
+
+func (e *httpError) Error() string {
+	return e.message
+}
+
+func revert(reason error) error {
+	return &httpError{
+		statusCode: http.StatusInternalServerError,
+		message:    fmt.Sprintf("execution reverted: %q", reason.Error()),
+	}
+}
+
+type handlerFunc func(r *request) (any, error)
+
+func (s *Server) handle(h handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.calls[r.URL.Path]++
+
+		if failures := s.failures[r.URL.Path]; len(failures) > 0 {
+			s.failures[r.URL.Path] = failures[1:]
+
+			writeError(w, failures[0].statusCode, failures[0].message)
+
+			return
+		}
+
+		req := &request{r: r}
+
+		if seed := r.Header.Get("X-Seed"); seed != "" {
+			signer, err := s.signer(seed)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err.Error())
+
+				return
+			}
+
+			req.signer = signer
+		}
+
+		resp, err := h(req)
+		if err != nil {
+			var httpErr *httpError
+
+			if errors.As(err, &httpErr) {
+				writeError(w, httpErr.statusCode, httpErr.message)
+			} else {
+				writeError(w, http.StatusInternalServerError, err.Error())
+			}
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if str, ok := resp.(string); ok {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(str))
+
+			return
+		}
+
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// writeError mimics chain-api exceptions filter, which responds with 500 to any error
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"statusCode": statusCode,
+		"error":      message,
+		"timestamp":  time.Now().UTC().Format(time.RFC3339Nano),
+	})
+}
+
+func (s *Server) signer(seedHex string) (common.Address, error) {
+	if signer, ok := s.signers[seedHex]; ok {
+		return signer, nil
+	}
+
+	seed, err := hex.DecodeString(seedHex)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error invalid seed. %w", err)
+	}
+
+	signer, err := AddressFromSeed(seed)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	s.signers[seedHex] = signer
+
+	return signer, nil
+}
+
+func (s *Server) newAddress(from common.Address) common.Address {
+	s.nonce++
+
+	return crypto.CreateAddress(from, s.nonce)
+}
+
+func (s *Server) newHash() string {
+	s.nonce++
+
+	return crypto.Keccak256Hash(big.NewInt(int64(s.nonce)).Bytes()).Hex()
+}
+
+func (s *Server) tx(multisigAddress common.Address, index int64) (*transaction, error) {
+	m, ok := s.multisigs[multisigAddress]
+	if !ok {
+		return nil, fmt.Errorf("error multisig %s not deployed", multisigAddress.Hex())
+	}
+
+	if index < 0 || index >= int64(len(m.txs)) {
+		return nil, revert(errTxDoesNotExist)
+	}
+
+	return m.txs[index], nil
+}
+
+func (t *transaction) view() *chainapi.MultisigTransaction {
+	return &chainapi.MultisigTransaction{
+		To:               t.to,
+		Value:            new(big.Int).Set(t.value),
+		Data:             common.CopyBytes(t.data),
+		Executed:         t.executed,
+		NumConfirmations: int64(len(t.confirmations)),
+	}
+}
+
+func (s *Server) submit(
+	signer common.Address,
+	multisigAddress common.Address,
+	tx *transaction,
+) (*chainapi.SubmitTransactionResponse, error) {
+	m, ok := s.multisigs[multisigAddress]
+	if !ok {
+		return nil, fmt.Errorf("error multisig %s not deployed", multisigAddress.Hex())
+	}
+
+	if !m.isOwner(signer) {
+		return nil, revert(errNotOwner)
+	}
+
+	if tx.value == nil {
+		tx.value = new(big.Int)
+	}
+
+	tx.confirmations = make(map[common.Address]bool)
+
+	m.txs = append(m.txs, tx)
+
+	return &chainapi.SubmitTransactionResponse{
+		TxHash:  s.newHash(),
+		Sender:  signer,
+		TxIndex: int64(len(m.txs) - 1),
+		To:      tx.to,
+		Value:   tx.value.String(),
+		Data:    tx.data,
+	}, nil
+}
+
+func (s *Server) multisigDeploy(r *request) (any, error) {
+	var req chainapi.MultisigDeployRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if len(req.Owners) == 0 {
+		return nil, revert(errors.New("owners required"))
+	}
+
+	if req.Confirmations <= 0 || req.Confirmations > len(req.Owners) {
+		return nil, revert(errors.New("invalid number of required confirmations"))
+	}
+
+	address := s.newAddress(r.signer)
+
+	s.multisigs[address] = &multisig{
+		owners:   req.Owners,
+		required: req.Confirmations,
+	}
+
+	return chainapi.DeployResponse{Address: address}, nil
+}
+
+func (s *Server) multisigOwners(r *request) (any, error) {
+	m, ok := s.multisigs[common.HexToAddress(r.r.PathValue("address"))]
+	if !ok {
+		return nil, fmt.Errorf("error multisig not deployed")
+	}
+
+	return m.owners, nil
+}
+
+func (s *Server) submitTransaction(r *request) (any, error) {
+	var req chainapi.SubmitTransactionRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	value, ok := new(big.Int).SetString(req.Value, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid BigNumberish string")
+	}
+
+	tx := &transaction{
+		to:    req.Destination,
+		value: value,
+		data:  req.Data,
+	}
+
+	if err := s.callEffect(req.ContractAddress, tx); err != nil {
+		return nil, err
+	}
+
+	return s.submit(r.signer, req.ContractAddress, tx)
+}
+
+// callEffect decodes payroll, license and agreement calls, so their execution changes the contracts state.
+// Other transactions, e.g. transfers, have no effect
+func (s *Server) callEffect(multisigAddress common.Address, tx *transaction) error {
+	method, args, err := chainapi.UnpackCall(tx.data)
+	if errors.Is(err, chainapi.ErrorUnknownCall) {
+		return nil
+	}
+
+	if err != nil {
+		return revert(err)
+	}
+
+	switch method {
+	case chainapi.MethodSetSalary:
+		p, err := s.authorizedPayroll(multisigAddress, tx.to)
+		if err != nil {
+			return err
+		}
+
+		employee, salary := args[0].(common.Address), args[1].(*big.Int)
+
+		tx.effect = func(common.Address) {
+			p.salaries[employee] = salary.Int64()
+		}
+	case chainapi.MethodPayoutInETH:
+		if _, err := s.authorizedPayroll(multisigAddress, tx.to); err != nil {
+			return err
+		}
+	case chainapi.MethodRequest:
+		url := args[0].(string)
+
+		if a, ok := s.agreements[tx.to]; ok {
+			if a.multisig != multisigAddress {
+				return revert(errors.New("Ownable: caller is not the owner"))
+			}
+
+			tx.effect = func(common.Address) {
+				a.url = url
+			}
+
+			return nil
+		}
+
+		l, err := s.ownedLicense(multisigAddress, tx.to)
+		if err != nil {
+			return err
+		}
+
+		tx.effect = func(common.Address) {
+			l.url = url
+		}
+	case chainapi.MethodPayout:
+		if _, err := s.ownedLicense(multisigAddress, tx.to); err != nil {
+			return err
+		}
+	case chainapi.MethodSetPayoutContract:
+		l, err := s.ownedLicense(multisigAddress, tx.to)
+		if err != nil {
+			return err
+		}
+
+		payoutContract := args[0].(common.Address)
+
+		tx.effect = func(common.Address) {
+			l.payoutContract = payoutContract
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) confirmTransaction(r *request) (any, error) {
+	var req chainapi.MultisigTxRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if !s.multisigs[req.ContractAddress].isOwnerOrNil(r.signer) {
+		return nil, revert(errNotOwner)
+	}
+
+	tx, err := s.tx(req.ContractAddress, req.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.executed {
+		// multisig contract uses the same revert reason for executed transactions
+		return nil, revert(errTxAlreadyConfirmed)
+	}
+
+	if tx.confirmations[r.signer] {
+		return nil, revert(errTxAlreadyConfirmed)
+	}
+
+	tx.confirmations[r.signer] = true
+
+	return chainapi.TransactionResponse{
+		TxHash:  s.newHash(),
+		Sender:  r.signer,
+		TxIndex: req.Index,
+	}, nil
+}
+
+func (s *Server) executeTransaction(r *request) (any, error) {
+	var req chainapi.ExecuteTransactionRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	m := s.multisigs[req.ContractAddress]
+
+	if !m.isOwnerOrNil(r.signer) {
+		return nil, revert(errNotOwner)
+	}
+
+	tx, err := s.tx(req.ContractAddress, req.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.executed {
+		return nil, revert(errTxAlreadyConfirmed)
+	}
+
+	if len(tx.confirmations) < m.required {
+		return nil, revert(errCannotExecute)
+	}
+
+	resp := chainapi.TransactionResponse{
+		TxHash:  s.newHash(),
+		Sender:  r.signer,
+		TxIndex: req.Index,
+	}
+
+	var deployed common.Address
+
+	if req.IsDeploy {
+		if !tx.deploy {
+			return nil, revert(errors.New("Failed to deploy contract"))
+		}
+
+		deployed = s.newAddress(req.ContractAddress)
+		resp.DeployedAddress = &deployed
+	}
+
+	if tx.effect != nil {
+		tx.effect(deployed)
+	}
+
+	tx.executed = true
+
+	return resp, nil
+}
+
+func (s *Server) revokeConfirmation(r *request) (any, error) {
+	var req chainapi.MultisigTxRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if !s.multisigs[req.ContractAddress].isOwnerOrNil(r.signer) {
+		return nil, revert(errNotOwner)
+	}
+
+	tx, err := s.tx(req.ContractAddress, req.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.executed {
+		return nil, revert(errTxAlreadyConfirmed)
+	}
+
+	if !tx.confirmations[r.signer] {
+		return nil, revert(errTxNotConfirmed)
+	}
+
+	delete(tx.confirmations, r.signer)
+
+	return chainapi.SentTransaction{
+		Hash: s.newHash(),
+		From: r.signer,
+		To:   req.ContractAddress,
+	}, nil
+}
+
+func (s *Server) transactionCount(r *request) (any, error) {
+	m, ok := s.multisigs[common.HexToAddress(r.r.PathValue("address"))]
+	if !ok {
+		return nil, fmt.Errorf("error multisig not deployed")
+	}
+
+	return strconv.Itoa(len(m.txs)), nil
+}
+
+func (s *Server) transaction(r *request) (any, error) {
+	var req chainapi.MultisigTxRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.tx(req.ContractAddress, req.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	return []any{
+		tx.to,
+		tx.value.String(),
+		hexutil.Bytes(tx.data),
+		tx.executed,
+		strconv.Itoa(len(tx.confirmations)),
+	}, nil
+}
+
+func (s *Server) multisigDeposit(r *request) (any, error) {
+	var req chainapi.DepositRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.multisigs[req.ContractAddress]; !ok {
+		return nil, fmt.Errorf("error multisig not deployed")
+	}
+
+	return chainapi.MultisigDepositResponse{
+		TxHash:          s.newHash(),
+		Sender:          r.signer,
+		Value:           money.FromWei(req.Value).Value,
+		ContractBalance: money.FromWei(req.Value).Value,
+	}, nil
+}
+
+func (m *multisig) isOwnerOrNil(address common.Address) bool {
+	// unknown multisig is reported by tx lookup
+	return m == nil || m.isOwner(address)
+}
+
+func (s *Server) payrollDeploy(r *request) (any, error) {
+	var req chainapi.PayrollDeployRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	address := s.newAddress(r.signer)
+
+	s.payrolls[address] = &payroll{
+		authorizedWallet: req.AuthorizedWallet,
+		salaries:         make(map[common.Address]int64),
+	}
+
+	return chainapi.DeployResponse{Address: address}, nil
+}
+
+func (s *Server) usdtPriceHandler(r *request) (any, error) {
+	if _, ok := s.payrolls[common.HexToAddress(r.r.PathValue("address"))]; !ok {
+		return nil, fmt.Errorf("error payroll not deployed")
+	}
+
+	return s.usdtPrice, nil
+}
+
+func (s *Server) authorizedPayroll(multisigAddress, payrollAddress common.Address) (*payroll, error) {
+	p, ok := s.payrolls[payrollAddress]
+	if !ok {
+		return nil, fmt.Errorf("error payroll not deployed")
+	}
+
+	if p.authorizedWallet != multisigAddress {
+		return nil, revert(errors.New("not authorized"))
+	}
+
+	return p, nil
+}
+
+func (s *Server) salary(r *request) (any, error) {
+	var req chainapi.SalaryRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	p, ok := s.payrolls[req.ContractAddress]
+	if !ok {
+		return nil, fmt.Errorf("error payroll not deployed")
+	}
+
+	return map[string]string{
+		"salaryInUsd": strconv.FormatInt(p.salaries[req.EmployeeAddress], 10),
+	}, nil
+}
+
+func (s *Server) payrollDeposit(r *request) (any, error) {
+	var req chainapi.DepositRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.payrolls[req.ContractAddress]; !ok {
+		return nil, fmt.Errorf("error payroll not deployed")
+	}
+
+	return chainapi.SentTransaction{
+		Hash: s.newHash(),
+		From: r.signer,
+		To:   req.ContractAddress,
+	}, nil
+}
+
+func (s *Server) licenseDeploy(r *request) (any, error) {
+	var req chainapi.LicenseDeployRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	if len(req.Owners) == 0 || len(req.Owners) != len(req.Shares) {
+		return nil, &httpError{
+			statusCode: http.StatusBadRequest,
+			message:    "BadRequestException: Bad Request Exception",
+		}
+	}
+
+	resp, err := s.submit(r.signer, req.MultisigWallet, &transaction{
+		deploy: true,
+		data:   []byte("StreamingRightsManagement"),
+		effect: func(deployed common.Address) {
+			l := &license{
+				multisig: req.MultisigWallet,
+				owners:   req.Owners,
+				shares:   make(map[common.Address]int, len(req.Owners)),
+			}
+
+			for i, owner := range req.Owners {
+				l.shares[owner] = req.Shares[i]
+			}
+
+			s.licenses[deployed] = l
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// chain-api omits deploy transaction data
+	resp.Data = nil
+
+	return resp, nil
+}
+
+func (s *Server) ownedLicense(multisigAddress, licenseAddress common.Address) (*license, error) {
+	l, ok := s.licenses[licenseAddress]
+	if !ok {
+		return nil, fmt.Errorf("error license not deployed")
+	}
+
+	if l.multisig != multisigAddress {
+		return nil, revert(errors.New("Ownable: caller is not the owner"))
+	}
+
+	return l, nil
+}
+
+// license decodes license read request. Shares request is a superset of the other license read requests
+func (s *Server) license(r *request) (*license, common.Address, error) {
+	var req chainapi.LicenseSharesRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, common.Address{}, err
+	}
+
+	l, ok := s.licenses[req.ContractAddress]
+	if !ok {
+		return nil, common.Address{}, fmt.Errorf("error license not deployed")
+	}
+
+	return l, req.OwnerAddress, nil
+}
+
+func (s *Server) licenseTotalPayout(r *request) (any, error) {
+	l, _, err := s.license(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.totalPayout == "" {
+		return "0", nil
+	}
+
+	return l.totalPayout, nil
+}
+
+func (s *Server) licenseShares(r *request) (any, error) {
+	l, owner, err := s.license(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return strconv.Itoa(l.shares[owner]), nil
+}
+
+func (s *Server) licenseOwners(r *request) (any, error) {
+	l, _, err := s.license(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.owners, nil
+}
+
+func (s *Server) licensePayoutContract(r *request) (any, error) {
+	l, _, err := s.license(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.payoutContract.Hex(), nil
+}
+
+func (s *Server) agreementDeploy(r *request) (any, error) {
+	var req chainapi.AgreementDeployRequest
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.submit(r.signer, req.MultisigWallet, &transaction{
+		deploy: true,
+		data:   []byte("Agreement"),
+		effect: func(deployed common.Address) {
+			s.agreements[deployed] = &agreement{
+				multisig: req.MultisigWallet,
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Data = nil
+
+	return resp, nil
+}
+
+func (s *Server) agreementResponse(r *request) (any, error) {
+	a, ok := s.agreements[common.HexToAddress(r.r.PathValue("address"))]
+	if !ok {
+		return nil, fmt.Errorf("error agreement not deployed")
+	}
+
+	return strconv.FormatBool(a.response), nil
+}
+
+func (s *Server) addressFromPrivateKey(r *request) (any, error) {
+	key, err := crypto.HexToECDSA(common.Bytes2Hex(common.FromHex(r.r.PathValue("privateKey"))))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key")
+	}
+
+	return crypto.PubkeyToAddress(key.PublicKey).Hex(), nil
+}
+
+func (s *Server) addressFromSeed(r *request) (any, error) {
+	var req struct {
+		SeedPhrase string `json:"seedPhrase"`
+	}
+
+	if err := r.decode(&req); err != nil {
+		return nil, err
+	}
+
+	seed, err := hdwallet.NewSeedFromMnemonic(req.SeedPhrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic")
+	}
+
+	address, err := AddressFromSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return address.Hex(), nil
+}
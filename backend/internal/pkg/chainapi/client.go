@@ -0,0 +1,149 @@
+// Package chainapi is a typed client of the chain-api service. chain-api sends transactions and reads
+// contracts state on behalf of the wallet derived from the X-Seed header
+package chainapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// DefaultTimeout is applied to requests whose context has no deadline
+	DefaultTimeout = 5 * time.Minute
+)
+
+type Client struct {
+	host       string
+	httpClient *http.Client
+	log        *slog.Logger
+	timeout    time.Duration
+}
+
+type Option func(c *Client)
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func WithLogger(log *slog.Logger) Option {
+	return func(c *Client) {
+		c.log = log
+	}
+}
+
+// WithTimeout overrides DefaultTimeout. Contract deploys wait for the block to be mined,
+// so timeout must be long enough for them
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+func NewClient(host string, opts ...Option) *Client {
+	c := &Client{
+		host:       strings.TrimRight(host, "/"),
+		httpClient: http.DefaultClient,
+		log:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		timeout:    DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// do sends request to the chain-api. Seed is sent in the X-Seed header if set.
+// If out is *string, raw response body is written to it, since some chain-api endpoints respond with plain text
+func (c *Client) do(
+	ctx context.Context,
+	method string,
+	path string,
+	seed []byte,
+	body any,
+	out any,
+) error {
+	if _, ok := ctx.Deadline(); !ok && c.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var requestBody io.Reader
+
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error marshal request body. %w", err)
+		}
+
+		requestBody = bytes.NewReader(raw)
+	}
+
+	endpoint := c.host + path
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, requestBody)
+	if err != nil {
+		return fmt.Errorf("error build request. %w", err)
+	}
+
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	if len(seed) > 0 {
+		req.Header.Add("X-Seed", common.Bytes2Hex(seed))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error send request to %s. %w", path, err)
+	}
+
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error read response body. %w", err)
+	}
+
+	c.log.Debug(
+		"chain-api response",
+		slog.String("endpoint", endpoint),
+		slog.Int("code", resp.StatusCode),
+		slog.String("body", string(raw)),
+	)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(path, resp.StatusCode, raw)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if str, ok := out.(*string); ok {
+		*str = strings.Trim(strings.TrimSpace(string(raw)), "\"")
+
+		return nil
+	}
+
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("error parse %s response body. %w", path, err)
+	}
+
+	return nil
+}
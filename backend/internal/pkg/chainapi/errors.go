@@ -0,0 +1,101 @@
+package chainapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrorRequest is wrapped by every Error returned by the chain-api
+	ErrorRequest = errors.New("chain-api request failed")
+	// ErrorTxAlreadyConfirmed multisig transaction is already confirmed by the signer or already executed
+	ErrorTxAlreadyConfirmed = errors.New("tx already confirmed")
+	ErrorTxNotConfirmed     = errors.New("tx not confirmed")
+	ErrorTxDoesNotExist     = errors.New("tx does not exist")
+	ErrorNotOwner           = errors.New("not owner")
+)
+
+// revertReasons maps multisig contract revert reasons to errors
+var revertReasons = []error{
+	ErrorTxAlreadyConfirmed,
+	ErrorTxNotConfirmed,
+	ErrorTxDoesNotExist,
+	ErrorNotOwner,
+}
+
+// Error is an error response of the chain-api
+type Error struct {
+	Path string
+	// StatusCode is a status code reported by the chain-api in the response body.
+	// chain-api exceptions filter responds with 500 to any error, so response status is used only as a fallback
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("chain-api %s responded with %d: %s", e.Path, e.StatusCode, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return ErrorRequest
+}
+
+// Is matches contract revert reasons, so errors.Is(err, ErrorTxAlreadyConfirmed) works on reverted transactions
+func (e *Error) Is(target error) bool {
+	for _, reason := range revertReasons {
+		if target == reason {
+			return strings.Contains(e.Message, reason.Error())
+		}
+	}
+
+	return false
+}
+
+// Temporary reports whether the request may succeed if retried
+func (e *Error) Temporary() bool {
+	return e.StatusCode >= http.StatusInternalServerError
+}
+
+type errorResponse struct {
+	StatusCode int `json:"statusCode"`
+	// Error is set by the chain-api exceptions filter
+	Error string `json:"error"`
+	// Message is set by the nest default exceptions handler. Validation errors come as array
+	Message json.RawMessage `json:"message"`
+}
+
+func decodeError(path string, statusCode int, raw []byte) error {
+	apiErr := &Error{
+		Path:       path,
+		StatusCode: statusCode,
+		Message:    strings.TrimSpace(string(raw)),
+	}
+
+	var resp errorResponse
+
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return apiErr
+	}
+
+	if resp.StatusCode != 0 {
+		apiErr.StatusCode = resp.StatusCode
+	}
+
+	switch {
+	case resp.Error != "":
+		apiErr.Message = resp.Error
+	case len(resp.Message) > 0:
+		var messages []string
+
+		if err := json.Unmarshal(resp.Message, &messages); err == nil {
+			apiErr.Message = strings.Join(messages, "; ")
+		} else {
+			apiErr.Message = strings.Trim(string(resp.Message), "\"")
+		}
+	}
+
+	return apiErr
+}
@@ -0,0 +1,41 @@
+package chainapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AddressFromSeed returns address of the wallet derived from the mnemonic
+func (c *Client) AddressFromSeed(ctx context.Context, mnemonic string) (common.Address, error) {
+	var address string
+
+	if err := c.do(
+		ctx, http.MethodPost, "/address-from-seed", nil, addressFromSeedRequest{SeedPhrase: mnemonic}, &address,
+	); err != nil {
+		return common.Address{}, err
+	}
+
+	return parseAddress(address)
+}
+
+// AddressFromPrivateKey returns address of the wallet with given hex encoded private key
+func (c *Client) AddressFromPrivateKey(ctx context.Context, privateKey string) (common.Address, error) {
+	var address string
+
+	if err := c.do(ctx, http.MethodGet, "/address/"+privateKey, nil, nil, &address); err != nil {
+		return common.Address{}, err
+	}
+
+	return parseAddress(address)
+}
+
+func parseAddress(address string) (common.Address, error) {
+	if !common.IsHexAddress(address) {
+		return common.Address{}, fmt.Errorf("error invalid address %s", address)
+	}
+
+	return common.HexToAddress(address), nil
+}
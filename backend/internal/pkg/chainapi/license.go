@@ -0,0 +1,99 @@
+package chainapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LicenseDeploy submits license contract deploy to the multisig
+func (c *Client) LicenseDeploy(
+	ctx context.Context,
+	seed []byte,
+	req LicenseDeployRequest,
+) (*SubmitTransactionResponse, error) {
+	resp := new(SubmitTransactionResponse)
+
+	if err := c.do(ctx, http.MethodPost, "/license/deploy", seed, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// LicenseTotalPayout reads payout figure in USD received from the oracle
+func (c *Client) LicenseTotalPayout(
+	ctx context.Context,
+	seed []byte,
+	license common.Address,
+) (string, error) {
+	var total string
+
+	if err := c.do(
+		ctx, http.MethodGet, "/license/total-payout", seed, ContractRequest{ContractAddress: license}, &total,
+	); err != nil {
+		return "", err
+	}
+
+	return total, nil
+}
+
+// LicenseShares reads owner share in percents
+func (c *Client) LicenseShares(
+	ctx context.Context,
+	seed []byte,
+	req LicenseSharesRequest,
+) (int, error) {
+	var share string
+
+	if err := c.do(ctx, http.MethodGet, "/license/shares", seed, req, &share); err != nil {
+		return 0, err
+	}
+
+	shareInt, err := strconv.Atoi(share)
+	if err != nil {
+		return 0, fmt.Errorf("error parse license owner share %s. %w", share, err)
+	}
+
+	return shareInt, nil
+}
+
+func (c *Client) LicenseOwners(
+	ctx context.Context,
+	seed []byte,
+	license common.Address,
+) ([]common.Address, error) {
+	var owners []common.Address
+
+	if err := c.do(
+		ctx, http.MethodGet, "/license/owners", seed, ContractRequest{ContractAddress: license}, &owners,
+	); err != nil {
+		return nil, err
+	}
+
+	return owners, nil
+}
+
+// LicensePayoutContract reads payroll contract license payouts are sent to. Returns zero address if it is not set
+func (c *Client) LicensePayoutContract(
+	ctx context.Context,
+	seed []byte,
+	license common.Address,
+) (common.Address, error) {
+	var payoutContract string
+
+	if err := c.do(
+		ctx, http.MethodGet, "/license/payout-contract", seed, ContractRequest{ContractAddress: license}, &payoutContract,
+	); err != nil {
+		return common.Address{}, err
+	}
+
+	if !common.IsHexAddress(payoutContract) {
+		return common.Address{}, nil
+	}
+
+	return common.HexToAddress(payoutContract), nil
+}
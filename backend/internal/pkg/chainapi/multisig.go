@@ -0,0 +1,157 @@
+package chainapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MultisigDeploy deploys new multisig wallet from the signer wallet
+func (c *Client) MultisigDeploy(
+	ctx context.Context,
+	seed []byte,
+	req MultisigDeployRequest,
+) (*DeployResponse, error) {
+	resp := new(DeployResponse)
+
+	if err := c.do(ctx, http.MethodPost, "/multi-sig/deploy", seed, req, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Address == (common.Address{}) {
+		return nil, fmt.Errorf("error multisig address is empty")
+	}
+
+	return resp, nil
+}
+
+func (c *Client) MultisigOwners(
+	ctx context.Context,
+	seed []byte,
+	multisig common.Address,
+) ([]common.Address, error) {
+	var owners []common.Address
+
+	if err := c.do(ctx, http.MethodGet, "/multi-sig/owners/"+multisig.Hex(), seed, nil, &owners); err != nil {
+		return nil, err
+	}
+
+	return owners, nil
+}
+
+// SubmitTransaction submits new transaction to the multisig. Submitted transaction is not confirmed by the signer
+func (c *Client) SubmitTransaction(
+	ctx context.Context,
+	seed []byte,
+	req SubmitTransactionRequest,
+) (*SubmitTransactionResponse, error) {
+	if req.Value == "" {
+		req.Value = "0"
+	}
+
+	resp := new(SubmitTransactionResponse)
+
+	if err := c.do(ctx, http.MethodPost, "/multi-sig/submit-transaction", seed, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ConfirmTransaction confirms multisig transaction on behalf of the signer.
+// Returns ErrorTxAlreadyConfirmed if the signer has already confirmed it or transaction is executed
+func (c *Client) ConfirmTransaction(
+	ctx context.Context,
+	seed []byte,
+	req MultisigTxRequest,
+) (*TransactionResponse, error) {
+	resp := new(TransactionResponse)
+
+	if err := c.do(ctx, http.MethodPost, "/multi-sig/confirm-transaction", seed, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ExecuteTransaction executes confirmed multisig transaction.
+// DeployedAddress of the response is set if req.IsDeploy is set
+func (c *Client) ExecuteTransaction(
+	ctx context.Context,
+	seed []byte,
+	req ExecuteTransactionRequest,
+) (*TransactionResponse, error) {
+	resp := new(TransactionResponse)
+
+	if err := c.do(ctx, http.MethodPost, "/multi-sig/execute-transaction", seed, req, resp); err != nil {
+		return nil, err
+	}
+
+	if req.IsDeploy && (resp.DeployedAddress == nil || *resp.DeployedAddress == (common.Address{})) {
+		return nil, fmt.Errorf("error deployed contract address is empty")
+	}
+
+	return resp, nil
+}
+
+// RevokeConfirmation revokes signer confirmation of the not executed multisig transaction
+func (c *Client) RevokeConfirmation(
+	ctx context.Context,
+	seed []byte,
+	req MultisigTxRequest,
+) (*SentTransaction, error) {
+	resp := new(SentTransaction)
+
+	if err := c.do(ctx, http.MethodPost, "/multi-sig/revoke-confirmation", seed, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Client) TransactionCount(
+	ctx context.Context,
+	seed []byte,
+	multisig common.Address,
+) (int64, error) {
+	var count BigInt
+
+	if err := c.do(
+		ctx, http.MethodGet, "/multi-sig/transaction-count/"+multisig.Hex(), seed, nil, &count,
+	); err != nil {
+		return 0, err
+	}
+
+	return count.Int64(), nil
+}
+
+func (c *Client) Transaction(
+	ctx context.Context,
+	seed []byte,
+	req MultisigTxRequest,
+) (*MultisigTransaction, error) {
+	resp := new(MultisigTransaction)
+
+	if err := c.do(ctx, http.MethodGet, "/multi-sig/transaction", seed, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// MultisigDeposit sends ETH from the signer wallet to the multisig
+func (c *Client) MultisigDeposit(
+	ctx context.Context,
+	seed []byte,
+	req DepositRequest,
+) (*MultisigDepositResponse, error) {
+	resp := new(MultisigDepositResponse)
+
+	if err := c.do(ctx, http.MethodPost, "/multi-sig/deposit", seed, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
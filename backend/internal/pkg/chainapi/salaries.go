@@ -0,0 +1,74 @@
+package chainapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PayrollDeploy deploys new payroll contract from the signer wallet
+func (c *Client) PayrollDeploy(
+	ctx context.Context,
+	seed []byte,
+	req PayrollDeployRequest,
+) (*DeployResponse, error) {
+	resp := new(DeployResponse)
+
+	if err := c.do(ctx, http.MethodPost, "/salaries/deploy", seed, req, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Address == (common.Address{}) {
+		return nil, fmt.Errorf("error payroll address is empty")
+	}
+
+	return resp, nil
+}
+
+// USDTPrice reads the latest ETH price in USD from the payroll contract price feed
+func (c *Client) USDTPrice(
+	ctx context.Context,
+	seed []byte,
+	payroll common.Address,
+) (money.Decimal, error) {
+	var price money.Decimal
+
+	if err := c.do(ctx, http.MethodGet, "/salaries/usdt-price/"+payroll.Hex(), seed, nil, &price); err != nil {
+		return money.Decimal{}, err
+	}
+
+	return price, nil
+}
+
+// Salary reads employee salary in USD stored in the payroll contract
+func (c *Client) Salary(
+	ctx context.Context,
+	seed []byte,
+	req SalaryRequest,
+) (*SalaryResponse, error) {
+	resp := new(SalaryResponse)
+
+	if err := c.do(ctx, http.MethodGet, "/salaries/salary", seed, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// PayrollDeposit sends ETH from the signer wallet to the payroll contract
+func (c *Client) PayrollDeposit(
+	ctx context.Context,
+	seed []byte,
+	req DepositRequest,
+) (*SentTransaction, error) {
+	resp := new(SentTransaction)
+
+	if err := c.do(ctx, http.MethodPost, "/salaries/deposit", seed, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
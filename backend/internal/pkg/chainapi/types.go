@@ -0,0 +1,215 @@
+package chainapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BigInt decodes uint256 values chain-api sends either as JSON numbers or as decimal strings
+type BigInt struct {
+	big.Int
+}
+
+func (b *BigInt) UnmarshalJSON(raw []byte) error {
+	str := strings.Trim(string(raw), "\"")
+
+	if _, ok := b.SetString(str, 10); !ok {
+		return fmt.Errorf("error parse big int %s", str)
+	}
+
+	return nil
+}
+
+func (b *BigInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// DeployResponse is a response of the endpoints deploying contract from the signer wallet
+type DeployResponse struct {
+	Address common.Address `json:"address"`
+}
+
+// SubmitTransactionResponse is a response of the endpoints submitting transaction to the multisig.
+// Submitted transaction is not confirmed by the sender
+type SubmitTransactionResponse struct {
+	TxHash  string         `json:"txHash"`
+	Sender  common.Address `json:"sender"`
+	TxIndex int64          `json:"txIndex,string"`
+	To      common.Address `json:"to"`
+	// Value in wei
+	Value string `json:"value"`
+	// Data is omitted by the contract deploy endpoints
+	Data hexutil.Bytes `json:"data,omitempty"`
+}
+
+// TransactionResponse is a response of the endpoints confirming and executing multisig transaction
+type TransactionResponse struct {
+	TxHash  string         `json:"txHash"`
+	Sender  common.Address `json:"sender"`
+	TxIndex int64          `json:"txIndex,string"`
+	// DeployedAddress is set by the deploy transactions execution
+	DeployedAddress *common.Address `json:"deployedAddress,omitempty"`
+}
+
+// SentTransaction is a transaction sent directly from the signer wallet
+type SentTransaction struct {
+	Hash string         `json:"hash"`
+	From common.Address `json:"from"`
+	To   common.Address `json:"to"`
+}
+
+// MultisigTransaction is a transaction stored in the multisig
+type MultisigTransaction struct {
+	To               common.Address
+	Value            *big.Int
+	Data             []byte
+	Executed         bool
+	NumConfirmations int64
+}
+
+// UnmarshalJSON decodes multisig getTransaction result. Contract call results are serialized as arrays
+func (t *MultisigTransaction) UnmarshalJSON(raw []byte) error {
+	var (
+		value         BigInt
+		data          hexutil.Bytes
+		confirmations BigInt
+	)
+
+	fields := []any{&t.To, &value, &data, &t.Executed, &confirmations}
+
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return fmt.Errorf("error unmarshal multisig transaction. %w", err)
+	}
+
+	t.Value = &value.Int
+	t.Data = data
+	t.NumConfirmations = confirmations.Int64()
+
+	return nil
+}
+
+type MultisigDeployRequest struct {
+	Owners        []common.Address `json:"owners"`
+	Confirmations int              `json:"confirmations"`
+}
+
+type SubmitTransactionRequest struct {
+	ContractAddress common.Address `json:"contractAddress"`
+	Destination     common.Address `json:"destination"`
+	// Value in wei
+	Value string        `json:"value"`
+	Data  hexutil.Bytes `json:"data"`
+}
+
+type MultisigTxRequest struct {
+	ContractAddress common.Address `json:"contractAddress"`
+	Index           int64          `json:"index"`
+}
+
+type ExecuteTransactionRequest struct {
+	ContractAddress common.Address `json:"contractAddress"`
+	Index           int64          `json:"index"`
+	// IsDeploy must be set for the transactions deploying contracts
+	IsDeploy bool `json:"isDeploy"`
+}
+
+type DepositRequest struct {
+	ContractAddress common.Address
+	// Value in wei
+	Value *big.Int
+}
+
+// depositRequest is DepositRequest as chain-api accepts it, value is parsed with parseEther
+type depositRequest struct {
+	ContractAddress common.Address `json:"contractAddress"`
+	// Value in ETH
+	Value string `json:"value"`
+}
+
+func (r DepositRequest) MarshalJSON() ([]byte, error) {
+	value := r.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	return json.Marshal(depositRequest{
+		ContractAddress: r.ContractAddress,
+		Value:           money.FromWei(value).Value.String(),
+	})
+}
+
+func (r *DepositRequest) UnmarshalJSON(raw []byte) error {
+	var req depositRequest
+
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return fmt.Errorf("error unmarshal deposit request. %w", err)
+	}
+
+	amount, err := money.Parse(req.Value, money.ETH)
+	if err != nil {
+		return fmt.Errorf("error parse deposit value. %w", err)
+	}
+
+	wei, err := amount.Wei()
+	if err != nil {
+		return fmt.Errorf("error convert deposit value to wei. %w", err)
+	}
+
+	r.ContractAddress = req.ContractAddress
+	r.Value = wei
+
+	return nil
+}
+
+type MultisigDepositResponse struct {
+	TxHash string         `json:"txHash"`
+	Sender common.Address `json:"sender"`
+	// Value in ETH
+	Value money.Decimal `json:"value"`
+	// ContractBalance in ETH
+	ContractBalance money.Decimal `json:"contractBalance"`
+}
+
+type PayrollDeployRequest struct {
+	// AuthorizedWallet is a multisig allowed to change salaries and send payouts
+	AuthorizedWallet common.Address `json:"authorizedWallet"`
+}
+
+type SalaryRequest struct {
+	ContractAddress common.Address `json:"contractAddress"`
+	EmployeeAddress common.Address `json:"employeeAddress"`
+}
+
+type SalaryResponse struct {
+	SalaryInUSD BigInt `json:"salaryInUsd"`
+}
+
+type LicenseDeployRequest struct {
+	MultisigWallet common.Address   `json:"multiSigWallet"`
+	Owners         []common.Address `json:"owners"`
+	// Shares in percents, must sum up to 100
+	Shares []int `json:"shares"`
+}
+
+type ContractRequest struct {
+	ContractAddress common.Address `json:"contractAddress"`
+}
+
+type LicenseSharesRequest struct {
+	ContractAddress common.Address `json:"contractAddress"`
+	OwnerAddress    common.Address `json:"ownerAddress"`
+}
+
+type AgreementDeployRequest struct {
+	MultisigWallet common.Address `json:"multiSigWallet"`
+}
+
+type addressFromSeedRequest struct {
+	SeedPhrase string `json:"seedPhrase"`
+}
@@ -1,10 +1,15 @@
 package config
 
+import "time"
+
 type Config struct {
 	Common   CommonConfig
 	Rest     RestConfig
 	DB       DBConfig
 	ChainAPI ChainAPIConfig
+	Jobs     JobsConfig
+	Secrets  SecretsConfig
+	Prices   PricesConfig
 }
 
 type CommonConfig struct {
@@ -13,7 +18,12 @@ type CommonConfig struct {
 	LogFile      string
 	LogAddSource bool
 
+	// JWTSecret is the legacy HS256 secret. Used for signing if JWTSigningKey is not set
 	JWTSecret []byte
+	// JWTSigningKey is a path to Ed25519 or ECDSA P-256 private key in PEM
+	JWTSigningKey string
+	// JWTVerificationKeys are paths to retired keys still accepted for tokens verification
+	JWTVerificationKeys []string
 }
 
 type RestConfig struct {
@@ -37,4 +47,41 @@ type DBConfig struct {
 
 type ChainAPIConfig struct {
 	Host string
+	// ChainID is the network multisigs and registered assets are deployed to, wallets sign in messages are issued for it
+	ChainID int64
+	// Timeout is applied to chain-api requests without deadline
+	Timeout time.Duration
+	// Signer is "remote" to sign multisig transactions by the chain-api or "local" to sign them in process
+	Signer string
+	// RPCURL is the ethereum node local signer sends transactions to
+	RPCURL string
+}
+
+type JobsConfig struct {
+	Workers       int
+	PollInterval  time.Duration
+	LeaseDuration time.Duration
+	MaxAttempts   int
+	BackoffBase   time.Duration
+	BackoffMax    time.Duration
+}
+
+type PricesConfig struct {
+	// Source is "chain-api" to read ETH price from the payroll contract price feed
+	// or "fixture" to serve rates from the Fixture file
+	Source string
+	// FeedAddress is the payroll contract whose price feed is read by the chain-api source
+	FeedAddress string
+	// Fixture is a path to JSON file with rates keyed by the currency pair, e.g. {"ETH/USD": "3000"}
+	Fixture string
+}
+
+// SecretsConfig holds master keys encrypting mnemonics and seeds at rest.
+// Keys are base64 encoded 32 bytes, passed as value or as file path
+type SecretsConfig struct {
+	MasterKey     string
+	MasterKeyFile string
+	// PreviousMasterKeys are rotated out keys still used for decryption until secrets are rewrapped
+	PreviousMasterKeys     []string
+	PreviousMasterKeyFiles []string
 }
@@ -14,8 +14,16 @@ var (
 	UserContextKey                    = ContextKey("user")
 	OrganizationIdContextKey          = ContextKey("org-id")
 	OrganizationParticipantContextKey = ContextKey("org-participant")
+	AccessTokenContextKey             = ContextKey("access-token")
+	ClientContextKey                  = ContextKey("client")
 )
 
+// ClientInfo describes the request origin, saved with issued sessions
+type ClientInfo struct {
+	RemoteAddr string
+	UserAgent  string
+}
+
 func UserContext(parent context.Context, user *models.User) context.Context {
 	return context.WithValue(parent, UserContextKey, user)
 }
@@ -46,3 +54,26 @@ func OrganizationId(ctx context.Context) (uuid.UUID, error) {
 
 	return uuid.Nil, fmt.Errorf("error organization id not passed in context")
 }
+
+func AccessTokenContext(parent context.Context, token string) context.Context {
+	return context.WithValue(parent, AccessTokenContextKey, token)
+}
+
+func AccessToken(ctx context.Context) (string, error) {
+	if token, ok := ctx.Value(AccessTokenContextKey).(string); ok {
+		return token, nil
+	}
+
+	return "", fmt.Errorf("error access token not passed in context")
+}
+
+func ClientContext(parent context.Context, client ClientInfo) context.Context {
+	return context.WithValue(parent, ClientContextKey, client)
+}
+
+// Client returns empty ClientInfo if it is not passed in context
+func Client(ctx context.Context) ClientInfo {
+	client, _ := ctx.Value(ClientContextKey).(ClientInfo)
+
+	return client
+}
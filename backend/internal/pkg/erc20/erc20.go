@@ -0,0 +1,42 @@
+// Package erc20 encodes calls of ERC-20 token contracts. Calls are sent to the token contract
+// through the multisig submit transaction with zero value
+package erc20
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// tokenABI is the part of ERC-20 ABI used to send tokens
+const tokenABI = `[
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[
+		{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"outputs":[
+		{"name":"","type":"bool"}]}
+]`
+
+var token = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(tokenABI))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}()
+
+// TransferData returns calldata of transfer(address,uint256) sending amount of token base units to the address
+func TransferData(to common.Address, amount *big.Int) ([]byte, error) {
+	if amount == nil || amount.Sign() < 0 {
+		return nil, fmt.Errorf("error invalid transfer amount %s", amount)
+	}
+
+	data, err := token.Pack("transfer", to, amount)
+	if err != nil {
+		return nil, fmt.Errorf("error pack transfer call. %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,225 @@
+package jwks
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrorUnsupportedKey = errors.New("unsupported key")
+	ErrorInvalidPEM     = errors.New("invalid pem")
+	ErrorUnknownKey     = errors.New("unknown key")
+)
+
+// Key is an asymmetric JWT key. Private is nil for verification only keys
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+// LoadKey reads Ed25519 or ECDSA P-256 key from PEM file. Private keys are PKCS8 or SEC1 encoded,
+// public keys are PKIX encoded. Key id is the RFC 7638 thumbprint of the public key
+func LoadKey(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error read key file. %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("error decode %s. %w", path, ErrorInvalidPEM)
+	}
+
+	var raw any
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		raw, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		raw, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		raw, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("error unexpected pem block %s. %w", block.Type, ErrorInvalidPEM)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parse key. %w", err)
+	}
+
+	return NewKey(raw)
+}
+
+// NewKey builds key from ed25519 or ecdsa P-256 private or public key
+func NewKey(raw any) (*Key, error) {
+	key := new(Key)
+
+	switch k := raw.(type) {
+	case ed25519.PrivateKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.Private = k
+		key.Public = k.Public()
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.Public = k
+	case *ecdsa.PrivateKey:
+		key.Method = jwt.SigningMethodES256
+		key.Private = k
+		key.Public = &k.PublicKey
+	case *ecdsa.PublicKey:
+		key.Method = jwt.SigningMethodES256
+		key.Public = k
+	default:
+		return nil, fmt.Errorf("error unexpected key type %T. %w", raw, ErrorUnsupportedKey)
+	}
+
+	if pk, ok := key.Public.(*ecdsa.PublicKey); ok && pk.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("error only P-256 curve is supported. %w", ErrorUnsupportedKey)
+	}
+
+	id, err := key.thumbprint()
+	if err != nil {
+		return nil, err
+	}
+
+	key.ID = id
+
+	return key, nil
+}
+
+// CanSign reports whether key holds a private part
+func (k *Key) CanSign() bool {
+	return k.Private != nil
+}
+
+// JWK is a public JSON Web Key, RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+}
+
+// JWK returns public part of the key
+func (k *Key) JWK() JWK {
+	jwk := JWK{
+		Kid: k.ID,
+		Alg: k.Method.Alg(),
+		Use: "sig",
+	}
+
+	switch pk := k.Public.(type) {
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pk)
+	case *ecdsa.PublicKey:
+		jwk.Kty = "EC"
+		jwk.Crv = "P-256"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pk.X.FillBytes(make([]byte, 32)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(pk.Y.FillBytes(make([]byte, 32)))
+	}
+
+	return jwk
+}
+
+// thumbprint returns RFC 7638 JWK thumbprint
+func (k *Key) thumbprint() (string, error) {
+	jwk := k.JWK()
+
+	var members string
+
+	switch jwk.Kty {
+	case "OKP":
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, jwk.Crv, jwk.Kty, jwk.X)
+	case "EC":
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q,"y":%q}`, jwk.Crv, jwk.Kty, jwk.X, jwk.Y)
+	default:
+		return "", ErrorUnsupportedKey
+	}
+
+	sum := sha256.Sum256([]byte(members))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// Set is a JSON Web Key Set
+type Set struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeyRing holds the signing key and keys accepted for tokens verification.
+// Signing key is always accepted. Keys retired from signing stay in the ring until issued tokens expire
+type KeyRing struct {
+	signing *Key
+	keys    map[string]*Key
+	order   []string
+}
+
+func NewKeyRing(signing *Key, verification ...*Key) (*KeyRing, error) {
+	if signing != nil && !signing.CanSign() {
+		return nil, fmt.Errorf("error signing key %s has no private part. %w", signing.ID, ErrorUnsupportedKey)
+	}
+
+	ring := &KeyRing{
+		signing: signing,
+		keys:    make(map[string]*Key, len(verification)+1),
+	}
+
+	for _, k := range append([]*Key{signing}, verification...) {
+		if k == nil {
+			continue
+		}
+
+		if _, ok := ring.keys[k.ID]; ok {
+			continue
+		}
+
+		ring.keys[k.ID] = k
+		ring.order = append(ring.order, k.ID)
+	}
+
+	return ring, nil
+}
+
+// Signing returns key used to sign new tokens, nil if not configured
+func (r *KeyRing) Signing() *Key {
+	return r.signing
+}
+
+// Key returns verification key by its id
+func (r *KeyRing) Key(id string) (*Key, error) {
+	if k, ok := r.keys[id]; ok {
+		return k, nil
+	}
+
+	return nil, fmt.Errorf("error key %s not found. %w", id, ErrorUnknownKey)
+}
+
+// Set returns public keys of the ring
+func (r *KeyRing) Set() Set {
+	set := Set{
+		Keys: make([]JWK, len(r.order)),
+	}
+
+	for i, id := range r.order {
+		set.Keys[i] = r.keys[id].JWK()
+	}
+
+	return set
+}
@@ -0,0 +1,241 @@
+package jwks
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newEd25519Key(t *testing.T) *Key {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key error: %v", err)
+	}
+
+	key, err := NewKey(private)
+	if err != nil {
+		t.Fatalf("NewKey() error: %v", err)
+	}
+
+	return key
+}
+
+func newECDSAKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	t.Helper()
+
+	private, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key error: %v", err)
+	}
+
+	return private
+}
+
+func writePEM(t *testing.T, typ string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write key file error: %v", err)
+	}
+
+	return path
+}
+
+func TestThumbprint(t *testing.T) {
+	// RFC 8037 appendix A.3
+	x, err := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	if err != nil {
+		t.Fatalf("decode public key error: %v", err)
+	}
+
+	key, err := NewKey(ed25519.PublicKey(x))
+	if err != nil {
+		t.Fatalf("NewKey() error: %v", err)
+	}
+
+	if want := "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"; key.ID != want {
+		t.Fatalf("key id = %s, want %s", key.ID, want)
+	}
+
+	if key.CanSign() {
+		t.Fatalf("CanSign() of public key = true")
+	}
+}
+
+func TestNewKey(t *testing.T) {
+	ec := newECDSAKey(t, elliptic.P256())
+
+	tests := []struct {
+		name    string
+		raw     any
+		method  jwt.SigningMethod
+		kty     string
+		canSign bool
+		wantErr error
+	}{
+		{name: "ed25519 private", raw: newEd25519Key(t).Private, method: jwt.SigningMethodEdDSA, kty: "OKP", canSign: true},
+		{name: "ecdsa private", raw: ec, method: jwt.SigningMethodES256, kty: "EC", canSign: true},
+		{name: "ecdsa public", raw: &ec.PublicKey, method: jwt.SigningMethodES256, kty: "EC"},
+		{name: "ecdsa P-384", raw: newECDSAKey(t, elliptic.P384()), wantErr: ErrorUnsupportedKey},
+		{name: "hmac secret", raw: []byte("secret"), wantErr: ErrorUnsupportedKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := NewKey(tt.raw)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("NewKey() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewKey() error: %v", err)
+			}
+
+			jwk := key.JWK()
+
+			if key.Method != tt.method || key.CanSign() != tt.canSign || jwk.Kty != tt.kty ||
+				jwk.Kid != key.ID || jwk.Alg != tt.method.Alg() || jwk.Use != "sig" {
+				t.Fatalf("NewKey() = %+v, jwk %+v", key, jwk)
+			}
+		})
+	}
+
+	private, err := NewKey(ec)
+	if err != nil {
+		t.Fatalf("NewKey() error: %v", err)
+	}
+
+	public, err := NewKey(&ec.PublicKey)
+	if err != nil {
+		t.Fatalf("NewKey() error: %v", err)
+	}
+
+	if private.ID != public.ID {
+		t.Fatalf("private key id %s does not match public key id %s", private.ID, public.ID)
+	}
+}
+
+func TestLoadKey(t *testing.T) {
+	ec := newECDSAKey(t, elliptic.P256())
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(ec)
+	if err != nil {
+		t.Fatalf("marshal pkcs8 error: %v", err)
+	}
+
+	sec1, err := x509.MarshalECPrivateKey(ec)
+	if err != nil {
+		t.Fatalf("marshal sec1 error: %v", err)
+	}
+
+	pkix, err := x509.MarshalPKIXPublicKey(&ec.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal pkix error: %v", err)
+	}
+
+	want, err := NewKey(ec)
+	if err != nil {
+		t.Fatalf("NewKey() error: %v", err)
+	}
+
+	for _, block := range []struct {
+		typ string
+		der []byte
+	}{
+		{"PRIVATE KEY", pkcs8},
+		{"EC PRIVATE KEY", sec1},
+		{"PUBLIC KEY", pkix},
+	} {
+		key, err := LoadKey(writePEM(t, block.typ, block.der))
+		if err != nil {
+			t.Fatalf("LoadKey() of %s error: %v", block.typ, err)
+		}
+
+		if key.ID != want.ID {
+			t.Fatalf("LoadKey() of %s key id = %s, want %s", block.typ, key.ID, want.ID)
+		}
+	}
+
+	if _, err = LoadKey(writePEM(t, "CERTIFICATE", pkix)); !errors.Is(err, ErrorInvalidPEM) {
+		t.Fatalf("LoadKey() of certificate error = %v, want ErrorInvalidPEM", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err = os.WriteFile(path, []byte("not a pem"), 0o600); err != nil {
+		t.Fatalf("write key file error: %v", err)
+	}
+
+	if _, err = LoadKey(path); !errors.Is(err, ErrorInvalidPEM) {
+		t.Fatalf("LoadKey() of garbage error = %v, want ErrorInvalidPEM", err)
+	}
+}
+
+func TestKeyRing(t *testing.T) {
+	var (
+		signing = newEd25519Key(t)
+		retired = newEd25519Key(t)
+	)
+
+	ring, err := NewKeyRing(signing, retired, signing)
+	if err != nil {
+		t.Fatalf("NewKeyRing() error: %v", err)
+	}
+
+	if ring.Signing() != signing {
+		t.Fatalf("Signing() = %s, want %s", ring.Signing().ID, signing.ID)
+	}
+
+	for _, k := range []*Key{signing, retired} {
+		if got, err := ring.Key(k.ID); err != nil || got != k {
+			t.Fatalf("Key(%s) = %v, %v", k.ID, got, err)
+		}
+	}
+
+	if _, err = ring.Key(newEd25519Key(t).ID); !errors.Is(err, ErrorUnknownKey) {
+		t.Fatalf("Key() of unknown key error = %v, want ErrorUnknownKey", err)
+	}
+
+	set := ring.Set()
+
+	if len(set.Keys) != 2 || set.Keys[0].Kid != signing.ID || set.Keys[1].Kid != retired.ID {
+		t.Fatalf("Set() = %+v, want signing and retired keys", set)
+	}
+
+	public, err := NewKey(retired.Public)
+	if err != nil {
+		t.Fatalf("NewKey() error: %v", err)
+	}
+
+	if _, err = NewKeyRing(public); !errors.Is(err, ErrorUnsupportedKey) {
+		t.Fatalf("NewKeyRing() with public signing key error = %v, want ErrorUnsupportedKey", err)
+	}
+
+	// verification only ring, tokens are signed with the legacy secret
+	ring, err = NewKeyRing(nil, public)
+	if err != nil {
+		t.Fatalf("NewKeyRing() error: %v", err)
+	}
+
+	if ring.Signing() != nil || len(ring.Set().Keys) != 1 {
+		t.Fatalf("verification only ring signing %v, set %+v", ring.Signing(), ring.Set())
+	}
+}
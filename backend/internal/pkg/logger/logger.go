@@ -54,15 +54,16 @@ func (b *LoggerBuilder) Build() *slog.Logger {
 
 		handler := opts.NewPrettyHandler(w)
 
-		return slog.New(handler)
+		return slog.New(NewRedactHandler(handler))
 	}
 
 	return newLogger(b.lvl, w)
 }
 
+// newLogger builds json logger. Seeds and mnemonics are redacted from the output
 func newLogger(lvl slog.Level, w io.Writer) *slog.Logger {
 	return slog.New(
-		slog.NewJSONHandler(w, &slog.HandlerOptions{Level: lvl}),
+		NewRedactHandler(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: lvl})),
 	)
 }
 
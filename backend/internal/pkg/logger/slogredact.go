@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are substrings of attribute keys whose values never reach the log output
+var sensitiveKeys = []string{
+	"seed",
+	"mnemonic",
+	"secret",
+	"password",
+	"private",
+	"master_key",
+	"master-key",
+}
+
+// RedactHandler replaces values of attributes holding key material, including nested groups
+type RedactHandler struct {
+	next slog.Handler
+}
+
+func NewRedactHandler(next slog.Handler) *RedactHandler {
+	return &RedactHandler{
+		next: next,
+	}
+}
+
+func (h *RedactHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	return h.next.Enabled(ctx, lvl)
+}
+
+func (h *RedactHandler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(redact(a))
+
+		return true
+	})
+
+	return h.next.Handle(ctx, out)
+}
+
+func (h *RedactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	safe := make([]slog.Attr, len(attrs))
+
+	for i, a := range attrs {
+		safe[i] = redact(a)
+	}
+
+	return NewRedactHandler(h.next.WithAttrs(safe))
+}
+
+func (h *RedactHandler) WithGroup(name string) slog.Handler {
+	return NewRedactHandler(h.next.WithGroup(name))
+}
+
+func redact(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+
+	if isSensitive(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+
+	if a.Value.Kind() != slog.KindGroup {
+		return a
+	}
+
+	group := a.Value.Group()
+	safe := make([]any, len(group))
+
+	for i, ga := range group {
+		safe[i] = redact(ga)
+	}
+
+	return slog.Group(a.Key, safe...)
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, k := range sensitiveKeys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+
+	return false
+}
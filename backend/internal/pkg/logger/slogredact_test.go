@@ -0,0 +1,109 @@
+package logger_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/logger"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
+	"github.com/google/uuid"
+)
+
+const (
+	mnemonic = "abandon abandon abandon"
+	// seedBase64 is base64 of the seed bytes below, json encodes []byte this way
+	seedBase64 = "AQID"
+)
+
+func newUser() *models.User {
+	return &models.User{
+		ID:        uuid.New(),
+		Name:      "Alice",
+		PK:        []byte{0x04},
+		Bip39Seed: []byte{1, 2, 3},
+		Mnemonic:  mnemonic,
+	}
+}
+
+func newLogger() (*slog.Logger, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+
+	return slog.New(logger.NewRedactHandler(slog.NewJSONHandler(out, nil))), out
+}
+
+func assertRedacted(t *testing.T, out string) {
+	t.Helper()
+
+	for _, secret := range []string{mnemonic, seedBase64, "Bip39Seed", "Mnemonic"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("log output contains %q: %s", secret, out)
+		}
+	}
+}
+
+func TestRedactNestedUser(t *testing.T) {
+	user := newUser()
+
+	tests := []struct {
+		name   string
+		params any
+	}{
+		{
+			name: "add user params",
+			params: organizations.AddUserParams{
+				User:           user,
+				Role:           models.RoleAdmin,
+				OrganizationID: uuid.New(),
+			},
+		},
+		{
+			name: "new multisig params",
+			params: chain.NewMultisigParams{
+				Title:         "Treasury",
+				Owners:        []models.OrganizationParticipant{&models.OrganizationUser{User: *user}},
+				Confirmations: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newLogger()
+
+			log.Info("params", slog.Any("params", tt.params))
+
+			assertRedacted(t, out.String())
+
+			if !strings.Contains(out.String(), user.ID.String()) {
+				t.Fatalf("log output has no user id: %s", out)
+			}
+		})
+	}
+}
+
+func TestRedactKeys(t *testing.T) {
+	log, out := newLogger()
+
+	log.With(slog.String("master_key", "c2VjcmV0")).Info(
+		"user",
+		slog.Any("user", newUser()),
+		slog.String("mnemonic", mnemonic),
+		slog.Group("wallet", slog.String("seed", seedBase64), slog.String("address", "0xabc")),
+	)
+
+	assertRedacted(t, out.String())
+
+	for _, want := range []string{`"master_key":"[REDACTED]"`, `"name":"Alice"`, `"address":"0xabc"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("log output has no %s: %s", want, out)
+		}
+	}
+
+	if strings.Contains(out.String(), "c2VjcmV0") {
+		t.Fatalf("log output contains master key: %s", out)
+	}
+}
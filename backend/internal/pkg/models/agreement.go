@@ -0,0 +1,123 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AgreementStatus int
+
+const (
+	// AgreementStatusPending agreement contract deploy awaits confirmations of the multisig owners
+	AgreementStatusPending AgreementStatus = iota
+	AgreementStatusDeployed
+	// AgreementStatusRequested boolean data request is sent to the oracle, outcome awaits resolution
+	AgreementStatusRequested
+	// AgreementStatusResolved oracle response is recorded, Outcome is set
+	AgreementStatusResolved
+	AgreementStatusFailed
+)
+
+func (s AgreementStatus) String() string {
+	switch s {
+	case AgreementStatusPending:
+		return "pending"
+	case AgreementStatusDeployed:
+		return "deployed"
+	case AgreementStatusRequested:
+		return "requested"
+	case AgreementStatusResolved:
+		return "resolved"
+	case AgreementStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Agreement is a contract owned by the organization multisig, resolved by the oracle boolean response
+type Agreement struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	MultisigID     uuid.UUID
+	Title          string
+	Address        []byte
+	Status         AgreementStatus
+	// RequestURL is the last url requested by the agreement contract oracle
+	RequestURL string
+	// Outcome is the oracle response. Valid only since AgreementStatusResolved
+	Outcome    bool
+	ResolvedAt time.Time
+	CreatedBy  uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type AgreementOperationKind int
+
+const (
+	AgreementOperationKindDeploy AgreementOperationKind = iota
+	AgreementOperationKindRequest
+)
+
+func (k AgreementOperationKind) String() string {
+	switch k {
+	case AgreementOperationKindDeploy:
+		return "deploy"
+	case AgreementOperationKindRequest:
+		return "request"
+	default:
+		return "unknown"
+	}
+}
+
+type AgreementOperationStatus int
+
+const (
+	// AgreementOperationStatusPending operation awaits confirmations of the multisig owners
+	AgreementOperationStatusPending AgreementOperationStatus = iota
+	// AgreementOperationStatusConfirmed required number of confirmations reached, operation is executing
+	AgreementOperationStatusConfirmed
+	// AgreementOperationStatusSubmitted operation is submitted to the multisig, TxIndex is set
+	AgreementOperationStatusSubmitted
+	AgreementOperationStatusExecuted
+	AgreementOperationStatusFailed
+)
+
+func (s AgreementOperationStatus) String() string {
+	switch s {
+	case AgreementOperationStatusPending:
+		return "pending"
+	case AgreementOperationStatusConfirmed:
+		return "confirmed"
+	case AgreementOperationStatusSubmitted:
+		return "submitted"
+	case AgreementOperationStatusExecuted:
+		return "executed"
+	case AgreementOperationStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// AgreementOperation is an agreement contract call sent through the agreement multisig
+type AgreementOperation struct {
+	ID             uuid.UUID
+	AgreementID    uuid.UUID
+	OrganizationID uuid.UUID
+	MultisigID     uuid.UUID
+	Kind           AgreementOperationKind
+	Status         AgreementOperationStatus
+	// URL is requested by AgreementOperationKindRequest operation
+	URL           string
+	TxIndex       int64
+	TxHash        string
+	ConfirmedBy   uuid.UUIDs
+	Confirmations int
+	CreatedBy     uuid.UUID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	ExecutedAt    time.Time
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/google/uuid"
+)
+
+// Asset is an ERC-20 token registered by the organization. Transactions without asset send native ETH
+type Asset struct {
+	ID              uuid.UUID
+	OrganizationID  uuid.UUID
+	Symbol          string
+	ContractAddress []byte
+	// Decimals of the token base unit, e.g. 6 for USDT
+	Decimals int32
+	// ChainID is the network the token contract is deployed to
+	ChainID   int64
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+}
+
+// Currency returns currency the asset amounts are denominated in
+func (a *Asset) Currency() money.Currency {
+	return money.Currency{
+		Code:     a.Symbol,
+		Decimals: a.Decimals,
+	}
+}
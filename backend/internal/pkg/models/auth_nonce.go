@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// AuthNonce is a single use wallet sign in challenge
+type AuthNonce struct {
+	Nonce   string
+	Address []byte
+	// Message is the EIP-4361 message the wallet signs
+	Message string
+
+	CreatedAt time.Time
+	ExpiredAt time.Time
+	UsedAt    time.Time
+}
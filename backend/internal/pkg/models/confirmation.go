@@ -0,0 +1,64 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// MultisigConfirmationEntityType is a type of the entity confirmed by multisig owners
+type MultisigConfirmationEntityType int
+
+const (
+	MultisigConfirmationEntityTypeUnknown MultisigConfirmationEntityType = iota
+	MultisigConfirmationEntityTypePayoutRun
+	MultisigConfirmationEntityTypeTransaction
+	MultisigConfirmationEntityTypeLicenseOperation
+	MultisigConfirmationEntityTypeAgreementOperation
+	MultisigConfirmationEntityTypeSalary
+	MultisigConfirmationEntityTypePayroll
+)
+
+func (t MultisigConfirmationEntityType) String() string {
+	switch t {
+	case MultisigConfirmationEntityTypePayoutRun:
+		return "payout_run"
+	case MultisigConfirmationEntityTypeTransaction:
+		return "transaction"
+	case MultisigConfirmationEntityTypeLicenseOperation:
+		return "license_operation"
+	case MultisigConfirmationEntityTypeAgreementOperation:
+		return "agreement_operation"
+	case MultisigConfirmationEntityTypeSalary:
+		return "salary"
+	case MultisigConfirmationEntityTypePayroll:
+		return "payroll"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseMultisigConfirmationEntityType returns MultisigConfirmationEntityTypeUnknown for unknown types
+func ParseMultisigConfirmationEntityType(s string) MultisigConfirmationEntityType {
+	for t := MultisigConfirmationEntityTypePayoutRun; t <= MultisigConfirmationEntityTypePayroll; t++ {
+		if t.String() == s {
+			return t
+		}
+	}
+
+	return MultisigConfirmationEntityTypeUnknown
+}
+
+// EntityConfirmation is a state of the entity confirmation by the multisig owners
+type EntityConfirmation struct {
+	EntityID   uuid.UUID
+	EntityType MultisigConfirmationEntityType
+	MultisigID uuid.UUID
+
+	ConfirmedBy           uuid.UUIDs
+	Confirmations         int
+	ConfirmationsRequired int
+	RejectedBy            uuid.UUIDs
+
+	// Pending entity accepts confirmations, rejections and revocations. Entity leaves pending
+	// state once required confirmations are collected or rejections make them unreachable
+	Pending bool
+}
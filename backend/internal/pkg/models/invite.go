@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invite is an organization invite link. Invited users join the organization
+// with the preassigned role and position
+type Invite struct {
+	LinkHash       string
+	OrganizationID uuid.UUID
+	CreatedBy      uuid.UUID
+
+	Role     Role
+	Position string
+	// PublicKey binds invite to the user wallet. Only the user with this key accepts bound invite
+	PublicKey []byte
+
+	MaxUses int
+	Uses    int
+
+	CreatedAt time.Time
+	ExpiredAt time.Time
+	// UsedAt is the last use date
+	UsedAt    time.Time
+	RevokedAt time.Time
+}
+
+// IsActive reports whether invite can be used at the moment
+func (i *Invite) IsActive(now time.Time) bool {
+	return i.RevokedAt.IsZero() && i.ExpiredAt.After(now) && i.Uses < i.MaxUses
+}
+
+// IsBound reports whether invite is addressed to a specific wallet
+func (i *Invite) IsBound() bool {
+	return len(i.PublicKey) > 0
+}
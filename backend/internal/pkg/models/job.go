@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobStatus int
+
+const (
+	JobStatusQueued JobStatus = iota
+	JobStatusRunning
+	JobStatusSucceeded
+	JobStatusFailed
+)
+
+func (s JobStatus) String() string {
+	switch s {
+	case JobStatusQueued:
+		return "queued"
+	case JobStatusRunning:
+		return "running"
+	case JobStatusSucceeded:
+		return "succeeded"
+	case JobStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Job is a persistent unit of background work. Payload and Result are opaque
+// json documents owned by the handler registered for the job Kind.
+type Job struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	CreatedBy      uuid.UUID
+	Kind           string
+
+	Payload []byte
+	Result  []byte
+
+	Status      JobStatus
+	Attempts    int
+	MaxAttempts int
+	LastError   string
+
+	RunAt time.Time
+	// LeaseOwner is the worker running the job until LockedUntil
+	LeaseOwner  uuid.UUID
+	LockedUntil time.Time
+
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+func (j *Job) Done() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed
+}
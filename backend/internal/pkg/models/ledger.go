@@ -0,0 +1,164 @@
+package models
+
+import (
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/google/uuid"
+)
+
+type LedgerAccountType int
+
+const (
+	LedgerAccountTypeUnknown LedgerAccountType = iota
+	LedgerAccountTypeAsset
+	LedgerAccountTypeLiability
+	LedgerAccountTypeEquity
+	LedgerAccountTypeIncome
+	LedgerAccountTypeExpense
+)
+
+func (t LedgerAccountType) String() string {
+	switch t {
+	case LedgerAccountTypeAsset:
+		return "asset"
+	case LedgerAccountTypeLiability:
+		return "liability"
+	case LedgerAccountTypeEquity:
+		return "equity"
+	case LedgerAccountTypeIncome:
+		return "income"
+	case LedgerAccountTypeExpense:
+		return "expense"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLedgerAccountType returns LedgerAccountTypeUnknown for unknown types
+func ParseLedgerAccountType(s string) LedgerAccountType {
+	for t := LedgerAccountTypeAsset; t <= LedgerAccountTypeExpense; t++ {
+		if t.String() == s {
+			return t
+		}
+	}
+
+	return LedgerAccountTypeUnknown
+}
+
+// DebitNormal reports whether debits increase balance of the account type.
+// Assets and expenses are debit normal, liabilities, equity and income are credit normal
+func (t LedgerAccountType) DebitNormal() bool {
+	return t == LedgerAccountTypeAsset || t == LedgerAccountTypeExpense
+}
+
+// Codes of the default chart of accounts, created for every organization on the first posting
+const (
+	LedgerAccountMultisigs     = "1000"
+	LedgerAccountPayrolls      = "1100"
+	LedgerAccountContributions = "3000"
+	LedgerAccountExpenses      = "5000"
+	LedgerAccountSalaries      = "6000"
+)
+
+// LedgerAccount is an account of the organization chart of accounts
+type LedgerAccount struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Code           string
+	Name           string
+	Type           LedgerAccountType
+	CreatedAt      time.Time
+}
+
+// LedgerEntrySource is the kind of the entity the journal entry is posted for
+type LedgerEntrySource string
+
+const (
+	LedgerEntrySourceManual          LedgerEntrySource = "manual"
+	LedgerEntrySourceTransaction     LedgerEntrySource = "transaction"
+	LedgerEntrySourcePayment         LedgerEntrySource = "payment"
+	LedgerEntrySourcePayrollDeposit  LedgerEntrySource = "payroll_deposit"
+	LedgerEntrySourceMultisigDeposit LedgerEntrySource = "multisig_deposit"
+)
+
+// LedgerEntry is a journal entry. Debits and credits of its lines are equal for every currency.
+// Only one entry is posted for the source entity
+type LedgerEntry struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Description    string
+	SourceType     LedgerEntrySource
+	SourceID       uuid.UUID
+	Lines          []LedgerLine
+	// OccurredAt is the accounting date of the entry, balances as of date include entries occurred before it
+	OccurredAt time.Time
+	CreatedBy  uuid.UUID
+	CreatedAt  time.Time
+}
+
+type LedgerLine struct {
+	ID          uuid.UUID
+	EntryID     uuid.UUID
+	AccountID   uuid.UUID
+	AccountCode string
+	// Currency is the asset symbol, e.g. ETH or USD
+	Currency string
+	Debit    money.Decimal
+	Credit   money.Decimal
+	// Rate is the currency price in FiatCurrency snapshotted when the entry was posted. Nil if not priced
+	Rate *money.Decimal
+}
+
+// Fiat returns debit and credit in FiatCurrency. ok is false if the line is not priced
+func (l LedgerLine) Fiat() (debit, credit money.Decimal, ok bool) {
+	if l.Rate == nil {
+		return money.Decimal{}, money.Decimal{}, false
+	}
+
+	rate := *l.Rate
+
+	return l.Debit.Mul(rate).Round(FiatCurrency.Decimals), l.Credit.Mul(rate).Round(FiatCurrency.Decimals), true
+}
+
+// LedgerBalance is account totals in the currency
+type LedgerBalance struct {
+	Account  *LedgerAccount
+	Currency string
+	Debit    money.Decimal
+	Credit   money.Decimal
+	// FiatDebit and FiatCredit are totals in FiatCurrency at the rates of the moments entries were posted.
+	// Unpriced lines are not included
+	FiatDebit  money.Decimal
+	FiatCredit money.Decimal
+	// UnpricedLines is the number of lines without price snapshot, e.g. manual entries in crypto
+	UnpricedLines int64
+}
+
+// LedgerUnposted is an executed transfer or payout missing from the ledger, e.g. its posting failed
+type LedgerUnposted struct {
+	SourceType  LedgerEntrySource
+	SourceID    uuid.UUID
+	Description string
+	Currency    string
+	Amount      money.Decimal
+	OccurredAt  time.Time
+}
+
+// Balance returns account balance signed according to the account normal side
+func (b LedgerBalance) Balance() money.Decimal {
+	if b.Account != nil && !b.Account.Type.DebitNormal() {
+		return b.Credit.Sub(b.Debit)
+	}
+
+	return b.Debit.Sub(b.Credit)
+}
+
+// FiatBalance returns account balance in FiatCurrency signed according to the account normal side
+func (b LedgerBalance) FiatBalance() money.Decimal {
+	if b.Account != nil && !b.Account.Type.DebitNormal() {
+		return b.FiatCredit.Sub(b.FiatDebit).Round(FiatCurrency.Decimals)
+	}
+
+	return b.FiatDebit.Sub(b.FiatCredit).Round(FiatCurrency.Decimals)
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LicenseStatus int
+
+const (
+	// LicenseStatusPending license contract deploy awaits confirmations of the multisig owners
+	LicenseStatusPending LicenseStatus = iota
+	LicenseStatusDeployed
+	LicenseStatusFailed
+)
+
+func (s LicenseStatus) String() string {
+	switch s {
+	case LicenseStatusPending:
+		return "pending"
+	case LicenseStatusDeployed:
+		return "deployed"
+	case LicenseStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// License is a streaming rights management contract owned by the organization multisig.
+// Payout figure is requested from the oracle and distributed between shareholders through the payout payroll
+type License struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	MultisigID     uuid.UUID
+	Title          string
+	Address        []byte
+	Status         LicenseStatus
+	// OracleURL is the last url requested by the license contract oracle
+	OracleURL string
+	// PayrollID is the payroll contract set as license payout contract
+	PayrollID    uuid.UUID
+	Shareholders []LicenseShareholder
+	CreatedBy    uuid.UUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type LicenseShareholder struct {
+	LicenseID uuid.UUID
+	// ParticipantID is set if shareholder is an organization participant
+	ParticipantID uuid.UUID
+	Address       []byte
+	// Share in percents. Shares of all license shareholders sum up to 100
+	Share int
+}
+
+type LicenseOperationKind int
+
+const (
+	LicenseOperationKindDeploy LicenseOperationKind = iota
+	LicenseOperationKindRequest
+	LicenseOperationKindSetPayoutContract
+	LicenseOperationKindPayout
+)
+
+func (k LicenseOperationKind) String() string {
+	switch k {
+	case LicenseOperationKindDeploy:
+		return "deploy"
+	case LicenseOperationKindRequest:
+		return "request"
+	case LicenseOperationKindSetPayoutContract:
+		return "set_payout_contract"
+	case LicenseOperationKindPayout:
+		return "payout"
+	default:
+		return "unknown"
+	}
+}
+
+type LicenseOperationStatus int
+
+const (
+	// LicenseOperationStatusPending operation awaits confirmations of the multisig owners
+	LicenseOperationStatusPending LicenseOperationStatus = iota
+	// LicenseOperationStatusConfirmed required number of confirmations reached, operation is executing
+	LicenseOperationStatusConfirmed
+	// LicenseOperationStatusSubmitted operation is submitted to the multisig, TxIndex is set
+	LicenseOperationStatusSubmitted
+	LicenseOperationStatusExecuted
+	LicenseOperationStatusFailed
+)
+
+func (s LicenseOperationStatus) String() string {
+	switch s {
+	case LicenseOperationStatusPending:
+		return "pending"
+	case LicenseOperationStatusConfirmed:
+		return "confirmed"
+	case LicenseOperationStatusSubmitted:
+		return "submitted"
+	case LicenseOperationStatusExecuted:
+		return "executed"
+	case LicenseOperationStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// LicenseOperation is a license contract call sent through the license multisig
+type LicenseOperation struct {
+	ID             uuid.UUID
+	LicenseID      uuid.UUID
+	OrganizationID uuid.UUID
+	MultisigID     uuid.UUID
+	Kind           LicenseOperationKind
+	Status         LicenseOperationStatus
+	// URL is requested by LicenseOperationKindRequest operation
+	URL string
+	// PayrollID is set as payout contract by LicenseOperationKindSetPayoutContract operation
+	PayrollID     uuid.UUID
+	TxIndex       int64
+	TxHash        string
+	ConfirmedBy   uuid.UUIDs
+	Confirmations int
+	CreatedBy     uuid.UUID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	ExecutedAt    time.Time
+}
+
+// LicenseInfo is the license state read from the contract
+type LicenseInfo struct {
+	Shareholders []LicenseShareholder
+	// TotalPayoutInUSD is the last payout figure received from the oracle
+	TotalPayoutInUSD string
+	PayoutContract   []byte
+}
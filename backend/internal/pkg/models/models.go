@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
 	"github.com/google/uuid"
 )
 
@@ -13,8 +14,10 @@ type Multisig struct {
 	OrganizationID        uuid.UUID
 	Owners                []OrganizationParticipant
 	ConfirmationsRequired int
-	CreatedAt             time.Time
-	UpdatedAt             time.Time
+	// RotationRequiredAt is set once an owner is removed from the organization
+	RotationRequiredAt time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
 
 type MultisigConfirmation struct {
@@ -24,18 +27,96 @@ type MultisigConfirmation struct {
 	UpdatedAt  time.Time
 }
 
+type PayrollStatus int
+
+const (
+	// PayrollStatusDeployed payroll contract is deployed and authorized to the multisig
+	PayrollStatusDeployed PayrollStatus = iota
+	// PayrollStatusPending payroll deploy awaits confirmations of the multisig owners
+	PayrollStatusPending
+	// PayrollStatusConfirmed required number of confirmations reached, payroll is deploying
+	PayrollStatusConfirmed
+	PayrollStatusFailed
+)
+
+func (s PayrollStatus) String() string {
+	switch s {
+	case PayrollStatusDeployed:
+		return "deployed"
+	case PayrollStatusPending:
+		return "pending"
+	case PayrollStatusConfirmed:
+		return "confirmed"
+	case PayrollStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
 type Payroll struct {
 	ID             uuid.UUID
 	Title          string
 	Address        []byte
 	OrganizationID uuid.UUID
 	MultisigID     uuid.UUID
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	Status         PayrollStatus
+
+	ConfirmedBy   uuid.UUIDs
+	Confirmations int
+
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type SalaryStatus int
+
+const (
+	// SalaryStatusPending salary awaits confirmations of the payroll multisig owners
+	SalaryStatusPending SalaryStatus = iota
+	// SalaryStatusSubmitted set-salary transaction is submitted to the payroll multisig
+	SalaryStatusSubmitted
+	SalaryStatusFailed
+	// SalaryStatusConfirmed required number of confirmations reached, salary is sending on-chain
+	SalaryStatusConfirmed
+	// SalaryStatusExecuted set-salary transaction is executed by the payroll multisig
+	SalaryStatusExecuted
+)
+
+func (s SalaryStatus) String() string {
+	switch s {
+	case SalaryStatusPending:
+		return "pending"
+	case SalaryStatusSubmitted:
+		return "submitted"
+	case SalaryStatusFailed:
+		return "failed"
+	case SalaryStatusConfirmed:
+		return "confirmed"
+	case SalaryStatusExecuted:
+		return "executed"
+	default:
+		return "unknown"
+	}
 }
 
 type Salary struct {
-	ID         uuid.UUID
-	EmployeeID uuid.UUID
-	Amount     float64
+	ID              uuid.UUID
+	OrganizationID  uuid.UUID
+	PayrollID       uuid.UUID
+	EmployeeID      uuid.UUID
+	EmployeeAddress []byte
+	// Amount in USD
+	Amount  money.Amount
+	Status  SalaryStatus
+	TxIndex int64
+	TxHash  string
+
+	ConfirmedBy   uuid.UUIDs
+	Confirmations int
+
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
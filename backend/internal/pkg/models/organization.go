@@ -14,14 +14,60 @@ func (i *Organizations) MarshalBinary() ([]byte, error) {
 }
 
 type Organization struct {
-	ID         uuid.UUID `json:"id"`
-	Name       string    `json:"name"`
-	Address    string    `json:"addess"`
-	WalletSeed []byte    `json:"wallet_seed"`
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Address string    `json:"addess"`
+	// WalletSeed is never serialized, organizations are cached
+	WalletSeed []byte    `json:"-"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
+	// ArchivedAt is set for read only organizations
+	ArchivedAt time.Time `json:"archived_at"`
 }
 
 func (i *Organization) MarshalBinary() ([]byte, error) {
 	return json.Marshal(i)
 }
+
+func (i *Organization) IsArchived() bool {
+	return !i.ArchivedAt.IsZero()
+}
+
+type OwnershipTransferStatus int
+
+const (
+	// OwnershipTransferStatusPending transfer awaits current owner confirmation
+	OwnershipTransferStatusPending OwnershipTransferStatus = iota
+	// OwnershipTransferStatusConfirmed owner role is transferred to the new owner
+	OwnershipTransferStatusConfirmed
+	OwnershipTransferStatusCancelled
+)
+
+func (s OwnershipTransferStatus) String() string {
+	switch s {
+	case OwnershipTransferStatusPending:
+		return "pending"
+	case OwnershipTransferStatusConfirmed:
+		return "confirmed"
+	case OwnershipTransferStatusCancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+// OwnershipTransfer moves owner role from one organization user to another.
+// Transfer takes effect once the current owner confirms it, the former owner becomes admin
+type OwnershipTransfer struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	FromUserID     uuid.UUID
+	ToUserID       uuid.UUID
+	Status         OwnershipTransferStatus
+
+	CreatedBy   uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ConfirmedAt time.Time
+	CancelledAt time.Time
+}
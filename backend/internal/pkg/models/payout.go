@@ -0,0 +1,117 @@
+package models
+
+import (
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/google/uuid"
+)
+
+type PayoutRunStatus int
+
+const (
+	// PayoutRunStatusPending payout run awaits confirmations of the multisig owners
+	PayoutRunStatusPending PayoutRunStatus = iota
+	// PayoutRunStatusConfirmed required number of confirmations reached, payout is executing
+	PayoutRunStatusConfirmed
+	PayoutRunStatusExecuted
+	PayoutRunStatusFailed
+)
+
+func (s PayoutRunStatus) String() string {
+	switch s {
+	case PayoutRunStatusPending:
+		return "pending"
+	case PayoutRunStatusConfirmed:
+		return "confirmed"
+	case PayoutRunStatusExecuted:
+		return "executed"
+	case PayoutRunStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// PayoutRun is a snapshot of salaries due for the payroll. Run is executed
+// once enough payroll multisig owners confirmed it.
+type PayoutRun struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	PayrollID      uuid.UUID
+	MultisigID     uuid.UUID
+	Status         PayoutRunStatus
+
+	// DepositAmount in ETH, deposited to the payroll contract before payout. Optional
+	DepositAmount money.Amount
+	DepositTxHash string
+
+	Payments      []Payment
+	ConfirmedBy   uuid.UUIDs
+	Confirmations int
+
+	CreatedBy  uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	ExecutedAt time.Time
+}
+
+type PaymentStatus int
+
+const (
+	PaymentStatusPending PaymentStatus = iota
+	// PaymentStatusSubmitted payout transaction is submitted to the multisig
+	PaymentStatusSubmitted
+	// PaymentStatusConfirmed payout transaction is confirmed on-chain by the multisig owners
+	PaymentStatusConfirmed
+	PaymentStatusPaid
+	PaymentStatusFailed
+)
+
+func (s PaymentStatus) String() string {
+	switch s {
+	case PaymentStatusPending:
+		return "pending"
+	case PaymentStatusSubmitted:
+		return "submitted"
+	case PaymentStatusConfirmed:
+		return "confirmed"
+	case PaymentStatusPaid:
+		return "paid"
+	case PaymentStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Payment is a single employee payout within the payout run
+type Payment struct {
+	ID              uuid.UUID
+	PayoutRunID     uuid.UUID
+	OrganizationID  uuid.UUID
+	PayrollID       uuid.UUID
+	SalaryID        uuid.UUID
+	EmployeeID      uuid.UUID
+	EmployeeAddress []byte
+	// Amount in USD
+	Amount    money.Amount
+	Status    PaymentStatus
+	TxIndex   int64
+	TxHash    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	PaidAt    time.Time
+}
+
+type PayrollDeposit struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	PayrollID      uuid.UUID
+	PayoutRunID    uuid.UUID
+	// Amount in ETH
+	Amount    money.Amount
+	TxHash    string
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+}
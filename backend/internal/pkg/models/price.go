@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/google/uuid"
+)
+
+// FiatCurrency is the currency prices are quoted in and fiat equivalents are reported in
+var FiatCurrency = money.USD
+
+// Price is the exchange rate snapshot taken when funds were moved, e.g. ETH price in USD at the moment
+// transaction was executed. Snapshots are keyed by the ledger entry source, so fiat equivalents never change
+type Price struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	SourceType     LedgerEntrySource
+	SourceID       uuid.UUID
+	// Base is the code of the priced currency, e.g. ETH or the asset symbol
+	Base string
+	// Quote is the code of the currency rate is expressed in, FiatCurrency
+	Quote string
+	// Rate is the price of one Base unit in Quote
+	Rate money.Decimal
+	// Provider is the name of the price source, e.g. chain-api or fixture
+	Provider   string
+	ObservedAt time.Time
+	CreatedAt  time.Time
+}
+
+// Convert returns fiat equivalent of the amount, rounded to the fiat currency decimals
+func (p *Price) Convert(amount money.Decimal) money.Amount {
+	return money.New(amount.Mul(p.Rate).Round(FiatCurrency.Decimals), FiatCurrency)
+}
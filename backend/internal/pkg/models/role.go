@@ -0,0 +1,151 @@
+package models
+
+// Role is an organization user role. Role grants a fixed set of permissions
+type Role int
+
+const (
+	RoleUnknown Role = iota
+	// RoleViewer has read only access to the organization
+	RoleViewer
+	// RoleApprover confirms entities awaiting multisig owners confirmations
+	RoleApprover
+	// RoleAccountant creates transactions, payrolls, payouts, contract operations and ledger entries
+	RoleAccountant
+	// RoleAdmin has every permission, but can not grant or revoke owner role
+	RoleAdmin
+	// RoleOwner has every permission
+	RoleOwner
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleApprover:
+		return "approver"
+	case RoleAccountant:
+		return "accountant"
+	case RoleAdmin:
+		return "admin"
+	case RoleOwner:
+		return "owner"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseRole returns RoleUnknown for unknown roles
+func ParseRole(s string) Role {
+	for r := RoleViewer; r <= RoleOwner; r++ {
+		if r.String() == s {
+			return r
+		}
+	}
+
+	return RoleUnknown
+}
+
+// Can reports whether the role grants the permission
+func (r Role) Can(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Permissions returns permissions granted by the role
+func (r Role) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+// Permission is a named action in the organization, checked by the authorizer
+type Permission string
+
+// AllowedInArchive reports whether the permission is granted in archived organization.
+// Archived organization is read only, only its settings and participants are managed
+func (p Permission) AllowedInArchive() bool {
+	switch p {
+	case PermissionOrganizationManage, PermissionParticipantsManage, PermissionRolesAssign:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	PermissionOrganizationManage Permission = "organization.manage"
+
+	PermissionParticipantsInvite Permission = "participants.invite"
+	PermissionParticipantsManage Permission = "participants.manage"
+	PermissionRolesAssign        Permission = "roles.assign"
+
+	PermissionMultisigCreate Permission = "multisig.create"
+
+	PermissionTxCreate  Permission = "tx.create"
+	PermissionTxConfirm Permission = "tx.confirm"
+	PermissionTxCancel  Permission = "tx.cancel"
+
+	PermissionPayrollDeploy  Permission = "payroll.deploy"
+	PermissionPayrollConfirm Permission = "payroll.confirm"
+	PermissionSalarySet      Permission = "salary.set"
+
+	PermissionPayoutCreate  Permission = "payout.create"
+	PermissionPayoutConfirm Permission = "payout.confirm"
+
+	PermissionLicenseManage  Permission = "license.manage"
+	PermissionLicenseConfirm Permission = "license.confirm"
+
+	PermissionAgreementManage  Permission = "agreement.manage"
+	PermissionAgreementConfirm Permission = "agreement.confirm"
+
+	PermissionLedgerManage Permission = "ledger.manage"
+
+	PermissionAssetManage Permission = "asset.manage"
+)
+
+var allPermissions = []Permission{
+	PermissionOrganizationManage,
+	PermissionParticipantsInvite,
+	PermissionParticipantsManage,
+	PermissionRolesAssign,
+	PermissionMultisigCreate,
+	PermissionTxCreate,
+	PermissionTxConfirm,
+	PermissionTxCancel,
+	PermissionPayrollDeploy,
+	PermissionPayrollConfirm,
+	PermissionSalarySet,
+	PermissionPayoutCreate,
+	PermissionPayoutConfirm,
+	PermissionLicenseManage,
+	PermissionLicenseConfirm,
+	PermissionAgreementManage,
+	PermissionAgreementConfirm,
+	PermissionLedgerManage,
+	PermissionAssetManage,
+}
+
+var rolePermissions = map[Role][]Permission{
+	RoleApprover: {
+		PermissionTxConfirm,
+		PermissionPayrollConfirm,
+		PermissionPayoutConfirm,
+		PermissionLicenseConfirm,
+		PermissionAgreementConfirm,
+	},
+	RoleAccountant: {
+		PermissionTxCreate,
+		PermissionTxCancel,
+		PermissionPayrollDeploy,
+		PermissionSalarySet,
+		PermissionPayoutCreate,
+		PermissionLicenseManage,
+		PermissionAgreementManage,
+		PermissionLedgerManage,
+	},
+	RoleAdmin: allPermissions,
+	RoleOwner: allPermissions,
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is an issued access and refresh tokens pair
+type Session struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+
+	RemoteAddr string
+	UserAgent  string
+
+	CreatedAt time.Time
+	// ExpiredAt is the refresh token expiration date
+	ExpiredAt time.Time
+	RevokedAt time.Time
+
+	// Current is set for the session of the request
+	Current bool
+}
+
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt.IsZero() && s.ExpiredAt.After(now)
+}
@@ -3,23 +3,75 @@ package models
 import (
 	"time"
 
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
 	"github.com/google/uuid"
 )
 
+type TransactionStatus int
+
+const (
+	// TransactionStatusPending transaction awaits confirmations of the multisig owners. Transaction
+	// returns to the pending status if confirmations are revoked before execution
+	TransactionStatusPending TransactionStatus = iota
+	// TransactionStatusConfirmed required number of confirmations reached, transaction is executing
+	TransactionStatusConfirmed
+	// TransactionStatusSubmitted transaction is submitted to the multisig, TxIndex is set
+	TransactionStatusSubmitted
+	// TransactionStatusExecuted transaction is executed by the multisig, CommitedAt is set
+	TransactionStatusExecuted
+	TransactionStatusFailed
+)
+
+func (s TransactionStatus) String() string {
+	switch s {
+	case TransactionStatusPending:
+		return "pending"
+	case TransactionStatusConfirmed:
+		return "confirmed"
+	case TransactionStatusSubmitted:
+		return "submitted"
+	case TransactionStatusExecuted:
+		return "executed"
+	case TransactionStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
 type Transaction struct {
 	Id uuid.UUID
 
 	Description    string
 	OrganizationId uuid.UUID
 	CreatedBy      *OrganizationUser
-	Amount         float64
+	// Amount in ETH or in the asset tokens sent to ToAddr
+	Amount money.Amount
+	// AssetID is nil for native ETH transfers
+	AssetID uuid.UUID
+	Asset   *Asset
+	// Price is the rate of the amount currency in FiatCurrency snapshotted when transaction was executed
+	Price *Price
 
 	ToAddr []byte
 
-	MaxFeeAllowed float64
+	// MaxFeeAllowed in ETH
+	MaxFeeAllowed money.Amount
 	Deadline      time.Time
 
-	Status int
+	MultisigID            uuid.UUID
+	ConfirmationsRequired int
+	ConfirmedBy           uuid.UUIDs
+	Confirmations         int
+	RejectedBy            uuid.UUIDs
+
+	// TxIndex is an index of the transaction in the multisig. Valid only since TransactionStatusSubmitted
+	TxIndex int64
+	TxHash  string
+	// SubmittedAt is set once transaction is submitted to the multisig. Transaction is never submitted twice
+	SubmittedAt time.Time
+
+	Status TransactionStatus
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
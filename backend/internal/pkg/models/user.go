@@ -1,6 +1,7 @@
 package models
 
 import (
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,9 +19,10 @@ type User struct {
 
 	Credentails *UserCredentials
 
-	PK        []byte
-	Bip39Seed []byte
-	Mnemonic  string
+	PK []byte
+	// Bip39Seed and Mnemonic are skipped by json, so they do not leak with structs logged by slog.Any
+	Bip39Seed []byte `json:"-"`
+	Mnemonic  string `json:"-"`
 	Activated bool
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -59,6 +61,14 @@ func (u *User) PublicKey() []byte {
 	return u.PK
 }
 
+// LogValue keeps seed and mnemonic out of the logs
+func (u *User) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", u.ID.String()),
+		slog.String("name", u.Name),
+	)
+}
+
 type OrganizationParticipantType int
 
 const (
@@ -76,6 +86,7 @@ type OrganizationParticipant interface {
 
 	IsAdmin() bool
 	IsOwner() bool
+	Role() Role
 	Position() string
 	IsActive() bool
 
@@ -88,8 +99,7 @@ type OrganizationUser struct {
 	User
 
 	OrgPosition string
-	Admin       bool
-	Owner       bool
+	OrgRole     Role
 
 	Employee *Employee
 
@@ -115,11 +125,15 @@ func (u *OrganizationUser) GetEmployee() *Employee {
 }
 
 func (u *OrganizationUser) IsAdmin() bool {
-	return u.Admin
+	return u.OrgRole == RoleAdmin || u.OrgRole == RoleOwner
 }
 
 func (u *OrganizationUser) IsOwner() bool {
-	return u.Owner
+	return u.OrgRole == RoleOwner
+}
+
+func (u *OrganizationUser) Role() Role {
+	return u.OrgRole
 }
 
 func (u *OrganizationUser) Position() string {
@@ -143,14 +157,15 @@ func (u *OrganizationUser) DeletedDate() time.Time {
 }
 
 type Employee struct {
-	ID             uuid.UUID
-	EmployeeName   string
-	UserID         uuid.UUID
-	OrganizationId uuid.UUID
-	WalletAddress  []byte
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	DeletedAt      time.Time
+	ID               uuid.UUID
+	EmployeeName     string
+	EmployeePosition string
+	UserID           uuid.UUID
+	OrganizationId   uuid.UUID
+	WalletAddress    []byte
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        time.Time
 }
 
 func (u *Employee) Id() uuid.UUID {
@@ -185,8 +200,13 @@ func (u *Employee) IsOwner() bool {
 	return false
 }
 
+// Role employee without user account has no permissions
+func (u *Employee) Role() Role {
+	return RoleUnknown
+}
+
 func (u *Employee) Position() string {
-	return "" // todo
+	return u.EmployeePosition
 }
 
 func (u *Employee) IsActive() bool {
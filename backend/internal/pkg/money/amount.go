@@ -0,0 +1,125 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Currency is a currency or token code with the number of decimals of its base unit
+type Currency struct {
+	Code     string
+	Decimals int32
+}
+
+var (
+	// ETH base unit is wei
+	ETH = Currency{Code: "ETH", Decimals: 18}
+	// USD base unit is cent
+	USD = Currency{Code: "USD", Decimals: 2}
+)
+
+func (c Currency) String() string {
+	return c.Code
+}
+
+// Amount is an exact amount of the currency. Amount is marshaled to JSON as its decimal value only,
+// currency is defined by the field holding the amount. Repositories store Value as numeric
+type Amount struct {
+	Value    Decimal
+	Currency Currency
+}
+
+func New(value Decimal, currency Currency) Amount {
+	return Amount{
+		Value:    value,
+		Currency: currency,
+	}
+}
+
+func Zero(currency Currency) Amount {
+	return Amount{
+		Currency: currency,
+	}
+}
+
+// Parse parses decimal amount of the currency, e.g. "1.5" ETH
+func Parse(s string, currency Currency) (Amount, error) {
+	value, err := ParseDecimal(s)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return New(value, currency), nil
+}
+
+// FromUnits returns amount of the currency base units, e.g. wei
+func FromUnits(units *big.Int, currency Currency) Amount {
+	return New(NewDecimal(units, currency.Decimals), currency)
+}
+
+// FromWei returns ETH amount of wei
+func FromWei(wei *big.Int) Amount {
+	return FromUnits(wei, ETH)
+}
+
+// Units returns amount in the currency base units. Returns ErrorPrecision if amount is fractional in base units
+func (a Amount) Units() (*big.Int, error) {
+	units, ok := a.Value.Shift(a.Currency.Decimals).Int()
+	if !ok {
+		return nil, fmt.Errorf("error %s has more than %d decimals. %w", a, a.Currency.Decimals, ErrorPrecision)
+	}
+
+	return units, nil
+}
+
+// Wei returns ETH amount in wei. Returns ErrorCurrencyMismatch for other currencies
+func (a Amount) Wei() (*big.Int, error) {
+	if a.Currency != ETH {
+		return nil, fmt.Errorf("error %s is not ETH amount. %w", a, ErrorCurrencyMismatch)
+	}
+
+	return a.Units()
+}
+
+// String returns value with the currency code, e.g. "1.5 ETH"
+func (a Amount) String() string {
+	return strings.TrimSpace(a.Value.String() + " " + a.Currency.Code)
+}
+
+func (a Amount) Sign() int {
+	return a.Value.Sign()
+}
+
+func (a Amount) IsZero() bool {
+	return a.Value.IsZero()
+}
+
+func (a Amount) IsPositive() bool {
+	return a.Value.Sign() > 0
+}
+
+func (a Amount) Add(o Amount) (Amount, error) {
+	if a.Currency != o.Currency {
+		return Amount{}, fmt.Errorf("error add %s to %s. %w", o, a, ErrorCurrencyMismatch)
+	}
+
+	return New(a.Value.Add(o.Value), a.Currency), nil
+}
+
+func (a Amount) Sub(o Amount) (Amount, error) {
+	if a.Currency != o.Currency {
+		return Amount{}, fmt.Errorf("error subtract %s from %s. %w", o, a, ErrorCurrencyMismatch)
+	}
+
+	return New(a.Value.Sub(o.Value), a.Currency), nil
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return a.Value.MarshalJSON()
+}
+
+// UnmarshalJSON sets the value only, currency is kept
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	return a.Value.UnmarshalJSON(data)
+}
@@ -0,0 +1,134 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestAmountWei(t *testing.T) {
+	tests := []struct {
+		eth string
+		wei string
+	}{
+		{eth: "0", wei: "0"},
+		{eth: "1", wei: "1000000000000000000"},
+		{eth: "1.5", wei: "1500000000000000000"},
+		{eth: "0.000000000000000001", wei: "1"},
+		{eth: "-2.25", wei: "-2250000000000000000"},
+		{eth: "123456789.123456789123456789", wei: "123456789123456789123456789"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.eth, func(t *testing.T) {
+			amount, err := Parse(tt.eth, ETH)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.eth, err)
+			}
+
+			wei, err := amount.Wei()
+			if err != nil {
+				t.Fatalf("Wei() of %s error: %v", amount, err)
+			}
+
+			if got := wei.String(); got != tt.wei {
+				t.Fatalf("Wei() of %s = %s, want %s", amount, got, tt.wei)
+			}
+
+			back := FromWei(wei)
+
+			if back.Currency != ETH || back.Value.String() != MustParseDecimal(tt.eth).String() {
+				t.Fatalf("FromWei(%s) = %s, want %s ETH", wei, back, tt.eth)
+			}
+		})
+	}
+}
+
+func TestAmountWeiErrors(t *testing.T) {
+	if _, err := mustParseAmount(t, "0.0000000000000000001", ETH).Wei(); !errors.Is(err, ErrorPrecision) {
+		t.Fatalf("Wei() of 19 decimals error = %v, want ErrorPrecision", err)
+	}
+
+	if _, err := mustParseAmount(t, "1", USD).Wei(); !errors.Is(err, ErrorCurrencyMismatch) {
+		t.Fatalf("Wei() of USD error = %v, want ErrorCurrencyMismatch", err)
+	}
+
+	if _, err := Parse("-+1", ETH); !errors.Is(err, ErrorInvalidAmount) {
+		t.Fatalf("Parse(-+1) error = %v, want ErrorInvalidAmount", err)
+	}
+}
+
+func TestAmountUnits(t *testing.T) {
+	cents, err := mustParseAmount(t, "12.34", USD).Units()
+	if err != nil || cents.String() != "1234" {
+		t.Fatalf("Units() of 12.34 USD = %s, %v, want 1234", cents, err)
+	}
+
+	if _, err := mustParseAmount(t, "12.345", USD).Units(); !errors.Is(err, ErrorPrecision) {
+		t.Fatalf("Units() of 12.345 USD error = %v, want ErrorPrecision", err)
+	}
+
+	huge, _ := new(big.Int).SetString("340282366920938463463374607431768211456", 10)
+
+	if got, want := FromUnits(huge, ETH).String(), "340282366920938463463.374607431768211456 ETH"; got != want {
+		t.Fatalf("FromUnits(2^128) = %s, want %s", got, want)
+	}
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	a := mustParseAmount(t, "1.5", ETH)
+	b := mustParseAmount(t, "2", ETH)
+
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "3.5 ETH" {
+		t.Fatalf("1.5 ETH + 2 ETH = %s, %v", sum, err)
+	}
+
+	diff, err := a.Sub(b)
+	if err != nil || diff.String() != "-0.5 ETH" || diff.IsPositive() || diff.Sign() != -1 {
+		t.Fatalf("1.5 ETH - 2 ETH = %s, %v", diff, err)
+	}
+
+	if _, err = a.Add(mustParseAmount(t, "1", USD)); !errors.Is(err, ErrorCurrencyMismatch) {
+		t.Fatalf("ETH + USD error = %v, want ErrorCurrencyMismatch", err)
+	}
+
+	if !Zero(USD).IsZero() || Zero(USD).String() != "0 USD" {
+		t.Fatalf("Zero(USD) = %s", Zero(USD))
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	amount := mustParseAmount(t, "-0.000000000000000001", ETH)
+
+	out, err := json.Marshal(amount)
+	if err != nil {
+		t.Fatalf("marshal %s error: %v", amount, err)
+	}
+
+	if got, want := string(out), `"-0.000000000000000001"`; got != want {
+		t.Fatalf("marshal %s = %s, want %s", amount, got, want)
+	}
+
+	decoded := Zero(ETH)
+
+	if err = json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("unmarshal %s error: %v", out, err)
+	}
+
+	if decoded.Currency != ETH || !decoded.Value.Equal(amount.Value) {
+		t.Fatalf("unmarshal %s = %s, want %s", out, decoded, amount)
+	}
+}
+
+func mustParseAmount(t *testing.T, s string, currency Currency) Amount {
+	t.Helper()
+
+	amount, err := Parse(s, currency)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", s, err)
+	}
+
+	return amount
+}
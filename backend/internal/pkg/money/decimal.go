@@ -0,0 +1,298 @@
+// Package money holds exact amounts of currencies and tokens. Amounts never pass through float64:
+// they are kept as arbitrary precision decimals, marshaled to JSON as strings, stored as Postgres
+// numeric and sent to the chain as integer base units (wei)
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrorInvalidAmount    = errors.New("invalid amount")
+	ErrorPrecision        = errors.New("amount has more decimals than the currency supports")
+	ErrorCurrencyMismatch = errors.New("amounts currencies mismatch")
+)
+
+// maxExponent limits exponent of the parsed numbers, so "1e999999999" does not allocate gigabytes
+const maxExponent = 1000
+
+// Decimal is an arbitrary precision decimal number, coef * 10^-scale. Zero value is 0.
+// Decimal is immutable, every operation returns a new value
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+// NewDecimal returns coef * 10^-scale
+func NewDecimal(coef *big.Int, scale int32) Decimal {
+	if coef == nil {
+		return Decimal{}
+	}
+
+	c := new(big.Int).Set(coef)
+
+	if scale < 0 {
+		c.Mul(c, pow10(-scale))
+		scale = 0
+	}
+
+	return Decimal{coef: c, scale: scale}
+}
+
+func NewFromInt(v int64) Decimal {
+	return Decimal{coef: big.NewInt(v)}
+}
+
+// ParseDecimal parses decimal notation with optional sign and exponent, e.g. "-12.5" or "1.5e-3"
+func ParseDecimal(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	mantissa := str
+
+	var exp int64
+
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil || e > maxExponent || e < -maxExponent {
+			return Decimal{}, fmt.Errorf("error parse exponent of %q. %w", s, ErrorInvalidAmount)
+		}
+
+		mantissa, exp = str[:i], e
+	}
+
+	// one sign at most, digits check below rejects "-+5" and "--5"
+	neg := strings.HasPrefix(mantissa, "-")
+	if neg || strings.HasPrefix(mantissa, "+") {
+		mantissa = mantissa[1:]
+	}
+
+	intPart, fracPart := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		intPart, fracPart = mantissa[:i], mantissa[i+1:]
+	}
+
+	digits := intPart + fracPart
+
+	if digits == "" || len(fracPart) > maxExponent || strings.IndexFunc(digits, notDigit) >= 0 {
+		return Decimal{}, fmt.Errorf("error parse %q. %w", s, ErrorInvalidAmount)
+	}
+
+	coef, _ := new(big.Int).SetString(digits, 10)
+
+	if neg {
+		coef.Neg(coef)
+	}
+
+	return NewDecimal(coef, int32(int64(len(fracPart))-exp)), nil
+}
+
+// MustParseDecimal panics if s is not a decimal. Used for constants
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+func notDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+
+	return d.coef
+}
+
+// align returns coefficients of both numbers at the same scale
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	ac, bc := a.int(), b.int()
+
+	switch {
+	case a.scale > b.scale:
+		bc = new(big.Int).Mul(bc, pow10(a.scale-b.scale))
+		return ac, bc, a.scale
+	case a.scale < b.scale:
+		ac = new(big.Int).Mul(ac, pow10(b.scale-a.scale))
+		return ac, bc, b.scale
+	default:
+		return ac, bc, a.scale
+	}
+}
+
+// String returns plain decimal notation without trailing fractional zeros
+func (d Decimal) String() string {
+	c := d.int()
+	if c.Sign() == 0 {
+		return "0"
+	}
+
+	digits := new(big.Int).Abs(c).String()
+
+	if d.scale > 0 {
+		if pad := int(d.scale) - len(digits) + 1; pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+
+		point := len(digits) - int(d.scale)
+		digits = strings.TrimRight(digits[:point]+"."+digits[point:], "0")
+		digits = strings.TrimSuffix(digits, ".")
+	}
+
+	if c.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp returns -1, 0 or +1 if d is less than, equal to or greater than o
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _ := align(d, o)
+
+	return a.Cmp(b)
+}
+
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b, scale := align(d, o)
+
+	return Decimal{coef: new(big.Int).Add(a, b), scale: scale}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b, scale := align(d, o)
+
+	return Decimal{coef: new(big.Int).Sub(a, b), scale: scale}
+}
+
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), o.int()), scale: d.scale + o.scale}
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Shift returns d * 10^n
+func (d Decimal) Shift(n int32) Decimal {
+	return NewDecimal(d.int(), d.scale-n)
+}
+
+// Int returns d as integer. ok is false if d has fractional part
+func (d Decimal) Int() (i *big.Int, ok bool) {
+	if d.scale == 0 {
+		return new(big.Int).Set(d.int()), true
+	}
+
+	q, r := new(big.Int).QuoRem(d.int(), pow10(d.scale), new(big.Int))
+
+	return q, r.Sign() == 0
+}
+
+// Round returns d rounded half away from zero to the given number of decimal places
+func (d Decimal) Round(places int32) Decimal {
+	if d.scale <= places {
+		return d
+	}
+
+	unit := pow10(d.scale - places)
+	q, r := new(big.Int).QuoRem(d.int(), unit, new(big.Int))
+
+	if r.Abs(r).Lsh(r, 1).Cmp(unit) >= 0 {
+		q.Add(q, big.NewInt(int64(d.Sign())))
+	}
+
+	return NewDecimal(q, places)
+}
+
+func (d Decimal) IsInteger() bool {
+	_, ok := d.Int()
+
+	return ok
+}
+
+// MarshalJSON marshals d as a string, so JSON clients do not round it to float
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON accepts both strings and numbers. Numbers are parsed from their text, so they are exact too
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	str := string(data)
+
+	if str == "null" {
+		*d = Decimal{}
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(str); err == nil {
+		str = unquoted
+	}
+
+	parsed, err := ParseDecimal(str)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+// Scan reads Postgres numeric. NULL is scanned as zero
+func (d *Decimal) Scan(src any) error {
+	var (
+		parsed Decimal
+		err    error
+	)
+
+	switch v := src.(type) {
+	case nil:
+		parsed = Decimal{}
+	case []byte:
+		parsed, err = ParseDecimal(string(v))
+	case string:
+		parsed, err = ParseDecimal(v)
+	case int64:
+		parsed = NewFromInt(v)
+	default:
+		return fmt.Errorf("error scan %T into decimal. %w", src, ErrorInvalidAmount)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+// Value writes d as Postgres numeric
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
@@ -0,0 +1,276 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "0", want: "0"},
+		{in: "-0", want: "0"},
+		{in: "12.5", want: "12.5"},
+		{in: "+12.5", want: "12.5"},
+		{in: "-12.5", want: "-12.5"},
+		{in: " 7 ", want: "7"},
+		{in: "1.50000", want: "1.5"},
+		{in: ".5", want: "0.5"},
+		{in: "5.", want: "5"},
+		{in: "0.000000000000000001", want: "0.000000000000000001"},
+		{in: "1.5e-3", want: "0.0015"},
+		{in: "1.5E3", want: "1500"},
+		{in: "-2e+2", want: "-200"},
+		{in: "123456789012345678901234567890.123456789", want: "123456789012345678901234567890.123456789"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := ParseDecimal(tt.in)
+			if err != nil {
+				t.Fatalf("ParseDecimal(%q) error: %v", tt.in, err)
+			}
+
+			if got := d.String(); got != tt.want {
+				t.Fatalf("ParseDecimal(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDecimalInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		" ",
+		"-",
+		"+",
+		".",
+		"-+5",
+		"+-5",
+		"--5",
+		"++5",
+		"5-",
+		"1.2.3",
+		"1,5",
+		"abc",
+		"0x10",
+		"1e",
+		"1e--3",
+		"1e2.5",
+		"e5",
+		"1e1001",
+		"1e-1001",
+		"NaN",
+		"Inf",
+	}
+
+	for _, in := range tests {
+		t.Run(in, func(t *testing.T) {
+			d, err := ParseDecimal(in)
+			if !errors.Is(err, ErrorInvalidAmount) {
+				t.Fatalf("ParseDecimal(%q) = %s, %v, want ErrorInvalidAmount", in, d, err)
+			}
+		})
+	}
+}
+
+func TestDecimalRoundTrip(t *testing.T) {
+	tests := []string{
+		"0",
+		"1",
+		"-1",
+		"0.1",
+		"-0.000001",
+		"1000000",
+		"3.14159265358979323846264338327950288",
+		"-9223372036854775808",
+		"9223372036854775808",
+		"18446744073709551616.000000000000000001",
+	}
+
+	for _, in := range tests {
+		t.Run(in, func(t *testing.T) {
+			d := MustParseDecimal(in)
+
+			again, err := ParseDecimal(d.String())
+			if err != nil {
+				t.Fatalf("ParseDecimal(%q) error: %v", d.String(), err)
+			}
+
+			if d.String() != in || !again.Equal(d) {
+				t.Fatalf("round trip of %q = %s", in, again)
+			}
+		})
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, b := MustParseDecimal("1.25"), MustParseDecimal("-0.005")
+
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{name: "add", got: a.Add(b), want: "1.245"},
+		{name: "sub", got: a.Sub(b), want: "1.255"},
+		{name: "mul", got: a.Mul(b), want: "-0.00625"},
+		{name: "neg", got: b.Neg(), want: "0.005"},
+		{name: "shift left", got: a.Shift(3), want: "1250"},
+		{name: "shift right", got: a.Shift(-3), want: "0.00125"},
+		{name: "round half up", got: MustParseDecimal("2.345").Round(2), want: "2.35"},
+		{name: "round half away from zero", got: MustParseDecimal("-2.345").Round(2), want: "-2.35"},
+		{name: "round down", got: MustParseDecimal("2.344").Round(2), want: "2.34"},
+		{name: "zero value", got: Decimal{}.Add(a), want: "1.25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.50", b: "1.5", want: 0},
+		{a: "-1", b: "0.0001", want: -1},
+		{a: "10", b: "9.999999999999999999", want: 1},
+		{a: "-10", b: "-9", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := MustParseDecimal(tt.a).Cmp(MustParseDecimal(tt.b)); got != tt.want {
+				t.Fatalf("Cmp(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalBeyondInt64(t *testing.T) {
+	maxInt := NewFromInt(math.MaxInt64)
+	minInt := NewFromInt(math.MinInt64)
+
+	over := maxInt.Add(NewFromInt(1))
+	under := minInt.Sub(NewFromInt(1))
+
+	if got, want := over.String(), "9223372036854775808"; got != want {
+		t.Fatalf("MaxInt64 + 1 = %s, want %s", got, want)
+	}
+
+	if got, want := under.String(), "-9223372036854775809"; got != want {
+		t.Fatalf("MinInt64 - 1 = %s, want %s", got, want)
+	}
+
+	if got, want := maxInt.Mul(maxInt).String(), "85070591730234615847396907784232501249"; got != want {
+		t.Fatalf("MaxInt64^2 = %s, want %s", got, want)
+	}
+
+	i, ok := over.Int()
+	if !ok || i.IsInt64() || i.Cmp(new(big.Int).Add(big.NewInt(math.MaxInt64), big.NewInt(1))) != 0 {
+		t.Fatalf("Int() of %s = %s, %t", over, i, ok)
+	}
+
+	if _, ok := MustParseDecimal("1.5").Int(); ok {
+		t.Fatalf("Int() of fractional decimal is ok")
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: `"12.5"`, want: "12.5"},
+		{in: `12.5`, want: "12.5"},
+		{in: `"-0.000000000000000001"`, want: "-0.000000000000000001"},
+		{in: `123456789012345678901234567890`, want: "123456789012345678901234567890"},
+		{in: `null`, want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var d Decimal
+
+			if err := json.Unmarshal([]byte(tt.in), &d); err != nil {
+				t.Fatalf("unmarshal %s error: %v", tt.in, err)
+			}
+
+			if got := d.String(); got != tt.want {
+				t.Fatalf("unmarshal %s = %s, want %s", tt.in, got, tt.want)
+			}
+
+			out, err := json.Marshal(d)
+			if err != nil {
+				t.Fatalf("marshal %s error: %v", d, err)
+			}
+
+			if got, want := string(out), strconv.Quote(tt.want); got != want {
+				t.Fatalf("marshal %s = %s, want %s", d, got, want)
+			}
+		})
+	}
+
+	for _, in := range []string{`"-+5"`, `"abc"`, `true`, `{}`} {
+		var d Decimal
+
+		if err := json.Unmarshal([]byte(in), &d); err == nil {
+			t.Fatalf("unmarshal %s = %s, want error", in, d)
+		}
+	}
+}
+
+func TestDecimalSQL(t *testing.T) {
+	tests := []struct {
+		name string
+		src  any
+		want string
+	}{
+		{name: "nil", src: nil, want: "0"},
+		{name: "bytes", src: []byte("1234.5678"), want: "1234.5678"},
+		{name: "string", src: "-0.01", want: "-0.01"},
+		{name: "int64", src: int64(math.MinInt64), want: "-9223372036854775808"},
+		{name: "numeric beyond int64", src: []byte("99999999999999999999.999999999999999999"), want: "99999999999999999999.999999999999999999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Decimal
+
+			if err := d.Scan(tt.src); err != nil {
+				t.Fatalf("Scan(%v) error: %v", tt.src, err)
+			}
+
+			v, err := d.Value()
+			if err != nil {
+				t.Fatalf("Value() error: %v", err)
+			}
+
+			if v != tt.want {
+				t.Fatalf("Scan(%v) then Value() = %v, want %s", tt.src, v, tt.want)
+			}
+		})
+	}
+
+	var d Decimal
+
+	if err := d.Scan(1.5); !errors.Is(err, ErrorInvalidAmount) {
+		t.Fatalf("Scan(float64) error = %v, want ErrorInvalidAmount", err)
+	}
+
+	if err := d.Scan([]byte("--1")); !errors.Is(err, ErrorInvalidAmount) {
+		t.Fatalf("Scan(--1) error = %v, want ErrorInvalidAmount", err)
+	}
+}
@@ -0,0 +1,141 @@
+// Package pdf writes plain text PDF documents. Text is set in the built-in Courier font on A4 pages,
+// which is enough for tabular reports, so the backend does not depend on a layout engine
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth  = 595
+	pageHeight = 842
+	margin     = 40
+	fontSize   = 9
+	leading    = 12
+
+	// LineWidth is the number of characters fitting the page width. Longer lines are truncated
+	LineWidth = (pageWidth - 2*margin) * 10 / (fontSize * 6)
+
+	// linesPerPage leaves room for the page footer
+	linesPerPage = (pageHeight-2*margin)/leading - 2
+)
+
+// Document is a text document built line by line
+type Document struct {
+	title string
+	lines []string
+}
+
+func New(title string) *Document {
+	return &Document{
+		title: title,
+	}
+}
+
+// Line adds a line of text. Non-ASCII characters are replaced, since the standard font is not embedded
+func (d *Document) Line(format string, args ...any) {
+	line := fmt.Sprintf(format, args...)
+
+	for _, l := range strings.Split(line, "\n") {
+		if len(l) > LineWidth {
+			l = l[:LineWidth]
+		}
+
+		d.lines = append(d.lines, l)
+	}
+}
+
+// Bytes renders the document. Every page has the title and the page number in the footer
+func (d *Document) Bytes() []byte {
+	pages := make([][]string, 0, len(d.lines)/linesPerPage+1)
+
+	for start := 0; start < len(d.lines) || len(pages) == 0; start += linesPerPage {
+		end := min(start+linesPerPage, len(d.lines))
+		pages = append(pages, d.lines[start:end])
+	}
+
+	// objects 1-3 are catalog, pages tree and font, every page takes a page object and a content stream
+	objects := make([]string, 3, 3+2*len(pages))
+	kids := make([]string, len(pages))
+
+	for n, lines := range pages {
+		pageID := 4 + 2*n
+		kids[n] = fmt.Sprintf("%d 0 R", pageID)
+
+		var content strings.Builder
+
+		fmt.Fprintf(&content, "BT /F1 %d Tf %d TL %d %d Td\n", fontSize, leading, margin, pageHeight-margin)
+
+		for _, l := range lines {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", escape(l))
+		}
+
+		fmt.Fprintf(
+			&content,
+			"ET\nBT /F1 %d Tf %d %d Td (%s) Tj ET\n",
+			fontSize,
+			margin,
+			margin/2,
+			escape(fmt.Sprintf("%s - page %d of %d", d.title, n+1, len(pages))),
+		)
+
+		objects = append(
+			objects,
+			fmt.Sprintf(
+				"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				pageWidth,
+				pageHeight,
+				pageID+1,
+			),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		)
+	}
+
+	objects[0] = "<< /Type /Catalog /Pages 2 0 R >>"
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+	objects[2] = "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>"
+
+	var out bytes.Buffer
+
+	out.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+
+	for n, obj := range objects {
+		offsets[n] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", n+1, obj)
+	}
+
+	xref := out.Len()
+
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return out.Bytes()
+}
+
+// escape escapes string delimiters and replaces characters the standard font encoding lacks
+func escape(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package prices
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/chainapi"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type chainAPISource struct {
+	client *chainapi.Client
+	feed   common.Address
+}
+
+// NewChainAPISource returns source reading ETH price in USD from the price feed of the payroll contract
+// deployed at the feed address. Other pairs are unavailable
+func NewChainAPISource(client *chainapi.Client, feed common.Address) Source {
+	return &chainAPISource{
+		client: client,
+		feed:   feed,
+	}
+}
+
+func (s *chainAPISource) Kind() string {
+	return KindChainAPI
+}
+
+func (s *chainAPISource) Price(ctx context.Context, base, quote string) (*Quote, error) {
+	if base != money.ETH.Code || quote != money.USD.Code {
+		return nil, fmt.Errorf("error %s/%s is not priced by the chain-api. %w", base, quote, ErrorPriceUnavailable)
+	}
+
+	if s.feed == (common.Address{}) {
+		return nil, fmt.Errorf("error price feed address is not configured. %w", ErrorPriceUnavailable)
+	}
+
+	// price feed is read only, so the request is not signed
+	rate, err := s.client.USDTPrice(ctx, nil, s.feed)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch price from the chain-api. %w", err)
+	}
+
+	if rate.Sign() <= 0 {
+		return nil, fmt.Errorf("error chain-api returned price %s. %w", rate, ErrorPriceUnavailable)
+	}
+
+	return &Quote{
+		Base:       base,
+		Quote:      quote,
+		Rate:       rate,
+		Provider:   KindChainAPI,
+		ObservedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+)
+
+type fixtureSource struct {
+	rates map[string]money.Decimal
+}
+
+// NewFixtureSource returns source serving fixed rates keyed by the currency pair, e.g. "ETH/USD"
+func NewFixtureSource(rates map[string]money.Decimal) Source {
+	normalized := make(map[string]money.Decimal, len(rates))
+
+	for pair, rate := range rates {
+		normalized[strings.ToUpper(pair)] = rate
+	}
+
+	return &fixtureSource{
+		rates: normalized,
+	}
+}
+
+// LoadFixture reads fixture source rates from the JSON file, e.g. {"ETH/USD": "3000", "USDC/USD": "1"}
+func LoadFixture(path string) (Source, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error read prices fixture. %w", err)
+	}
+
+	rates := make(map[string]money.Decimal)
+
+	if err = json.Unmarshal(raw, &rates); err != nil {
+		return nil, fmt.Errorf("error parse prices fixture. %w", err)
+	}
+
+	for pair, rate := range rates {
+		if len(strings.Split(pair, "/")) != 2 || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("error invalid prices fixture rate %s = %s", pair, rate)
+		}
+	}
+
+	return NewFixtureSource(rates), nil
+}
+
+func (s *fixtureSource) Kind() string {
+	return KindFixture
+}
+
+func (s *fixtureSource) Price(_ context.Context, base, quote string) (*Quote, error) {
+	rate, ok := s.rates[strings.ToUpper(base+"/"+quote)]
+	if !ok {
+		return nil, fmt.Errorf("error %s/%s is not in the fixture. %w", base, quote, ErrorPriceUnavailable)
+	}
+
+	return &Quote{
+		Base:       base,
+		Quote:      quote,
+		Rate:       rate,
+		Provider:   KindFixture,
+		ObservedAt: time.Now(),
+	}, nil
+}
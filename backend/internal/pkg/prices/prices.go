@@ -0,0 +1,39 @@
+// Package prices provides exchange rates of currencies and tokens. Sources are pluggable: chain-api source
+// reads ETH price from the payroll contract price feed, fixture source serves rates from a JSON file,
+// so the backend can be run and tested offline
+package prices
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+)
+
+var (
+	// ErrorPriceUnavailable the source has no rate of the currency pair
+	ErrorPriceUnavailable = errors.New("price is unavailable")
+)
+
+const (
+	KindChainAPI = "chain-api"
+	KindFixture  = "fixture"
+)
+
+// Quote is the rate of one base currency unit in the quote currency
+type Quote struct {
+	Base       string
+	Quote      string
+	Rate       money.Decimal
+	Provider   string
+	ObservedAt time.Time
+}
+
+// Source returns current exchange rates
+type Source interface {
+	Kind() string
+	// Price returns current rate of the base currency in the quote currency.
+	// Returns ErrorPriceUnavailable if the source does not price the pair
+	Price(ctx context.Context, base, quote string) (*Quote, error)
+}
@@ -0,0 +1,332 @@
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	ErrorInvalidKey   = errors.New("invalid master key")
+	ErrorUnknownKey   = errors.New("unknown master key")
+	ErrorNotEncrypted = errors.New("value is not encrypted")
+	ErrorDecrypt      = errors.New("error decrypt value")
+)
+
+const (
+	keySize     = 32
+	keyIDSize   = 4
+	nonceSize   = 12
+	version     = 1
+	wrappedSize = nonceSize + keySize + 16
+)
+
+// magic prefixes every envelope. Plaintext mnemonics are printable and never start with zero byte
+var magic = []byte{0x00, 'b', 'k', 'e'}
+
+// MasterKey is a 256 bit key encrypting data keys. Data and lookup subkeys are derived from it
+type MasterKey struct {
+	ID string
+
+	wrap   cipher.AEAD
+	lookup []byte
+}
+
+// NewMasterKey builds master key from 32 raw bytes
+func NewMasterKey(raw []byte) (*MasterKey, error) {
+	if len(raw) != keySize {
+		return nil, fmt.Errorf("error master key must be %d bytes, got %d. %w", keySize, len(raw), ErrorInvalidKey)
+	}
+
+	wrap, err := newAEAD(derive(raw, "blockd wrap key"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &MasterKey{
+		ID:     hex.EncodeToString(derive(raw, "blockd key id")[:keyIDSize]),
+		wrap:   wrap,
+		lookup: derive(raw, "blockd lookup key"),
+	}, nil
+}
+
+// ParseMasterKey decodes base64 encoded master key
+func ParseMasterKey(s string) (*MasterKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("error decode master key. %w", ErrorInvalidKey)
+	}
+
+	return NewMasterKey(raw)
+}
+
+// LoadMasterKey reads base64 encoded master key from file
+func LoadMasterKey(path string) (*MasterKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error read master key file. %w", err)
+	}
+
+	return ParseMasterKey(string(data))
+}
+
+// Keeper envelope encrypts key material at rest. Every value is encrypted with its own random data key,
+// the data key is encrypted with the active master key. Previous master keys are kept for decryption
+// until all records are rewrapped with the active one
+type Keeper struct {
+	active *MasterKey
+	keys   map[string]*MasterKey
+	order  []string
+}
+
+func NewKeeper(active *MasterKey, previous ...*MasterKey) (*Keeper, error) {
+	if active == nil {
+		return nil, fmt.Errorf("error master key required. %w", ErrorInvalidKey)
+	}
+
+	k := &Keeper{
+		active: active,
+		keys:   make(map[string]*MasterKey, len(previous)+1),
+	}
+
+	for _, mk := range append([]*MasterKey{active}, previous...) {
+		if mk == nil {
+			continue
+		}
+
+		if _, ok := k.keys[mk.ID]; ok {
+			continue
+		}
+
+		k.keys[mk.ID] = mk
+		k.order = append(k.order, mk.ID)
+	}
+
+	return k, nil
+}
+
+// ActiveKeyID returns id of the master key used for encryption
+func (k *Keeper) ActiveKeyID() string {
+	return k.active.ID
+}
+
+// Encrypt returns envelope of the value. Empty value stays empty
+func (k *Keeper) Encrypt(plain []byte) ([]byte, error) {
+	if len(plain) == 0 {
+		return nil, nil
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("error generate data key. %w", err)
+	}
+
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, err := seal(k.active.wrap, dataKey, []byte(k.active.ID))
+	if err != nil {
+		return nil, fmt.Errorf("error wrap data key. %w", err)
+	}
+
+	ciphertext, err := seal(data, plain, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypt value. %w", err)
+	}
+
+	out := make([]byte, 0, len(magic)+2+keyIDSize+len(wrapped)+len(ciphertext))
+
+	out = append(out, magic...)
+	out = append(out, version)
+	out = append(out, byte(len(k.active.ID)))
+	out = append(out, k.active.ID...)
+	out = append(out, wrapped...)
+	out = append(out, ciphertext...)
+
+	return out, nil
+}
+
+// Decrypt opens envelope. Empty value stays empty, value without envelope returns ErrorNotEncrypted
+func (k *Keeper) Decrypt(blob []byte) ([]byte, error) {
+	if len(blob) == 0 {
+		return nil, nil
+	}
+
+	env, err := parse(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	mk, ok := k.keys[env.keyID]
+	if !ok {
+		return nil, fmt.Errorf("error decrypt value with key %s. %w", env.keyID, ErrorUnknownKey)
+	}
+
+	dataKey, err := open(mk.wrap, env.wrapped, []byte(env.keyID))
+	if err != nil {
+		return nil, fmt.Errorf("error unwrap data key. %w", err)
+	}
+
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := open(data, env.ciphertext, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return plain, nil
+}
+
+// Rewrap encrypts data key of the envelope with the active master key, the value itself is not reencrypted.
+// Reports whether envelope has been changed
+func (k *Keeper) Rewrap(blob []byte) ([]byte, bool, error) {
+	env, err := parse(blob)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if env.keyID == k.active.ID {
+		return blob, false, nil
+	}
+
+	mk, ok := k.keys[env.keyID]
+	if !ok {
+		return nil, false, fmt.Errorf("error rewrap value with key %s. %w", env.keyID, ErrorUnknownKey)
+	}
+
+	dataKey, err := open(mk.wrap, env.wrapped, []byte(env.keyID))
+	if err != nil {
+		return nil, false, fmt.Errorf("error unwrap data key. %w", err)
+	}
+
+	wrapped, err := seal(k.active.wrap, dataKey, []byte(k.active.ID))
+	if err != nil {
+		return nil, false, fmt.Errorf("error wrap data key. %w", err)
+	}
+
+	out := make([]byte, 0, len(blob))
+
+	out = append(out, magic...)
+	out = append(out, version)
+	out = append(out, byte(len(k.active.ID)))
+	out = append(out, k.active.ID...)
+	out = append(out, wrapped...)
+	out = append(out, env.ciphertext...)
+
+	return out, true, nil
+}
+
+// Digest returns keyed digest of the value under the active master key. Used to look up encrypted values
+func (k *Keeper) Digest(plain []byte) []byte {
+	return digest(k.active, plain)
+}
+
+// Digests returns digests of the value under every known master key, the active key first.
+// Lookups match records not yet rewrapped after rotation
+func (k *Keeper) Digests(plain []byte) [][]byte {
+	out := make([][]byte, len(k.order))
+
+	for i, id := range k.order {
+		out[i] = digest(k.keys[id], plain)
+	}
+
+	return out
+}
+
+// IsEncrypted reports whether value is an envelope
+func IsEncrypted(blob []byte) bool {
+	_, err := parse(blob)
+	return err == nil
+}
+
+type envelope struct {
+	keyID      string
+	wrapped    []byte
+	ciphertext []byte
+}
+
+func parse(blob []byte) (*envelope, error) {
+	if !bytes.HasPrefix(blob, magic) || len(blob) < len(magic)+2 || blob[len(magic)] != version {
+		return nil, ErrorNotEncrypted
+	}
+
+	rest := blob[len(magic)+1:]
+
+	idLen := int(rest[0])
+	rest = rest[1:]
+
+	if idLen != keyIDSize*2 || len(rest) < idLen+wrappedSize+nonceSize {
+		return nil, ErrorNotEncrypted
+	}
+
+	return &envelope{
+		keyID:      string(rest[:idLen]),
+		wrapped:    rest[idLen : idLen+wrappedSize],
+		ciphertext: rest[idLen+wrappedSize:],
+	}, nil
+}
+
+func derive(key []byte, label string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(label))
+
+	return mac.Sum(nil)
+}
+
+func digest(mk *MasterKey, plain []byte) []byte {
+	mac := hmac.New(sha256.New, mk.lookup)
+	mac.Write(plain)
+
+	return mac.Sum(nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error create cipher. %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error create gcm. %w", err)
+	}
+
+	return aead, nil
+}
+
+// seal returns nonce || ciphertext
+func seal(aead cipher.AEAD, plain, ad []byte) ([]byte, error) {
+	nonce := make([]byte, nonceSize, nonceSize+len(plain)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("error generate nonce. %w", err)
+	}
+
+	return aead.Seal(nonce, nonce, plain, ad), nil
+}
+
+func open(aead cipher.AEAD, sealed, ad []byte) ([]byte, error) {
+	if len(sealed) < nonceSize {
+		return nil, ErrorDecrypt
+	}
+
+	plain, err := aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], ad)
+	if err != nil {
+		return nil, ErrorDecrypt
+	}
+
+	return plain, nil
+}
@@ -0,0 +1,183 @@
+package secrets
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func newMasterKey(t *testing.T) *MasterKey {
+	t.Helper()
+
+	raw := make([]byte, keySize)
+	if _, err := rand.Read(raw); err != nil {
+		t.Fatalf("generate master key error: %v", err)
+	}
+
+	mk, err := NewMasterKey(raw)
+	if err != nil {
+		t.Fatalf("NewMasterKey() error: %v", err)
+	}
+
+	return mk
+}
+
+func newKeeper(t *testing.T, active *MasterKey, previous ...*MasterKey) *Keeper {
+	t.Helper()
+
+	k, err := NewKeeper(active, previous...)
+	if err != nil {
+		t.Fatalf("NewKeeper() error: %v", err)
+	}
+
+	return k
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	k := newKeeper(t, newMasterKey(t))
+	plain := []byte("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+
+	blob, err := k.Encrypt(plain)
+	if err != nil {
+		t.Fatalf("Encrypt() error: %v", err)
+	}
+
+	if !IsEncrypted(blob) || bytes.Contains(blob, plain) {
+		t.Fatalf("Encrypt() = %x is not an envelope of the value", blob)
+	}
+
+	// every value has its own data key and nonce
+	again, err := k.Encrypt(plain)
+	if err != nil {
+		t.Fatalf("Encrypt() error: %v", err)
+	}
+
+	if bytes.Equal(blob, again) {
+		t.Fatalf("Encrypt() of the same value returned the same envelope")
+	}
+
+	decrypted, err := k.Decrypt(blob)
+	if err != nil {
+		t.Fatalf("Decrypt() error: %v", err)
+	}
+
+	if !bytes.Equal(decrypted, plain) {
+		t.Fatalf("Decrypt() = %q, want %q", decrypted, plain)
+	}
+
+	if empty, err := k.Encrypt(nil); err != nil || empty != nil {
+		t.Fatalf("Encrypt(nil) = %x, %v, want empty", empty, err)
+	}
+}
+
+func TestDecryptErrors(t *testing.T) {
+	k := newKeeper(t, newMasterKey(t))
+
+	blob, err := k.Encrypt([]byte{1, 2, 3})
+	if err != nil {
+		t.Fatalf("Encrypt() error: %v", err)
+	}
+
+	if _, err = k.Decrypt([]byte("abandon about")); !errors.Is(err, ErrorNotEncrypted) {
+		t.Fatalf("Decrypt() of plaintext error = %v, want ErrorNotEncrypted", err)
+	}
+
+	tampered := bytes.Clone(blob)
+	tampered[len(tampered)-1] ^= 1
+
+	if _, err = k.Decrypt(tampered); !errors.Is(err, ErrorDecrypt) {
+		t.Fatalf("Decrypt() of tampered envelope error = %v, want ErrorDecrypt", err)
+	}
+
+	if _, err = newKeeper(t, newMasterKey(t)).Decrypt(blob); !errors.Is(err, ErrorUnknownKey) {
+		t.Fatalf("Decrypt() with another master key error = %v, want ErrorUnknownKey", err)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	var (
+		previous = newMasterKey(t)
+		active   = newMasterKey(t)
+		plain    = []byte{1, 2, 3}
+	)
+
+	blob, err := newKeeper(t, previous).Encrypt(plain)
+	if err != nil {
+		t.Fatalf("Encrypt() error: %v", err)
+	}
+
+	k := newKeeper(t, active, previous)
+
+	if decrypted, err := k.Decrypt(blob); err != nil || !bytes.Equal(decrypted, plain) {
+		t.Fatalf("Decrypt() with previous master key = %x, %v, want %x", decrypted, err, plain)
+	}
+
+	rewrapped, changed, err := k.Rewrap(blob)
+	if err != nil || !changed {
+		t.Fatalf("Rewrap() changed = %t, error: %v", changed, err)
+	}
+
+	// rewrapped envelope is opened by the active key only
+	if decrypted, err := newKeeper(t, active).Decrypt(rewrapped); err != nil || !bytes.Equal(decrypted, plain) {
+		t.Fatalf("Decrypt() of rewrapped envelope = %x, %v, want %x", decrypted, err, plain)
+	}
+
+	if _, changed, err = k.Rewrap(rewrapped); err != nil || changed {
+		t.Fatalf("Rewrap() of envelope under the active key changed = %t, error: %v", changed, err)
+	}
+}
+
+func TestDigests(t *testing.T) {
+	var (
+		previous = newMasterKey(t)
+		active   = newMasterKey(t)
+		seed     = []byte{1, 2, 3}
+	)
+
+	k := newKeeper(t, active, previous)
+
+	// seed stored before rotation was looked up by the previous key digest
+	stored := newKeeper(t, previous).Digest(seed)
+
+	digests := k.Digests(seed)
+
+	if len(digests) != 2 {
+		t.Fatalf("Digests() returned %d digests, want 2", len(digests))
+	}
+
+	if !bytes.Equal(digests[0], k.Digest(seed)) {
+		t.Fatalf("Digests() first digest is not the active key one")
+	}
+
+	if !bytes.Equal(digests[1], stored) {
+		t.Fatalf("Digests() does not match seed stored under the previous key")
+	}
+
+	if bytes.Equal(digests[0], digests[1]) {
+		t.Fatalf("Digests() are equal for different master keys")
+	}
+
+	if bytes.Equal(k.Digest([]byte{1, 2, 4}), digests[0]) {
+		t.Fatalf("Digest() is equal for different seeds")
+	}
+}
+
+func TestParseMasterKey(t *testing.T) {
+	mk, err := ParseMasterKey("AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=\n")
+	if err != nil {
+		t.Fatalf("ParseMasterKey() error: %v", err)
+	}
+
+	if len(mk.ID) != keyIDSize*2 {
+		t.Fatalf("master key id = %q, want %d hex chars", mk.ID, keyIDSize*2)
+	}
+
+	if _, err = ParseMasterKey("AAAA"); !errors.Is(err, ErrorInvalidKey) {
+		t.Fatalf("ParseMasterKey() of short key error = %v, want ErrorInvalidKey", err)
+	}
+
+	if _, err = ParseMasterKey("not base64"); !errors.Is(err, ErrorInvalidKey) {
+		t.Fatalf("ParseMasterKey() of invalid base64 error = %v, want ErrorInvalidKey", err)
+	}
+}
@@ -0,0 +1,371 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/chainapi"
+	"github.com/emochka2007/block-accounting/internal/pkg/hdwallet"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// revertReasons are multisig revert reasons reported with chainapi errors
+var revertReasons = []error{
+	chainapi.ErrorTxAlreadyConfirmed,
+	chainapi.ErrorTxNotConfirmed,
+	chainapi.ErrorTxDoesNotExist,
+	chainapi.ErrorNotOwner,
+}
+
+// Backend is an ethereum node the local signer sends transactions to. Satisfied by *ethclient.Client
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+type localProvider struct {
+	log     *slog.Logger
+	backend Backend
+	chainID *big.Int
+
+	mu sync.Mutex
+	// nonces serializes transactions of the wallet, so concurrent sends do not reuse the pending nonce
+	nonces map[common.Address]*sync.Mutex
+}
+
+// NewLocalProvider returns provider of signers signing EIP-155 transactions in process
+func NewLocalProvider(log *slog.Logger, backend Backend, chainID int64) Provider {
+	return &localProvider{
+		log:     log,
+		backend: backend,
+		chainID: big.NewInt(chainID),
+		nonces:  make(map[common.Address]*sync.Mutex),
+	}
+}
+
+func (p *localProvider) Kind() string {
+	return KindLocal
+}
+
+func (p *localProvider) Signer(seed []byte) (Signer, error) {
+	wallet, account, err := deriveAccount(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &localSigner{
+		provider: p,
+		wallet:   wallet,
+		account:  account,
+	}, nil
+}
+
+// Address returns address of the wallet account derived from the seed, the same the signers send transactions from
+func Address(seed []byte) (common.Address, error) {
+	_, account, err := deriveAccount(seed)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return account.Address, nil
+}
+
+// deriveAccount derives the first account of the default path, the same chain-api derives
+func deriveAccount(seed []byte) (*hdwallet.Wallet, accounts.Account, error) {
+	if len(seed) == 0 {
+		return nil, accounts.Account{}, ErrorNoSeed
+	}
+
+	wallet, err := hdwallet.NewFromSeed(seed)
+	if err != nil {
+		return nil, accounts.Account{}, fmt.Errorf("error create wallet from seed. %w", err)
+	}
+
+	account, err := wallet.Derive(hdwallet.DefaultBaseDerivationPath, true)
+	if err != nil {
+		return nil, accounts.Account{}, fmt.Errorf("error derive wallet account. %w", err)
+	}
+
+	return wallet, account, nil
+}
+
+func (p *localProvider) lock(address common.Address) func() {
+	p.mu.Lock()
+
+	l, ok := p.nonces[address]
+	if !ok {
+		l = new(sync.Mutex)
+		p.nonces[address] = l
+	}
+
+	p.mu.Unlock()
+
+	l.Lock()
+
+	return l.Unlock
+}
+
+type localSigner struct {
+	provider *localProvider
+	wallet   *hdwallet.Wallet
+	account  accounts.Account
+}
+
+func (s *localSigner) SubmitTransaction(
+	ctx context.Context,
+	req chainapi.SubmitTransactionRequest,
+) (*chainapi.SubmitTransactionResponse, error) {
+	value := new(big.Int)
+
+	if req.Value != "" {
+		if _, ok := value.SetString(req.Value, 10); !ok {
+			return nil, fmt.Errorf("error parse transaction value %s", req.Value)
+		}
+	}
+
+	receipt, err := s.send(ctx, req.ContractAddress, "submitTransaction", req.Destination, value, []byte(req.Data))
+	if err != nil {
+		return nil, err
+	}
+
+	event := multisig.Events["SubmitTransaction"]
+
+	log, err := findLog(receipt, req.ContractAddress, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := event.Inputs.NonIndexed().Unpack(log.Data)
+	if err != nil || len(fields) != 2 {
+		return nil, fmt.Errorf("error unpack SubmitTransaction event. %w", err)
+	}
+
+	eventValue, _ := fields[0].(*big.Int)
+	data, _ := fields[1].([]byte)
+
+	resp := &chainapi.SubmitTransactionResponse{
+		TxHash:  receipt.TxHash.Hex(),
+		Sender:  common.BytesToAddress(log.Topics[1].Bytes()),
+		TxIndex: log.Topics[2].Big().Int64(),
+		To:      common.BytesToAddress(log.Topics[3].Bytes()),
+		Data:    data,
+	}
+
+	if eventValue != nil {
+		resp.Value = eventValue.String()
+	}
+
+	return resp, nil
+}
+
+func (s *localSigner) ConfirmTransaction(
+	ctx context.Context,
+	req chainapi.MultisigTxRequest,
+) (*chainapi.TransactionResponse, error) {
+	receipt, err := s.send(ctx, req.ContractAddress, "confirmTransaction", big.NewInt(req.Index))
+	if err != nil {
+		return nil, err
+	}
+
+	return &chainapi.TransactionResponse{
+		TxHash:  receipt.TxHash.Hex(),
+		Sender:  s.account.Address,
+		TxIndex: req.Index,
+	}, nil
+}
+
+func (s *localSigner) RevokeConfirmation(
+	ctx context.Context,
+	req chainapi.MultisigTxRequest,
+) (*chainapi.SentTransaction, error) {
+	receipt, err := s.send(ctx, req.ContractAddress, "revokeConfirmation", big.NewInt(req.Index))
+	if err != nil {
+		return nil, err
+	}
+
+	return &chainapi.SentTransaction{
+		Hash: receipt.TxHash.Hex(),
+		From: s.account.Address,
+		To:   req.ContractAddress,
+	}, nil
+}
+
+func (s *localSigner) ExecuteTransaction(
+	ctx context.Context,
+	req chainapi.ExecuteTransactionRequest,
+) (*chainapi.TransactionResponse, error) {
+	if !req.IsDeploy {
+		receipt, err := s.send(ctx, req.ContractAddress, "executeTransaction", big.NewInt(req.Index))
+		if err != nil {
+			return nil, err
+		}
+
+		if err = executed(receipt, req.ContractAddress); err != nil {
+			return nil, err
+		}
+
+		return &chainapi.TransactionResponse{
+			TxHash:  receipt.TxHash.Hex(),
+			Sender:  s.account.Address,
+			TxIndex: req.Index,
+		}, nil
+	}
+
+	// CREATE2 salt, derived the same way chain-api does
+	input := strconv.FormatInt(req.Index, 10) + strconv.FormatInt(time.Now().UnixMilli(), 10)
+	salt := new(big.Int).SetBytes(crypto.Keccak256([]byte(input))[:4])
+
+	receipt, err := s.send(ctx, req.ContractAddress, "executeDeployTransaction", big.NewInt(req.Index), salt)
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := findLog(receipt, req.ContractAddress, multisig.Events["ContractDeployed"].ID)
+	if err != nil {
+		return nil, err
+	}
+
+	deployed := common.BytesToAddress(log.Topics[1].Bytes())
+
+	return &chainapi.TransactionResponse{
+		TxHash:          receipt.TxHash.Hex(),
+		Sender:          s.account.Address,
+		TxIndex:         req.Index,
+		DeployedAddress: &deployed,
+	}, nil
+}
+
+// send calls the multisig method and waits for the transaction to be mined
+func (s *localSigner) send(
+	ctx context.Context,
+	contract common.Address,
+	method string,
+	args ...any,
+) (*types.Receipt, error) {
+	data, err := multisig.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error pack %s call. %w", method, err)
+	}
+
+	signed, err := s.signAndSend(ctx, contract, data)
+	if err != nil {
+		return nil, fmt.Errorf("error send %s transaction. %w", method, err)
+	}
+
+	s.provider.log.Debug(
+		"transaction sent",
+		slog.String("method", method),
+		slog.String("from", s.account.Address.Hex()),
+		slog.String("hash", signed.Hash().Hex()),
+	)
+
+	receipt, err := bind.WaitMined(ctx, s.provider.backend, signed)
+	if err != nil {
+		return nil, fmt.Errorf("error wait %s transaction %s. %w", method, signed.Hash().Hex(), err)
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("error %s transaction %s failed. %w", method, signed.Hash().Hex(), ErrorReverted)
+	}
+
+	return receipt, nil
+}
+
+func (s *localSigner) signAndSend(ctx context.Context, to common.Address, data []byte) (*types.Transaction, error) {
+	backend := s.provider.backend
+	from := s.account.Address
+
+	unlock := s.provider.lock(from)
+	defer unlock()
+
+	gas, err := backend.EstimateGas(ctx, ethereum.CallMsg{
+		From: from,
+		To:   &to,
+		Data: data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error estimate gas. %w", revertError(err))
+	}
+
+	nonce, err := backend.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch nonce. %w", err)
+	}
+
+	gasPrice, err := backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch gas price. %w", err)
+	}
+
+	tx := types.NewTransaction(nonce, to, new(big.Int), gas, gasPrice, data)
+
+	signed, err := s.wallet.SignTxEIP155(s.account, tx, s.provider.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("error sign transaction. %w", err)
+	}
+
+	if err = backend.SendTransaction(ctx, signed); err != nil {
+		return nil, fmt.Errorf("error send transaction. %w", revertError(err))
+	}
+
+	return signed, nil
+}
+
+// revertError maps node errors of the reverted calls to the chainapi revert reasons
+func revertError(err error) error {
+	if !strings.Contains(err.Error(), "revert") {
+		return err
+	}
+
+	for _, reason := range revertReasons {
+		if strings.Contains(err.Error(), reason.Error()) {
+			return fmt.Errorf("%w: %w. %w", ErrorReverted, reason, err)
+		}
+	}
+
+	return fmt.Errorf("%w. %w", ErrorReverted, err)
+}
+
+// executed checks the multisig has executed the transaction. Inner call reverted without a reason does not revert
+// executeTransaction, the multisig emits ExecuteTransactionFailed instead and the receipt is successful
+func executed(receipt *types.Receipt, contract common.Address) error {
+	if _, err := findLog(receipt, contract, multisig.Events["ExecuteTransaction"].ID); err == nil {
+		return nil
+	}
+
+	failed := multisig.Events["ExecuteTransactionFailed"]
+
+	reason := "ExecuteTransaction event not emitted"
+
+	if log, err := findLog(receipt, contract, failed.ID); err == nil {
+		if fields, err := failed.Inputs.NonIndexed().Unpack(log.Data); err == nil && len(fields) == 1 {
+			reason, _ = fields[0].(string)
+		}
+	}
+
+	return fmt.Errorf("error transaction %s not executed: %s. %w", receipt.TxHash.Hex(), reason, ErrorReverted)
+}
+
+// findLog returns the first event log emitted by the contract. Events of the same signature emitted
+// by other contracts called within the transaction are skipped
+func findLog(receipt *types.Receipt, contract common.Address, id common.Hash) (*types.Log, error) {
+	for _, log := range receipt.Logs {
+		if log.Address == contract && len(log.Topics) > 0 && log.Topics[0] == id {
+			return log, nil
+		}
+	}
+
+	return nil, errors.New("error event log not found in transaction receipt")
+}
@@ -0,0 +1,385 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/chainapi"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const chainID = 80002
+
+var (
+	multisigAddress = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	otherAddress    = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	destination     = common.HexToAddress("0x3000000000000000000000000000000000000003")
+)
+
+// backendStub is a node mining every sent transaction at once. Receipt logs are built by logs
+type backendStub struct {
+	Backend
+
+	mu       sync.Mutex
+	nonce    uint64
+	sent     []*types.Transaction
+	status   uint64
+	logs     func(tx *types.Transaction) []*types.Log
+	estimate error
+}
+
+func (b *backendStub) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	if b.estimate != nil {
+		return 0, b.estimate
+	}
+
+	return 100_000, nil
+}
+
+func (b *backendStub) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.nonce, nil
+}
+
+func (b *backendStub) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return big.NewInt(30_000_000_000), nil
+}
+
+func (b *backendStub) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sent = append(b.sent, tx)
+	b.nonce++
+
+	return nil
+}
+
+func (b *backendStub) TransactionReceipt(_ context.Context, hash common.Hash) (*types.Receipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, tx := range b.sent {
+		if tx.Hash() != hash {
+			continue
+		}
+
+		receipt := &types.Receipt{
+			TxHash: hash,
+			Status: b.status,
+		}
+
+		if b.logs != nil {
+			receipt.Logs = b.logs(tx)
+		}
+
+		return receipt, nil
+	}
+
+	return nil, ethereum.NotFound
+}
+
+func newBackend() *backendStub {
+	return &backendStub{
+		nonce:  7,
+		status: types.ReceiptStatusSuccessful,
+	}
+}
+
+func newSigner(t *testing.T, backend Backend) (Signer, common.Address) {
+	t.Helper()
+
+	seed := make([]byte, 64)
+	seed[0] = 1
+
+	s, err := NewLocalProvider(slog.New(slog.NewTextHandler(io.Discard, nil)), backend, chainID).Signer(seed)
+	if err != nil {
+		t.Fatalf("Signer() error: %v", err)
+	}
+
+	address, err := Address(seed)
+	if err != nil {
+		t.Fatalf("Address() error: %v", err)
+	}
+
+	return s, address
+}
+
+func addressTopic(a common.Address) common.Hash {
+	return common.BytesToHash(a.Bytes())
+}
+
+func submitLog(t *testing.T, contract, owner common.Address, index int64) *types.Log {
+	t.Helper()
+
+	event := multisig.Events["SubmitTransaction"]
+
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(5), []byte{0xca, 0xfe})
+	if err != nil {
+		t.Fatalf("pack SubmitTransaction event error: %v", err)
+	}
+
+	return &types.Log{
+		Address: contract,
+		Topics: []common.Hash{
+			event.ID,
+			addressTopic(owner),
+			common.BigToHash(big.NewInt(index)),
+			addressTopic(destination),
+		},
+		Data: data,
+	}
+}
+
+func executeFailedLog(t *testing.T, contract, owner common.Address, index int64, reason string) *types.Log {
+	t.Helper()
+
+	event := multisig.Events["ExecuteTransactionFailed"]
+
+	data, err := event.Inputs.NonIndexed().Pack(reason)
+	if err != nil {
+		t.Fatalf("pack ExecuteTransactionFailed event error: %v", err)
+	}
+
+	return &types.Log{
+		Address: contract,
+		Topics:  []common.Hash{event.ID, addressTopic(owner), common.BigToHash(big.NewInt(index))},
+		Data:    data,
+	}
+}
+
+func TestSubmitTransactionSignsEIP155(t *testing.T) {
+	backend := newBackend()
+	s, from := newSigner(t, backend)
+
+	backend.logs = func(*types.Transaction) []*types.Log {
+		return []*types.Log{
+			// the same event of another multisig called within the transaction
+			submitLog(t, otherAddress, otherAddress, 99),
+			submitLog(t, multisigAddress, from, 3),
+		}
+	}
+
+	resp, err := s.SubmitTransaction(context.Background(), chainapi.SubmitTransactionRequest{
+		ContractAddress: multisigAddress,
+		Destination:     destination,
+		Value:           "5",
+		Data:            []byte{0xca, 0xfe},
+	})
+	if err != nil {
+		t.Fatalf("SubmitTransaction() error: %v", err)
+	}
+
+	if len(backend.sent) != 1 {
+		t.Fatalf("SubmitTransaction() sent %d transactions, want 1", len(backend.sent))
+	}
+
+	tx := backend.sent[0]
+
+	if !tx.Protected() || tx.ChainId().Int64() != chainID {
+		t.Fatalf("sent transaction is not EIP-155 signed for chain %d, chain id %s", chainID, tx.ChainId())
+	}
+
+	sender, err := types.Sender(types.NewEIP155Signer(big.NewInt(chainID)), tx)
+	if err != nil {
+		t.Fatalf("recover sender error: %v", err)
+	}
+
+	if sender != from {
+		t.Fatalf("transaction signed by %s, want %s", sender.Hex(), from.Hex())
+	}
+
+	if *tx.To() != multisigAddress || tx.Nonce() != 7 || tx.Value().Sign() != 0 || tx.Gas() != 100_000 {
+		t.Fatalf("sent transaction to %s nonce %d value %s gas %d", tx.To().Hex(), tx.Nonce(), tx.Value(), tx.Gas())
+	}
+
+	method, err := multisig.MethodById(tx.Data())
+	if err != nil || method.Name != "submitTransaction" {
+		t.Fatalf("sent transaction calls %v, %v, want submitTransaction", method, err)
+	}
+
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		t.Fatalf("unpack submitTransaction call error: %v", err)
+	}
+
+	if args[0].(common.Address) != destination || args[1].(*big.Int).Int64() != 5 {
+		t.Fatalf("submitTransaction args = %v", args)
+	}
+
+	if resp.TxIndex != 3 || resp.Sender != from || resp.To != destination || resp.Value != "5" ||
+		resp.TxHash != tx.Hash().Hex() || string(resp.Data) != "\xca\xfe" {
+		t.Fatalf("SubmitTransaction() = %+v", resp)
+	}
+}
+
+func TestSubmitTransactionEventOfOtherContract(t *testing.T) {
+	backend := newBackend()
+	s, _ := newSigner(t, backend)
+
+	backend.logs = func(*types.Transaction) []*types.Log {
+		return []*types.Log{submitLog(t, otherAddress, otherAddress, 99)}
+	}
+
+	if _, err := s.SubmitTransaction(context.Background(), chainapi.SubmitTransactionRequest{
+		ContractAddress: multisigAddress,
+		Destination:     destination,
+	}); err == nil {
+		t.Fatalf("SubmitTransaction() accepted event emitted by another contract")
+	}
+}
+
+func TestExecuteTransaction(t *testing.T) {
+	tests := []struct {
+		name    string
+		logs    func(from common.Address) []*types.Log
+		wantErr bool
+	}{
+		{
+			name: "executed",
+			logs: func(from common.Address) []*types.Log {
+				return []*types.Log{{
+					Address: multisigAddress,
+					Topics: []common.Hash{
+						multisig.Events["ExecuteTransaction"].ID,
+						addressTopic(from),
+						common.BigToHash(big.NewInt(2)),
+						addressTopic(destination),
+					},
+				}}
+			},
+		},
+		{
+			name: "inner call failed",
+			logs: func(from common.Address) []*types.Log {
+				return []*types.Log{executeFailedLog(t, multisigAddress, from, 2, "insufficient balance")}
+			},
+			wantErr: true,
+		},
+		{
+			name: "executed by another contract",
+			logs: func(from common.Address) []*types.Log {
+				return []*types.Log{{
+					Address: otherAddress,
+					Topics: []common.Hash{
+						multisig.Events["ExecuteTransaction"].ID,
+						addressTopic(from),
+						common.BigToHash(big.NewInt(2)),
+						addressTopic(destination),
+					},
+				}}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newBackend()
+			s, from := newSigner(t, backend)
+
+			backend.logs = func(*types.Transaction) []*types.Log {
+				return tt.logs(from)
+			}
+
+			resp, err := s.ExecuteTransaction(context.Background(), chainapi.ExecuteTransactionRequest{
+				ContractAddress: multisigAddress,
+				Index:           2,
+			})
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrorReverted) {
+					t.Fatalf("ExecuteTransaction() error = %v, want ErrorReverted", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ExecuteTransaction() error: %v", err)
+			}
+
+			if resp.TxIndex != 2 || resp.Sender != from || resp.DeployedAddress != nil {
+				t.Fatalf("ExecuteTransaction() = %+v", resp)
+			}
+		})
+	}
+}
+
+func TestExecuteDeployTransaction(t *testing.T) {
+	backend := newBackend()
+	s, _ := newSigner(t, backend)
+
+	deployed := common.HexToAddress("0x4000000000000000000000000000000000000004")
+	event := multisig.Events["ContractDeployed"].ID
+
+	backend.logs = func(*types.Transaction) []*types.Log {
+		return []*types.Log{
+			{Address: otherAddress, Topics: []common.Hash{event, addressTopic(otherAddress)}},
+			{Address: multisigAddress, Topics: []common.Hash{event, addressTopic(deployed)}},
+		}
+	}
+
+	resp, err := s.ExecuteTransaction(context.Background(), chainapi.ExecuteTransactionRequest{
+		ContractAddress: multisigAddress,
+		Index:           4,
+		IsDeploy:        true,
+	})
+	if err != nil {
+		t.Fatalf("ExecuteTransaction() error: %v", err)
+	}
+
+	if resp.DeployedAddress == nil || *resp.DeployedAddress != deployed {
+		t.Fatalf("ExecuteTransaction() deployed address = %v, want %s", resp.DeployedAddress, deployed.Hex())
+	}
+
+	method, err := multisig.MethodById(backend.sent[0].Data())
+	if err != nil || method.Name != "executeDeployTransaction" {
+		t.Fatalf("sent transaction calls %v, %v, want executeDeployTransaction", method, err)
+	}
+}
+
+func TestSendReverted(t *testing.T) {
+	backend := newBackend()
+	s, _ := newSigner(t, backend)
+
+	backend.estimate = errors.New("execution reverted: tx already confirmed")
+
+	_, err := s.ConfirmTransaction(context.Background(), chainapi.MultisigTxRequest{
+		ContractAddress: multisigAddress,
+		Index:           1,
+	})
+	if !errors.Is(err, ErrorReverted) || !errors.Is(err, chainapi.ErrorTxAlreadyConfirmed) {
+		t.Fatalf("ConfirmTransaction() error = %v, want ErrorReverted and ErrorTxAlreadyConfirmed", err)
+	}
+
+	if len(backend.sent) != 0 {
+		t.Fatalf("reverting transaction has been sent")
+	}
+
+	backend.estimate = nil
+	backend.status = types.ReceiptStatusFailed
+
+	if _, err = s.RevokeConfirmation(context.Background(), chainapi.MultisigTxRequest{
+		ContractAddress: multisigAddress,
+		Index:           1,
+	}); !errors.Is(err, ErrorReverted) {
+		t.Fatalf("RevokeConfirmation() with failed receipt error = %v, want ErrorReverted", err)
+	}
+}
+
+func TestSignerWithoutSeed(t *testing.T) {
+	if _, err := NewLocalProvider(slog.New(slog.NewTextHandler(io.Discard, nil)), newBackend(), chainID).
+		Signer(nil); !errors.Is(err, ErrorNoSeed) {
+		t.Fatalf("Signer(nil) error = %v, want ErrorNoSeed", err)
+	}
+}
@@ -0,0 +1,42 @@
+package signer
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// multisigABI is the part of MultiSigWallet contract ABI used by the local signer
+const multisigABI = `[
+	{"type":"function","name":"submitTransaction","stateMutability":"nonpayable","inputs":[
+		{"name":"_to","type":"address"},{"name":"_value","type":"uint256"},{"name":"_data","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"confirmTransaction","stateMutability":"nonpayable","inputs":[
+		{"name":"_txIndex","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"executeTransaction","stateMutability":"nonpayable","inputs":[
+		{"name":"_txIndex","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"executeDeployTransaction","stateMutability":"nonpayable","inputs":[
+		{"name":"_txIndex","type":"uint256"},{"name":"_salt","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"revokeConfirmation","stateMutability":"nonpayable","inputs":[
+		{"name":"_txIndex","type":"uint256"}],"outputs":[]},
+	{"type":"event","name":"SubmitTransaction","anonymous":false,"inputs":[
+		{"name":"owner","type":"address","indexed":true},{"name":"txIndex","type":"uint256","indexed":true},
+		{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false},
+		{"name":"data","type":"bytes","indexed":false}]},
+	{"type":"event","name":"ExecuteTransaction","anonymous":false,"inputs":[
+		{"name":"owner","type":"address","indexed":true},{"name":"txIndex","type":"uint256","indexed":true},
+		{"name":"to","type":"address","indexed":true}]},
+	{"type":"event","name":"ExecuteTransactionFailed","anonymous":false,"inputs":[
+		{"name":"owner","type":"address","indexed":true},{"name":"txIndex","type":"uint256","indexed":true},
+		{"name":"reason","type":"string","indexed":false}]},
+	{"type":"event","name":"ContractDeployed","anonymous":false,"inputs":[
+		{"name":"contractAddress","type":"address","indexed":true}]}
+]`
+
+var multisig = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(multisigABI))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}()
@@ -0,0 +1,67 @@
+package signer
+
+import (
+	"context"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/chainapi"
+)
+
+type remoteProvider struct {
+	client *chainapi.Client
+}
+
+// NewRemoteProvider returns provider of signers delegating to the chain-api
+func NewRemoteProvider(client *chainapi.Client) Provider {
+	return &remoteProvider{
+		client: client,
+	}
+}
+
+func (p *remoteProvider) Kind() string {
+	return KindRemote
+}
+
+func (p *remoteProvider) Signer(seed []byte) (Signer, error) {
+	if len(seed) == 0 {
+		return nil, ErrorNoSeed
+	}
+
+	return &remoteSigner{
+		client: p.client,
+		seed:   seed,
+	}, nil
+}
+
+// remoteSigner sends the seed to the chain-api in the X-Seed header
+type remoteSigner struct {
+	client *chainapi.Client
+	seed   []byte
+}
+
+func (s *remoteSigner) SubmitTransaction(
+	ctx context.Context,
+	req chainapi.SubmitTransactionRequest,
+) (*chainapi.SubmitTransactionResponse, error) {
+	return s.client.SubmitTransaction(ctx, s.seed, req)
+}
+
+func (s *remoteSigner) ConfirmTransaction(
+	ctx context.Context,
+	req chainapi.MultisigTxRequest,
+) (*chainapi.TransactionResponse, error) {
+	return s.client.ConfirmTransaction(ctx, s.seed, req)
+}
+
+func (s *remoteSigner) RevokeConfirmation(
+	ctx context.Context,
+	req chainapi.MultisigTxRequest,
+) (*chainapi.SentTransaction, error) {
+	return s.client.RevokeConfirmation(ctx, s.seed, req)
+}
+
+func (s *remoteSigner) ExecuteTransaction(
+	ctx context.Context,
+	req chainapi.ExecuteTransactionRequest,
+) (*chainapi.TransactionResponse, error) {
+	return s.client.ExecuteTransaction(ctx, s.seed, req)
+}
@@ -0,0 +1,47 @@
+// Package signer sends multisig transactions on behalf of the user wallet. Remote signer delegates
+// signing to the chain-api and ships the seed in the X-Seed header, local signer derives the key
+// in process and signs EIP-155 transactions itself, so multisig transactions are sent without the seed
+package signer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/chainapi"
+)
+
+var (
+	// ErrorNoSeed the wallet has no custodial seed, e.g. the user joined with wallet signature
+	ErrorNoSeed = errors.New("wallet has no custodial seed")
+	// ErrorReverted transaction has been reverted or would revert, retrying it makes no sense
+	ErrorReverted = errors.New("transaction reverted")
+)
+
+const (
+	KindRemote = "remote"
+	KindLocal  = "local"
+)
+
+// Signer sends multisig transactions. Contract revert reasons are reported with chainapi errors,
+// so errors.Is(err, chainapi.ErrorTxAlreadyConfirmed) works for both implementations
+type Signer interface {
+	// SubmitTransaction submits new transaction to the multisig. Submitted transaction is not confirmed by the signer
+	SubmitTransaction(
+		ctx context.Context,
+		req chainapi.SubmitTransactionRequest,
+	) (*chainapi.SubmitTransactionResponse, error)
+	ConfirmTransaction(ctx context.Context, req chainapi.MultisigTxRequest) (*chainapi.TransactionResponse, error)
+	RevokeConfirmation(ctx context.Context, req chainapi.MultisigTxRequest) (*chainapi.SentTransaction, error)
+	// ExecuteTransaction executes confirmed multisig transaction.
+	// DeployedAddress of the response is set if req.IsDeploy is set
+	ExecuteTransaction(
+		ctx context.Context,
+		req chainapi.ExecuteTransactionRequest,
+	) (*chainapi.TransactionResponse, error)
+}
+
+// Provider returns signer of the wallet derived from the BIP-39 seed
+type Provider interface {
+	Kind() string
+	Signer(seed []byte) (Signer, error)
+}
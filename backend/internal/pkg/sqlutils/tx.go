@@ -46,8 +46,6 @@ func Transaction(ctx context.Context, db *sql.DB, fn func(context.Context) error
 				return
 			}
 
-			err = err
-
 			return
 		}
 
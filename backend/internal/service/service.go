@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"github.com/emochka2007/block-accounting/internal/interface/rest"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
 )
 
 type Service interface {
@@ -16,37 +17,48 @@ type Service interface {
 type ServiceImpl struct {
 	log  *slog.Logger
 	rest *rest.Server
+	jobs jobs.JobsInteractor
 }
 
 func NewService(
 	log *slog.Logger,
 	rest *rest.Server,
+	jobs jobs.JobsInteractor,
 ) Service {
 	return &ServiceImpl{
 		log:  log,
 		rest: rest,
+		jobs: jobs,
 	}
 }
 
 func (s *ServiceImpl) Run(ctx context.Context) error {
 	s.log.Info("starting blockd service 0w0")
 
-	errch := make(chan error)
+	errch := make(chan error, 2)
+	jobsDone := make(chan struct{})
 
 	defer s.rest.Close()
 
 	go func() {
-		defer func() {
-			close(errch)
-		}()
-
 		errch <- s.rest.Serve(ctx)
 	}()
 
+	go func() {
+		defer close(jobsDone)
+
+		if err := s.jobs.Run(ctx); err != nil {
+			errch <- err
+		}
+	}()
+
 	select {
 	case <-ctx.Done():
 		s.log.Info("shutting down service")
 
+		// wait for running jobs to save their state
+		<-jobsDone
+
 		return nil
 	case err := <-errch:
 		return fmt.Errorf("error at service runtime. %w", err)
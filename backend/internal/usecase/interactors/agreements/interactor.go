@@ -0,0 +1,723 @@
+package agreements
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
+	txinteractor "github.com/emochka2007/block-accounting/internal/usecase/interactors/transactions"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/agreements"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/users"
+	"github.com/google/uuid"
+)
+
+const (
+	JobKindAgreementOperation = "agreement_operation"
+)
+
+var (
+	ErrorAgreementNotFound            = errors.New("agreement not found")
+	ErrorAgreementNotDeployed         = errors.New("agreement is not deployed")
+	ErrorAgreementNotRequested        = errors.New("agreement outcome is not requested")
+	ErrorAgreementOperationNotFound   = errors.New("agreement operation not found")
+	ErrorAgreementOperationNotPending = errors.New("agreement operation is not pending")
+	ErrorInvalidRequestURL            = errors.New("invalid agreement request url")
+)
+
+type DeployParams struct {
+	Title      string
+	MultisigID uuid.UUID
+}
+
+type RequestParams struct {
+	AgreementID uuid.UUID
+	URL         string
+}
+
+type ResolveParams struct {
+	AgreementID    uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+type ListParams struct {
+	OrganizationID uuid.UUID
+	IDs            uuid.UUIDs
+	Limit          int64
+}
+
+type ConfirmOperationParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+type ListOperationsParams struct {
+	OrganizationID uuid.UUID
+	IDs            uuid.UUIDs
+	AgreementIDs   uuid.UUIDs
+	Limit          int64
+}
+
+// AgreementsInteractor manages agreement contracts. Deploy and oracle request go through the agreement multisig:
+// operation is created with the actor confirmation and executed in background once confirmations
+// required by the multisig are collected. Oracle response is recorded by Resolve
+type AgreementsInteractor interface {
+	Deploy(ctx context.Context, params DeployParams) (*models.AgreementOperation, error)
+	Request(ctx context.Context, params RequestParams) (*models.AgreementOperation, error)
+	Resolve(ctx context.Context, params ResolveParams) (*models.Agreement, error)
+
+	List(ctx context.Context, params ListParams) ([]*models.Agreement, error)
+
+	ConfirmOperation(ctx context.Context, params ConfirmOperationParams) (*models.AgreementOperation, error)
+	ListOperations(ctx context.Context, params ListOperationsParams) ([]*models.AgreementOperation, error)
+}
+
+type agreementsInteractor struct {
+	log                     *slog.Logger
+	agreementsRepo          agreements.Repository
+	txRepo                  transactions.Repository
+	usersRepo               users.Repository
+	orgInteractor           organizations.OrganizationsInteractor
+	chainInteractor         chain.ChainInteractor
+	jobsInteractor          jobs.JobsInteractor
+	confirmationsInteractor confirmations.ConfirmationsInteractor
+	authorizer              authorizer.Authorizer
+}
+
+func NewAgreementsInteractor(
+	log *slog.Logger,
+	agreementsRepo agreements.Repository,
+	txRepo transactions.Repository,
+	usersRepo users.Repository,
+	orgInteractor organizations.OrganizationsInteractor,
+	chainInteractor chain.ChainInteractor,
+	jobsInteractor jobs.JobsInteractor,
+	confirmationsInteractor confirmations.ConfirmationsInteractor,
+	authorizer authorizer.Authorizer,
+) AgreementsInteractor {
+	i := &agreementsInteractor{
+		log:                     log,
+		agreementsRepo:          agreementsRepo,
+		txRepo:                  txRepo,
+		usersRepo:               usersRepo,
+		orgInteractor:           orgInteractor,
+		chainInteractor:         chainInteractor,
+		jobsInteractor:          jobsInteractor,
+		confirmationsInteractor: confirmationsInteractor,
+		authorizer:              authorizer,
+	}
+
+	jobsInteractor.RegisterHandler(JobKindAgreementOperation, i.operationJob)
+
+	confirmationsInteractor.RegisterEntity(models.MultisigConfirmationEntityTypeAgreementOperation, confirmations.EntityHandler{
+		Entity:     i.confirmationEntity,
+		Confirmed:  i.confirmed,
+		Permission: models.PermissionAgreementConfirm,
+	})
+
+	return i
+}
+
+func (i *agreementsInteractor) Deploy(ctx context.Context, params DeployParams) (*models.AgreementOperation, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	if _, err = i.authorizer.Authorize(ctx, organizationID, models.PermissionAgreementManage); err != nil {
+		return nil, err
+	}
+
+	multisig, err := i.multisig(ctx, organizationID, params.MultisigID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMultisigOwner(multisig, user.Id()) {
+		return nil, chain.ErrorNotMultisigOwner
+	}
+
+	createdAt := time.Now()
+
+	agreement := models.Agreement{
+		ID:             uuid.Must(uuid.NewV7()),
+		OrganizationID: organizationID,
+		MultisigID:     multisig.ID,
+		Title:          params.Title,
+		Status:         models.AgreementStatusPending,
+		CreatedBy:      user.Id(),
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	}
+
+	if err = i.agreementsRepo.CreateAgreement(ctx, agreement); err != nil {
+		return nil, fmt.Errorf("error create agreement. %w", err)
+	}
+
+	return i.newOperation(ctx, &agreement, models.AgreementOperation{
+		Kind: models.AgreementOperationKindDeploy,
+	})
+}
+
+func (i *agreementsInteractor) Request(ctx context.Context, params RequestParams) (*models.AgreementOperation, error) {
+	if u, err := url.ParseRequestURI(params.URL); err != nil || u.Host == "" {
+		return nil, ErrorInvalidRequestURL
+	}
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	agreement, err := i.agreement(ctx, organizationID, params.AgreementID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(agreement.Address) == 0 || agreement.Status == models.AgreementStatusFailed {
+		return nil, ErrorAgreementNotDeployed
+	}
+
+	return i.newOperation(ctx, agreement, models.AgreementOperation{
+		Kind: models.AgreementOperationKindRequest,
+		URL:  params.URL,
+	})
+}
+
+// Resolve reads the oracle response from the agreement contract and records it as the agreement outcome.
+// Oracle fulfills the request asynchronously, so Resolve may be called again to refresh the outcome
+func (i *agreementsInteractor) Resolve(ctx context.Context, params ResolveParams) (*models.Agreement, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	if _, err = i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionAgreementManage); err != nil {
+		return nil, err
+	}
+
+	agreement, err := i.agreement(ctx, params.OrganizationID, params.AgreementID)
+	if err != nil {
+		return nil, err
+	}
+
+	if agreement.Status != models.AgreementStatusRequested && agreement.Status != models.AgreementStatusResolved {
+		return nil, ErrorAgreementNotRequested
+	}
+
+	outcome, err := i.chainInteractor.AgreementResponse(ctx, chain.AgreementResponseParams{
+		Signer:           user,
+		AgreementAddress: agreement.Address,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch agreement response. %w", err)
+	}
+
+	resolvedAt := time.Now()
+
+	if err = i.agreementsRepo.UpdateAgreement(ctx, agreements.UpdateAgreementParams{
+		ID:             agreement.ID,
+		OrganizationID: agreement.OrganizationID,
+		Status:         models.AgreementStatusResolved,
+		Outcome:        outcome,
+		ResolvedAt:     resolvedAt,
+		UpdatedAt:      resolvedAt,
+	}); err != nil {
+		return nil, fmt.Errorf("error save agreement outcome. %w", err)
+	}
+
+	agreement.Status = models.AgreementStatusResolved
+	agreement.Outcome = outcome
+	agreement.ResolvedAt = resolvedAt
+	agreement.UpdatedAt = resolvedAt
+
+	return agreement, nil
+}
+
+// newOperation saves agreement operation and confirms it on behalf of the actor
+func (i *agreementsInteractor) newOperation(
+	ctx context.Context,
+	agreement *models.Agreement,
+	op models.AgreementOperation,
+) (*models.AgreementOperation, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	actor, err := i.authorizer.Authorize(ctx, agreement.OrganizationID, models.PermissionAgreementManage)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now()
+
+	op.ID = uuid.Must(uuid.NewV7())
+	op.AgreementID = agreement.ID
+	op.OrganizationID = agreement.OrganizationID
+	op.MultisigID = agreement.MultisigID
+	op.Status = models.AgreementOperationStatusPending
+	op.CreatedBy = user.Id()
+	op.CreatedAt = createdAt
+	op.UpdatedAt = createdAt
+
+	if err = i.agreementsRepo.AddOperation(ctx, op); err != nil {
+		return nil, fmt.Errorf("error add agreement operation. %w", err)
+	}
+
+	// creator without confirm permission leaves the operation awaiting owners confirmations
+	if !i.authorizer.Can(actor, models.PermissionAgreementConfirm) {
+		return i.operation(ctx, op.OrganizationID, op.ID)
+	}
+
+	return i.ConfirmOperation(ctx, ConfirmOperationParams{
+		ID:             op.ID,
+		OrganizationID: agreement.OrganizationID,
+	})
+}
+
+func (i *agreementsInteractor) List(ctx context.Context, params ListParams) ([]*models.Agreement, error) {
+	list, err := i.agreementsRepo.ListAgreements(ctx, agreements.ListAgreementsParams{
+		IDs:            params.IDs,
+		OrganizationID: params.OrganizationID,
+		Limit:          params.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch agreements. %w", err)
+	}
+
+	return list, nil
+}
+
+func (i *agreementsInteractor) agreement(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*models.Agreement, error) {
+	list, err := i.List(ctx, ListParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{id},
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, ErrorAgreementNotFound
+	}
+
+	return list[0], nil
+}
+
+func (i *agreementsInteractor) ListOperations(
+	ctx context.Context,
+	params ListOperationsParams,
+) ([]*models.AgreementOperation, error) {
+	ops, err := i.agreementsRepo.ListOperations(ctx, agreements.ListOperationsParams{
+		IDs:            params.IDs,
+		OrganizationID: params.OrganizationID,
+		AgreementIDs:   params.AgreementIDs,
+		Limit:          params.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch agreement operations. %w", err)
+	}
+
+	if len(ops) == 0 {
+		return ops, nil
+	}
+
+	ids := make(uuid.UUIDs, len(ops))
+
+	for i, op := range ops {
+		ids[i] = op.ID
+	}
+
+	confirmations, err := i.txRepo.MultisigConfirmations(ctx, transactions.MultisigConfirmationsParams{
+		EntityIDs:  ids,
+		EntityType: models.MultisigConfirmationEntityTypeAgreementOperation,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch agreement operations confirmations. %w", err)
+	}
+
+	for _, op := range ops {
+		op.ConfirmedBy = confirmations[op.ID]
+		op.Confirmations = len(op.ConfirmedBy)
+	}
+
+	return ops, nil
+}
+
+func (i *agreementsInteractor) operation(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*models.AgreementOperation, error) {
+	ops, err := i.ListOperations(ctx, ListOperationsParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{id},
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ops) == 0 {
+		return nil, ErrorAgreementOperationNotFound
+	}
+
+	return ops[0], nil
+}
+
+type operationPayload struct {
+	OperationID uuid.UUID `json:"operation_id"`
+}
+
+func (i *agreementsInteractor) ConfirmOperation(
+	ctx context.Context,
+	params ConfirmOperationParams,
+) (*models.AgreementOperation, error) {
+	if _, err := i.confirmationsInteractor.Confirm(ctx, confirmations.ConfirmParams{
+		OrganizationID: params.OrganizationID,
+		EntityType:     models.MultisigConfirmationEntityTypeAgreementOperation,
+		EntityID:       params.ID,
+	}); err != nil {
+		if errors.Is(err, confirmations.ErrorEntityNotPending) {
+			return nil, ErrorAgreementOperationNotPending
+		}
+
+		return nil, err
+	}
+
+	return i.operation(ctx, params.OrganizationID, params.ID)
+}
+
+func (i *agreementsInteractor) confirmationEntity(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*confirmations.Entity, error) {
+	op, err := i.operation(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &confirmations.Entity{
+		ID:             op.ID,
+		OrganizationID: op.OrganizationID,
+		MultisigID:     op.MultisigID,
+		Pending:        op.Status == models.AgreementOperationStatusPending,
+	}, nil
+}
+
+// confirmed starts execution of the agreement operation confirmed by the multisig owners
+func (i *agreementsInteractor) confirmed(ctx context.Context, entity *confirmations.Entity) error {
+	if err := i.agreementsRepo.UpdateOperation(ctx, agreements.UpdateOperationParams{
+		ID:             entity.ID,
+		OrganizationID: entity.OrganizationID,
+		Status:         models.AgreementOperationStatusConfirmed,
+		FromStatuses:   []models.AgreementOperationStatus{models.AgreementOperationStatusPending},
+		UpdatedAt:      time.Now(),
+	}); err != nil {
+		if errors.Is(err, agreements.ErrorOperationStatusConflict) {
+			return confirmations.ErrorEntityStatusConflict
+		}
+
+		return fmt.Errorf("error mark agreement operation as confirmed. %w", err)
+	}
+
+	if _, err := i.jobsInteractor.Enqueue(ctx, jobs.EnqueueParams{
+		Kind:           JobKindAgreementOperation,
+		OrganizationID: entity.OrganizationID,
+		Payload: operationPayload{
+			OperationID: entity.ID,
+		},
+	}); err != nil {
+		return fmt.Errorf("error enqueue agreement operation execution. %w", err)
+	}
+
+	return nil
+}
+
+type OperationResult struct {
+	OperationID uuid.UUID `json:"operation_id"`
+	AgreementID uuid.UUID `json:"agreement_id"`
+	TxHash      string    `json:"tx_hash"`
+}
+
+// operationJob submits agreement contract call to the multisig, confirms it on behalf of the owners
+// who confirmed the operation and executes it. Submitted tx index is saved, so retried job does not submit twice
+func (i *agreementsInteractor) operationJob(ctx context.Context, job *models.Job) (result any, err error) {
+	var payload operationPayload
+
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("error unmarshal job payload. %w", err))
+	}
+
+	op, err := i.operation(ctx, job.OrganizationID, payload.OperationID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	if op.Status == models.AgreementOperationStatusExecuted {
+		return OperationResult{OperationID: op.ID, AgreementID: op.AgreementID, TxHash: op.TxHash}, nil
+	}
+
+	agreement, err := i.agreement(ctx, job.OrganizationID, op.AgreementID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	defer func() {
+		if !jobs.Failed(job, err) {
+			return
+		}
+
+		if fErr := i.failOperation(ctx, agreement, op); fErr != nil {
+			err = errors.Join(err, fErr)
+		}
+	}()
+
+	multisig, err := i.multisig(ctx, job.OrganizationID, op.MultisigID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	submitter, confirmers, err := i.signers(ctx, op, multisig)
+	if err != nil {
+		return nil, err
+	}
+
+	if op.Status == models.AgreementOperationStatusConfirmed {
+		var submitted *chain.SubmitTransactionResult
+
+		switch op.Kind {
+		case models.AgreementOperationKindDeploy:
+			submitted, err = i.chainInteractor.AgreementDeploy(ctx, chain.AgreementDeployParams{
+				Signer:          submitter,
+				MultisigAddress: multisig.Address,
+			})
+		case models.AgreementOperationKindRequest:
+			submitted, err = i.chainInteractor.AgreementRequest(ctx, chain.AgreementRequestParams{
+				Signer:           submitter,
+				MultisigAddress:  multisig.Address,
+				AgreementAddress: agreement.Address,
+				URL:              op.URL,
+			})
+		default:
+			return nil, jobs.Permanent(fmt.Errorf("error unknown agreement operation kind %d", op.Kind))
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		op.Status = models.AgreementOperationStatusSubmitted
+		op.TxIndex = submitted.TxIndex
+
+		if err = i.agreementsRepo.UpdateOperation(ctx, agreements.UpdateOperationParams{
+			ID:             op.ID,
+			OrganizationID: op.OrganizationID,
+			Status:         op.Status,
+			TxIndex:        op.TxIndex,
+			UpdatedAt:      time.Now(),
+		}); err != nil {
+			return nil, fmt.Errorf("error save submitted agreement operation. %w", err)
+		}
+	}
+
+	if op.Status != models.AgreementOperationStatusSubmitted {
+		return nil, jobs.Permanent(fmt.Errorf("error unexpected agreement operation status %s", op.Status))
+	}
+
+	for _, confirmer := range confirmers {
+		if _, err = i.chainInteractor.MultisigConfirm(ctx, chain.MultisigTxParams{
+			Signer:          confirmer,
+			MultisigAddress: multisig.Address,
+			TxIndex:         op.TxIndex,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	executeParams := chain.MultisigTxParams{
+		Signer:          submitter,
+		MultisigAddress: multisig.Address,
+		TxIndex:         op.TxIndex,
+	}
+
+	updateAgreement := agreements.UpdateAgreementParams{
+		ID:             agreement.ID,
+		OrganizationID: agreement.OrganizationID,
+	}
+
+	switch op.Kind {
+	case models.AgreementOperationKindDeploy:
+		deployed, err := i.chainInteractor.MultisigExecuteDeploy(ctx, executeParams)
+		if err != nil {
+			return nil, err
+		}
+
+		op.TxHash = deployed.TxHash
+		updateAgreement.Status = models.AgreementStatusDeployed
+		updateAgreement.Address = deployed.DeployedAddress
+	case models.AgreementOperationKindRequest:
+		if op.TxHash, err = i.chainInteractor.MultisigExecute(ctx, executeParams); err != nil {
+			return nil, err
+		}
+
+		updateAgreement.Status = models.AgreementStatusRequested
+		updateAgreement.RequestURL = op.URL
+	}
+
+	executedAt := time.Now()
+	updateAgreement.UpdatedAt = executedAt
+
+	// agreement call is already executed on-chain, retry would fail anyway
+	if err = i.agreementsRepo.UpdateAgreement(ctx, updateAgreement); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("error save agreement. %w", err))
+	}
+
+	op.Status = models.AgreementOperationStatusExecuted
+
+	if err = i.agreementsRepo.UpdateOperation(ctx, agreements.UpdateOperationParams{
+		ID:             op.ID,
+		OrganizationID: op.OrganizationID,
+		Status:         op.Status,
+		TxHash:         op.TxHash,
+		ExecutedAt:     executedAt,
+		UpdatedAt:      executedAt,
+	}); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("error save executed agreement operation. %w", err))
+	}
+
+	return OperationResult{OperationID: op.ID, AgreementID: agreement.ID, TxHash: op.TxHash}, nil
+}
+
+func (i *agreementsInteractor) failOperation(
+	ctx context.Context,
+	agreement *models.Agreement,
+	op *models.AgreementOperation,
+) error {
+	updatedAt := time.Now()
+
+	if err := i.agreementsRepo.UpdateOperation(ctx, agreements.UpdateOperationParams{
+		ID:             op.ID,
+		OrganizationID: op.OrganizationID,
+		Status:         models.AgreementOperationStatusFailed,
+		UpdatedAt:      updatedAt,
+	}); err != nil {
+		return fmt.Errorf("error mark agreement operation as failed. %w", err)
+	}
+
+	if op.Kind != models.AgreementOperationKindDeploy {
+		return nil
+	}
+
+	if err := i.agreementsRepo.UpdateAgreement(ctx, agreements.UpdateAgreementParams{
+		ID:             agreement.ID,
+		OrganizationID: agreement.OrganizationID,
+		Status:         models.AgreementStatusFailed,
+		UpdatedAt:      updatedAt,
+	}); err != nil {
+		return fmt.Errorf("error mark agreement as failed. %w", err)
+	}
+
+	return nil
+}
+
+// signers returns operation creator, who submits and executes it,
+// and owners whose confirmations are sent on-chain
+func (i *agreementsInteractor) signers(
+	ctx context.Context,
+	op *models.AgreementOperation,
+	multisig *models.Multisig,
+) (*models.User, []*models.User, error) {
+	confirmedBy := op.ConfirmedBy
+	if len(confirmedBy) > multisig.ConfirmationsRequired {
+		confirmedBy = confirmedBy[:multisig.ConfirmationsRequired]
+	}
+
+	usersList, err := i.usersRepo.Get(ctx, users.GetParams{
+		Ids: append(uuid.UUIDs{op.CreatedBy}, confirmedBy...),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetch agreement operation signers. %w", err)
+	}
+
+	usersMap := make(map[uuid.UUID]*models.User, len(usersList))
+
+	for _, u := range usersList {
+		usersMap[u.Id()] = u
+	}
+
+	submitter, ok := usersMap[op.CreatedBy]
+	if !ok {
+		return nil, nil, jobs.Permanent(fmt.Errorf("error agreement operation creator not found"))
+	}
+
+	confirmers := make([]*models.User, 0, len(confirmedBy))
+
+	for _, id := range confirmedBy {
+		u, ok := usersMap[id]
+		if !ok {
+			return nil, nil, jobs.Permanent(fmt.Errorf("error agreement operation confirmer %s not found", id))
+		}
+
+		confirmers = append(confirmers, u)
+	}
+
+	if len(confirmers) < multisig.ConfirmationsRequired {
+		return nil, nil, jobs.Permanent(fmt.Errorf("error not enough agreement operation confirmations"))
+	}
+
+	return submitter, confirmers, nil
+}
+
+func (i *agreementsInteractor) multisig(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*models.Multisig, error) {
+	multisigs, err := i.chainInteractor.ListMultisigs(ctx, chain.ListMultisigsParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{id},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch multisig. %w", err)
+	}
+
+	if len(multisigs) == 0 {
+		return nil, txinteractor.ErrorMultisigNotFound
+	}
+
+	return &multisigs[0], nil
+}
+
+func isMultisigOwner(multisig *models.Multisig, userID uuid.UUID) bool {
+	for _, owner := range multisig.Owners {
+		if owner.Id() == userID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,519 @@
+package agreements
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/agreements"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
+	"github.com/google/uuid"
+)
+
+// memoryOrganizations keeps a single organization and its participants in memory
+type memoryOrganizations struct {
+	organizations.Repository
+
+	org          *models.Organization
+	participants []models.OrganizationParticipant
+}
+
+func (r *memoryOrganizations) Get(_ context.Context, params organizations.GetParams) ([]*models.Organization, error) {
+	if !slices.Contains(params.Ids, r.org.ID) {
+		return nil, nil
+	}
+
+	return []*models.Organization{r.org}, nil
+}
+
+func (r *memoryOrganizations) Participants(
+	_ context.Context,
+	params organizations.ParticipantsParams,
+) ([]models.OrganizationParticipant, error) {
+	var participants []models.OrganizationParticipant
+
+	for _, p := range r.participants {
+		if params.OrganizationId != r.org.ID || !slices.Contains(params.Ids, p.Id()) {
+			continue
+		}
+
+		participants = append(participants, p)
+	}
+
+	if len(participants) == 0 {
+		return nil, organizations.ErrorNotFound
+	}
+
+	return participants, nil
+}
+
+// memoryAgreements keeps agreements and their operations in memory
+type memoryAgreements struct {
+	agreements.Repository
+
+	mu         sync.Mutex
+	agreements []*models.Agreement
+	ops        []*models.AgreementOperation
+}
+
+func (r *memoryAgreements) CreateAgreement(_ context.Context, agreement models.Agreement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.agreements = append(r.agreements, &agreement)
+
+	return nil
+}
+
+func (r *memoryAgreements) ListAgreements(
+	_ context.Context,
+	params agreements.ListAgreementsParams,
+) ([]*models.Agreement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var list []*models.Agreement
+
+	for _, a := range r.agreements {
+		if a.OrganizationID == params.OrganizationID && slices.Contains(params.IDs, a.ID) {
+			copied := *a
+			list = append(list, &copied)
+		}
+	}
+
+	return list, nil
+}
+
+func (r *memoryAgreements) UpdateAgreement(_ context.Context, params agreements.UpdateAgreementParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, a := range r.agreements {
+		if a.ID != params.ID || a.OrganizationID != params.OrganizationID {
+			continue
+		}
+
+		a.Status = params.Status
+
+		if !params.ResolvedAt.IsZero() {
+			a.Outcome = params.Outcome
+			a.ResolvedAt = params.ResolvedAt
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryAgreements) AddOperation(_ context.Context, op models.AgreementOperation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ops = append(r.ops, &op)
+
+	return nil
+}
+
+func (r *memoryAgreements) ListOperations(
+	_ context.Context,
+	params agreements.ListOperationsParams,
+) ([]*models.AgreementOperation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ops []*models.AgreementOperation
+
+	for _, op := range r.ops {
+		if op.OrganizationID == params.OrganizationID && slices.Contains(params.IDs, op.ID) {
+			copied := *op
+			ops = append(ops, &copied)
+		}
+	}
+
+	return ops, nil
+}
+
+func (r *memoryAgreements) UpdateOperation(_ context.Context, params agreements.UpdateOperationParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, op := range r.ops {
+		if op.ID != params.ID || op.OrganizationID != params.OrganizationID {
+			continue
+		}
+
+		if len(params.FromStatuses) > 0 && !slices.Contains(params.FromStatuses, op.Status) {
+			return agreements.ErrorOperationStatusConflict
+		}
+
+		op.Status = params.Status
+
+		return nil
+	}
+
+	return agreements.ErrorOperationStatusConflict
+}
+
+type decisionKey struct {
+	entityID uuid.UUID
+	ownerID  uuid.UUID
+}
+
+// memoryMultisigs keeps multisigs and owner decisions in memory
+type memoryMultisigs struct {
+	transactions.Repository
+
+	mu        sync.Mutex
+	multisigs []models.Multisig
+	decisions map[decisionKey]bool
+}
+
+func (r *memoryMultisigs) ListMultisig(
+	_ context.Context,
+	params transactions.ListMultisigsParams,
+) ([]models.Multisig, error) {
+	var multisigs []models.Multisig
+
+	for _, m := range r.multisigs {
+		if m.OrganizationID == params.OrganizationID && slices.Contains(params.IDs, m.ID) {
+			multisigs = append(multisigs, m)
+		}
+	}
+
+	return multisigs, nil
+}
+
+func (r *memoryMultisigs) ConfirmMultisig(_ context.Context, params transactions.ConfirmMultisigParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decisions[decisionKey{params.EntityID, params.CinfirmedBy.Id()}] = params.Rejected
+
+	return nil
+}
+
+func (r *memoryMultisigs) MultisigConfirmations(
+	_ context.Context,
+	params transactions.MultisigConfirmationsParams,
+) (map[uuid.UUID]uuid.UUIDs, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	owners := make(map[uuid.UUID]uuid.UUIDs)
+
+	for key, rejected := range r.decisions {
+		if rejected == params.Rejected && slices.Contains(params.EntityIDs, key.entityID) {
+			owners[key.entityID] = append(owners[key.entityID], key.ownerID)
+		}
+	}
+
+	return owners, nil
+}
+
+func (r *memoryMultisigs) MultisigConfirmationsCount(
+	_ context.Context,
+	params transactions.MultisigConfirmationsCountParams,
+) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int
+
+	for key, rejected := range r.decisions {
+		if key.entityID == params.EntityID && !rejected {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// memoryChain lists multisigs of the transactions repository and answers agreement requests with outcome
+type memoryChain struct {
+	chain.ChainInteractor
+
+	txRepo  *memoryMultisigs
+	outcome bool
+}
+
+func (i *memoryChain) ListMultisigs(ctx context.Context, params chain.ListMultisigsParams) ([]models.Multisig, error) {
+	return i.txRepo.ListMultisig(ctx, transactions.ListMultisigsParams{
+		OrganizationID: params.OrganizationID,
+		IDs:            params.IDs,
+	})
+}
+
+func (i *memoryChain) AgreementResponse(context.Context, chain.AgreementResponseParams) (bool, error) {
+	return i.outcome, nil
+}
+
+// memoryJobs records enqueued jobs without running them
+type memoryJobs struct {
+	jobs.JobsInteractor
+
+	enqueued []jobs.EnqueueParams
+}
+
+func (i *memoryJobs) RegisterHandler(string, jobs.Handler) {}
+
+func (i *memoryJobs) Enqueue(_ context.Context, params jobs.EnqueueParams) (*models.Job, error) {
+	i.enqueued = append(i.enqueued, params)
+
+	return &models.Job{ID: uuid.New(), Kind: params.Kind}, nil
+}
+
+type fixture struct {
+	interactor     AgreementsInteractor
+	agreementsRepo *memoryAgreements
+	jobs           *memoryJobs
+	orgID          uuid.UUID
+	multisigID     uuid.UUID
+	// deployed agreement without oracle request
+	agreementID uuid.UUID
+
+	// multisig owners, 2 confirmations are required
+	owner           *models.OrganizationUser
+	approver        *models.OrganizationUser
+	ownerAccountant *models.OrganizationUser
+	// accountant is not an owner of the agreement multisig
+	accountant *models.OrganizationUser
+}
+
+func newOrganizationUser(role models.Role) *models.OrganizationUser {
+	return &models.OrganizationUser{
+		User: models.User{
+			ID:        uuid.New(),
+			Activated: true,
+		},
+		OrgRole: role,
+	}
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := &fixture{
+		jobs:            &memoryJobs{},
+		orgID:           uuid.New(),
+		multisigID:      uuid.New(),
+		agreementID:     uuid.New(),
+		owner:           newOrganizationUser(models.RoleOwner),
+		approver:        newOrganizationUser(models.RoleApprover),
+		ownerAccountant: newOrganizationUser(models.RoleAccountant),
+		accountant:      newOrganizationUser(models.RoleAccountant),
+	}
+
+	txRepo := &memoryMultisigs{
+		multisigs: []models.Multisig{{
+			ID:                    f.multisigID,
+			OrganizationID:        f.orgID,
+			Owners:                []models.OrganizationParticipant{f.owner, f.approver, f.ownerAccountant},
+			ConfirmationsRequired: 2,
+		}},
+		decisions: make(map[decisionKey]bool),
+	}
+
+	f.agreementsRepo = &memoryAgreements{
+		agreements: []*models.Agreement{{
+			ID:             f.agreementID,
+			OrganizationID: f.orgID,
+			MultisigID:     f.multisigID,
+			Address:        []byte("agreement"),
+			Status:         models.AgreementStatusDeployed,
+		}},
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	a := authorizer.NewAuthorizer(log, &memoryOrganizations{
+		org: &models.Organization{ID: f.orgID},
+		participants: []models.OrganizationParticipant{
+			f.owner, f.approver, f.ownerAccountant, f.accountant,
+		},
+	})
+
+	f.interactor = NewAgreementsInteractor(
+		log,
+		f.agreementsRepo,
+		txRepo,
+		nil,
+		nil,
+		&memoryChain{txRepo: txRepo, outcome: true},
+		f.jobs,
+		confirmations.NewConfirmationsInteractor(log, txRepo, a),
+		a,
+	)
+
+	return f
+}
+
+func (f *fixture) as(user *models.OrganizationUser) context.Context {
+	return ctxmeta.OrganizationIdContext(ctxmeta.UserContext(context.Background(), &user.User), f.orgID)
+}
+
+func TestDeploy(t *testing.T) {
+	f := newFixture(t)
+
+	op, err := f.interactor.Deploy(f.as(f.owner), DeployParams{Title: "Delivery", MultisigID: f.multisigID})
+	if err != nil {
+		t.Fatalf("Deploy() error: %v", err)
+	}
+
+	// creator allowed to confirm confirms the deploy right away
+	if op.Kind != models.AgreementOperationKindDeploy || op.Status != models.AgreementOperationStatusPending ||
+		!slices.Equal(op.ConfirmedBy, uuid.UUIDs{f.owner.ID}) {
+		t.Fatalf("Deploy() = %+v, want pending deploy confirmed by the creator", op)
+	}
+
+	agreement := f.agreementsRepo.agreements[len(f.agreementsRepo.agreements)-1]
+
+	if agreement.ID != op.AgreementID || agreement.Status != models.AgreementStatusPending ||
+		agreement.MultisigID != f.multisigID || agreement.CreatedBy != f.owner.ID {
+		t.Fatalf("created agreement = %+v, want pending agreement", agreement)
+	}
+
+	tests := []struct {
+		name    string
+		actor   *models.OrganizationUser
+		params  DeployParams
+		wantErr error
+	}{
+		{
+			name:    "not a multisig owner",
+			actor:   f.accountant,
+			params:  DeployParams{MultisigID: f.multisigID},
+			wantErr: chain.ErrorNotMultisigOwner,
+		},
+		{
+			name:    "no manage permission",
+			actor:   f.approver,
+			params:  DeployParams{MultisigID: f.multisigID},
+			wantErr: authorizer.ErrorPermissionDenied,
+		},
+		{
+			name:    "unknown multisig",
+			actor:   f.owner,
+			params:  DeployParams{MultisigID: uuid.New()},
+			wantErr: confirmations.ErrorMultisigNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.interactor.Deploy(f.as(tt.actor), tt.params); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Deploy() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequest(t *testing.T) {
+	f := newFixture(t)
+
+	if _, err := f.interactor.Request(f.as(f.ownerAccountant), RequestParams{
+		AgreementID: f.agreementID,
+		URL:         "delivered",
+	}); !errors.Is(err, ErrorInvalidRequestURL) {
+		t.Fatalf("Request() with invalid url error = %v, want ErrorInvalidRequestURL", err)
+	}
+
+	if _, err := f.interactor.Request(f.as(f.ownerAccountant), RequestParams{
+		AgreementID: uuid.New(),
+		URL:         "https://oracle.example.com/delivered",
+	}); !errors.Is(err, ErrorAgreementNotFound) {
+		t.Fatalf("Request() of unknown agreement error = %v, want ErrorAgreementNotFound", err)
+	}
+
+	op, err := f.interactor.Request(f.as(f.ownerAccountant), RequestParams{
+		AgreementID: f.agreementID,
+		URL:         "https://oracle.example.com/delivered",
+	})
+	if err != nil {
+		t.Fatalf("Request() error: %v", err)
+	}
+
+	// creator can not confirm the operation, so it awaits owners confirmations
+	if op.Kind != models.AgreementOperationKindRequest || op.URL != "https://oracle.example.com/delivered" ||
+		op.Status != models.AgreementOperationStatusPending || op.Confirmations != 0 {
+		t.Fatalf("Request() = %+v, want pending request without confirmations", op)
+	}
+
+	params := ConfirmOperationParams{ID: op.ID, OrganizationID: f.orgID}
+
+	for _, owner := range []*models.OrganizationUser{f.owner, f.approver} {
+		if op, err = f.interactor.ConfirmOperation(f.as(owner), params); err != nil {
+			t.Fatalf("ConfirmOperation() error: %v", err)
+		}
+	}
+
+	if op.Status != models.AgreementOperationStatusConfirmed || op.Confirmations != 2 {
+		t.Fatalf("ConfirmOperation() = %+v, want confirmed operation", op)
+	}
+
+	if len(f.jobs.enqueued) != 1 || f.jobs.enqueued[0].Kind != JobKindAgreementOperation ||
+		f.jobs.enqueued[0].Payload.(operationPayload).OperationID != op.ID {
+		t.Fatalf("enqueued jobs = %+v, want single agreement operation job", f.jobs.enqueued)
+	}
+
+	if _, err = f.interactor.ConfirmOperation(f.as(f.ownerAccountant), params); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("ConfirmOperation() by accountant error = %v, want ErrorPermissionDenied", err)
+	}
+
+	if _, err = f.interactor.ConfirmOperation(f.as(f.owner), params); !errors.Is(err, ErrorAgreementOperationNotPending) {
+		t.Fatalf("ConfirmOperation() of confirmed operation error = %v, want ErrorAgreementOperationNotPending", err)
+	}
+
+	// deploy is not executed yet
+	f.agreementsRepo.agreements[0].Address = nil
+
+	if _, err = f.interactor.Request(f.as(f.ownerAccountant), RequestParams{
+		AgreementID: f.agreementID,
+		URL:         "https://oracle.example.com/delivered",
+	}); !errors.Is(err, ErrorAgreementNotDeployed) {
+		t.Fatalf("Request() of not deployed agreement error = %v, want ErrorAgreementNotDeployed", err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	f := newFixture(t)
+
+	params := ResolveParams{AgreementID: f.agreementID, OrganizationID: f.orgID}
+
+	if _, err := f.interactor.Resolve(f.as(f.accountant), params); !errors.Is(err, ErrorAgreementNotRequested) {
+		t.Fatalf("Resolve() of not requested agreement error = %v, want ErrorAgreementNotRequested", err)
+	}
+
+	f.agreementsRepo.agreements[0].Status = models.AgreementStatusRequested
+
+	if _, err := f.interactor.Resolve(f.as(f.approver), params); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("Resolve() by approver error = %v, want ErrorPermissionDenied", err)
+	}
+
+	agreement, err := f.interactor.Resolve(f.as(f.accountant), params)
+	if err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+
+	if agreement.Status != models.AgreementStatusResolved || !agreement.Outcome || agreement.ResolvedAt.IsZero() {
+		t.Fatalf("Resolve() = %+v, want resolved agreement", agreement)
+	}
+
+	if saved := f.agreementsRepo.agreements[0]; saved.Status != models.AgreementStatusResolved || !saved.Outcome {
+		t.Fatalf("saved agreement = %+v, want resolved agreement", saved)
+	}
+
+	// resolved agreement outcome is refreshed
+	if _, err = f.interactor.Resolve(f.as(f.accountant), params); err != nil {
+		t.Fatalf("Resolve() of resolved agreement error: %v", err)
+	}
+}
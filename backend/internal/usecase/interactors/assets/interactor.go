@@ -0,0 +1,187 @@
+package assets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/assets"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorAssetNotFound        = errors.New("asset not found")
+	ErrorAssetExists          = errors.New("asset already exists")
+	ErrorInvalidAsset         = errors.New("invalid asset")
+	ErrorAssetNetworkMismatch = errors.New("asset is deployed to another network")
+)
+
+// maxSymbolLength is the length of ledger currency code
+const maxSymbolLength = 16
+
+type CreateParams struct {
+	Symbol          string
+	ContractAddress []byte
+	Decimals        int32
+	// ChainID defaults to the network multisigs are deployed to
+	ChainID int64
+}
+
+type ListParams struct {
+	OrganizationID uuid.UUID
+	IDs            uuid.UUIDs
+}
+
+// AssetsInteractor keeps the registry of ERC-20 tokens the organization transfers
+type AssetsInteractor interface {
+	Create(ctx context.Context, params CreateParams) (*models.Asset, error)
+	List(ctx context.Context, params ListParams) ([]*models.Asset, error)
+
+	// Transferable returns organization asset the multisigs can transfer. Returns ErrorAssetNetworkMismatch
+	// if the token is deployed to another network. Transferable does not authorize the actor
+	Transferable(ctx context.Context, organizationID, assetID uuid.UUID) (*models.Asset, error)
+}
+
+type assetsInteractor struct {
+	log        *slog.Logger
+	chainID    int64
+	assetsRepo assets.Repository
+	authorizer authorizer.Authorizer
+}
+
+func NewAssetsInteractor(
+	log *slog.Logger,
+	chainID int64,
+	assetsRepo assets.Repository,
+	authorizer authorizer.Authorizer,
+) AssetsInteractor {
+	return &assetsInteractor{
+		log:        log,
+		chainID:    chainID,
+		assetsRepo: assetsRepo,
+		authorizer: authorizer,
+	}
+}
+
+func (i *assetsInteractor) Create(ctx context.Context, params CreateParams) (*models.Asset, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	symbol := strings.ToUpper(strings.TrimSpace(params.Symbol))
+
+	if symbol == "" || len(symbol) > maxSymbolLength || symbol == money.ETH.Code {
+		return nil, fmt.Errorf("error invalid symbol %q. %w", params.Symbol, ErrorInvalidAsset)
+	}
+
+	if len(params.ContractAddress) != common.AddressLength ||
+		common.BytesToAddress(params.ContractAddress) == (common.Address{}) {
+		return nil, fmt.Errorf("error invalid contract address. %w", ErrorInvalidAsset)
+	}
+
+	// ERC-20 decimals are uint8
+	if params.Decimals < 0 || params.Decimals > 255 {
+		return nil, fmt.Errorf("error invalid decimals %d. %w", params.Decimals, ErrorInvalidAsset)
+	}
+
+	if params.ChainID < 0 {
+		return nil, fmt.Errorf("error invalid chain id %d. %w", params.ChainID, ErrorInvalidAsset)
+	}
+
+	if params.ChainID == 0 {
+		params.ChainID = i.chainID
+	}
+
+	actor, err := i.authorizer.Authorize(ctx, organizationID, models.PermissionAssetManage)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := i.assetsRepo.List(ctx, assets.ListParams{
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch assets. %w", err)
+	}
+
+	// symbol is the ledger currency, so it must be unique within the organization
+	for _, a := range existing {
+		if a.Symbol == symbol ||
+			(a.ChainID == params.ChainID && bytes.Equal(a.ContractAddress, params.ContractAddress)) {
+			return nil, ErrorAssetExists
+		}
+	}
+
+	asset := models.Asset{
+		ID:              uuid.Must(uuid.NewV7()),
+		OrganizationID:  organizationID,
+		Symbol:          symbol,
+		ContractAddress: params.ContractAddress,
+		Decimals:        params.Decimals,
+		ChainID:         params.ChainID,
+		CreatedBy:       actor.Id(),
+		CreatedAt:       time.Now(),
+	}
+
+	if err = i.assetsRepo.Add(ctx, asset); err != nil {
+		return nil, fmt.Errorf("error save asset. %w", err)
+	}
+
+	return &asset, nil
+}
+
+func (i *assetsInteractor) List(ctx context.Context, params ListParams) ([]*models.Asset, error) {
+	if _, err := i.authorizer.Member(ctx, params.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	list, err := i.assetsRepo.List(ctx, assets.ListParams{
+		OrganizationID: params.OrganizationID,
+		IDs:            params.IDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch assets. %w", err)
+	}
+
+	return list, nil
+}
+
+func (i *assetsInteractor) Transferable(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	assetID uuid.UUID,
+) (*models.Asset, error) {
+	list, err := i.assetsRepo.List(ctx, assets.ListParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{assetID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch asset. %w", err)
+	}
+
+	if len(list) == 0 {
+		return nil, ErrorAssetNotFound
+	}
+
+	if list[0].ChainID != i.chainID {
+		return nil, fmt.Errorf(
+			"error %s is deployed to chain %d, multisigs are on chain %d. %w",
+			list[0].Symbol,
+			list[0].ChainID,
+			i.chainID,
+			ErrorAssetNetworkMismatch,
+		)
+	}
+
+	return list[0], nil
+}
@@ -0,0 +1,135 @@
+package authorizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorUnauthorizedAccess   = errors.New("unauthorized access")
+	ErrorPermissionDenied     = errors.New("permission denied")
+	ErrorOrganizationArchived = errors.New("organization is archived")
+)
+
+// Authorizer checks organization users permissions. Every interactor authorizes actions with it
+type Authorizer interface {
+	// Authorize returns active organization user acting in the context. Returns ErrorUnauthorizedAccess
+	// if user is not a participant of the organization, ErrorPermissionDenied if user role
+	// does not grant the permission and ErrorOrganizationArchived if the permission is not granted
+	// in archived organization
+	Authorize(
+		ctx context.Context,
+		organizationID uuid.UUID,
+		permission models.Permission,
+	) (*models.OrganizationUser, error)
+
+	// Member returns active organization user acting in the context regardless of the role.
+	// Returns ErrorUnauthorizedAccess if user is not a participant of the organization
+	Member(ctx context.Context, organizationID uuid.UUID) (*models.OrganizationUser, error)
+
+	// Can reports whether the participant role grants the permission
+	Can(participant models.OrganizationParticipant, permission models.Permission) bool
+}
+
+type authorizer struct {
+	log     *slog.Logger
+	orgRepo organizations.Repository
+}
+
+func NewAuthorizer(
+	log *slog.Logger,
+	orgRepo organizations.Repository,
+) Authorizer {
+	return &authorizer{
+		log:     log,
+		orgRepo: orgRepo,
+	}
+}
+
+func (a *authorizer) Authorize(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	permission models.Permission,
+) (*models.OrganizationUser, error) {
+	actor, err := a.Member(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !a.Can(actor, permission) {
+		return nil, fmt.Errorf("error %s role has no %s permission. %w", actor.Role(), permission, ErrorPermissionDenied)
+	}
+
+	if permission.AllowedInArchive() {
+		return actor, nil
+	}
+
+	orgs, err := a.orgRepo.Get(ctx, organizations.GetParams{
+		Ids:   uuid.UUIDs{organizationID},
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization. %w", err)
+	}
+
+	if len(orgs) == 0 {
+		return nil, ErrorUnauthorizedAccess
+	}
+
+	if orgs[0].IsArchived() {
+		return nil, fmt.Errorf("error %s is not allowed. %w", permission, ErrorOrganizationArchived)
+	}
+
+	return actor, nil
+}
+
+func (a *authorizer) Member(ctx context.Context, organizationID uuid.UUID) (*models.OrganizationUser, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	participants, err := a.orgRepo.Participants(ctx, organizations.ParticipantsParams{
+		Ids:            uuid.UUIDs{user.Id()},
+		OrganizationId: organizationID,
+		UsersOnly:      true,
+		ActiveOnly:     true,
+	})
+	if err != nil {
+		if errors.Is(err, organizations.ErrorNotFound) {
+			return nil, ErrorUnauthorizedAccess
+		}
+
+		return nil, fmt.Errorf("error fetch actor participant. %w", err)
+	}
+
+	var actor *models.OrganizationUser
+
+	// user who is an employee too has several participant records, employee record has no role
+	for _, p := range participants {
+		if u := p.GetUser(); u != nil && (actor == nil || u.Role() > actor.Role()) {
+			actor = u
+		}
+	}
+
+	if actor == nil {
+		return nil, ErrorUnauthorizedAccess
+	}
+
+	return actor, nil
+}
+
+func (a *authorizer) Can(participant models.OrganizationParticipant, permission models.Permission) bool {
+	if participant == nil || !participant.IsActive() || !participant.DeletedDate().IsZero() {
+		return false
+	}
+
+	return participant.Role().Can(permission)
+}
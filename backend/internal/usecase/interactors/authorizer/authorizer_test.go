@@ -0,0 +1,187 @@
+package authorizer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	"github.com/google/uuid"
+)
+
+// memoryOrganizations keeps a single organization and its participants in memory
+type memoryOrganizations struct {
+	organizations.Repository
+
+	org          *models.Organization
+	participants []models.OrganizationParticipant
+}
+
+func (r *memoryOrganizations) Get(_ context.Context, params organizations.GetParams) ([]*models.Organization, error) {
+	if !slices.Contains(params.Ids, r.org.ID) {
+		return nil, nil
+	}
+
+	return []*models.Organization{r.org}, nil
+}
+
+func (r *memoryOrganizations) Participants(
+	_ context.Context,
+	params organizations.ParticipantsParams,
+) ([]models.OrganizationParticipant, error) {
+	var participants []models.OrganizationParticipant
+
+	for _, p := range r.participants {
+		if params.OrganizationId != r.org.ID || !slices.Contains(params.Ids, p.Id()) ||
+			(params.UsersOnly && p.GetUser() == nil) ||
+			(params.ActiveOnly && !p.DeletedDate().IsZero()) {
+			continue
+		}
+
+		participants = append(participants, p)
+	}
+
+	if len(participants) == 0 {
+		return nil, organizations.ErrorNotFound
+	}
+
+	return participants, nil
+}
+
+func newOrganizationUser(role models.Role) *models.OrganizationUser {
+	return &models.OrganizationUser{
+		User: models.User{
+			ID:        uuid.New(),
+			Activated: true,
+		},
+		OrgRole: role,
+	}
+}
+
+func as(user *models.OrganizationUser) context.Context {
+	return ctxmeta.UserContext(context.Background(), &user.User)
+}
+
+func TestAuthorize(t *testing.T) {
+	var (
+		owner      = newOrganizationUser(models.RoleOwner)
+		accountant = newOrganizationUser(models.RoleAccountant)
+		approver   = newOrganizationUser(models.RoleApprover)
+		viewer     = newOrganizationUser(models.RoleViewer)
+		deleted    = newOrganizationUser(models.RoleAdmin)
+		outsider   = newOrganizationUser(models.RoleOwner)
+	)
+
+	deleted.DeletedAt = time.Now()
+
+	repo := &memoryOrganizations{
+		org:          &models.Organization{ID: uuid.New()},
+		participants: []models.OrganizationParticipant{owner, accountant, approver, viewer, deleted},
+	}
+
+	a := NewAuthorizer(slog.New(slog.NewTextHandler(io.Discard, nil)), repo)
+
+	tests := []struct {
+		name       string
+		actor      *models.OrganizationUser
+		permission models.Permission
+		archived   bool
+		wantErr    error
+	}{
+		{name: "owner", actor: owner, permission: models.PermissionRolesAssign},
+		{name: "accountant creates", actor: accountant, permission: models.PermissionTxCreate},
+		{name: "accountant confirms", actor: accountant, permission: models.PermissionTxConfirm, wantErr: ErrorPermissionDenied},
+		{name: "approver confirms", actor: approver, permission: models.PermissionPayoutConfirm},
+		{name: "approver creates", actor: approver, permission: models.PermissionPayoutCreate, wantErr: ErrorPermissionDenied},
+		{name: "viewer", actor: viewer, permission: models.PermissionParticipantsInvite, wantErr: ErrorPermissionDenied},
+		{name: "deleted admin", actor: deleted, permission: models.PermissionTxCreate, wantErr: ErrorUnauthorizedAccess},
+		{name: "not a participant", actor: outsider, permission: models.PermissionTxCreate, wantErr: ErrorUnauthorizedAccess},
+		{
+			name:       "archived organization",
+			actor:      accountant,
+			permission: models.PermissionTxCreate,
+			archived:   true,
+			wantErr:    ErrorOrganizationArchived,
+		},
+		{
+			name:       "archived organization settings",
+			actor:      owner,
+			permission: models.PermissionOrganizationManage,
+			archived:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.org.ArchivedAt = time.Time{}
+
+			if tt.archived {
+				repo.org.ArchivedAt = time.Now()
+			}
+
+			actor, err := a.Authorize(as(tt.actor), repo.org.ID, tt.permission)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Authorize() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Authorize() error: %v", err)
+			}
+
+			if actor.Id() != tt.actor.Id() {
+				t.Fatalf("Authorize() = %s, want %s", actor.Id(), tt.actor.Id())
+			}
+		})
+	}
+
+	if _, err := a.Authorize(context.Background(), repo.org.ID, models.PermissionTxCreate); err == nil {
+		t.Fatalf("Authorize() without user error is nil")
+	}
+}
+
+func TestMemberEmployeeRecord(t *testing.T) {
+	accountant := newOrganizationUser(models.RoleAccountant)
+
+	// the user is an employee too, employee record has no role
+	employeeRecord := *accountant
+	employeeRecord.OrgRole = models.RoleUnknown
+	employeeRecord.Employee = &models.Employee{ID: uuid.New(), UserID: accountant.ID}
+
+	repo := &memoryOrganizations{
+		org:          &models.Organization{ID: uuid.New()},
+		participants: []models.OrganizationParticipant{&employeeRecord, accountant},
+	}
+
+	a := NewAuthorizer(slog.New(slog.NewTextHandler(io.Discard, nil)), repo)
+
+	actor, err := a.Member(as(accountant), repo.org.ID)
+	if err != nil {
+		t.Fatalf("Member() error: %v", err)
+	}
+
+	if actor.Role() != models.RoleAccountant {
+		t.Fatalf("Member() role = %s, want accountant", actor.Role())
+	}
+
+	if a.Can(&models.Employee{ID: uuid.New()}, models.PermissionTxCreate) {
+		t.Fatalf("Can() of employee without user account = true")
+	}
+
+	inactive := newOrganizationUser(models.RoleOwner)
+	inactive.Activated = false
+
+	if a.Can(inactive, models.PermissionTxCreate) || a.Can(nil, models.PermissionTxCreate) {
+		t.Fatalf("Can() of inactive or missing participant = true")
+	}
+}
@@ -0,0 +1,90 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/chainapi"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type AgreementDeployParams struct {
+	// Signer must be one of the multisig owners
+	Signer          *models.User
+	MultisigAddress []byte
+}
+
+// AgreementDeploy submits agreement contract deploy to the multisig. Agreement contract is owned by the multisig.
+// Submitted transaction must be confirmed and executed with MultisigExecuteDeploy
+func (i *chainInteractor) AgreementDeploy(
+	ctx context.Context,
+	params AgreementDeployParams,
+) (*SubmitTransactionResult, error) {
+	seed, err := i.chainAPISeed(params.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := i.client.AgreementDeploy(ctx, seed, chainapi.AgreementDeployRequest{
+		MultisigWallet: common.BytesToAddress(params.MultisigAddress),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error submit agreement deploy. %w", chainError(err))
+	}
+
+	return submitResult(resp), nil
+}
+
+type AgreementRequestParams struct {
+	// Signer must be one of the multisig owners
+	Signer           *models.User
+	MultisigAddress  []byte
+	AgreementAddress []byte
+	// URL is an oracle url returning boolean agreement outcome
+	URL string
+}
+
+// AgreementRequest submits oracle boolean data request to the multisig
+func (i *chainInteractor) AgreementRequest(
+	ctx context.Context,
+	params AgreementRequestParams,
+) (*SubmitTransactionResult, error) {
+	if params.URL == "" {
+		return nil, fmt.Errorf("error empty agreement oracle url")
+	}
+
+	submitted, err := i.MultisigSubmit(ctx, MultisigSubmitParams{
+		Signer:          params.Signer,
+		MultisigAddress: params.MultisigAddress,
+		Destination:     params.AgreementAddress,
+		Data:            chainapi.RequestCall(params.URL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error submit agreement request. %w", err)
+	}
+
+	return submitted, nil
+}
+
+type AgreementResponseParams struct {
+	Signer           *models.User
+	AgreementAddress []byte
+}
+
+// AgreementResponse reads the last oracle response stored in the agreement contract
+func (i *chainInteractor) AgreementResponse(
+	ctx context.Context,
+	params AgreementResponseParams,
+) (bool, error) {
+	outcome, err := i.client.AgreementResponse(
+		ctx,
+		i.readSeed(params.Signer),
+		common.BytesToAddress(params.AgreementAddress),
+	)
+	if err != nil {
+		return false, fmt.Errorf("error fetch agreement response. %w", err)
+	}
+
+	return outcome, nil
+}
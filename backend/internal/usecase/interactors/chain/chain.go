@@ -1,274 +1,388 @@
 package chain
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"log/slog"
-	"net/http"
+	"math/big"
 	"time"
 
-	"github.com/emochka2007/block-accounting/internal/pkg/config"
+	"github.com/emochka2007/block-accounting/internal/pkg/chainapi"
 	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
 	"github.com/emochka2007/block-accounting/internal/pkg/logger"
 	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/emochka2007/block-accounting/internal/pkg/signer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/ledger"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/users"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 )
 
+const (
+	JobKindMultisigDeploy  = "multisig_deploy"
+	JobKindPayrollDeploy   = "payroll_deploy"
+	JobKindSetSalary       = "set_salary"
+	JobKindMultisigDeposit = "multisig_deposit"
+)
+
+var (
+	ErrorChainAPIRequest      = chainapi.ErrorRequest
+	ErrorPayrollNotFound      = errors.New("payroll not found")
+	ErrorPayrollNotDeployed   = errors.New("payroll is not deployed")
+	ErrorPayrollNotPending    = errors.New("payroll is not pending")
+	ErrorSalaryNotFound       = errors.New("salary not found")
+	ErrorEmployeeNotFound     = errors.New("employee not found")
+	ErrorInvalidSalary        = errors.New("invalid salary amount")
+	ErrorNotMultisigOwner     = confirmations.ErrorNotMultisigOwner
+	ErrorMultisigNotFound     = confirmations.ErrorMultisigNotFound
+	ErrorInvalidDepositAmount = errors.New("invalid deposit amount")
+	ErrorNoCustodialSeed      = signer.ErrorNoSeed
+	ErrorTxReverted           = signer.ErrorReverted
+	// ErrorSignerUnsupported the transaction is signed by the chain-api wallet, which needs the seed
+	ErrorSignerUnsupported = errors.New("operation is not supported by the signer")
+)
+
 type ChainInteractor interface {
 	PubKey(ctx context.Context, user *models.User) ([]byte, error)
 
-	NewMultisig(ctx context.Context, params NewMultisigParams) error
+	NewMultisig(ctx context.Context, params NewMultisigParams) (*models.Job, error)
 	ListMultisigs(ctx context.Context, params ListMultisigsParams) ([]models.Multisig, error)
+	// MultisigDeposit sends ETH from the actor wallet to the multisig in background
+	MultisigDeposit(ctx context.Context, params MultisigDepositParams) (*models.Job, error)
 
-	PayrollDeploy(ctx context.Context, params PayrollDeployParams) error
+	// PayrollDeploy saves pending payroll. Payroll contract is deployed in background once
+	// payroll multisig owners confirm it
+	PayrollDeploy(ctx context.Context, params PayrollDeployParams) (*models.Payroll, error)
+	ConfirmPayroll(ctx context.Context, params ConfirmPayrollParams) (*models.Payroll, error)
 	ListPayrolls(ctx context.Context, params ListPayrollsParams) ([]models.Payroll, error)
+
+	NewSalary(ctx context.Context, params NewSalaryParams) (*models.Salary, error)
+	ListSalaries(ctx context.Context, params ListSalariesParams) ([]models.Salary, error)
+	OnChainSalary(ctx context.Context, params OnChainSalaryParams) (*big.Int, error)
+
+	PayrollPayout(ctx context.Context, params PayrollPayoutParams) (*SubmitTransactionResult, error)
+	PayrollDeposit(ctx context.Context, params PayrollDepositParams) (string, error)
+
+	MultisigSubmit(ctx context.Context, params MultisigSubmitParams) (*SubmitTransactionResult, error)
+	MultisigConfirm(ctx context.Context, params MultisigTxParams) (string, error)
+	MultisigRevoke(ctx context.Context, params MultisigTxParams) (string, error)
+	MultisigExecute(ctx context.Context, params MultisigTxParams) (string, error)
+	MultisigExecuteDeploy(ctx context.Context, params MultisigTxParams) (*ExecuteDeployResult, error)
+
+	LicenseDeploy(ctx context.Context, params LicenseDeployParams) (*SubmitTransactionResult, error)
+	LicenseRequest(ctx context.Context, params LicenseCallParams) (*SubmitTransactionResult, error)
+	LicenseSetPayoutContract(ctx context.Context, params LicenseCallParams) (*SubmitTransactionResult, error)
+	LicensePayout(ctx context.Context, params LicenseCallParams) (*SubmitTransactionResult, error)
+	LicenseInfo(ctx context.Context, params LicenseInfoParams) (*models.LicenseInfo, error)
+
+	AgreementDeploy(ctx context.Context, params AgreementDeployParams) (*SubmitTransactionResult, error)
+	AgreementRequest(ctx context.Context, params AgreementRequestParams) (*SubmitTransactionResult, error)
+	AgreementResponse(ctx context.Context, params AgreementResponseParams) (bool, error)
 }
 
 type chainInteractor struct {
-	log          *slog.Logger
-	config       config.Config
-	txRepository transactions.Repository
+	log                     *slog.Logger
+	client                  *chainapi.Client
+	signers                 signer.Provider
+	txRepository            transactions.Repository
+	usersRepo               users.Repository
+	orgRepository           organizations.Repository
+	jobsInteractor          jobs.JobsInteractor
+	confirmationsInteractor confirmations.ConfirmationsInteractor
+	ledgerInteractor        ledger.LedgerInteractor
+	authorizer              authorizer.Authorizer
 }
 
 func NewChainInteractor(
 	log *slog.Logger,
-	config config.Config,
+	client *chainapi.Client,
+	signers signer.Provider,
 	txRepository transactions.Repository,
+	usersRepo users.Repository,
+	orgRepository organizations.Repository,
+	jobsInteractor jobs.JobsInteractor,
+	confirmationsInteractor confirmations.ConfirmationsInteractor,
+	ledgerInteractor ledger.LedgerInteractor,
+	authorizer authorizer.Authorizer,
 ) ChainInteractor {
-	return &chainInteractor{
-		log:          log,
-		config:       config,
-		txRepository: txRepository,
+	i := &chainInteractor{
+		log:                     log,
+		client:                  client,
+		signers:                 signers,
+		txRepository:            txRepository,
+		usersRepo:               usersRepo,
+		orgRepository:           orgRepository,
+		jobsInteractor:          jobsInteractor,
+		confirmationsInteractor: confirmationsInteractor,
+		ledgerInteractor:        ledgerInteractor,
+		authorizer:              authorizer,
 	}
-}
 
-type NewMultisigParams struct {
-	Title         string
-	Owners        []models.OrganizationParticipant
-	Confirmations int
-}
+	jobsInteractor.RegisterHandler(JobKindMultisigDeploy, i.multisigDeployJob)
+	jobsInteractor.RegisterHandler(JobKindPayrollDeploy, i.payrollDeployJob)
+	jobsInteractor.RegisterHandler(JobKindSetSalary, i.setSalaryJob)
+	jobsInteractor.RegisterHandler(JobKindMultisigDeposit, i.multisigDepositJob)
 
-type newMultisigChainResponse struct {
-	Address string `json:"address"`
-}
+	confirmationsInteractor.RegisterEntity(models.MultisigConfirmationEntityTypePayroll, confirmations.EntityHandler{
+		Entity:     i.payrollConfirmationEntity,
+		Confirmed:  i.payrollConfirmed,
+		Permission: models.PermissionPayrollConfirm,
+	})
 
-func (i *chainInteractor) NewMultisig(ctx context.Context, params NewMultisigParams) error {
-	endpoint := i.config.ChainAPI.Host + "/multi-sig/deploy"
+	confirmationsInteractor.RegisterEntity(models.MultisigConfirmationEntityTypeSalary, confirmations.EntityHandler{
+		Entity:     i.salaryConfirmationEntity,
+		Confirmed:  i.salaryConfirmed,
+		Permission: models.PermissionPayrollConfirm,
+	})
 
-	i.log.Debug(
-		"deploy multisig",
-		slog.String("endpoint", endpoint),
-		slog.Any("params", params),
-	)
+	return i
+}
 
-	pks := make([]string, len(params.Owners))
+// chainError marks chain-api errors with 4xx status and reverted transactions as permanent,
+// since retrying them makes no sense
+func chainError(err error) error {
+	var apiErr *chainapi.Error
 
-	for i, owner := range params.Owners {
-		if owner.GetUser() == nil {
-			return fmt.Errorf("error invalis owners set")
-		}
+	if errors.As(err, &apiErr) && !apiErr.Temporary() {
+		return jobs.Permanent(err)
+	}
 
-		pks[i] = "0x" + common.Bytes2Hex(owner.GetUser().PublicKey())
+	if errors.Is(err, signer.ErrorReverted) || errors.Is(err, signer.ErrorNoSeed) {
+		return jobs.Permanent(err)
 	}
 
-	requestBody, err := json.Marshal(map[string]any{
-		"owners":        pks,
-		"confirmations": params.Confirmations,
-	})
-	if err != nil {
-		return fmt.Errorf("error marshal request body. %w", err)
+	return err
+}
+
+// depositWei validates deposit amount. Amount must be positive and fit into wei
+func depositWei(amount money.Amount) (*big.Int, error) {
+	if !amount.IsPositive() {
+		return nil, ErrorInvalidDepositAmount
 	}
 
-	user, err := ctxmeta.User(ctx)
+	wei, err := amount.Wei()
 	if err != nil {
-		return fmt.Errorf("error fetch user from context. %w", err)
+		return nil, errors.Join(ErrorInvalidDepositAmount, err)
 	}
 
-	organizationID, err := ctxmeta.OrganizationId(ctx)
+	return wei, nil
+}
+
+// signer returns signer of the multisig transactions sent on behalf of the user
+func (i *chainInteractor) signer(user *models.User) (signer.Signer, error) {
+	s, err := i.signers.Signer(user.Seed())
 	if err != nil {
-		return fmt.Errorf("error fetch organization id from context. %w", err)
+		return nil, fmt.Errorf("error create %s signer. %w", i.signers.Kind(), chainError(err))
 	}
 
-	go func() {
-		pid := uuid.Must(uuid.NewV7()).String()
-		startTime := time.Now()
+	return s, nil
+}
 
-		i.log.Info(
-			"new multisig worker started",
-			slog.String("pid", pid),
-		)
+// chainAPISeed returns the user seed for the transactions signed by the chain-api: contract deploys and deposits.
+// Local signer keeps seeds in the backend, so these transactions fail with ErrorSignerUnsupported
+func (i *chainInteractor) chainAPISeed(user models.UserIdentity) ([]byte, error) {
+	if err := i.chainAPISigned(); err != nil {
+		return nil, err
+	}
 
-		doneCh := make(chan struct{})
-
-		defer func() {
-			if err := recover(); err != nil {
-				i.log.Error("worker paniced!", slog.Any("panic", err))
-			}
-
-			doneCh <- struct{}{}
-			close(doneCh)
-		}()
-
-		go func() {
-			warn := time.After(1 * time.Minute)
-			select {
-			case <-doneCh:
-				i.log.Info(
-					"new multisig worker done",
-					slog.String("pid", pid),
-					slog.Time("started at", startTime),
-					slog.Time("done at", time.Now()),
-					slog.Duration("work time", time.Since(startTime)),
-				)
-			case <-warn:
-				i.log.Warn(
-					"new multisig worker seems sleeping",
-					slog.String("pid", pid),
-					slog.Duration("work time", time.Since(startTime)),
-				)
-			}
-		}()
-
-		requestContext, cancel := context.WithTimeout(context.TODO(), time.Minute*15)
-		defer cancel()
-
-		body := bytes.NewBuffer(requestBody)
-
-		req, err := http.NewRequestWithContext(requestContext, http.MethodPost, endpoint, body)
-		if err != nil {
-			i.log.Error(
-				"error build request",
-				logger.Err(err),
-			)
+	return user.Seed(), nil
+}
 
-			return
-		}
+func (i *chainInteractor) chainAPISigned() error {
+	if i.signers.Kind() == signer.KindLocal {
+		return fmt.Errorf("error transaction is signed by the chain-api. %w", ErrorSignerUnsupported)
+	}
 
-		req.Header.Add("Content-Type", "application/json")
-		req.Header.Add("X-Seed", common.Bytes2Hex(user.Seed()))
+	return nil
+}
 
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			i.log.Error(
-				"error send deploy multisig request",
-				slog.String("endpoint", endpoint),
-				slog.Any("params", params),
-			)
+// readSeed returns the user seed sent with the contract reads. Reads are sent without the seed
+// if the local signer is used
+func (i *chainInteractor) readSeed(user models.UserIdentity) []byte {
+	if i.signers.Kind() == signer.KindLocal {
+		return nil
+	}
 
-			return
-		}
+	return user.Seed()
+}
 
-		defer resp.Body.Close()
+// jobUser fetches user who enqueued the job
+func (i *chainInteractor) jobUser(ctx context.Context, job *models.Job) (*models.User, error) {
+	users, err := i.usersRepo.Get(ctx, users.GetParams{
+		Ids: uuid.UUIDs{job.CreatedBy},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch job creator. %w", err)
+	}
 
-		raw, err := io.ReadAll(resp.Body)
-		if err != nil {
-			i.log.Error(
-				"error read body",
-				logger.Err(err),
-			)
+	if len(users) == 0 {
+		return nil, jobs.Permanent(fmt.Errorf("error job creator not found"))
+	}
 
-			return
-		}
+	return users[0], nil
+}
 
-		respObject := new(newMultisigChainResponse)
+type NewMultisigParams struct {
+	Title         string
+	Owners        []models.OrganizationParticipant
+	Confirmations int
+}
 
-		if err := json.Unmarshal(raw, &respObject); err != nil {
-			i.log.Error(
-				"error parse chain-api response body",
-				logger.Err(err),
-			)
+type multisigDeployPayload struct {
+	Title         string     `json:"title"`
+	OwnersIDs     uuid.UUIDs `json:"owners_ids"`
+	Confirmations int        `json:"confirmations"`
+}
 
-			return
-		}
+type MultisigDeployResult struct {
+	MultisigID uuid.UUID `json:"multisig_id"`
+	Address    string    `json:"address"`
+}
 
-		if respObject.Address == "" {
-			i.log.Error(
-				"error multisig address is empty",
-			)
+func (i *chainInteractor) NewMultisig(ctx context.Context, params NewMultisigParams) (*models.Job, error) {
+	i.log.Debug(
+		"deploy multisig",
+		slog.String("title", params.Title),
+		slog.Int("owners", len(params.Owners)),
+		slog.Int("confirmations", params.Confirmations),
+	)
 
-			return
-		}
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
 
-		multisigAddress := common.Hex2Bytes(respObject.Address[2:])
+	if _, err = i.authorizer.Authorize(ctx, organizationID, models.PermissionMultisigCreate); err != nil {
+		return nil, err
+	}
 
-		createdAt := time.Now()
+	if err = i.chainAPISigned(); err != nil {
+		return nil, err
+	}
 
-		msg := models.Multisig{
-			ID:                    uuid.Must(uuid.NewV7()),
-			Title:                 params.Title,
-			Address:               multisigAddress,
-			OrganizationID:        organizationID,
-			Owners:                params.Owners,
-			ConfirmationsRequired: params.Confirmations,
-			CreatedAt:             createdAt,
-			UpdatedAt:             createdAt,
-		}
+	ownersIDs := make(uuid.UUIDs, len(params.Owners))
 
-		i.log.Debug(
-			"deploy multisig response",
-			slog.Int("code", resp.StatusCode),
-			slog.String("body", string(raw)),
-			slog.Any("parsed", respObject),
-			slog.Any("multisig object", msg),
-		)
+	for i, owner := range params.Owners {
+		if owner.GetUser() == nil {
+			return nil, fmt.Errorf("error invalis owners set")
+		}
 
-		if err := i.txRepository.AddMultisig(requestContext, msg); err != nil {
-			i.log.Error(
-				"error add new multisig",
-				logger.Err(err),
-			)
+		ownersIDs[i] = owner.Id()
+	}
 
-			return
-		}
-	}()
+	job, err := i.jobsInteractor.Enqueue(ctx, jobs.EnqueueParams{
+		Kind:           JobKindMultisigDeploy,
+		OrganizationID: organizationID,
+		// deploy is not idempotent, retry would deploy another contract and orphan the first one
+		MaxAttempts: 1,
+		Payload: multisigDeployPayload{
+			Title:         params.Title,
+			OwnersIDs:     ownersIDs,
+			Confirmations: params.Confirmations,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error enqueue multisig deploy job. %w", err)
+	}
 
-	return nil
+	return job, nil
 }
 
-func (i *chainInteractor) PubKey(ctx context.Context, user *models.User) ([]byte, error) {
-	pubAddr := i.config.ChainAPI.Host + "/address-from-seed"
+func (i *chainInteractor) multisigDeployJob(ctx context.Context, job *models.Job) (any, error) {
+	var payload multisigDeployPayload
+
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("error unmarshal job payload. %w", err))
+	}
+
+	user, err := i.jobUser(ctx, job)
+	if err != nil {
+		return nil, err
+	}
 
-	requestBody, err := json.Marshal(map[string]any{
-		"seedPhrase": user.Mnemonic,
+	owners, err := i.orgRepository.Participants(ctx, organizations.ParticipantsParams{
+		OrganizationId: job.OrganizationID,
+		Ids:            payload.OwnersIDs,
+		UsersOnly:      true,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error marshal request body. %w", err)
+		return nil, fmt.Errorf("error fetch multisig owners. %w", err)
 	}
 
-	body := bytes.NewBuffer(requestBody)
+	pks := make([]common.Address, len(owners))
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pubAddr, body)
-	if err != nil {
-		return nil, fmt.Errorf("error build request. %w", err)
+	for i, owner := range owners {
+		if owner.GetUser() == nil {
+			return nil, jobs.Permanent(fmt.Errorf("error invalis owners set"))
+		}
+
+		pks[i] = common.BytesToAddress(owner.GetUser().PublicKey())
 	}
 
-	req.Header.Add("X-Seed", common.Bytes2Hex(user.Seed()))
-	req.Header.Add("Content-Type", "application/json")
+	requestContext, cancel := context.WithTimeout(ctx, time.Minute*15)
+	defer cancel()
 
-	resp, err := http.DefaultClient.Do(req)
+	seed, err := i.chainAPISeed(user)
 	if err != nil {
-		return nil, fmt.Errorf("error fetch pub address. %w", err)
+		return nil, jobs.Permanent(err)
 	}
 
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
+	resp, err := i.client.MultisigDeploy(requestContext, seed, chainapi.MultisigDeployRequest{
+		Owners:        pks,
+		Confirmations: payload.Confirmations,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error read resp body. %w", err)
+		return nil, fmt.Errorf("error deploy multisig. %w", chainError(err))
+	}
+
+	createdAt := time.Now()
+
+	msg := models.Multisig{
+		ID:                    uuid.Must(uuid.NewV7()),
+		Title:                 payload.Title,
+		Address:               resp.Address.Bytes(),
+		OrganizationID:        job.OrganizationID,
+		Owners:                owners,
+		ConfirmationsRequired: payload.Confirmations,
+		CreatedAt:             createdAt,
+		UpdatedAt:             createdAt,
+	}
+
+	if err := i.txRepository.AddMultisig(ctx, msg); err != nil {
+		return nil, fmt.Errorf("error add new multisig. %w", err)
 	}
 
-	pubKeyStr := string(respBody)[2:]
+	return MultisigDeployResult{
+		MultisigID: msg.ID,
+		Address:    resp.Address.Hex(),
+	}, nil
+}
+
+func (i *chainInteractor) PubKey(ctx context.Context, user *models.User) ([]byte, error) {
+	// local signer derives the wallet itself, the mnemonic is not sent to the chain-api
+	if i.signers.Kind() == signer.KindLocal {
+		address, err := signer.Address(user.Seed())
+		if err != nil {
+			return nil, fmt.Errorf("error derive pub address. %w", err)
+		}
+
+		return address.Bytes(), nil
+	}
 
-	if pubKeyStr == "" {
-		return nil, fmt.Errorf("error empty public key")
+	address, err := i.client.AddressFromSeed(ctx, user.Mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch pub address. %w", err)
 	}
 
-	return common.Hex2Bytes(pubKeyStr), nil
+	return address.Bytes(), nil
 }
 
 type PayrollDeployParams struct {
@@ -277,26 +391,42 @@ type PayrollDeployParams struct {
 	Title      string
 }
 
-type newPayrollContractChainResponse struct {
-	Address string `json:"address"`
+type payrollDeployPayload struct {
+	PayrollID        uuid.UUID `json:"payroll_id"`
+	AuthorizedWallet string    `json:"authorized_wallet"`
+}
+
+type PayrollDeployResult struct {
+	PayrollID uuid.UUID `json:"payroll_id"`
+	Address   string    `json:"address"`
 }
 
 func (i *chainInteractor) PayrollDeploy(
 	ctx context.Context,
 	params PayrollDeployParams,
-) error {
+) (*models.Payroll, error) {
 	user, err := ctxmeta.User(ctx)
 	if err != nil {
-		return fmt.Errorf("error fetch user from context. %w", err)
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
 	}
 
 	if user.Id() != params.FirstAdmin.Id() || params.FirstAdmin.GetUser() == nil {
-		return fmt.Errorf("error unauthorized access")
+		return nil, fmt.Errorf("error first admin must be the payroll creator. %w", authorizer.ErrorUnauthorizedAccess)
 	}
 
 	organizationID, err := ctxmeta.OrganizationId(ctx)
 	if err != nil {
-		return fmt.Errorf("error fetch organization id from context. %w", err)
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	actor, err := i.authorizer.Authorize(ctx, organizationID, models.PermissionPayrollDeploy)
+	if err != nil {
+		return nil, err
+	}
+
+	// payroll contract is deployed by the chain-api once the payroll is confirmed
+	if err = i.chainAPISigned(); err != nil {
+		return nil, err
 	}
 
 	multisigs, err := i.ListMultisigs(ctx, ListMultisigsParams{
@@ -304,11 +434,11 @@ func (i *chainInteractor) PayrollDeploy(
 		IDs:            uuid.UUIDs{params.MultisigID},
 	})
 	if err != nil {
-		return fmt.Errorf("error fetch multisigs by id. %w", err)
+		return nil, fmt.Errorf("error fetch multisigs by id. %w", err)
 	}
 
 	if len(multisigs) == 0 {
-		return fmt.Errorf("error empty multisigs set")
+		return nil, fmt.Errorf("error empty multisigs set")
 	}
 
 	i.log.Debug(
@@ -316,159 +446,198 @@ func (i *chainInteractor) PayrollDeploy(
 		slog.String("organization id", organizationID.String()),
 		slog.String("multisig id", params.MultisigID.String()),
 		slog.String("multisig address", common.Bytes2Hex(multisigs[0].Address)),
-		slog.String("X-Seed header data", common.Bytes2Hex(user.Seed())),
 	)
 
-	maddr := common.Bytes2Hex(multisigs[0].Address)
+	if len(multisigs[0].Address) == 0 {
+		return nil, fmt.Errorf("empty multisig address")
+	}
+
+	payrollID := uuid.Must(uuid.NewV7())
 
-	if maddr == "" {
-		return fmt.Errorf("empty multisig address")
+	if err := i.txRepository.AddPayrollContract(ctx, transactions.AddPayrollContract{
+		ID:             payrollID,
+		Title:          params.Title,
+		OrganizationID: organizationID,
+		MultisigID:     params.MultisigID,
+		Status:         models.PayrollStatusPending,
+		CreatedBy:      user.Id(),
+		CreatedAt:      time.Now(),
+	}); err != nil {
+		return nil, fmt.Errorf("error add new payroll contract. %w", err)
 	}
 
-	if maddr[0] != 0 && maddr[1] != 'x' {
-		maddr = "0x" + maddr
+	// payroll creator is not required to be one of the multisig owners or to have confirm permission,
+	// in that case payroll awaits confirmations of the owners
+	if isMultisigOwner(&multisigs[0], user.Id()) && i.authorizer.Can(actor, models.PermissionPayrollConfirm) {
+		return i.ConfirmPayroll(ctx, ConfirmPayrollParams{
+			ID:             payrollID,
+			OrganizationID: organizationID,
+		})
 	}
 
-	requestBody, err := json.Marshal(map[string]any{
-		"authorizedWallet": maddr,
-	})
+	return i.payroll(ctx, organizationID, payrollID)
+}
+
+type ConfirmPayrollParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+func (i *chainInteractor) ConfirmPayroll(
+	ctx context.Context,
+	params ConfirmPayrollParams,
+) (*models.Payroll, error) {
+	if _, err := i.confirmationsInteractor.Confirm(ctx, confirmations.ConfirmParams{
+		OrganizationID: params.OrganizationID,
+		EntityType:     models.MultisigConfirmationEntityTypePayroll,
+		EntityID:       params.ID,
+	}); err != nil {
+		if errors.Is(err, confirmations.ErrorEntityNotPending) {
+			return nil, ErrorPayrollNotPending
+		}
+
+		return nil, err
+	}
+
+	return i.payroll(ctx, params.OrganizationID, params.ID)
+}
+
+func (i *chainInteractor) payrollConfirmationEntity(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*confirmations.Entity, error) {
+	payroll, err := i.payroll(ctx, organizationID, id)
 	if err != nil {
-		return fmt.Errorf("error marshal request body. %w", err)
+		return nil, err
 	}
 
-	go func() {
-		pid := uuid.Must(uuid.NewV7()).String()
-		startTime := time.Now()
+	return &confirmations.Entity{
+		ID:             payroll.ID,
+		OrganizationID: payroll.OrganizationID,
+		MultisigID:     payroll.MultisigID,
+		Pending:        payroll.Status == models.PayrollStatusPending,
+	}, nil
+}
 
-		i.log.Info(
-			"new paroll worker started",
-			slog.String("pid", pid),
-		)
+// payrollConfirmed starts deploy of the payroll confirmed by the multisig owners
+func (i *chainInteractor) payrollConfirmed(ctx context.Context, entity *confirmations.Entity) error {
+	multisigs, err := i.ListMultisigs(ctx, ListMultisigsParams{
+		OrganizationID: entity.OrganizationID,
+		IDs:            uuid.UUIDs{entity.MultisigID},
+	})
+	if err != nil {
+		return fmt.Errorf("error fetch payroll multisig. %w", err)
+	}
 
-		doneCh := make(chan struct{})
-
-		defer func() {
-			if err := recover(); err != nil {
-				i.log.Error("worker paniced!", slog.Any("panic", err))
-			}
-
-			doneCh <- struct{}{}
-			close(doneCh)
-		}()
-
-		go func() {
-			warn := time.After(2 * time.Minute)
-			select {
-			case <-doneCh:
-				i.log.Info(
-					"new paroll worker done",
-					slog.String("pid", pid),
-					slog.Time("started at", startTime),
-					slog.Time("done at", time.Now()),
-					slog.Duration("work time", time.Since(startTime)),
-				)
-			case <-warn:
-				i.log.Warn(
-					"new paroll worker seems sleeping",
-					slog.String("pid", pid),
-					slog.Duration("work time", time.Since(startTime)),
-				)
-			}
-		}()
-
-		requestContext, cancel := context.WithTimeout(context.TODO(), time.Minute*20)
-		defer cancel()
-
-		body := bytes.NewBuffer(requestBody)
-
-		endpoint := i.config.ChainAPI.Host + "/salaries/deploy"
-
-		i.log.Debug(
-			"request",
-			slog.String("body", string(requestBody)),
-			slog.String("endpoint", endpoint),
-		)
+	if len(multisigs) == 0 {
+		return confirmations.ErrorMultisigNotFound
+	}
 
-		req, err := http.NewRequestWithContext(requestContext, http.MethodPost, endpoint, body)
-		if err != nil {
-			i.log.Error(
-				"error build request",
-				logger.Err(fmt.Errorf("error build request. %w", err)),
-			)
-			return
+	if err = i.txRepository.UpdatePayroll(ctx, transactions.UpdatePayrollParams{
+		ID:             entity.ID,
+		OrganizationID: entity.OrganizationID,
+		Status:         models.PayrollStatusConfirmed,
+		FromStatuses:   []models.PayrollStatus{models.PayrollStatusPending},
+		UpdatedAt:      time.Now(),
+	}); err != nil {
+		if errors.Is(err, transactions.ErrorPayrollStatusConflict) {
+			return confirmations.ErrorEntityStatusConflict
 		}
 
-		req.Header.Add("Content-Type", "application/json")
-		req.Header.Add("X-Seed", common.Bytes2Hex(user.Seed()))
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			i.log.Error(
-				"error fetch deploy salary contract",
-				logger.Err(err),
-			)
+		return fmt.Errorf("error mark payroll as confirmed. %w", err)
+	}
 
-			return
-		}
+	if _, err = i.jobsInteractor.Enqueue(ctx, jobs.EnqueueParams{
+		Kind:           JobKindPayrollDeploy,
+		OrganizationID: entity.OrganizationID,
+		// deploy is not idempotent, retry would deploy another contract and orphan the first one
+		MaxAttempts: 1,
+		Payload: payrollDeployPayload{
+			PayrollID:        entity.ID,
+			AuthorizedWallet: common.BytesToAddress(multisigs[0].Address).Hex(),
+		},
+	}); err != nil {
+		return fmt.Errorf("error enqueue payroll deploy job. %w", err)
+	}
 
-		defer resp.Body.Close()
+	return nil
+}
 
-		raw, err := io.ReadAll(resp.Body)
-		if err != nil {
-			i.log.Error(
-				"error read body",
-				logger.Err(err),
-			)
+func (i *chainInteractor) payrollDeployJob(ctx context.Context, job *models.Job) (result any, err error) {
+	var payload payrollDeployPayload
 
-			return
-		}
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("error unmarshal job payload. %w", err))
+	}
 
-		respObject := new(newPayrollContractChainResponse)
+	payroll, err := i.payroll(ctx, job.OrganizationID, payload.PayrollID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
 
-		if err := json.Unmarshal(raw, &respObject); err != nil {
-			i.log.Error(
-				"error parse chain-api response body",
-				logger.Err(err),
-			)
+	if payroll.Status == models.PayrollStatusDeployed {
+		return PayrollDeployResult{
+			PayrollID: payroll.ID,
+			Address:   common.BytesToAddress(payroll.Address).Hex(),
+		}, nil
+	}
 
+	defer func() {
+		if !jobs.Failed(job, err) {
 			return
 		}
 
-		i.log.Debug(
-			"payroll deploy",
-			slog.Any("response", respObject),
-		)
+		if uErr := i.txRepository.UpdatePayroll(ctx, transactions.UpdatePayrollParams{
+			ID:             payroll.ID,
+			OrganizationID: payroll.OrganizationID,
+			Status:         models.PayrollStatusFailed,
+			FromStatuses:   []models.PayrollStatus{models.PayrollStatusConfirmed},
+			UpdatedAt:      time.Now(),
+		}); uErr != nil {
+			err = errors.Join(err, fmt.Errorf("error mark payroll as failed. %w", uErr))
+		}
+	}()
 
-		if respObject.Address == "" {
-			i.log.Error(
-				"error multisig address is empty",
-			)
+	if payroll.Status != models.PayrollStatusConfirmed {
+		return nil, jobs.Permanent(fmt.Errorf("error unexpected payroll status %s", payroll.Status))
+	}
 
-			return
-		}
+	user, err := i.jobUser(ctx, job)
+	if err != nil {
+		return nil, err
+	}
 
-		addr := common.Hex2Bytes(respObject.Address[2:])
+	requestContext, cancel := context.WithTimeout(ctx, time.Minute*20)
+	defer cancel()
 
-		createdAt := time.Now()
+	seed, err := i.chainAPISeed(user)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
 
-		if err := i.txRepository.AddPayrollContract(requestContext, transactions.AddPayrollContract{
-			ID:             uuid.Must(uuid.NewV7()),
-			Title:          params.Title,
-			Address:        addr,
-			OrganizationID: organizationID,
-			MultisigID:     params.MultisigID,
-			CreatedAt:      createdAt,
-		}); err != nil {
-			i.log.Error(
-				"error add new payroll contract",
-				logger.Err(err),
-			)
+	resp, err := i.client.PayrollDeploy(requestContext, seed, chainapi.PayrollDeployRequest{
+		AuthorizedWallet: common.HexToAddress(payload.AuthorizedWallet),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error deploy salary contract. %w", chainError(err))
+	}
 
-			return
-		}
-	}()
+	if err := i.txRepository.UpdatePayroll(ctx, transactions.UpdatePayrollParams{
+		ID:             payroll.ID,
+		OrganizationID: payroll.OrganizationID,
+		Address:        resp.Address.Bytes(),
+		Status:         models.PayrollStatusDeployed,
+		UpdatedAt:      time.Now(),
+	}); err != nil {
+		// contract is already deployed, retry would deploy another one
+		return nil, jobs.Permanent(fmt.Errorf("error save deployed payroll contract. %w", err))
+	}
 
-	return nil
+	return PayrollDeployResult{
+		PayrollID: payroll.ID,
+		Address:   resp.Address.Hex(),
+	}, nil
 }
 
 type ListMultisigsParams struct {
@@ -491,6 +660,142 @@ func (i *chainInteractor) ListMultisigs(
 	return multisigs, nil
 }
 
+type MultisigDepositParams struct {
+	MultisigID uuid.UUID
+	// Amount in ETH
+	Amount money.Amount
+}
+
+type multisigDepositPayload struct {
+	MultisigID uuid.UUID `json:"multisig_id"`
+	// Amount in ETH
+	Amount money.Decimal `json:"amount"`
+}
+
+type MultisigDepositResult struct {
+	MultisigID uuid.UUID `json:"multisig_id"`
+	TxHash     string    `json:"tx_hash"`
+	// ContractBalance in ETH after the deposit
+	ContractBalance money.Decimal `json:"contract_balance"`
+}
+
+func (i *chainInteractor) MultisigDeposit(ctx context.Context, params MultisigDepositParams) (*models.Job, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	if _, err = depositWei(params.Amount); err != nil {
+		return nil, err
+	}
+
+	if _, err = i.authorizer.Authorize(ctx, organizationID, models.PermissionTxCreate); err != nil {
+		return nil, err
+	}
+
+	if err = i.chainAPISigned(); err != nil {
+		return nil, err
+	}
+
+	multisigs, err := i.ListMultisigs(ctx, ListMultisigsParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{params.MultisigID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(multisigs) == 0 {
+		return nil, ErrorMultisigNotFound
+	}
+
+	job, err := i.jobsInteractor.Enqueue(ctx, jobs.EnqueueParams{
+		Kind:           JobKindMultisigDeposit,
+		OrganizationID: organizationID,
+		// deposit is not idempotent, retry could send funds twice
+		MaxAttempts: 1,
+		Payload: multisigDepositPayload{
+			MultisigID: params.MultisigID,
+			Amount:     params.Amount.Value,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error enqueue multisig deposit job. %w", err)
+	}
+
+	return job, nil
+}
+
+func (i *chainInteractor) multisigDepositJob(ctx context.Context, job *models.Job) (any, error) {
+	var payload multisigDepositPayload
+
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("error unmarshal job payload. %w", err))
+	}
+
+	amount := money.New(payload.Amount, money.ETH)
+
+	wei, err := depositWei(amount)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	user, err := i.jobUser(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	multisigs, err := i.ListMultisigs(ctx, ListMultisigsParams{
+		OrganizationID: job.OrganizationID,
+		IDs:            uuid.UUIDs{payload.MultisigID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(multisigs) == 0 {
+		return nil, jobs.Permanent(ErrorMultisigNotFound)
+	}
+
+	seed, err := i.chainAPISeed(user)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	resp, err := i.client.MultisigDeposit(ctx, seed, chainapi.DepositRequest{
+		ContractAddress: common.BytesToAddress(multisigs[0].Address),
+		Value:           wei,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error deposit multisig. %w", chainError(err))
+	}
+
+	// deposit is already sent, posting failure must not fail the job
+	if _, err = i.ledgerInteractor.Post(ctx, ledger.PostParams{
+		OrganizationID: job.OrganizationID,
+		Description:    "Multisig deposit",
+		SourceType:     models.LedgerEntrySourceMultisigDeposit,
+		SourceID:       job.ID,
+		CreatedBy:      user.Id(),
+		Lines: []ledger.PostLine{
+			ledger.DebitLine(models.LedgerAccountMultisigs, amount),
+			ledger.CreditLine(models.LedgerAccountContributions, amount),
+		},
+	}); err != nil && !errors.Is(err, ledger.ErrorEntryExists) {
+		i.log.Error(
+			"error post multisig deposit to ledger",
+			slog.String("tx hash", resp.TxHash),
+			logger.Err(err),
+		)
+	}
+
+	return MultisigDepositResult{
+		MultisigID:      payload.MultisigID,
+		TxHash:          resp.TxHash,
+		ContractBalance: resp.ContractBalance,
+	}, nil
+}
+
 type ListPayrollsParams struct {
 	IDs            []uuid.UUID
 	Limit          int
@@ -510,33 +815,748 @@ func (i *chainInteractor) ListPayrolls(
 		return nil, fmt.Errorf("error fetch payrolls from repository. %w", err)
 	}
 
-	return payrolls, nil
-}
+	if len(payrolls) == 0 {
+		return payrolls, nil
+	}
 
-type NewSalaryParams struct {
-	OrganizationID uuid.UUID
-	EmployeeID     uuid.UUID
+	ids := make(uuid.UUIDs, len(payrolls))
+
+	for i, p := range payrolls {
+		ids[i] = p.ID
+	}
+
+	confirmedBy, err := i.txRepository.MultisigConfirmations(ctx, transactions.MultisigConfirmationsParams{
+		EntityIDs:  ids,
+		EntityType: models.MultisigConfirmationEntityTypePayroll,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch payrolls confirmations. %w", err)
+	}
+
+	for i := range payrolls {
+		payrolls[i].ConfirmedBy = confirmedBy[payrolls[i].ID]
+		payrolls[i].Confirmations = len(payrolls[i].ConfirmedBy)
+	}
+
+	return payrolls, nil
+}
+
+func (i *chainInteractor) payroll(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*models.Payroll, error) {
+	payrolls, err := i.ListPayrolls(ctx, ListPayrollsParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{id},
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payrolls) == 0 {
+		return nil, ErrorPayrollNotFound
+	}
+
+	return &payrolls[0], nil
+}
+
+type NewSalaryParams struct {
+	PayrollID  uuid.UUID
+	EmployeeID uuid.UUID
+	// Amount in USD. Payroll contract accepts only whole numbers
+	Amount money.Amount
+}
+
+type setSalaryPayload struct {
+	SalaryID uuid.UUID `json:"salary_id"`
+}
+
+type SetSalaryResult struct {
+	SalaryID uuid.UUID `json:"salary_id"`
+	TxIndex  int64     `json:"tx_index"`
+	TxHash   string    `json:"tx_hash"`
+}
+
+type SubmitTransactionResult struct {
+	TxHash  string
+	TxIndex int64
 }
 
+func submitResult(resp *chainapi.SubmitTransactionResponse) *SubmitTransactionResult {
+	return &SubmitTransactionResult{
+		TxHash:  resp.TxHash,
+		TxIndex: resp.TxIndex,
+	}
+}
+
+// NewSalary saves new employee salary with the actor confirmation. Once payroll multisig owners confirm
+// the salary, it is submitted to the payroll contract through the payroll multisig.
+// Only multisig owners can submit transactions, so actor must be one of them.
 func (i *chainInteractor) NewSalary(
 	ctx context.Context,
 	params NewSalaryParams,
-) error {
+) (*models.Salary, error) {
 	user, err := ctxmeta.User(ctx)
 	if err != nil {
-		return fmt.Errorf("error fetch user from context. %w", err)
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
 	}
 
 	organizationID, err := ctxmeta.OrganizationId(ctx)
 	if err != nil {
-		return fmt.Errorf("error fetch organization id from context. %w", err)
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
 	}
 
-	i.log.Debug(
-		"not implemented",
-		slog.String("org id", organizationID.String()),
-		slog.Any("user", user),
-	)
+	if !params.Amount.IsPositive() || !params.Amount.Value.IsInteger() {
+		return nil, fmt.Errorf("error salary must be a positive whole number. %w", ErrorInvalidSalary)
+	}
+
+	actor, err := i.authorizer.Authorize(ctx, organizationID, models.PermissionSalarySet)
+	if err != nil {
+		return nil, err
+	}
+
+	payroll, multisig, err := i.payrollWithMultisig(ctx, organizationID, params.PayrollID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payroll.Status != models.PayrollStatusDeployed {
+		return nil, ErrorPayrollNotDeployed
+	}
+
+	if !isMultisigOwner(multisig, user.Id()) {
+		return nil, ErrorNotMultisigOwner
+	}
+
+	employee, err := i.participant(ctx, organizationID, params.EmployeeID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch employee. %w", err)
+	}
+
+	employeeAddress := ParticipantAddress(employee)
+	if len(employeeAddress) == 0 {
+		return nil, fmt.Errorf("error employee has no wallet address")
+	}
+
+	createdAt := time.Now()
+
+	salary := models.Salary{
+		ID:              uuid.Must(uuid.NewV7()),
+		OrganizationID:  organizationID,
+		PayrollID:       payroll.ID,
+		EmployeeID:      employee.Id(),
+		EmployeeAddress: employeeAddress,
+		Amount:          params.Amount,
+		Status:          models.SalaryStatusPending,
+		CreatedBy:       user.Id(),
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+	}
+
+	if err = i.txRepository.AddSalary(ctx, salary); err != nil {
+		return nil, fmt.Errorf("error add new salary. %w", err)
+	}
+
+	// creator without confirm permission leaves the salary awaiting owners confirmations
+	if !i.authorizer.Can(actor, models.PermissionPayrollConfirm) {
+		return i.salary(ctx, organizationID, salary.ID)
+	}
+
+	if _, err = i.confirmationsInteractor.Confirm(ctx, confirmations.ConfirmParams{
+		OrganizationID: organizationID,
+		EntityType:     models.MultisigConfirmationEntityTypeSalary,
+		EntityID:       salary.ID,
+	}); err != nil {
+		return nil, fmt.Errorf("error confirm salary. %w", err)
+	}
+
+	return i.salary(ctx, organizationID, salary.ID)
+}
+
+func (i *chainInteractor) salaryConfirmationEntity(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*confirmations.Entity, error) {
+	salary, err := i.salary(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	payroll, err := i.payroll(ctx, organizationID, salary.PayrollID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &confirmations.Entity{
+		ID:             salary.ID,
+		OrganizationID: salary.OrganizationID,
+		MultisigID:     payroll.MultisigID,
+		Pending:        salary.Status == models.SalaryStatusPending,
+	}, nil
+}
+
+// salaryConfirmed starts submission of the salary confirmed by the payroll multisig owners
+func (i *chainInteractor) salaryConfirmed(ctx context.Context, entity *confirmations.Entity) error {
+	if err := i.txRepository.UpdateSalary(ctx, transactions.UpdateSalaryParams{
+		ID:           entity.ID,
+		Status:       models.SalaryStatusConfirmed,
+		FromStatuses: []models.SalaryStatus{models.SalaryStatusPending},
+		UpdatedAt:    time.Now(),
+	}); err != nil {
+		if errors.Is(err, transactions.ErrorSalaryStatusConflict) {
+			return confirmations.ErrorEntityStatusConflict
+		}
+
+		return fmt.Errorf("error mark salary as confirmed. %w", err)
+	}
+
+	if _, err := i.jobsInteractor.Enqueue(ctx, jobs.EnqueueParams{
+		Kind:           JobKindSetSalary,
+		OrganizationID: entity.OrganizationID,
+		Payload: setSalaryPayload{
+			SalaryID: entity.ID,
+		},
+	}); err != nil {
+		return fmt.Errorf("error enqueue set salary job. %w", err)
+	}
+
+	return nil
+}
+
+// setSalaryJob submits confirmed salary to the payroll multisig, confirms it on behalf of the owners
+// who confirmed the salary and executes it. Submitted tx index is saved, so retried job does not submit twice
+func (i *chainInteractor) setSalaryJob(ctx context.Context, job *models.Job) (result any, err error) {
+	var payload setSalaryPayload
+
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("error unmarshal job payload. %w", err))
+	}
+
+	salary, err := i.salary(ctx, job.OrganizationID, payload.SalaryID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	if salary.Status == models.SalaryStatusExecuted {
+		return SetSalaryResult{SalaryID: salary.ID, TxIndex: salary.TxIndex, TxHash: salary.TxHash}, nil
+	}
+
+	defer func() {
+		if !jobs.Failed(job, err) {
+			return
+		}
+
+		if uErr := i.txRepository.UpdateSalary(ctx, transactions.UpdateSalaryParams{
+			ID:        salary.ID,
+			Status:    models.SalaryStatusFailed,
+			UpdatedAt: time.Now(),
+		}); uErr != nil {
+			err = errors.Join(err, fmt.Errorf("error mark salary as failed. %w", uErr))
+		}
+	}()
+
+	payroll, multisig, err := i.payrollWithMultisig(ctx, job.OrganizationID, salary.PayrollID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	submitter, confirmers, err := i.salarySigners(ctx, salary, multisig.ConfirmationsRequired)
+	if err != nil {
+		return nil, err
+	}
+
+	usd, ok := salary.Amount.Value.Int()
+	if !ok {
+		return nil, jobs.Permanent(fmt.Errorf("error salary %s is not a whole number. %w", salary.Amount, ErrorInvalidSalary))
+	}
+
+	requestContext, cancel := context.WithTimeout(ctx, time.Minute*15)
+	defer cancel()
+
+	if salary.Status == models.SalaryStatusConfirmed {
+		submitted, err := i.MultisigSubmit(requestContext, MultisigSubmitParams{
+			Signer:          submitter,
+			MultisigAddress: multisig.Address,
+			Destination:     payroll.Address,
+			Data:            chainapi.SetSalaryCall(common.BytesToAddress(salary.EmployeeAddress), usd),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error submit set salary transaction. %w", err)
+		}
+
+		salary.Status = models.SalaryStatusSubmitted
+		salary.TxIndex = submitted.TxIndex
+
+		if err := i.txRepository.UpdateSalary(ctx, transactions.UpdateSalaryParams{
+			ID:        salary.ID,
+			Status:    salary.Status,
+			TxIndex:   submitted.TxIndex,
+			TxHash:    submitted.TxHash,
+			UpdatedAt: time.Now(),
+		}); err != nil {
+			return nil, fmt.Errorf("error save submitted salary. %w", err)
+		}
+	}
+
+	if salary.Status != models.SalaryStatusSubmitted {
+		return nil, jobs.Permanent(fmt.Errorf("error unexpected salary status %s", salary.Status))
+	}
+
+	for _, confirmer := range confirmers {
+		if _, err = i.MultisigConfirm(requestContext, MultisigTxParams{
+			Signer:          confirmer,
+			MultisigAddress: multisig.Address,
+			TxIndex:         salary.TxIndex,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	txHash, err := i.MultisigExecute(requestContext, MultisigTxParams{
+		Signer:          submitter,
+		MultisigAddress: multisig.Address,
+		TxIndex:         salary.TxIndex,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.txRepository.UpdateSalary(ctx, transactions.UpdateSalaryParams{
+		ID:        salary.ID,
+		Status:    models.SalaryStatusExecuted,
+		TxHash:    txHash,
+		UpdatedAt: time.Now(),
+	}); err != nil {
+		// salary is already set on-chain, retry would fail anyway
+		return nil, jobs.Permanent(fmt.Errorf("error save executed salary. %w", err))
+	}
+
+	return SetSalaryResult{
+		SalaryID: salary.ID,
+		TxIndex:  salary.TxIndex,
+		TxHash:   txHash,
+	}, nil
+}
+
+// salarySigners returns salary creator, who submits and executes it,
+// and owners whose confirmations are sent on-chain
+func (i *chainInteractor) salarySigners(
+	ctx context.Context,
+	salary *models.Salary,
+	confirmationsRequired int,
+) (*models.User, []*models.User, error) {
+	confirmedBy := salary.ConfirmedBy
+	if len(confirmedBy) > confirmationsRequired {
+		confirmedBy = confirmedBy[:confirmationsRequired]
+	}
+
+	usersList, err := i.usersRepo.Get(ctx, users.GetParams{
+		Ids: append(uuid.UUIDs{salary.CreatedBy}, confirmedBy...),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetch salary signers. %w", err)
+	}
+
+	usersMap := make(map[uuid.UUID]*models.User, len(usersList))
+
+	for _, u := range usersList {
+		usersMap[u.Id()] = u
+	}
+
+	submitter, ok := usersMap[salary.CreatedBy]
+	if !ok {
+		return nil, nil, jobs.Permanent(fmt.Errorf("error salary creator not found"))
+	}
+
+	confirmers := make([]*models.User, 0, len(confirmedBy))
+
+	for _, id := range confirmedBy {
+		u, ok := usersMap[id]
+		if !ok {
+			return nil, nil, jobs.Permanent(fmt.Errorf("error salary confirmer %s not found", id))
+		}
+
+		confirmers = append(confirmers, u)
+	}
+
+	if len(confirmers) < confirmationsRequired {
+		return nil, nil, jobs.Permanent(fmt.Errorf("error not enough salary confirmations"))
+	}
+
+	return submitter, confirmers, nil
+}
+
+type ListSalariesParams struct {
+	OrganizationID uuid.UUID
+	IDs            uuid.UUIDs
+	PayrollIDs     uuid.UUIDs
+	EmployeeIDs    uuid.UUIDs
+	Limit          int
+}
+
+func (i *chainInteractor) ListSalaries(
+	ctx context.Context,
+	params ListSalariesParams,
+) ([]models.Salary, error) {
+	salaries, err := i.txRepository.ListSalaries(ctx, transactions.ListSalariesParams{
+		IDs:            params.IDs,
+		OrganizationID: params.OrganizationID,
+		PayrollIDs:     params.PayrollIDs,
+		EmployeeIDs:    params.EmployeeIDs,
+		Limit:          int64(params.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch salaries from repository. %w", err)
+	}
+
+	if len(salaries) == 0 {
+		return salaries, nil
+	}
+
+	ids := make(uuid.UUIDs, len(salaries))
+
+	for i, s := range salaries {
+		ids[i] = s.ID
+	}
+
+	confirmedBy, err := i.txRepository.MultisigConfirmations(ctx, transactions.MultisigConfirmationsParams{
+		EntityIDs:  ids,
+		EntityType: models.MultisigConfirmationEntityTypeSalary,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch salaries confirmations. %w", err)
+	}
+
+	for i := range salaries {
+		salaries[i].ConfirmedBy = confirmedBy[salaries[i].ID]
+		salaries[i].Confirmations = len(salaries[i].ConfirmedBy)
+	}
+
+	return salaries, nil
+}
+
+func (i *chainInteractor) salary(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*models.Salary, error) {
+	salaries, err := i.ListSalaries(ctx, ListSalariesParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{id},
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(salaries) == 0 {
+		return nil, ErrorSalaryNotFound
+	}
+
+	return &salaries[0], nil
+}
+
+type OnChainSalaryParams struct {
+	PayrollAddress  []byte
+	EmployeeAddress []byte
+}
+
+// OnChainSalary reads employee salary in USD stored in the payroll contract
+func (i *chainInteractor) OnChainSalary(
+	ctx context.Context,
+	params OnChainSalaryParams,
+) (*big.Int, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	resp, err := i.client.Salary(ctx, i.readSeed(user), chainapi.SalaryRequest{
+		ContractAddress: common.BytesToAddress(params.PayrollAddress),
+		EmployeeAddress: common.BytesToAddress(params.EmployeeAddress),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch on-chain salary. %w", err)
+	}
+
+	return &resp.SalaryInUSD.Int, nil
+}
+
+type PayrollPayoutParams struct {
+	// Signer must be one of the multisig owners
+	Signer          *models.User
+	MultisigAddress []byte
+	PayrollAddress  []byte
+	EmployeeAddress []byte
+}
+
+// PayrollPayout submits payoutInETH payroll contract call to the payroll multisig.
+// Submitted transaction must be confirmed and executed by the multisig owners.
+func (i *chainInteractor) PayrollPayout(
+	ctx context.Context,
+	params PayrollPayoutParams,
+) (*SubmitTransactionResult, error) {
+	submitted, err := i.MultisigSubmit(ctx, MultisigSubmitParams{
+		Signer:          params.Signer,
+		MultisigAddress: params.MultisigAddress,
+		Destination:     params.PayrollAddress,
+		Data:            chainapi.PayoutInETHCall(common.BytesToAddress(params.EmployeeAddress)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error submit payout transaction. %w", err)
+	}
+
+	return submitted, nil
+}
+
+type PayrollDepositParams struct {
+	Signer         *models.User
+	PayrollAddress []byte
+	// Amount in ETH
+	Amount money.Amount
+}
+
+// PayrollDeposit sends ETH from the signer wallet to the payroll contract. Returns transaction hash
+func (i *chainInteractor) PayrollDeposit(
+	ctx context.Context,
+	params PayrollDepositParams,
+) (string, error) {
+	wei, err := depositWei(params.Amount)
+	if err != nil {
+		return "", err
+	}
+
+	seed, err := i.chainAPISeed(params.Signer)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := i.client.PayrollDeposit(ctx, seed, chainapi.DepositRequest{
+		ContractAddress: common.BytesToAddress(params.PayrollAddress),
+		Value:           wei,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error deposit payroll. %w", err)
+	}
+
+	return resp.Hash, nil
+}
+
+type MultisigSubmitParams struct {
+	// Signer must be one of the multisig owners
+	Signer          *models.User
+	MultisigAddress []byte
+	Destination     []byte
+	// Value in wei
+	Value *big.Int
+	Data  []byte
+}
+
+// MultisigSubmit submits new transaction to the multisig. Submitted transaction is not confirmed by the signer
+func (i *chainInteractor) MultisigSubmit(
+	ctx context.Context,
+	params MultisigSubmitParams,
+) (*SubmitTransactionResult, error) {
+	value := params.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	s, err := i.signer(params.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.SubmitTransaction(ctx, chainapi.SubmitTransactionRequest{
+		ContractAddress: common.BytesToAddress(params.MultisigAddress),
+		Destination:     common.BytesToAddress(params.Destination),
+		Value:           value.String(),
+		Data:            params.Data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error submit multisig transaction. %w", chainError(err))
+	}
+
+	return submitResult(resp), nil
+}
+
+type MultisigTxParams struct {
+	// Signer must be one of the multisig owners
+	Signer          *models.User
+	MultisigAddress []byte
+	TxIndex         int64
+}
+
+func (p MultisigTxParams) request() chainapi.MultisigTxRequest {
+	return chainapi.MultisigTxRequest{
+		ContractAddress: common.BytesToAddress(p.MultisigAddress),
+		Index:           p.TxIndex,
+	}
+}
+
+// MultisigConfirm confirms submitted multisig transaction on behalf of the signer. Returns transaction hash
+func (i *chainInteractor) MultisigConfirm(ctx context.Context, params MultisigTxParams) (string, error) {
+	s, err := i.signer(params.Signer)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := s.ConfirmTransaction(ctx, params.request())
+	if err != nil {
+		// confirmation sent by the previous attempt is already on-chain
+		if errors.Is(err, chainapi.ErrorTxAlreadyConfirmed) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("error confirm multisig transaction. %w", chainError(err))
+	}
+
+	return resp.TxHash, nil
+}
+
+// MultisigRevoke revokes signer confirmation of the submitted multisig transaction. Returns transaction hash
+func (i *chainInteractor) MultisigRevoke(ctx context.Context, params MultisigTxParams) (string, error) {
+	s, err := i.signer(params.Signer)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := s.RevokeConfirmation(ctx, params.request())
+	if err != nil {
+		// confirmation has not been sent on-chain yet
+		if errors.Is(err, chainapi.ErrorTxNotConfirmed) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("error revoke multisig transaction confirmation. %w", chainError(err))
+	}
+
+	return resp.Hash, nil
+}
+
+// MultisigExecute executes confirmed multisig transaction. Returns transaction hash
+func (i *chainInteractor) MultisigExecute(ctx context.Context, params MultisigTxParams) (string, error) {
+	s, err := i.signer(params.Signer)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := s.ExecuteTransaction(ctx, chainapi.ExecuteTransactionRequest{
+		ContractAddress: common.BytesToAddress(params.MultisigAddress),
+		Index:           params.TxIndex,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error execute multisig transaction. %w", chainError(err))
+	}
+
+	return resp.TxHash, nil
+}
+
+type ExecuteDeployResult struct {
+	TxHash          string
+	DeployedAddress []byte
+}
+
+// MultisigExecuteDeploy executes confirmed multisig transaction deploying a contract. Returns deployed contract address
+func (i *chainInteractor) MultisigExecuteDeploy(
+	ctx context.Context,
+	params MultisigTxParams,
+) (*ExecuteDeployResult, error) {
+	s, err := i.signer(params.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.ExecuteTransaction(ctx, chainapi.ExecuteTransactionRequest{
+		ContractAddress: common.BytesToAddress(params.MultisigAddress),
+		Index:           params.TxIndex,
+		IsDeploy:        true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error execute multisig deploy transaction. %w", chainError(err))
+	}
+
+	return &ExecuteDeployResult{
+		TxHash:          resp.TxHash,
+		DeployedAddress: resp.DeployedAddress.Bytes(),
+	}, nil
+}
+
+func (i *chainInteractor) participant(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (models.OrganizationParticipant, error) {
+	participants, err := i.orgRepository.Participants(ctx, organizations.ParticipantsParams{
+		OrganizationId: organizationID,
+		Ids:            uuid.UUIDs{id},
+		ActiveOnly:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch participant. %w", err)
+	}
+
+	if len(participants) == 0 {
+		return nil, ErrorEmployeeNotFound
+	}
+
+	return participants[0], nil
+}
+
+func (i *chainInteractor) payrollWithMultisig(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	payrollID uuid.UUID,
+) (*models.Payroll, *models.Multisig, error) {
+	payrolls, err := i.txRepository.ListPayrolls(ctx, transactions.ListPayrollsParams{
+		IDs:            uuid.UUIDs{payrollID},
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetch payroll. %w", err)
+	}
+
+	if len(payrolls) == 0 {
+		return nil, nil, ErrorPayrollNotFound
+	}
+
+	multisigs, err := i.txRepository.ListMultisig(ctx, transactions.ListMultisigsParams{
+		IDs:            uuid.UUIDs{payrolls[0].MultisigID},
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetch payroll multisig. %w", err)
+	}
+
+	if len(multisigs) == 0 {
+		return nil, nil, fmt.Errorf("error payroll multisig not found")
+	}
+
+	return &payrolls[0], &multisigs[0], nil
+}
+
+func isMultisigOwner(multisig *models.Multisig, userID uuid.UUID) bool {
+	for _, owner := range multisig.Owners {
+		if owner.Id() == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParticipantAddress returns wallet address the participant receives payments to
+func ParticipantAddress(participant models.OrganizationParticipant) []byte {
+	if e := participant.GetEmployee(); e != nil && len(e.WalletAddress) > 0 {
+		return e.WalletAddress
+	}
+
+	if u := participant.GetUser(); u != nil {
+		return u.PublicKey()
+	}
 
 	return nil
 }
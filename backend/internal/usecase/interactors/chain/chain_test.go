@@ -0,0 +1,332 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/chainapi/chainapitest"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/signer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/users"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+const deployPath = "/multi-sig/deploy"
+
+// Stubs embed the interfaces, so calls the deploy job does not make panic
+
+type jobsStub struct {
+	jobs.JobsInteractor
+
+	enqueued []jobs.EnqueueParams
+}
+
+func (s *jobsStub) RegisterHandler(string, jobs.Handler) {}
+
+func (s *jobsStub) Enqueue(_ context.Context, params jobs.EnqueueParams) (*models.Job, error) {
+	s.enqueued = append(s.enqueued, params)
+
+	return &models.Job{ID: uuid.New(), Kind: params.Kind, MaxAttempts: params.MaxAttempts}, nil
+}
+
+type confirmationsStub struct {
+	confirmations.ConfirmationsInteractor
+}
+
+func (confirmationsStub) RegisterEntity(models.MultisigConfirmationEntityType, confirmations.EntityHandler) {
+}
+
+type authorizerStub struct {
+	authorizer.Authorizer
+}
+
+func (authorizerStub) Authorize(context.Context, uuid.UUID, models.Permission) (*models.OrganizationUser, error) {
+	return new(models.OrganizationUser), nil
+}
+
+type usersStub struct {
+	users.Repository
+
+	users []*models.User
+}
+
+func (s *usersStub) Get(_ context.Context, params users.GetParams) ([]*models.User, error) {
+	found := make([]*models.User, 0, len(params.Ids))
+
+	for _, u := range s.users {
+		for _, id := range params.Ids {
+			if u.Id() == id {
+				found = append(found, u)
+			}
+		}
+	}
+
+	return found, nil
+}
+
+type organizationsStub struct {
+	organizations.Repository
+
+	participants []models.OrganizationParticipant
+}
+
+func (s *organizationsStub) Participants(
+	context.Context,
+	organizations.ParticipantsParams,
+) ([]models.OrganizationParticipant, error) {
+	return s.participants, nil
+}
+
+type transactionsStub struct {
+	transactions.Repository
+
+	multisigs []models.Multisig
+}
+
+func (s *transactionsStub) AddMultisig(_ context.Context, multisig models.Multisig) error {
+	s.multisigs = append(s.multisigs, multisig)
+
+	return nil
+}
+
+type deployFixture struct {
+	server     *chainapitest.Server
+	interactor *chainInteractor
+	jobs       *jobsStub
+	txs        *transactionsStub
+	creator    *models.User
+	ownersIDs  uuid.UUIDs
+	owners     []common.Address
+}
+
+func newDeployFixture(t *testing.T) *deployFixture {
+	t.Helper()
+
+	server := chainapitest.NewServer()
+	t.Cleanup(server.Close)
+
+	f := &deployFixture{
+		server: server,
+		jobs:   new(jobsStub),
+		txs:    new(transactionsStub),
+	}
+
+	var (
+		usersList    []*models.User
+		participants []models.OrganizationParticipant
+	)
+
+	for n := range 3 {
+		seed := make([]byte, 64)
+		seed[0] = byte(n + 1)
+
+		address, err := chainapitest.AddressFromSeed(seed)
+		if err != nil {
+			t.Fatalf("AddressFromSeed() error: %v", err)
+		}
+
+		user := &models.User{ID: uuid.New(), Bip39Seed: seed, PK: address.Bytes()}
+
+		usersList = append(usersList, user)
+		participants = append(participants, &models.OrganizationUser{User: *user})
+
+		f.ownersIDs = append(f.ownersIDs, user.ID)
+		f.owners = append(f.owners, address)
+	}
+
+	f.creator = usersList[0]
+
+	client := server.Client()
+
+	f.interactor = NewChainInteractor(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		client,
+		signer.NewRemoteProvider(client),
+		f.txs,
+		&usersStub{users: usersList},
+		&organizationsStub{participants: participants},
+		f.jobs,
+		confirmationsStub{},
+		nil,
+		authorizerStub{},
+	).(*chainInteractor)
+
+	return f
+}
+
+func (f *deployFixture) job(t *testing.T, confirmations int) *models.Job {
+	t.Helper()
+
+	payload, err := json.Marshal(multisigDeployPayload{
+		Title:         "Treasury",
+		OwnersIDs:     f.ownersIDs,
+		Confirmations: confirmations,
+	})
+	if err != nil {
+		t.Fatalf("marshal payload error: %v", err)
+	}
+
+	return &models.Job{
+		ID:             uuid.New(),
+		OrganizationID: uuid.New(),
+		CreatedBy:      f.creator.ID,
+		Kind:           JobKindMultisigDeploy,
+		Payload:        payload,
+		Attempts:       1,
+		MaxAttempts:    1,
+	}
+}
+
+func TestNewMultisigEnqueuesSingleAttempt(t *testing.T) {
+	f := newDeployFixture(t)
+
+	ctx := ctxmeta.OrganizationIdContext(context.Background(), uuid.New())
+
+	if _, err := f.interactor.NewMultisig(ctx, NewMultisigParams{Title: "Treasury", Confirmations: 2}); err != nil {
+		t.Fatalf("NewMultisig() error: %v", err)
+	}
+
+	if len(f.jobs.enqueued) != 1 {
+		t.Fatalf("NewMultisig() enqueued %d jobs, want 1", len(f.jobs.enqueued))
+	}
+
+	// retried deploy would orphan the contract deployed by the first attempt
+	if params := f.jobs.enqueued[0]; params.Kind != JobKindMultisigDeploy || params.MaxAttempts != 1 {
+		t.Fatalf("NewMultisig() enqueued %s with %d attempts, want %s with 1", params.Kind, params.MaxAttempts,
+			JobKindMultisigDeploy)
+	}
+}
+
+func TestMultisigDeployJob(t *testing.T) {
+	f := newDeployFixture(t)
+	job := f.job(t, 2)
+
+	result, err := f.interactor.multisigDeployJob(context.Background(), job)
+	if err != nil {
+		t.Fatalf("multisigDeployJob() error: %v", err)
+	}
+
+	if calls := f.server.Calls(deployPath); calls != 1 {
+		t.Fatalf("chain-api deploy called %d times, want 1", calls)
+	}
+
+	if len(f.txs.multisigs) != 1 {
+		t.Fatalf("multisigDeployJob() saved %d multisigs, want 1", len(f.txs.multisigs))
+	}
+
+	saved := f.txs.multisigs[0]
+	deployed := result.(MultisigDeployResult)
+
+	if saved.ID != deployed.MultisigID || common.BytesToAddress(saved.Address).Hex() != deployed.Address {
+		t.Fatalf("saved multisig %s at %x, job result %+v", saved.ID, saved.Address, deployed)
+	}
+
+	if saved.OrganizationID != job.OrganizationID || saved.ConfirmationsRequired != 2 || len(saved.Owners) != 3 {
+		t.Fatalf("saved multisig = %+v", saved)
+	}
+
+	owners, err := f.server.Client().MultisigOwners(context.Background(), nil, common.BytesToAddress(saved.Address))
+	if err != nil {
+		t.Fatalf("MultisigOwners() error: %v", err)
+	}
+
+	if len(owners) != len(f.owners) {
+		t.Fatalf("deployed multisig owners = %v, want %v", owners, f.owners)
+	}
+
+	for n := range owners {
+		if owners[n] != f.owners[n] {
+			t.Fatalf("deployed multisig owners = %v, want %v", owners, f.owners)
+		}
+	}
+}
+
+func TestMultisigDeployJobFailure(t *testing.T) {
+	tests := []struct {
+		name          string
+		confirmations int
+		fail          func(s *chainapitest.Server)
+	}{
+		{
+			name:          "chain-api unavailable",
+			confirmations: 2,
+			fail: func(s *chainapitest.Server) {
+				s.FailNext(deployPath, http.StatusBadGateway, "node is not responding")
+			},
+		},
+		{
+			name:          "deploy reverted",
+			confirmations: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newDeployFixture(t)
+			job := f.job(t, tt.confirmations)
+
+			if tt.fail != nil {
+				tt.fail(f.server)
+			}
+
+			_, err := f.interactor.multisigDeployJob(context.Background(), job)
+			if err == nil {
+				t.Fatalf("multisigDeployJob() error is nil")
+			}
+
+			// the only attempt failed, so the job is not retried
+			if !jobs.Failed(job, err) {
+				t.Fatalf("job with failed deploy will be retried")
+			}
+
+			if len(f.txs.multisigs) != 0 {
+				t.Fatalf("multisig saved after failed deploy")
+			}
+
+			if calls := f.server.Calls(deployPath); calls != 1 {
+				t.Fatalf("chain-api deploy called %d times, want 1", calls)
+			}
+		})
+	}
+}
+
+func TestLocalSignerDoesNotDeployThroughChainAPI(t *testing.T) {
+	f := newDeployFixture(t)
+	f.interactor.signers = signer.NewLocalProvider(slog.New(slog.NewTextHandler(io.Discard, nil)), nil, 80002)
+
+	ctx := ctxmeta.OrganizationIdContext(context.Background(), uuid.New())
+
+	_, err := f.interactor.NewMultisig(ctx, NewMultisigParams{Title: "Treasury", Confirmations: 2})
+	if !errors.Is(err, ErrorSignerUnsupported) {
+		t.Fatalf("NewMultisig() error = %v, want ErrorSignerUnsupported", err)
+	}
+
+	if len(f.jobs.enqueued) != 0 {
+		t.Fatalf("NewMultisig() enqueued deploy job with the local signer")
+	}
+
+	// job enqueued before the signer was switched
+	job := f.job(t, 2)
+	job.MaxAttempts = 3
+
+	_, err = f.interactor.multisigDeployJob(context.Background(), job)
+	if !errors.Is(err, ErrorSignerUnsupported) || !jobs.Failed(job, err) {
+		t.Fatalf("multisigDeployJob() error = %v, want permanent ErrorSignerUnsupported", err)
+	}
+
+	if calls := f.server.Calls(deployPath); calls != 0 {
+		t.Fatalf("chain-api deploy called %d times with the local signer", calls)
+	}
+}
@@ -0,0 +1,168 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/chainapi"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type LicenseDeployParams struct {
+	// Signer must be one of the multisig owners
+	Signer          *models.User
+	MultisigAddress []byte
+	Shareholders    []models.LicenseShareholder
+}
+
+// LicenseDeploy submits license contract deploy to the multisig. License contract is owned by the multisig.
+// Submitted transaction must be confirmed and executed with MultisigExecuteDeploy
+func (i *chainInteractor) LicenseDeploy(
+	ctx context.Context,
+	params LicenseDeployParams,
+) (*SubmitTransactionResult, error) {
+	owners := make([]common.Address, len(params.Shareholders))
+	shares := make([]int, len(params.Shareholders))
+
+	for i, sh := range params.Shareholders {
+		owners[i] = common.BytesToAddress(sh.Address)
+		shares[i] = sh.Share
+	}
+
+	seed, err := i.chainAPISeed(params.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := i.client.LicenseDeploy(ctx, seed, chainapi.LicenseDeployRequest{
+		MultisigWallet: common.BytesToAddress(params.MultisigAddress),
+		Owners:         owners,
+		Shares:         shares,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error submit license deploy. %w", chainError(err))
+	}
+
+	return submitResult(resp), nil
+}
+
+type LicenseCallParams struct {
+	// Signer must be one of the multisig owners
+	Signer          *models.User
+	MultisigAddress []byte
+	LicenseAddress  []byte
+	// URL is an oracle url, required by LicenseRequest
+	URL string
+	// PayoutAddress is a payroll contract address, required by LicenseSetPayoutContract
+	PayoutAddress []byte
+}
+
+func (p LicenseCallParams) submit(data []byte) MultisigSubmitParams {
+	return MultisigSubmitParams{
+		Signer:          p.Signer,
+		MultisigAddress: p.MultisigAddress,
+		Destination:     p.LicenseAddress,
+		Data:            data,
+	}
+}
+
+// LicenseRequest submits oracle payout figure request to the multisig
+func (i *chainInteractor) LicenseRequest(
+	ctx context.Context,
+	params LicenseCallParams,
+) (*SubmitTransactionResult, error) {
+	if params.URL == "" {
+		return nil, fmt.Errorf("error empty license oracle url")
+	}
+
+	submitted, err := i.MultisigSubmit(ctx, params.submit(chainapi.RequestCall(params.URL)))
+	if err != nil {
+		return nil, fmt.Errorf("error submit license request. %w", err)
+	}
+
+	return submitted, nil
+}
+
+// LicenseSetPayoutContract submits payout payroll contract change to the multisig
+func (i *chainInteractor) LicenseSetPayoutContract(
+	ctx context.Context,
+	params LicenseCallParams,
+) (*SubmitTransactionResult, error) {
+	submitted, err := i.MultisigSubmit(
+		ctx,
+		params.submit(chainapi.SetPayoutContractCall(common.BytesToAddress(params.PayoutAddress))),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error submit license payout contract. %w", err)
+	}
+
+	return submitted, nil
+}
+
+// LicensePayout submits payout distribution between license shareholders to the multisig
+func (i *chainInteractor) LicensePayout(
+	ctx context.Context,
+	params LicenseCallParams,
+) (*SubmitTransactionResult, error) {
+	submitted, err := i.MultisigSubmit(ctx, params.submit(chainapi.PayoutCall()))
+	if err != nil {
+		return nil, fmt.Errorf("error submit license payout. %w", err)
+	}
+
+	return submitted, nil
+}
+
+type LicenseInfoParams struct {
+	Signer         *models.User
+	LicenseAddress []byte
+}
+
+// LicenseInfo reads license shareholders, payout figure and payout contract from the license contract
+func (i *chainInteractor) LicenseInfo(
+	ctx context.Context,
+	params LicenseInfoParams,
+) (*models.LicenseInfo, error) {
+	seed := i.readSeed(params.Signer)
+	license := common.BytesToAddress(params.LicenseAddress)
+
+	owners, err := i.client.LicenseOwners(ctx, seed, license)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch license owners. %w", err)
+	}
+
+	info := &models.LicenseInfo{
+		Shareholders: make([]models.LicenseShareholder, len(owners)),
+	}
+
+	for idx, owner := range owners {
+		share, err := i.client.LicenseShares(ctx, seed, chainapi.LicenseSharesRequest{
+			ContractAddress: license,
+			OwnerAddress:    owner,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error fetch license owner share. %w", err)
+		}
+
+		info.Shareholders[idx] = models.LicenseShareholder{
+			Address: owner.Bytes(),
+			Share:   share,
+		}
+	}
+
+	info.TotalPayoutInUSD, err = i.client.LicenseTotalPayout(ctx, seed, license)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch license total payout. %w", err)
+	}
+
+	payoutContract, err := i.client.LicensePayoutContract(ctx, seed, license)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch license payout contract. %w", err)
+	}
+
+	if payoutContract != (common.Address{}) {
+		info.PayoutContract = payoutContract.Bytes()
+	}
+
+	return info, nil
+}
@@ -0,0 +1,412 @@
+package confirmations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorUnknownEntityType    = errors.New("unknown confirmation entity type")
+	ErrorRejectNotSupported   = errors.New("entity type can not be rejected")
+	ErrorEntityNotPending     = errors.New("entity is not pending confirmation")
+	ErrorEntityStatusConflict = errors.New("entity status changed concurrently")
+	ErrorConfirmationNotFound = errors.New("confirmation not found")
+	ErrorMultisigNotFound     = errors.New("multisig not found")
+	ErrorNotMultisigOwner     = errors.New("user is not an owner of the multisig")
+)
+
+// Entity is a confirmable entity state, provided by the entity owning interactor
+type Entity struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	MultisigID     uuid.UUID
+
+	// ConfirmationsRequired overrides multisig confirmations number if it is greater
+	ConfirmationsRequired int
+
+	// Pending entity accepts confirmations and revocations
+	Pending bool
+}
+
+// EntityHandler connects confirmable entity with the confirmations workflow
+type EntityHandler struct {
+	// Entity fetches entity by id. Returns entity not found error of the owning interactor
+	Entity func(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) (*Entity, error)
+
+	// Confirmed is called once required number of confirmations is reached. It moves entity out of
+	// the pending state and returns ErrorEntityStatusConflict if entity was moved concurrently
+	Confirmed func(ctx context.Context, entity *Entity) error
+
+	// Rejected is called once rejections make required number of confirmations unreachable. It moves entity
+	// out of the pending state and returns ErrorEntityStatusConflict if entity was moved concurrently.
+	// Entity type can not be rejected if it is not set
+	Rejected func(ctx context.Context, entity *Entity) error
+
+	// Permission is required to confirm, reject or revoke the entity
+	Permission models.Permission
+}
+
+type ConfirmParams struct {
+	OrganizationID uuid.UUID
+	EntityType     models.MultisigConfirmationEntityType
+	EntityID       uuid.UUID
+}
+
+type RejectParams struct {
+	OrganizationID uuid.UUID
+	EntityType     models.MultisigConfirmationEntityType
+	EntityID       uuid.UUID
+}
+
+type RevokeParams struct {
+	OrganizationID uuid.UUID
+	EntityType     models.MultisigConfirmationEntityType
+	EntityID       uuid.UUID
+}
+
+type GetParams struct {
+	OrganizationID uuid.UUID
+	EntityType     models.MultisigConfirmationEntityType
+	EntityID       uuid.UUID
+}
+
+type ConfirmationsInteractor interface {
+	RegisterEntity(entityType models.MultisigConfirmationEntityType, h EntityHandler)
+
+	// Confirm adds multisig owner confirmation to the pending entity. Once required number of
+	// confirmations is reached, entity is moved forward by its handler
+	Confirm(ctx context.Context, params ConfirmParams) (*models.EntityConfirmation, error)
+	// Reject adds multisig owner rejection to the pending entity, replacing owner confirmation. Once
+	// not rejected owners are not enough to confirm the entity, entity is rejected by its handler
+	Reject(ctx context.Context, params RejectParams) (*models.EntityConfirmation, error)
+	// Revoke removes multisig owner confirmation or rejection from the pending entity
+	Revoke(ctx context.Context, params RevokeParams) (*models.EntityConfirmation, error)
+	Get(ctx context.Context, params GetParams) (*models.EntityConfirmation, error)
+}
+
+type confirmationsInteractor struct {
+	log        *slog.Logger
+	txRepo     transactions.Repository
+	authorizer authorizer.Authorizer
+
+	handlersMu sync.RWMutex
+	handlers   map[models.MultisigConfirmationEntityType]EntityHandler
+}
+
+func NewConfirmationsInteractor(
+	log *slog.Logger,
+	txRepo transactions.Repository,
+	authorizer authorizer.Authorizer,
+) ConfirmationsInteractor {
+	return &confirmationsInteractor{
+		log:        log,
+		txRepo:     txRepo,
+		authorizer: authorizer,
+		handlers:   make(map[models.MultisigConfirmationEntityType]EntityHandler),
+	}
+}
+
+func (i *confirmationsInteractor) RegisterEntity(
+	entityType models.MultisigConfirmationEntityType,
+	h EntityHandler,
+) {
+	i.handlersMu.Lock()
+	defer i.handlersMu.Unlock()
+
+	i.handlers[entityType] = h
+}
+
+func (i *confirmationsInteractor) handler(entityType models.MultisigConfirmationEntityType) (EntityHandler, error) {
+	i.handlersMu.RLock()
+	defer i.handlersMu.RUnlock()
+
+	h, ok := i.handlers[entityType]
+	if !ok {
+		return EntityHandler{}, ErrorUnknownEntityType
+	}
+
+	return h, nil
+}
+
+func (i *confirmationsInteractor) Confirm(
+	ctx context.Context,
+	params ConfirmParams,
+) (*models.EntityConfirmation, error) {
+	h, participant, err := i.authorize(ctx, params.OrganizationID, params.EntityType)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, multisig, err := i.pendingEntity(ctx, h, params.OrganizationID, params.EntityID)
+	if err != nil {
+		return nil, err
+	}
+
+	// confirmation is sent on-chain on behalf of the actor
+	if !isMultisigOwner(multisig, participant.Id()) {
+		return nil, ErrorNotMultisigOwner
+	}
+
+	if err = i.txRepo.ConfirmMultisig(ctx, transactions.ConfirmMultisigParams{
+		MultisigID:      multisig.ID,
+		OrganizationsID: params.OrganizationID,
+		CinfirmedBy:     participant.GetUser(),
+		ConfirmedAt:     time.Now(),
+		EntityID:        entity.ID,
+		EntityType:      params.EntityType,
+	}); err != nil {
+		return nil, fmt.Errorf("error confirm entity. %w", err)
+	}
+
+	count, err := i.txRepo.MultisigConfirmationsCount(ctx, transactions.MultisigConfirmationsCountParams{
+		MultisigID: multisig.ID,
+		EntityID:   entity.ID,
+		EntityType: params.EntityType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch entity confirmations count. %w", err)
+	}
+
+	if count >= confirmationsRequired(entity, multisig) {
+		// confirmation of another owner already moved the entity forward
+		if err = h.Confirmed(ctx, entity); err != nil && !errors.Is(err, ErrorEntityStatusConflict) {
+			return nil, fmt.Errorf("error process confirmed entity. %w", err)
+		}
+	}
+
+	return i.Get(ctx, GetParams(params))
+}
+
+func (i *confirmationsInteractor) Reject(
+	ctx context.Context,
+	params RejectParams,
+) (*models.EntityConfirmation, error) {
+	h, participant, err := i.authorize(ctx, params.OrganizationID, params.EntityType)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.Rejected == nil {
+		return nil, ErrorRejectNotSupported
+	}
+
+	entity, multisig, err := i.pendingEntity(ctx, h, params.OrganizationID, params.EntityID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMultisigOwner(multisig, participant.Id()) {
+		return nil, ErrorNotMultisigOwner
+	}
+
+	if err = i.txRepo.ConfirmMultisig(ctx, transactions.ConfirmMultisigParams{
+		MultisigID:      multisig.ID,
+		OrganizationsID: params.OrganizationID,
+		CinfirmedBy:     participant.GetUser(),
+		ConfirmedAt:     time.Now(),
+		Rejected:        true,
+		EntityID:        entity.ID,
+		EntityType:      params.EntityType,
+	}); err != nil {
+		return nil, fmt.Errorf("error reject entity. %w", err)
+	}
+
+	rejections, err := i.txRepo.MultisigConfirmations(ctx, transactions.MultisigConfirmationsParams{
+		MultisigID: multisig.ID,
+		EntityIDs:  uuid.UUIDs{entity.ID},
+		EntityType: params.EntityType,
+		Rejected:   true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch entity rejections. %w", err)
+	}
+
+	if len(multisig.Owners)-len(rejections[entity.ID]) < confirmationsRequired(entity, multisig) {
+		// entity is already moved forward by another owner decision
+		if err = h.Rejected(ctx, entity); err != nil && !errors.Is(err, ErrorEntityStatusConflict) {
+			return nil, fmt.Errorf("error process rejected entity. %w", err)
+		}
+	}
+
+	return i.Get(ctx, GetParams(params))
+}
+
+func (i *confirmationsInteractor) Revoke(
+	ctx context.Context,
+	params RevokeParams,
+) (*models.EntityConfirmation, error) {
+	h, participant, err := i.authorize(ctx, params.OrganizationID, params.EntityType)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, multisig, err := i.pendingEntity(ctx, h, params.OrganizationID, params.EntityID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.txRepo.RevokeMultisigConfirmation(ctx, transactions.RevokeMultisigConfirmationParams{
+		MultisigID: multisig.ID,
+		OwnerID:    participant.Id(),
+		RevokedAt:  time.Now(),
+		EntityID:   entity.ID,
+		EntityType: params.EntityType,
+	}); err != nil {
+		if errors.Is(err, transactions.ErrorConfirmationNotFound) {
+			return nil, ErrorConfirmationNotFound
+		}
+
+		return nil, fmt.Errorf("error revoke entity confirmation. %w", err)
+	}
+
+	return i.Get(ctx, GetParams(params))
+}
+
+func (i *confirmationsInteractor) Get(
+	ctx context.Context,
+	params GetParams,
+) (*models.EntityConfirmation, error) {
+	h, err := i.handler(params.EntityType)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := h.Entity(ctx, params.OrganizationID, params.EntityID)
+	if err != nil {
+		return nil, err
+	}
+
+	multisig, err := i.multisig(ctx, params.OrganizationID, entity.MultisigID)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmations, err := i.txRepo.MultisigConfirmations(ctx, transactions.MultisigConfirmationsParams{
+		MultisigID: multisig.ID,
+		EntityIDs:  uuid.UUIDs{entity.ID},
+		EntityType: params.EntityType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch entity confirmations. %w", err)
+	}
+
+	rejections, err := i.txRepo.MultisigConfirmations(ctx, transactions.MultisigConfirmationsParams{
+		MultisigID: multisig.ID,
+		EntityIDs:  uuid.UUIDs{entity.ID},
+		EntityType: params.EntityType,
+		Rejected:   true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch entity rejections. %w", err)
+	}
+
+	count, err := i.txRepo.MultisigConfirmationsCount(ctx, transactions.MultisigConfirmationsCountParams{
+		MultisigID: multisig.ID,
+		EntityID:   entity.ID,
+		EntityType: params.EntityType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch entity confirmations count. %w", err)
+	}
+
+	return &models.EntityConfirmation{
+		EntityID:              entity.ID,
+		EntityType:            params.EntityType,
+		MultisigID:            multisig.ID,
+		ConfirmedBy:           confirmations[entity.ID],
+		Confirmations:         count,
+		ConfirmationsRequired: confirmationsRequired(entity, multisig),
+		RejectedBy:            rejections[entity.ID],
+		Pending:               entity.Pending,
+	}, nil
+}
+
+// authorize returns entity handler and actor participant allowed to confirm entities of the type
+func (i *confirmationsInteractor) authorize(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	entityType models.MultisigConfirmationEntityType,
+) (EntityHandler, models.OrganizationParticipant, error) {
+	h, err := i.handler(entityType)
+	if err != nil {
+		return EntityHandler{}, nil, err
+	}
+
+	participant, err := i.authorizer.Authorize(ctx, organizationID, h.Permission)
+	if err != nil {
+		return EntityHandler{}, nil, err
+	}
+
+	return h, participant, nil
+}
+
+func (i *confirmationsInteractor) pendingEntity(
+	ctx context.Context,
+	h EntityHandler,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*Entity, *models.Multisig, error) {
+	entity, err := h.Entity(ctx, organizationID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !entity.Pending {
+		return nil, nil, ErrorEntityNotPending
+	}
+
+	multisig, err := i.multisig(ctx, organizationID, entity.MultisigID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entity, multisig, nil
+}
+
+func (i *confirmationsInteractor) multisig(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*models.Multisig, error) {
+	if id == uuid.Nil {
+		return nil, ErrorMultisigNotFound
+	}
+
+	multisigs, err := i.txRepo.ListMultisig(ctx, transactions.ListMultisigsParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{id},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch multisig. %w", err)
+	}
+
+	if len(multisigs) == 0 {
+		return nil, ErrorMultisigNotFound
+	}
+
+	return &multisigs[0], nil
+}
+
+// confirmationsRequired multisig does not execute anything with less confirmations than it requires
+func confirmationsRequired(entity *Entity, multisig *models.Multisig) int {
+	return max(entity.ConfirmationsRequired, multisig.ConfirmationsRequired)
+}
+
+func isMultisigOwner(multisig *models.Multisig, userID uuid.UUID) bool {
+	for _, owner := range multisig.Owners {
+		if owner.Id() == userID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,461 @@
+package confirmations
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
+	"github.com/google/uuid"
+)
+
+const testEntityType = models.MultisigConfirmationEntityTypeTransaction
+
+// memoryOrganizations keeps a single organization and its participants in memory
+type memoryOrganizations struct {
+	organizations.Repository
+
+	org          *models.Organization
+	participants []models.OrganizationParticipant
+}
+
+func (r *memoryOrganizations) Get(_ context.Context, params organizations.GetParams) ([]*models.Organization, error) {
+	if !slices.Contains(params.Ids, r.org.ID) {
+		return nil, nil
+	}
+
+	return []*models.Organization{r.org}, nil
+}
+
+func (r *memoryOrganizations) Participants(
+	_ context.Context,
+	params organizations.ParticipantsParams,
+) ([]models.OrganizationParticipant, error) {
+	var participants []models.OrganizationParticipant
+
+	for _, p := range r.participants {
+		if params.OrganizationId != r.org.ID || !slices.Contains(params.Ids, p.Id()) {
+			continue
+		}
+
+		participants = append(participants, p)
+	}
+
+	if len(participants) == 0 {
+		return nil, organizations.ErrorNotFound
+	}
+
+	return participants, nil
+}
+
+type decisionKey struct {
+	entityID uuid.UUID
+	ownerID  uuid.UUID
+}
+
+// memoryMultisigs keeps multisigs and owner decisions in memory. Each owner has a single
+// decision per entity, confirmation or rejection
+type memoryMultisigs struct {
+	transactions.Repository
+
+	mu        sync.Mutex
+	multisigs []models.Multisig
+	decisions map[decisionKey]bool
+}
+
+func (r *memoryMultisigs) ListMultisig(
+	_ context.Context,
+	params transactions.ListMultisigsParams,
+) ([]models.Multisig, error) {
+	var multisigs []models.Multisig
+
+	for _, m := range r.multisigs {
+		if m.OrganizationID == params.OrganizationID && slices.Contains(params.IDs, m.ID) {
+			multisigs = append(multisigs, m)
+		}
+	}
+
+	return multisigs, nil
+}
+
+func (r *memoryMultisigs) ConfirmMultisig(_ context.Context, params transactions.ConfirmMultisigParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decisions[decisionKey{params.EntityID, params.CinfirmedBy.Id()}] = params.Rejected
+
+	return nil
+}
+
+func (r *memoryMultisigs) RevokeMultisigConfirmation(
+	_ context.Context,
+	params transactions.RevokeMultisigConfirmationParams,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := decisionKey{params.EntityID, params.OwnerID}
+
+	if _, ok := r.decisions[key]; !ok {
+		return transactions.ErrorConfirmationNotFound
+	}
+
+	delete(r.decisions, key)
+
+	return nil
+}
+
+func (r *memoryMultisigs) MultisigConfirmations(
+	_ context.Context,
+	params transactions.MultisigConfirmationsParams,
+) (map[uuid.UUID]uuid.UUIDs, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	owners := make(map[uuid.UUID]uuid.UUIDs)
+
+	for key, rejected := range r.decisions {
+		if rejected == params.Rejected && slices.Contains(params.EntityIDs, key.entityID) {
+			owners[key.entityID] = append(owners[key.entityID], key.ownerID)
+		}
+	}
+
+	return owners, nil
+}
+
+func (r *memoryMultisigs) MultisigConfirmationsCount(
+	_ context.Context,
+	params transactions.MultisigConfirmationsCountParams,
+) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int
+
+	for key, rejected := range r.decisions {
+		if key.entityID == params.EntityID && !rejected {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+type fixture struct {
+	interactor ConfirmationsInteractor
+	orgID      uuid.UUID
+
+	// owners are approvers owning the 2 of 3 multisig
+	owners   []*models.OrganizationUser
+	approver *models.OrganizationUser
+	viewer   *models.OrganizationUser
+
+	entity    *Entity
+	confirmed int
+	rejected  int
+	// handlerErr is returned by the confirmed and rejected handlers
+	handlerErr error
+}
+
+func newOrganizationUser(role models.Role) *models.OrganizationUser {
+	return &models.OrganizationUser{
+		User: models.User{
+			ID:        uuid.New(),
+			Activated: true,
+		},
+		OrgRole: role,
+	}
+}
+
+func as(user *models.OrganizationUser) context.Context {
+	return ctxmeta.UserContext(context.Background(), &user.User)
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := &fixture{
+		orgID: uuid.New(),
+		owners: []*models.OrganizationUser{
+			newOrganizationUser(models.RoleApprover),
+			newOrganizationUser(models.RoleApprover),
+			newOrganizationUser(models.RoleApprover),
+		},
+		approver: newOrganizationUser(models.RoleApprover),
+		viewer:   newOrganizationUser(models.RoleViewer),
+	}
+
+	multisig := models.Multisig{
+		ID:                    uuid.New(),
+		OrganizationID:        f.orgID,
+		ConfirmationsRequired: 2,
+	}
+
+	participants := []models.OrganizationParticipant{f.approver, f.viewer}
+
+	for _, o := range f.owners {
+		multisig.Owners = append(multisig.Owners, o)
+		participants = append(participants, o)
+	}
+
+	f.entity = &Entity{
+		ID:             uuid.New(),
+		OrganizationID: f.orgID,
+		MultisigID:     multisig.ID,
+		Pending:        true,
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	f.interactor = NewConfirmationsInteractor(
+		log,
+		&memoryMultisigs{
+			multisigs: []models.Multisig{multisig},
+			decisions: make(map[decisionKey]bool),
+		},
+		authorizer.NewAuthorizer(log, &memoryOrganizations{
+			org:          &models.Organization{ID: f.orgID},
+			participants: participants,
+		}),
+	)
+
+	f.interactor.RegisterEntity(testEntityType, EntityHandler{
+		Entity: func(_ context.Context, organizationID uuid.UUID, id uuid.UUID) (*Entity, error) {
+			if organizationID != f.orgID || id != f.entity.ID {
+				return nil, errors.New("entity not found")
+			}
+
+			entity := *f.entity
+
+			return &entity, nil
+		},
+		Confirmed: func(_ context.Context, _ *Entity) error {
+			f.confirmed++
+			f.entity.Pending = false
+
+			return f.handlerErr
+		},
+		Rejected: func(_ context.Context, _ *Entity) error {
+			f.rejected++
+			f.entity.Pending = false
+
+			return f.handlerErr
+		},
+		Permission: models.PermissionTxConfirm,
+	})
+
+	return f
+}
+
+func (f *fixture) confirm(user *models.OrganizationUser) (*models.EntityConfirmation, error) {
+	return f.interactor.Confirm(as(user), ConfirmParams{
+		OrganizationID: f.orgID,
+		EntityType:     testEntityType,
+		EntityID:       f.entity.ID,
+	})
+}
+
+func (f *fixture) reject(user *models.OrganizationUser) (*models.EntityConfirmation, error) {
+	return f.interactor.Reject(as(user), RejectParams{
+		OrganizationID: f.orgID,
+		EntityType:     testEntityType,
+		EntityID:       f.entity.ID,
+	})
+}
+
+func (f *fixture) revoke(user *models.OrganizationUser) (*models.EntityConfirmation, error) {
+	return f.interactor.Revoke(as(user), RevokeParams{
+		OrganizationID: f.orgID,
+		EntityType:     testEntityType,
+		EntityID:       f.entity.ID,
+	})
+}
+
+func TestConfirm(t *testing.T) {
+	f := newFixture(t)
+
+	confirmation, err := f.confirm(f.owners[0])
+	if err != nil {
+		t.Fatalf("Confirm() error: %v", err)
+	}
+
+	if confirmation.Confirmations != 1 || confirmation.ConfirmationsRequired != 2 ||
+		!slices.Equal(confirmation.ConfirmedBy, uuid.UUIDs{f.owners[0].Id()}) || !confirmation.Pending {
+		t.Fatalf("Confirm() = %+v, want single pending confirmation", confirmation)
+	}
+
+	// repeated confirmation is not counted twice
+	if confirmation, err = f.confirm(f.owners[0]); err != nil || confirmation.Confirmations != 1 {
+		t.Fatalf("Confirm() repeated = %+v, %v", confirmation, err)
+	}
+
+	if f.confirmed != 0 {
+		t.Fatalf("entity confirmed with a single confirmation")
+	}
+
+	if _, err = f.confirm(f.approver); !errors.Is(err, ErrorNotMultisigOwner) {
+		t.Fatalf("Confirm() by not an owner error = %v, want ErrorNotMultisigOwner", err)
+	}
+
+	if _, err = f.confirm(f.viewer); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("Confirm() by viewer error = %v, want ErrorPermissionDenied", err)
+	}
+
+	if confirmation, err = f.confirm(f.owners[1]); err != nil {
+		t.Fatalf("Confirm() error: %v", err)
+	}
+
+	if f.confirmed != 1 || confirmation.Confirmations != 2 || confirmation.Pending {
+		t.Fatalf("Confirm() = %+v, confirmed %d times, want entity confirmed once", confirmation, f.confirmed)
+	}
+
+	if _, err = f.confirm(f.owners[2]); !errors.Is(err, ErrorEntityNotPending) {
+		t.Fatalf("Confirm() of confirmed entity error = %v, want ErrorEntityNotPending", err)
+	}
+
+	if _, err = f.interactor.Confirm(as(f.owners[0]), ConfirmParams{
+		OrganizationID: f.orgID,
+		EntityType:     models.MultisigConfirmationEntityTypeSalary,
+		EntityID:       f.entity.ID,
+	}); !errors.Is(err, ErrorUnknownEntityType) {
+		t.Fatalf("Confirm() of unknown entity type error = %v, want ErrorUnknownEntityType", err)
+	}
+}
+
+func TestConfirmEntityRequirement(t *testing.T) {
+	f := newFixture(t)
+
+	// entity requires more confirmations than the multisig
+	f.entity.ConfirmationsRequired = 3
+
+	for _, o := range f.owners[:2] {
+		if _, err := f.confirm(o); err != nil {
+			t.Fatalf("Confirm() error: %v", err)
+		}
+	}
+
+	if f.confirmed != 0 {
+		t.Fatalf("entity confirmed with 2 of 3 required confirmations")
+	}
+
+	// entity moved concurrently by another owner decision
+	f.handlerErr = ErrorEntityStatusConflict
+
+	confirmation, err := f.confirm(f.owners[2])
+	if err != nil {
+		t.Fatalf("Confirm() error: %v", err)
+	}
+
+	if f.confirmed != 1 || confirmation.ConfirmationsRequired != 3 {
+		t.Fatalf("Confirm() = %+v, confirmed %d times", confirmation, f.confirmed)
+	}
+}
+
+func TestReject(t *testing.T) {
+	f := newFixture(t)
+
+	if _, err := f.confirm(f.owners[0]); err != nil {
+		t.Fatalf("Confirm() error: %v", err)
+	}
+
+	// rejection replaces owner confirmation
+	confirmation, err := f.reject(f.owners[0])
+	if err != nil {
+		t.Fatalf("Reject() error: %v", err)
+	}
+
+	if confirmation.Confirmations != 0 || len(confirmation.ConfirmedBy) != 0 ||
+		!slices.Equal(confirmation.RejectedBy, uuid.UUIDs{f.owners[0].Id()}) {
+		t.Fatalf("Reject() = %+v, want confirmation replaced by rejection", confirmation)
+	}
+
+	// 2 owners left are still enough to confirm
+	if f.rejected != 0 || !confirmation.Pending {
+		t.Fatalf("entity rejected with 2 of 3 owners left")
+	}
+
+	if _, err = f.reject(f.approver); !errors.Is(err, ErrorNotMultisigOwner) {
+		t.Fatalf("Reject() by not an owner error = %v, want ErrorNotMultisigOwner", err)
+	}
+
+	if confirmation, err = f.reject(f.owners[1]); err != nil {
+		t.Fatalf("Reject() error: %v", err)
+	}
+
+	if f.rejected != 1 || f.confirmed != 0 || confirmation.Pending || len(confirmation.RejectedBy) != 2 {
+		t.Fatalf("Reject() = %+v, rejected %d times, want entity rejected once", confirmation, f.rejected)
+	}
+
+	if _, err = f.reject(f.owners[2]); !errors.Is(err, ErrorEntityNotPending) {
+		t.Fatalf("Reject() of rejected entity error = %v, want ErrorEntityNotPending", err)
+	}
+}
+
+func TestRejectNotSupported(t *testing.T) {
+	f := newFixture(t)
+
+	f.interactor.RegisterEntity(models.MultisigConfirmationEntityTypeSalary, EntityHandler{
+		Entity:     func(context.Context, uuid.UUID, uuid.UUID) (*Entity, error) { return f.entity, nil },
+		Confirmed:  func(context.Context, *Entity) error { return nil },
+		Permission: models.PermissionTxConfirm,
+	})
+
+	if _, err := f.interactor.Reject(as(f.owners[0]), RejectParams{
+		OrganizationID: f.orgID,
+		EntityType:     models.MultisigConfirmationEntityTypeSalary,
+		EntityID:       f.entity.ID,
+	}); !errors.Is(err, ErrorRejectNotSupported) {
+		t.Fatalf("Reject() error = %v, want ErrorRejectNotSupported", err)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	f := newFixture(t)
+
+	if _, err := f.revoke(f.owners[0]); !errors.Is(err, ErrorConfirmationNotFound) {
+		t.Fatalf("Revoke() without confirmation error = %v, want ErrorConfirmationNotFound", err)
+	}
+
+	if _, err := f.confirm(f.owners[0]); err != nil {
+		t.Fatalf("Confirm() error: %v", err)
+	}
+
+	if _, err := f.reject(f.owners[1]); err != nil {
+		t.Fatalf("Reject() error: %v", err)
+	}
+
+	confirmation, err := f.revoke(f.owners[0])
+	if err != nil {
+		t.Fatalf("Revoke() error: %v", err)
+	}
+
+	if confirmation.Confirmations != 0 || len(confirmation.ConfirmedBy) != 0 || len(confirmation.RejectedBy) != 1 {
+		t.Fatalf("Revoke() = %+v, want confirmation removed", confirmation)
+	}
+
+	if confirmation, err = f.revoke(f.owners[1]); err != nil {
+		t.Fatalf("Revoke() error: %v", err)
+	}
+
+	if len(confirmation.RejectedBy) != 0 || !confirmation.Pending {
+		t.Fatalf("Revoke() = %+v, want rejection removed", confirmation)
+	}
+
+	if _, err = f.revoke(f.owners[1]); !errors.Is(err, ErrorConfirmationNotFound) {
+		t.Fatalf("Revoke() of revoked rejection error = %v, want ErrorConfirmationNotFound", err)
+	}
+
+	f.entity.Pending = false
+
+	if _, err = f.revoke(f.owners[0]); !errors.Is(err, ErrorEntityNotPending) {
+		t.Fatalf("Revoke() of not pending entity error = %v, want ErrorEntityNotPending", err)
+	}
+}
@@ -0,0 +1,433 @@
+package invites
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/auth"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/users"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorInviteNotFound      = auth.ErrorInviteNotFound
+	ErrorInviteExpired       = auth.ErrorInviteLinkExpired
+	ErrorInviteRevoked       = auth.ErrorInviteRevoked
+	ErrorInviteUsesExhausted = auth.ErrorInviteUsesExhausted
+	ErrorInvalidInvite       = errors.New("invalid invite")
+	ErrorUnknownPublicKey    = errors.New("no user with public key")
+	ErrorAlreadyParticipant  = errors.New("user is already organization participant")
+	ErrorInviteNotAddressed  = errors.New("invite is addressed to another user")
+	ErrorInviteAccountBound  = errors.New("invite is bound to existing account")
+)
+
+const (
+	defaultExpiration = time.Hour * 24 * 7
+	maxUsesLimit      = 1000
+)
+
+type InvitesInteractor interface {
+	// Create creates invite link. Inviting with role higher than viewer requires roles.assign permission,
+	// only owners invite owners
+	Create(ctx context.Context, params CreateParams) (*models.Invite, error)
+	List(ctx context.Context, params ListParams) ([]*models.Invite, error)
+	Revoke(ctx context.Context, params RevokeParams) (*models.Invite, error)
+
+	// Get returns invite with its organization and inviter. Used by not authorized users to render the join page
+	Get(ctx context.Context, linkHash string) (*InviteInfo, error)
+	// Use counts invite use by a new account. Returns error if invite is revoked, expired or its uses exhausted,
+	// bound to a public key or the organization is archived
+	Use(ctx context.Context, linkHash string) (*models.Invite, error)
+	// Accept adds current user into the invite organization with the invite role and position
+	Accept(ctx context.Context, linkHash string) (*models.Invite, error)
+	// Received returns active invites bound to the current user public key
+	Received(ctx context.Context, params ReceivedParams) ([]*models.Invite, error)
+}
+
+type invitesInteractor struct {
+	log        *slog.Logger
+	authRepo   auth.Repository
+	orgRepo    organizations.Repository
+	usersRepo  users.Repository
+	authorizer authorizer.Authorizer
+}
+
+func NewInvitesInteractor(
+	log *slog.Logger,
+	authRepo auth.Repository,
+	orgRepo organizations.Repository,
+	usersRepo users.Repository,
+	authorizer authorizer.Authorizer,
+) InvitesInteractor {
+	return &invitesInteractor{
+		log:        log,
+		authRepo:   authRepo,
+		orgRepo:    orgRepo,
+		usersRepo:  usersRepo,
+		authorizer: authorizer,
+	}
+}
+
+type CreateParams struct {
+	OrganizationID uuid.UUID
+	// Role is RoleViewer if not set
+	Role     models.Role
+	Position string
+	// PublicKey binds invite to the existing user wallet. Bound invite has a single use
+	PublicKey []byte
+	// MaxUses is 1 if not set
+	MaxUses int
+	// ExpiredAt is a week from now if not set
+	ExpiredAt time.Time
+}
+
+func (i *invitesInteractor) Create(ctx context.Context, params CreateParams) (*models.Invite, error) {
+	actor, err := i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionParticipantsInvite)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Role == models.RoleUnknown {
+		params.Role = models.RoleViewer
+	}
+
+	if params.Role > models.RoleViewer && !i.authorizer.Can(actor, models.PermissionRolesAssign) {
+		return nil, fmt.Errorf("error invite with %s role. %w", params.Role, authorizer.ErrorPermissionDenied)
+	}
+
+	if params.Role == models.RoleOwner && !actor.IsOwner() {
+		return nil, fmt.Errorf("error only owner can invite owners. %w", authorizer.ErrorPermissionDenied)
+	}
+
+	if params.MaxUses == 0 {
+		params.MaxUses = 1
+	}
+
+	if params.MaxUses < 0 || params.MaxUses > maxUsesLimit {
+		return nil, fmt.Errorf("error max uses must be in range 1..%d. %w", maxUsesLimit, ErrorInvalidInvite)
+	}
+
+	if len(params.PublicKey) > 0 {
+		if params.MaxUses > 1 {
+			return nil, fmt.Errorf("error invite bound to public key has a single use. %w", ErrorInvalidInvite)
+		}
+
+		if err := i.checkInvitee(ctx, params.OrganizationID, params.PublicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	createdAt := time.Now()
+
+	if params.ExpiredAt.IsZero() {
+		params.ExpiredAt = createdAt.Add(defaultExpiration)
+	}
+
+	if !params.ExpiredAt.After(createdAt) {
+		return nil, fmt.Errorf("error expiration date in the past. %w", ErrorInvalidInvite)
+	}
+
+	linkHash := newLinkHash(actor.Id(), params.OrganizationID, createdAt)
+
+	if err := i.authRepo.AddInvite(ctx, auth.AddInviteParams{
+		LinkHash:       linkHash,
+		OrganizationID: params.OrganizationID,
+		CreatedBy:      actor.User,
+		Role:           params.Role,
+		Position:       params.Position,
+		PublicKey:      params.PublicKey,
+		MaxUses:        params.MaxUses,
+		CreatedAt:      createdAt,
+		ExpiredAt:      params.ExpiredAt,
+	}); err != nil {
+		return nil, fmt.Errorf("error add new invite link. %w", err)
+	}
+
+	return &models.Invite{
+		LinkHash:       linkHash,
+		OrganizationID: params.OrganizationID,
+		CreatedBy:      actor.Id(),
+		Role:           params.Role,
+		Position:       params.Position,
+		PublicKey:      params.PublicKey,
+		MaxUses:        params.MaxUses,
+		CreatedAt:      createdAt,
+		ExpiredAt:      params.ExpiredAt,
+	}, nil
+}
+
+// checkInvitee checks that the public key belongs to a user who is not yet the organization participant
+func (i *invitesInteractor) checkInvitee(ctx context.Context, organizationID uuid.UUID, publicKey []byte) error {
+	invitees, err := i.usersRepo.Get(ctx, users.GetParams{
+		PKs: [][]byte{publicKey},
+	})
+	if err != nil {
+		return fmt.Errorf("error fetch invitee. %w", err)
+	}
+
+	if len(invitees) == 0 {
+		return ErrorUnknownPublicKey
+	}
+
+	return i.checkNotParticipant(ctx, organizationID, invitees[0].Id())
+}
+
+func (i *invitesInteractor) checkNotParticipant(ctx context.Context, organizationID, userID uuid.UUID) error {
+	participants, err := i.orgRepo.Participants(ctx, organizations.ParticipantsParams{
+		OrganizationId: organizationID,
+		Ids:            uuid.UUIDs{userID},
+		UsersOnly:      true,
+	})
+	if err != nil {
+		if errors.Is(err, organizations.ErrorNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("error fetch organization participants. %w", err)
+	}
+
+	if len(participants) > 0 {
+		return ErrorAlreadyParticipant
+	}
+
+	return nil
+}
+
+// newLinkHash returns url safe invite link hash
+func newLinkHash(userID, organizationID uuid.UUID, createdAt time.Time) string {
+	linkHash := sha256.New()
+
+	linkHash.Write([]byte(
+		userID.String() + organizationID.String() + createdAt.String(),
+	))
+
+	return base64.RawURLEncoding.EncodeToString(linkHash.Sum(nil))
+}
+
+type ListParams struct {
+	OrganizationID uuid.UUID
+	// All includes revoked, expired and exhausted invites
+	All   bool
+	Limit uint8
+}
+
+func (i *invitesInteractor) List(ctx context.Context, params ListParams) ([]*models.Invite, error) {
+	if _, err := i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionParticipantsInvite); err != nil {
+		return nil, err
+	}
+
+	if params.Limit <= 0 || params.Limit > 50 {
+		params.Limit = 50
+	}
+
+	invites, err := i.authRepo.Invites(ctx, auth.InvitesParams{
+		OrganizationID: params.OrganizationID,
+		ActiveOnly:     !params.All,
+		Limit:          int64(params.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch invites. %w", err)
+	}
+
+	return invites, nil
+}
+
+type RevokeParams struct {
+	OrganizationID uuid.UUID
+	LinkHash       string
+}
+
+func (i *invitesInteractor) Revoke(ctx context.Context, params RevokeParams) (*models.Invite, error) {
+	actor, err := i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionParticipantsInvite)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.authRepo.RevokeInvite(ctx, auth.RevokeInviteParams{
+		LinkHash:       params.LinkHash,
+		OrganizationID: params.OrganizationID,
+		RevokedAt:      time.Now(),
+	}); err != nil {
+		return nil, fmt.Errorf("error revoke invite. %w", err)
+	}
+
+	i.log.Info(
+		"invite revoked",
+		slog.String("organization id", params.OrganizationID.String()),
+		slog.String("revoked by", actor.Id().String()),
+	)
+
+	return i.invite(ctx, params.LinkHash)
+}
+
+func (i *invitesInteractor) invite(ctx context.Context, linkHash string) (*models.Invite, error) {
+	invites, err := i.authRepo.Invites(ctx, auth.InvitesParams{
+		LinkHashes: []string{linkHash},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch invite. %w", err)
+	}
+
+	if len(invites) == 0 {
+		return nil, ErrorInviteNotFound
+	}
+
+	return invites[0], nil
+}
+
+type InviteInfo struct {
+	Invite       *models.Invite
+	Organization *models.Organization
+	Inviter      *models.User
+}
+
+func (i *invitesInteractor) Get(ctx context.Context, linkHash string) (*InviteInfo, error) {
+	invite, err := i.invite(ctx, linkHash)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs, err := i.orgRepo.Get(ctx, organizations.GetParams{
+		Ids:   uuid.UUIDs{invite.OrganizationID},
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch invite organization. %w", err)
+	}
+
+	if len(orgs) == 0 {
+		return nil, ErrorInviteNotFound
+	}
+
+	inviters, err := i.usersRepo.Get(ctx, users.GetParams{
+		Ids: uuid.UUIDs{invite.CreatedBy},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch inviter. %w", err)
+	}
+
+	info := &InviteInfo{
+		Invite:       invite,
+		Organization: orgs[0],
+	}
+
+	if len(inviters) > 0 {
+		info.Inviter = inviters[0]
+	}
+
+	return info, nil
+}
+
+func (i *invitesInteractor) Use(ctx context.Context, linkHash string) (*models.Invite, error) {
+	info, err := i.usable(ctx, linkHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Invite.IsBound() {
+		return nil, fmt.Errorf("error join with bound invite. %w", ErrorInviteAccountBound)
+	}
+
+	invite, err := i.authRepo.MarkAsUsedLink(ctx, linkHash, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error mark invite link as used. %w", err)
+	}
+
+	return invite, nil
+}
+
+func (i *invitesInteractor) Accept(ctx context.Context, linkHash string) (*models.Invite, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	info, err := i.usable(ctx, linkHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Invite.IsBound() && !bytes.Equal(info.Invite.PublicKey, user.PublicKey()) {
+		return nil, ErrorInviteNotAddressed
+	}
+
+	if err = i.checkNotParticipant(ctx, info.Invite.OrganizationID, user.Id()); err != nil {
+		return nil, err
+	}
+
+	invite, err := i.authRepo.MarkAsUsedLink(ctx, linkHash, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error mark invite link as used. %w", err)
+	}
+
+	if err = i.orgRepo.AddParticipant(ctx, organizations.AddParticipantParams{
+		OrganizationId: invite.OrganizationID,
+		UserId:         user.Id(),
+		Role:           invite.Role,
+		Position:       invite.Position,
+	}); err != nil {
+		return nil, fmt.Errorf("error add user into organization. %w", err)
+	}
+
+	i.log.Info(
+		"invite accepted",
+		slog.String("organization id", invite.OrganizationID.String()),
+		slog.String("user id", user.Id().String()),
+	)
+
+	return invite, nil
+}
+
+// usable returns invite info if invite organization accepts new participants
+func (i *invitesInteractor) usable(ctx context.Context, linkHash string) (*InviteInfo, error) {
+	info, err := i.Get(ctx, linkHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Organization.IsArchived() {
+		return nil, fmt.Errorf("error join archived organization. %w", authorizer.ErrorOrganizationArchived)
+	}
+
+	return info, nil
+}
+
+type ReceivedParams struct {
+	Limit uint8
+}
+
+func (i *invitesInteractor) Received(ctx context.Context, params ReceivedParams) ([]*models.Invite, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	if len(user.PublicKey()) == 0 {
+		return []*models.Invite{}, nil
+	}
+
+	if params.Limit <= 0 || params.Limit > 50 {
+		params.Limit = 50
+	}
+
+	invites, err := i.authRepo.Invites(ctx, auth.InvitesParams{
+		PublicKey:  user.PublicKey(),
+		ActiveOnly: true,
+		Limit:      int64(params.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch received invites. %w", err)
+	}
+
+	return invites, nil
+}
@@ -0,0 +1,585 @@
+package invites
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/auth"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/users"
+	"github.com/google/uuid"
+)
+
+// memoryInvites keeps invites in memory, newest first
+type memoryInvites struct {
+	auth.Repository
+
+	mu      sync.Mutex
+	invites []*models.Invite
+}
+
+func (r *memoryInvites) AddInvite(_ context.Context, params auth.AddInviteParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.invites = slices.Insert(r.invites, 0, &models.Invite{
+		LinkHash:       params.LinkHash,
+		OrganizationID: params.OrganizationID,
+		CreatedBy:      params.CreatedBy.Id(),
+		Role:           params.Role,
+		Position:       params.Position,
+		PublicKey:      params.PublicKey,
+		MaxUses:        params.MaxUses,
+		CreatedAt:      params.CreatedAt,
+		ExpiredAt:      params.ExpiredAt,
+	})
+
+	return nil
+}
+
+func (r *memoryInvites) find(linkHash string) *models.Invite {
+	for _, i := range r.invites {
+		if i.LinkHash == linkHash {
+			return i
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryInvites) MarkAsUsedLink(_ context.Context, linkHash string, usedAt time.Time) (*models.Invite, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invite := r.find(linkHash)
+
+	switch {
+	case invite == nil:
+		return nil, auth.ErrorInviteNotFound
+	case !invite.RevokedAt.IsZero():
+		return nil, auth.ErrorInviteRevoked
+	case !invite.ExpiredAt.After(usedAt):
+		return nil, auth.ErrorInviteLinkExpired
+	case invite.Uses >= invite.MaxUses:
+		return nil, auth.ErrorInviteUsesExhausted
+	}
+
+	invite.Uses++
+	invite.UsedAt = usedAt
+
+	used := *invite
+
+	return &used, nil
+}
+
+func (r *memoryInvites) Invites(_ context.Context, params auth.InvitesParams) ([]*models.Invite, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var invites []*models.Invite
+
+	for _, i := range r.invites {
+		if (len(params.LinkHashes) > 0 && !slices.Contains(params.LinkHashes, i.LinkHash)) ||
+			(params.OrganizationID != uuid.Nil && i.OrganizationID != params.OrganizationID) ||
+			(len(params.PublicKey) > 0 && !bytes.Equal(i.PublicKey, params.PublicKey)) ||
+			(params.ActiveOnly && !i.IsActive(time.Now())) {
+			continue
+		}
+
+		if params.Limit > 0 && int64(len(invites)) == params.Limit {
+			break
+		}
+
+		found := *i
+
+		invites = append(invites, &found)
+	}
+
+	return invites, nil
+}
+
+func (r *memoryInvites) RevokeInvite(_ context.Context, params auth.RevokeInviteParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invite := r.find(params.LinkHash)
+	if invite == nil || invite.OrganizationID != params.OrganizationID || !invite.RevokedAt.IsZero() {
+		return auth.ErrorInviteNotFound
+	}
+
+	invite.RevokedAt = params.RevokedAt
+
+	return nil
+}
+
+// memoryOrganizations keeps a single organization and its users in memory
+type memoryOrganizations struct {
+	organizations.Repository
+
+	mu    sync.Mutex
+	org   *models.Organization
+	users *memoryUsers
+	roles map[uuid.UUID]models.Role
+	// positions of the users joined by invites
+	positions map[uuid.UUID]string
+}
+
+func (r *memoryOrganizations) Get(_ context.Context, params organizations.GetParams) ([]*models.Organization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !slices.Contains(params.Ids, r.org.ID) {
+		return nil, nil
+	}
+
+	org := *r.org
+
+	return []*models.Organization{&org}, nil
+}
+
+func (r *memoryOrganizations) Participants(
+	_ context.Context,
+	params organizations.ParticipantsParams,
+) ([]models.OrganizationParticipant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var participants []models.OrganizationParticipant
+
+	for _, u := range r.users.users {
+		role, ok := r.roles[u.ID]
+		if !ok || params.OrganizationId != r.org.ID || !slices.Contains(params.Ids, u.ID) {
+			continue
+		}
+
+		participants = append(participants, &models.OrganizationUser{
+			User:        *u,
+			OrgRole:     role,
+			OrgPosition: r.positions[u.ID],
+		})
+	}
+
+	if len(participants) == 0 {
+		return nil, organizations.ErrorNotFound
+	}
+
+	return participants, nil
+}
+
+func (r *memoryOrganizations) AddParticipant(_ context.Context, params organizations.AddParticipantParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.roles[params.UserId] = params.Role
+	r.positions[params.UserId] = params.Position
+
+	return nil
+}
+
+type memoryUsers struct {
+	users.Repository
+
+	users []*models.User
+}
+
+func (r *memoryUsers) Get(_ context.Context, params users.GetParams) ([]*models.User, error) {
+	var found []*models.User
+
+	for _, u := range r.users {
+		if slices.Contains(params.Ids, u.ID) || slices.ContainsFunc(params.PKs, func(pk []byte) bool {
+			return bytes.Equal(pk, u.PK)
+		}) {
+			found = append(found, u)
+		}
+	}
+
+	return found, nil
+}
+
+type fixture struct {
+	interactor InvitesInteractor
+	invites    *memoryInvites
+	orgs       *memoryOrganizations
+	org        *models.Organization
+
+	owner  *models.User
+	admin  *models.User
+	viewer *models.User
+	// stranger has an account, but is not the organization participant
+	stranger *models.User
+}
+
+func newUser(name string, pk byte) *models.User {
+	return &models.User{
+		ID:        uuid.New(),
+		Name:      name,
+		PK:        []byte{0x04, pk},
+		Activated: true,
+	}
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	f := &fixture{
+		invites:  new(memoryInvites),
+		org:      &models.Organization{ID: uuid.New(), Name: "Acme"},
+		owner:    newUser("Olivia", 1),
+		admin:    newUser("Adam", 2),
+		viewer:   newUser("Victor", 3),
+		stranger: newUser("Sam", 4),
+	}
+
+	usersRepo := &memoryUsers{users: []*models.User{f.owner, f.admin, f.viewer, f.stranger}}
+
+	f.orgs = &memoryOrganizations{
+		org:   f.org,
+		users: usersRepo,
+		roles: map[uuid.UUID]models.Role{
+			f.owner.ID:  models.RoleOwner,
+			f.admin.ID:  models.RoleAdmin,
+			f.viewer.ID: models.RoleViewer,
+		},
+		positions: make(map[uuid.UUID]string),
+	}
+
+	f.interactor = NewInvitesInteractor(log, f.invites, f.orgs, usersRepo, authorizer.NewAuthorizer(log, f.orgs))
+
+	return f
+}
+
+// as returns context of the request made by the user
+func as(user *models.User) context.Context {
+	return ctxmeta.UserContext(context.Background(), user)
+}
+
+func (f *fixture) create(t *testing.T, params CreateParams) *models.Invite {
+	t.Helper()
+
+	params.OrganizationID = f.org.ID
+
+	invite, err := f.interactor.Create(as(f.admin), params)
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+
+	return invite
+}
+
+func TestCreate(t *testing.T) {
+	f := newFixture(t)
+
+	invite := f.create(t, CreateParams{Position: "Intern"})
+
+	if invite.Role != models.RoleViewer || invite.MaxUses != 1 || invite.CreatedBy != f.admin.ID || invite.IsBound() {
+		t.Fatalf("Create() = %+v, want single use viewer invite", invite)
+	}
+
+	if week := time.Until(invite.ExpiredAt); week < 6*24*time.Hour || week > 7*24*time.Hour {
+		t.Fatalf("Create() expires in %s, want a week", week)
+	}
+
+	tests := []struct {
+		name    string
+		actor   func(f *fixture) *models.User
+		params  func(f *fixture) CreateParams
+		wantErr error
+	}{
+		{
+			name:   "accountant by admin",
+			actor:  func(f *fixture) *models.User { return f.admin },
+			params: func(f *fixture) CreateParams { return CreateParams{Role: models.RoleAccountant, MaxUses: 10} },
+		},
+		{
+			name:   "owner by owner",
+			actor:  func(f *fixture) *models.User { return f.owner },
+			params: func(f *fixture) CreateParams { return CreateParams{Role: models.RoleOwner} },
+		},
+		{
+			name:    "owner by admin",
+			actor:   func(f *fixture) *models.User { return f.admin },
+			params:  func(f *fixture) CreateParams { return CreateParams{Role: models.RoleOwner} },
+			wantErr: authorizer.ErrorPermissionDenied,
+		},
+		{
+			name:    "by viewer",
+			actor:   func(f *fixture) *models.User { return f.viewer },
+			params:  func(f *fixture) CreateParams { return CreateParams{} },
+			wantErr: authorizer.ErrorPermissionDenied,
+		},
+		{
+			name:    "too many uses",
+			actor:   func(f *fixture) *models.User { return f.admin },
+			params:  func(f *fixture) CreateParams { return CreateParams{MaxUses: maxUsesLimit + 1} },
+			wantErr: ErrorInvalidInvite,
+		},
+		{
+			name:    "negative uses",
+			actor:   func(f *fixture) *models.User { return f.admin },
+			params:  func(f *fixture) CreateParams { return CreateParams{MaxUses: -1} },
+			wantErr: ErrorInvalidInvite,
+		},
+		{
+			name:    "expired",
+			actor:   func(f *fixture) *models.User { return f.admin },
+			params:  func(f *fixture) CreateParams { return CreateParams{ExpiredAt: time.Now().Add(-time.Minute)} },
+			wantErr: ErrorInvalidInvite,
+		},
+		{
+			name:   "bound",
+			actor:  func(f *fixture) *models.User { return f.admin },
+			params: func(f *fixture) CreateParams { return CreateParams{PublicKey: f.stranger.PK} },
+		},
+		{
+			name:    "bound with several uses",
+			actor:   func(f *fixture) *models.User { return f.admin },
+			params:  func(f *fixture) CreateParams { return CreateParams{PublicKey: f.stranger.PK, MaxUses: 2} },
+			wantErr: ErrorInvalidInvite,
+		},
+		{
+			name:    "bound to unknown wallet",
+			actor:   func(f *fixture) *models.User { return f.admin },
+			params:  func(f *fixture) CreateParams { return CreateParams{PublicKey: []byte{0x04, 0xff}} },
+			wantErr: ErrorUnknownPublicKey,
+		},
+		{
+			name:    "bound to participant",
+			actor:   func(f *fixture) *models.User { return f.admin },
+			params:  func(f *fixture) CreateParams { return CreateParams{PublicKey: f.viewer.PK} },
+			wantErr: ErrorAlreadyParticipant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params(f)
+			params.OrganizationID = f.org.ID
+
+			_, err := f.interactor.Create(as(tt.actor(f)), params)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Create() error: %v", err)
+			}
+		})
+	}
+}
+
+func TestUse(t *testing.T) {
+	f := newFixture(t)
+
+	invite := f.create(t, CreateParams{Role: models.RoleApprover, MaxUses: 2})
+
+	for range 2 {
+		used, err := f.interactor.Use(context.Background(), invite.LinkHash)
+		if err != nil {
+			t.Fatalf("Use() error: %v", err)
+		}
+
+		if used.Role != models.RoleApprover || used.UsedAt.IsZero() {
+			t.Fatalf("Use() = %+v", used)
+		}
+	}
+
+	if _, err := f.interactor.Use(context.Background(), invite.LinkHash); !errors.Is(err, ErrorInviteUsesExhausted) {
+		t.Fatalf("Use() of exhausted invite error = %v, want ErrorInviteUsesExhausted", err)
+	}
+
+	if _, err := f.interactor.Use(context.Background(), "unknown"); !errors.Is(err, ErrorInviteNotFound) {
+		t.Fatalf("Use() of unknown invite error = %v, want ErrorInviteNotFound", err)
+	}
+
+	expired := f.create(t, CreateParams{ExpiredAt: time.Now().Add(time.Hour)})
+	f.invites.find(expired.LinkHash).ExpiredAt = time.Now().Add(-time.Minute)
+
+	if _, err := f.interactor.Use(context.Background(), expired.LinkHash); !errors.Is(err, ErrorInviteExpired) {
+		t.Fatalf("Use() of expired invite error = %v, want ErrorInviteExpired", err)
+	}
+
+	bound := f.create(t, CreateParams{PublicKey: f.stranger.PK})
+
+	// a new account can not take the invite addressed to the existing one
+	if _, err := f.interactor.Use(context.Background(), bound.LinkHash); !errors.Is(err, ErrorInviteAccountBound) {
+		t.Fatalf("Use() of bound invite error = %v, want ErrorInviteAccountBound", err)
+	}
+
+	archived := f.create(t, CreateParams{})
+	f.org.ArchivedAt = time.Now()
+
+	if _, err := f.interactor.Use(context.Background(), archived.LinkHash); !errors.Is(err, authorizer.ErrorOrganizationArchived) {
+		t.Fatalf("Use() of archived organization invite error = %v, want ErrorOrganizationArchived", err)
+	}
+}
+
+func TestRevokeAndList(t *testing.T) {
+	f := newFixture(t)
+
+	revoked := f.create(t, CreateParams{})
+	active := f.create(t, CreateParams{Role: models.RoleAccountant})
+
+	params := RevokeParams{
+		OrganizationID: f.org.ID,
+		LinkHash:       revoked.LinkHash,
+	}
+
+	if _, err := f.interactor.Revoke(as(f.viewer), params); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("Revoke() by viewer error = %v, want ErrorPermissionDenied", err)
+	}
+
+	invite, err := f.interactor.Revoke(as(f.owner), params)
+	if err != nil {
+		t.Fatalf("Revoke() error: %v", err)
+	}
+
+	if invite.RevokedAt.IsZero() {
+		t.Fatalf("Revoke() returned not revoked invite")
+	}
+
+	if _, err = f.interactor.Revoke(as(f.owner), params); !errors.Is(err, ErrorInviteNotFound) {
+		t.Fatalf("second Revoke() error = %v, want ErrorInviteNotFound", err)
+	}
+
+	if _, err = f.interactor.Use(context.Background(), revoked.LinkHash); !errors.Is(err, ErrorInviteRevoked) {
+		t.Fatalf("Use() of revoked invite error = %v, want ErrorInviteRevoked", err)
+	}
+
+	list, err := f.interactor.List(as(f.admin), ListParams{OrganizationID: f.org.ID})
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+
+	if len(list) != 1 || list[0].LinkHash != active.LinkHash {
+		t.Fatalf("List() = %+v, want the active invite only", list)
+	}
+
+	if list, err = f.interactor.List(as(f.admin), ListParams{OrganizationID: f.org.ID, All: true}); err != nil || len(list) != 2 {
+		t.Fatalf("List() of all invites = %+v, %v, want 2 invites", list, err)
+	}
+
+	if _, err = f.interactor.List(as(f.viewer), ListParams{OrganizationID: f.org.ID}); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("List() by viewer error = %v, want ErrorPermissionDenied", err)
+	}
+
+	info, err := f.interactor.Get(context.Background(), active.LinkHash)
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+
+	if info.Organization.ID != f.org.ID || info.Inviter == nil || info.Inviter.ID != f.admin.ID ||
+		info.Invite.Role != models.RoleAccountant {
+		t.Fatalf("Get() = %+v", info)
+	}
+}
+
+func TestAccept(t *testing.T) {
+	f := newFixture(t)
+
+	invite := f.create(t, CreateParams{Role: models.RoleAccountant, Position: "Bookkeeper", MaxUses: 5})
+
+	if _, err := f.interactor.Accept(as(f.viewer), invite.LinkHash); !errors.Is(err, ErrorAlreadyParticipant) {
+		t.Fatalf("Accept() by participant error = %v, want ErrorAlreadyParticipant", err)
+	}
+
+	accepted, err := f.interactor.Accept(as(f.stranger), invite.LinkHash)
+	if err != nil {
+		t.Fatalf("Accept() error: %v", err)
+	}
+
+	if accepted.Uses != 1 {
+		t.Fatalf("Accept() uses = %d, want 1", accepted.Uses)
+	}
+
+	if role := f.orgs.roles[f.stranger.ID]; role != models.RoleAccountant || f.orgs.positions[f.stranger.ID] != "Bookkeeper" {
+		t.Fatalf("accepted user role %s, position %q", role, f.orgs.positions[f.stranger.ID])
+	}
+
+	// the existing user joined without a new account, so the invite is accepted once
+	if _, err = f.interactor.Accept(as(f.stranger), invite.LinkHash); !errors.Is(err, ErrorAlreadyParticipant) {
+		t.Fatalf("second Accept() error = %v, want ErrorAlreadyParticipant", err)
+	}
+
+	if _, err = f.interactor.Accept(context.Background(), invite.LinkHash); err == nil {
+		t.Fatalf("Accept() without user error is nil")
+	}
+}
+
+func TestAcceptBound(t *testing.T) {
+	f := newFixture(t)
+	other := newUser("Mallory", 5)
+
+	f.orgs.users.users = append(f.orgs.users.users, other)
+
+	bound := f.create(t, CreateParams{PublicKey: f.stranger.PK, Role: models.RoleApprover})
+
+	if _, err := f.interactor.Accept(as(other), bound.LinkHash); !errors.Is(err, ErrorInviteNotAddressed) {
+		t.Fatalf("Accept() by another user error = %v, want ErrorInviteNotAddressed", err)
+	}
+
+	received, err := f.interactor.Received(as(f.stranger), ReceivedParams{})
+	if err != nil {
+		t.Fatalf("Received() error: %v", err)
+	}
+
+	if len(received) != 1 || received[0].LinkHash != bound.LinkHash {
+		t.Fatalf("Received() = %+v, want the bound invite", received)
+	}
+
+	if received, err = f.interactor.Received(as(other), ReceivedParams{}); err != nil || len(received) != 0 {
+		t.Fatalf("Received() by another user = %+v, %v, want none", received, err)
+	}
+
+	if _, err = f.interactor.Accept(as(f.stranger), bound.LinkHash); err != nil {
+		t.Fatalf("Accept() by addressee error: %v", err)
+	}
+
+	if role := f.orgs.roles[f.stranger.ID]; role != models.RoleApprover {
+		t.Fatalf("accepted user role = %s, want approver", role)
+	}
+
+	if received, err = f.interactor.Received(as(f.stranger), ReceivedParams{}); err != nil || len(received) != 0 {
+		t.Fatalf("Received() after accept = %+v, %v, want none", received, err)
+	}
+
+	// users joined without wallet signature have no public key
+	if received, err = f.interactor.Received(as(&models.User{ID: uuid.New()}), ReceivedParams{}); err != nil || len(received) != 0 {
+		t.Fatalf("Received() by user without public key = %+v, %v, want none", received, err)
+	}
+}
+
+func TestAcceptArchived(t *testing.T) {
+	f := newFixture(t)
+
+	invite := f.create(t, CreateParams{})
+	f.org.ArchivedAt = time.Now()
+
+	if _, err := f.interactor.Accept(as(f.stranger), invite.LinkHash); !errors.Is(err, authorizer.ErrorOrganizationArchived) {
+		t.Fatalf("Accept() into archived organization error = %v, want ErrorOrganizationArchived", err)
+	}
+
+	if _, ok := f.orgs.roles[f.stranger.ID]; ok {
+		t.Fatalf("user joined archived organization")
+	}
+
+	if uses := f.invites.find(invite.LinkHash).Uses; uses != 0 {
+		t.Fatalf("invite uses = %d after rejected accept, want 0", uses)
+	}
+}
@@ -0,0 +1,431 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/config"
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/logger"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/jobs"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorJobNotFound       = errors.New("job not found")
+	ErrorUnknownJobKind    = errors.New("unknown job kind")
+	ErrorPermanentJobError = errors.New("permanent job error")
+	ErrorLeaseLost         = jobs.ErrorLeaseLost
+)
+
+// Permanent marks handler error as non retriable. Job will be failed right away.
+func Permanent(err error) error {
+	return errors.Join(err, ErrorPermanentJobError)
+}
+
+// Failed reports whether job will not be retried after handler returned err.
+// Handlers may use it to mark related entities as failed.
+func Failed(job *models.Job, err error) bool {
+	return err != nil && (errors.Is(err, ErrorPermanentJobError) || job.Attempts >= job.MaxAttempts)
+}
+
+// Handler performs the job. Returned value is stored as job result.
+type Handler func(ctx context.Context, job *models.Job) (any, error)
+
+type EnqueueParams struct {
+	Kind           string
+	OrganizationID uuid.UUID
+	Payload        any
+	MaxAttempts    int
+	RunAt          time.Time
+}
+
+type GetParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+type JobsInteractor interface {
+	RegisterHandler(kind string, h Handler)
+
+	Enqueue(ctx context.Context, params EnqueueParams) (*models.Job, error)
+	Get(ctx context.Context, params GetParams) (*models.Job, error)
+
+	// Run starts workers. Blocks until ctx is done.
+	Run(ctx context.Context) error
+}
+
+type jobsInteractor struct {
+	log *slog.Logger
+	// worker identifies this process as the lease owner of the jobs it runs
+	worker        uuid.UUID
+	config        config.JobsConfig
+	jobsRepo      jobs.Repository
+	orgInteractor organizations.OrganizationsInteractor
+
+	handlersMu sync.RWMutex
+	handlers   map[string]Handler
+}
+
+func NewJobsInteractor(
+	log *slog.Logger,
+	config config.JobsConfig,
+	jobsRepo jobs.Repository,
+	orgInteractor organizations.OrganizationsInteractor,
+) JobsInteractor {
+	if config.Workers <= 0 {
+		config.Workers = 4
+	}
+
+	if config.PollInterval <= 0 {
+		config.PollInterval = 2 * time.Second
+	}
+
+	if config.LeaseDuration <= 0 {
+		config.LeaseDuration = time.Minute
+	}
+
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+
+	if config.BackoffBase <= 0 {
+		config.BackoffBase = 5 * time.Second
+	}
+
+	if config.BackoffMax <= 0 {
+		config.BackoffMax = 10 * time.Minute
+	}
+
+	return &jobsInteractor{
+		log:           log,
+		worker:        uuid.New(),
+		config:        config,
+		jobsRepo:      jobsRepo,
+		orgInteractor: orgInteractor,
+		handlers:      make(map[string]Handler),
+	}
+}
+
+func (i *jobsInteractor) RegisterHandler(kind string, h Handler) {
+	i.handlersMu.Lock()
+	defer i.handlersMu.Unlock()
+
+	i.handlers[kind] = h
+}
+
+func (i *jobsInteractor) handler(kind string) (Handler, bool) {
+	i.handlersMu.RLock()
+	defer i.handlersMu.RUnlock()
+
+	h, ok := i.handlers[kind]
+
+	return h, ok
+}
+
+func (i *jobsInteractor) Enqueue(ctx context.Context, params EnqueueParams) (*models.Job, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	if _, ok := i.handler(params.Kind); !ok {
+		return nil, fmt.Errorf("error enqueue job %s. %w", params.Kind, ErrorUnknownJobKind)
+	}
+
+	payload, err := json.Marshal(params.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal job payload. %w", err)
+	}
+
+	if params.MaxAttempts <= 0 {
+		params.MaxAttempts = i.config.MaxAttempts
+	}
+
+	createdAt := time.Now()
+
+	if params.RunAt.IsZero() {
+		params.RunAt = createdAt
+	}
+
+	job := models.Job{
+		ID:             uuid.Must(uuid.NewV7()),
+		OrganizationID: params.OrganizationID,
+		CreatedBy:      user.Id(),
+		Kind:           params.Kind,
+		Payload:        payload,
+		Status:         models.JobStatusQueued,
+		MaxAttempts:    params.MaxAttempts,
+		RunAt:          params.RunAt,
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	}
+
+	if err = i.jobsRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("error create new job. %w", err)
+	}
+
+	i.log.Debug(
+		"job enqueued",
+		slog.String("job id", job.ID.String()),
+		slog.String("kind", job.Kind),
+	)
+
+	return &job, nil
+}
+
+func (i *jobsInteractor) Get(ctx context.Context, params GetParams) (*models.Job, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	if _, err = i.orgInteractor.Participant(ctx, organizations.ParticipantParams{
+		ID:             user.Id(),
+		OrganizationID: params.OrganizationID,
+		UsersOnly:      true,
+		ActiveOnly:     true,
+	}); err != nil {
+		return nil, fmt.Errorf("error fetch actor participant. %w", err)
+	}
+
+	jobs, err := i.jobsRepo.Get(ctx, jobs.GetParams{
+		IDs:            uuid.UUIDs{params.ID},
+		OrganizationID: params.OrganizationID,
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch job. %w", err)
+	}
+
+	if len(jobs) == 0 {
+		return nil, ErrorJobNotFound
+	}
+
+	return jobs[0], nil
+}
+
+func (i *jobsInteractor) Run(ctx context.Context) error {
+	i.log.Info(
+		"starting jobs workers",
+		slog.String("worker", i.worker.String()),
+		slog.Int("workers", i.config.Workers),
+		slog.Duration("poll interval", i.config.PollInterval),
+	)
+
+	// every slot is a running job. jobs are acquired only when free slots are available,
+	// otherwise lease of the acquired job could expire before it starts
+	slots := make(chan struct{}, i.config.Workers)
+
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+
+	ticker := time.NewTicker(i.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		free := cap(slots) - len(slots)
+		if free == 0 {
+			continue
+		}
+
+		acquired, err := i.jobsRepo.Acquire(ctx, jobs.AcquireParams{
+			Kinds:       i.kinds(),
+			Limit:       int64(free),
+			Worker:      i.worker,
+			LockedUntil: time.Now().Add(i.config.LeaseDuration),
+		})
+		if err != nil {
+			i.log.Error("error acquire jobs", logger.Err(err))
+
+			continue
+		}
+
+		for _, job := range acquired {
+			slots <- struct{}{}
+			wg.Add(1)
+
+			go func(job *models.Job) {
+				defer func() {
+					<-slots
+					wg.Done()
+				}()
+
+				i.process(ctx, job)
+			}(job)
+		}
+	}
+}
+
+func (i *jobsInteractor) kinds() []string {
+	i.handlersMu.RLock()
+	defer i.handlersMu.RUnlock()
+
+	kinds := make([]string, 0, len(i.handlers))
+
+	for k := range i.handlers {
+		kinds = append(kinds, k)
+	}
+
+	return kinds
+}
+
+func (i *jobsInteractor) process(ctx context.Context, job *models.Job) {
+	log := i.log.With(
+		slog.String("job id", job.ID.String()),
+		slog.String("kind", job.Kind),
+		slog.Int("attempt", job.Attempts),
+	)
+
+	startTime := time.Now()
+
+	// job state must be saved even if workers are shutting down
+	storeCtx := context.WithoutCancel(ctx)
+
+	log.Info("job started")
+
+	h, ok := i.handler(job.Kind)
+	if !ok {
+		i.fail(storeCtx, log, job, Permanent(ErrorUnknownJobKind))
+
+		return
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// keep lease alive while handler is working, so other workers will not pick up this job
+	go func() {
+		ticker := time.NewTicker(i.config.LeaseDuration / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-jobCtx.Done():
+				return
+			case <-ticker.C:
+				err := i.jobsRepo.Heartbeat(jobCtx, jobs.HeartbeatParams{
+					ID:          job.ID,
+					Worker:      i.worker,
+					LockedUntil: time.Now().Add(i.config.LeaseDuration),
+				})
+				if errors.Is(err, ErrorLeaseLost) {
+					// the job is reclaimed by another worker, stop the handler
+					log.Warn("job lease lost, cancel job")
+					cancel()
+
+					return
+				}
+
+				if err != nil {
+					log.Warn("error extend job lease", logger.Err(err))
+				}
+			}
+		}
+	}()
+
+	result, err := i.runHandler(jobCtx, h, job)
+	if err != nil {
+		i.fail(storeCtx, log, job, err)
+
+		return
+	}
+
+	resultRaw, err := json.Marshal(result)
+	if err != nil {
+		i.fail(storeCtx, log, job, Permanent(fmt.Errorf("error marshal job result. %w", err)))
+
+		return
+	}
+
+	if err = i.jobsRepo.Complete(storeCtx, jobs.CompleteParams{
+		ID:         job.ID,
+		Worker:     i.worker,
+		Result:     resultRaw,
+		FinishedAt: time.Now(),
+	}); err != nil {
+		if errors.Is(err, ErrorLeaseLost) {
+			log.Warn("job lease lost, result dropped")
+
+			return
+		}
+
+		log.Error("error mark job as succeeded", logger.Err(err))
+
+		return
+	}
+
+	log.Info(
+		"job done",
+		slog.Duration("work time", time.Since(startTime)),
+	)
+}
+
+func (i *jobsInteractor) runHandler(ctx context.Context, h Handler, job *models.Job) (result any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job handler paniced. %v", p)
+		}
+	}()
+
+	return h(ctx, job)
+}
+
+func (i *jobsInteractor) fail(ctx context.Context, log *slog.Logger, job *models.Job, jobErr error) {
+	params := jobs.FailParams{
+		ID:         job.ID,
+		Worker:     i.worker,
+		Error:      jobErr.Error(),
+		FinishedAt: time.Now(),
+	}
+
+	if !Failed(job, jobErr) {
+		params.RetryAt = time.Now().Add(i.backoff(job.Attempts))
+	}
+
+	log.Error(
+		"job failed",
+		logger.Err(jobErr),
+		slog.Bool("retry", !params.RetryAt.IsZero()),
+		slog.Time("retry at", params.RetryAt),
+	)
+
+	if err := i.jobsRepo.Fail(ctx, params); err != nil {
+		if errors.Is(err, ErrorLeaseLost) {
+			log.Warn("job lease lost, failure dropped")
+
+			return
+		}
+
+		log.Error("error mark job as failed", logger.Err(err))
+	}
+}
+
+// backoff returns exponential delay with jitter for the given attempt number
+func (i *jobsInteractor) backoff(attempt int) time.Duration {
+	delay := i.config.BackoffBase
+
+	for a := 1; a < attempt && delay < i.config.BackoffMax; a++ {
+		delay *= 2
+	}
+
+	if delay > i.config.BackoffMax {
+		delay = i.config.BackoffMax
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
@@ -0,0 +1,187 @@
+package jobs
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/config"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/jobs"
+	"github.com/google/uuid"
+)
+
+// memoryJobs keeps a single job in memory. Heartbeat, Complete and Fail follow
+// the repository lease check: the job is updated only by its lease owner
+type memoryJobs struct {
+	jobs.Repository
+
+	mu  sync.Mutex
+	job models.Job
+}
+
+func (r *memoryJobs) lease(worker uuid.UUID, until time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.job.Status = models.JobStatusRunning
+	r.job.Attempts++
+	r.job.LeaseOwner = worker
+	r.job.LockedUntil = until
+}
+
+func (r *memoryJobs) get() models.Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.job
+}
+
+func (r *memoryJobs) held(id, worker uuid.UUID) bool {
+	return r.job.ID == id && r.job.Status == models.JobStatusRunning &&
+		r.job.LeaseOwner == worker && r.job.LockedUntil.After(time.Now())
+}
+
+func (r *memoryJobs) Heartbeat(_ context.Context, params jobs.HeartbeatParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.held(params.ID, params.Worker) {
+		return jobs.ErrorLeaseLost
+	}
+
+	r.job.LockedUntil = params.LockedUntil
+
+	return nil
+}
+
+func (r *memoryJobs) Complete(_ context.Context, params jobs.CompleteParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.held(params.ID, params.Worker) {
+		return jobs.ErrorLeaseLost
+	}
+
+	r.job.Status = models.JobStatusSucceeded
+	r.job.Result = params.Result
+	r.job.LeaseOwner = uuid.Nil
+
+	return nil
+}
+
+func (r *memoryJobs) Fail(_ context.Context, params jobs.FailParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.held(params.ID, params.Worker) {
+		return jobs.ErrorLeaseLost
+	}
+
+	r.job.Status = models.JobStatusFailed
+	r.job.LastError = params.Error
+	r.job.LeaseOwner = uuid.Nil
+
+	return nil
+}
+
+func newWorker(repo jobs.Repository, lease time.Duration) *jobsInteractor {
+	return NewJobsInteractor(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		config.JobsConfig{LeaseDuration: lease, MaxAttempts: 1},
+		repo,
+		nil,
+	).(*jobsInteractor)
+}
+
+func newRepo() *memoryJobs {
+	return &memoryJobs{
+		job: models.Job{
+			ID:          uuid.New(),
+			Kind:        "test",
+			Status:      models.JobStatusQueued,
+			MaxAttempts: 1,
+		},
+	}
+}
+
+func TestExpiredWorkerResultDropped(t *testing.T) {
+	repo := newRepo()
+	expired := newWorker(repo, time.Hour)
+	current := newWorker(repo, time.Hour)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	expired.RegisterHandler("test", func(context.Context, *models.Job) (any, error) {
+		close(started)
+		<-release
+
+		return "expired", nil
+	})
+
+	current.RegisterHandler("test", func(context.Context, *models.Job) (any, error) {
+		return "current", nil
+	})
+
+	repo.lease(expired.worker, time.Now().Add(time.Hour))
+	job := repo.get()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		expired.process(context.Background(), &job)
+	}()
+
+	<-started
+
+	// the lease has expired and the job is reclaimed by another worker
+	repo.lease(current.worker, time.Now().Add(time.Hour))
+
+	close(release)
+	<-done
+
+	if got := repo.get(); got.Status != models.JobStatusRunning || got.LeaseOwner != current.worker {
+		t.Fatalf("expired worker updated reclaimed job: status %d, lease owner %s", got.Status, got.LeaseOwner)
+	}
+
+	reclaimed := repo.get()
+	current.process(context.Background(), &reclaimed)
+
+	if got := repo.get(); got.Status != models.JobStatusSucceeded || string(got.Result) != `"current"` {
+		t.Fatalf("job status %d, result %s, want succeeded by current worker", got.Status, got.Result)
+	}
+}
+
+func TestLeaseLostCancelsHandler(t *testing.T) {
+	repo := newRepo()
+	worker := newWorker(repo, 20*time.Millisecond)
+
+	worker.RegisterHandler("test", func(ctx context.Context, _ *models.Job) (any, error) {
+		// another worker takes the job, so the next heartbeat fails
+		repo.lease(uuid.New(), time.Now().Add(time.Hour))
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			t.Error("handler is not cancelled after lease lost")
+
+			return nil, nil
+		}
+	})
+
+	repo.lease(worker.worker, time.Now().Add(time.Hour))
+	job := repo.get()
+
+	worker.process(context.Background(), &job)
+
+	if got := repo.get(); got.Status != models.JobStatusRunning || got.LastError != "" {
+		t.Fatalf("worker without lease updated job: status %d, last error %q", got.Status, got.LastError)
+	}
+}
@@ -6,11 +6,16 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/jwks"
+	"github.com/emochka2007/block-accounting/internal/pkg/logger"
 	"github.com/emochka2007/block-accounting/internal/pkg/models"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/users"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/auth"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/cache"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
@@ -18,32 +23,125 @@ import (
 var (
 	ErrorInvalidTokenClaims = errors.New("invalid token claims")
 	ErrorTokenExpired       = errors.New("token expired")
+	ErrorNoSigningKey       = errors.New("no signing key configured")
+	ErrorSessionRevoked     = errors.New("session revoked")
+	ErrorTokenReplaced      = errors.New("token replaced")
+	ErrorSessionNotFound    = auth.ErrorSessionNotFound
 )
 
 type JWTInteractor interface {
-	NewToken(user models.UserIdentity, duration time.Duration, remoteAddr string) (AccessToken, error)
+	// NewToken creates new session for given user. Client info is taken from context
+	NewToken(ctx context.Context, user models.UserIdentity, duration time.Duration) (AccessToken, error)
 	User(token string) (*models.User, error)
 	RefreshToken(ctx context.Context, token string, rToken string) (AccessToken, error)
+	// JWKS returns public keys accepted for tokens verification
+	JWKS() jwks.Set
+
+	// Sessions returns active sessions of the current user
+	Sessions(ctx context.Context) ([]*models.Session, error)
+	// Logout revokes the current session
+	Logout(ctx context.Context) error
+	// RevokeSession revokes the current user session by its id
+	RevokeSession(ctx context.Context, id uuid.UUID) error
+	// RevokeOtherSessions revokes all current user sessions except the current one. Returns revoked sessions count
+	RevokeOtherSessions(ctx context.Context) (int, error)
 }
 
 type jwtInteractor struct {
-	secret          []byte
+	log *slog.Logger
+	// secret is the legacy HS256 secret. Tokens are signed with it only if there is no signing key in keys
+	secret []byte
+	keys   *jwks.KeyRing
+	// cache holds revoked sessions denylist and current access tokens hashes of the sessions
+	cache           cache.Cache
 	usersInteractor users.UsersInteractor
 	authRepository  auth.Repository
 }
 
 func NewJWT(
+	log *slog.Logger,
 	secret []byte,
+	keys *jwks.KeyRing,
+	cache cache.Cache,
 	usersInteractor users.UsersInteractor,
 	authRepository auth.Repository,
 ) JWTInteractor {
 	return &jwtInteractor{
+		log:             log,
 		secret:          secret,
+		keys:            keys,
+		cache:           cache,
 		usersInteractor: usersInteractor,
 		authRepository:  authRepository,
 	}
 }
 
+func (w *jwtInteractor) JWKS() jwks.Set {
+	return w.keys.Set()
+}
+
+// keyFunc returns verification key by token kid header. HS256 tokens are verified with the legacy secret
+func (w *jwtInteractor) keyFunc(t *jwt.Token) (interface{}, error) {
+	if t.Method.Alg() == jwt.SigningMethodHS256.Alg() {
+		if len(w.secret) == 0 {
+			return nil, fmt.Errorf("error hs256 tokens are not accepted. %w", ErrorInvalidTokenClaims)
+		}
+
+		return w.secret, nil
+	}
+
+	kid, ok := t.Header["kid"].(string)
+	if !ok {
+		return nil, fmt.Errorf("error token kid header missing. %w", ErrorInvalidTokenClaims)
+	}
+
+	key, err := w.keys.Key(kid)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch verification key. %w", err)
+	}
+
+	if key.Method.Alg() != t.Method.Alg() {
+		return nil, fmt.Errorf("error token alg does not match key %s. %w", kid, ErrorInvalidTokenClaims)
+	}
+
+	return key.Public, nil
+}
+
+// sign signs claims with the signing key or the legacy secret
+func (w *jwtInteractor) sign(claims jwt.MapClaims) (string, error) {
+	if key := w.keys.Signing(); key != nil {
+		token := jwt.NewWithClaims(key.Method, claims)
+		token.Header["kid"] = key.ID
+
+		return token.SignedString(key.Private)
+	}
+
+	if len(w.secret) == 0 {
+		return "", ErrorNoSigningKey
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(w.secret)
+}
+
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+)
+
+// tokenType returns typ claim of the token. Tokens issued before the claim was added are told apart
+// by the rt_hash claim, which only refresh tokens have
+func tokenType(claims jwt.MapClaims) string {
+	if typ, ok := claims["typ"].(string); ok {
+		return typ
+	}
+
+	if _, ok := claims["rt_hash"]; ok {
+		return tokenTypeRefresh
+	}
+
+	return tokenTypeAccess
+}
+
 type AccessToken struct {
 	Token     string
 	ExpiredAt time.Time
@@ -53,22 +151,33 @@ type AccessToken struct {
 }
 
 // NewToken creates new JWT token for given user
-func (w *jwtInteractor) NewToken(user models.UserIdentity, duration time.Duration, remoteAddr string) (AccessToken, error) {
-	tokens, err := w.newTokens(user.Id(), duration)
+func (w *jwtInteractor) NewToken(
+	ctx context.Context,
+	user models.UserIdentity,
+	duration time.Duration,
+) (AccessToken, error) {
+	sessionId := uuid.Must(uuid.NewV7())
+
+	tokens, err := w.newTokens(user.Id(), sessionId, duration)
 	if err != nil {
 		return AccessToken{}, fmt.Errorf("error create new tokens. %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 2*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel()
 
+	client := ctxmeta.Client(ctx)
+
 	if err := w.authRepository.AddToken(ctx, auth.AddTokenParams{
+		SessionId:             sessionId,
 		UserId:                user.Id(),
 		Token:                 tokens.Token,
 		TokenExpiredAt:        tokens.ExpiredAt,
 		RefreshToken:          tokens.RefreshToken,
 		RefreshTokenExpiredAt: tokens.RTExpiredAt,
 		CreatedAt:             time.Now(),
+		RemoteAddr:            client.RemoteAddr,
+		UserAgent:             client.UserAgent,
 	}); err != nil {
 		return AccessToken{}, fmt.Errorf("error save tokens into repository. %w", err)
 	}
@@ -79,13 +188,15 @@ func (w *jwtInteractor) NewToken(user models.UserIdentity, duration time.Duratio
 func (w *jwtInteractor) User(tokenStr string) (*models.User, error) {
 	claims := make(jwt.MapClaims)
 
-	_, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (interface{}, error) {
-		return w.secret, nil
-	})
+	_, err := jwt.ParseWithClaims(tokenStr, claims, w.keyFunc)
 	if err != nil {
 		return nil, errors.Join(fmt.Errorf("error parse jwt token. %w", err), ErrorInvalidTokenClaims)
 	}
 
+	if typ := tokenType(claims); typ != tokenTypeAccess {
+		return nil, fmt.Errorf("error %s token used as access token. %w", typ, ErrorInvalidTokenClaims)
+	}
+
 	if expDate, ok := claims["exp"].(float64); ok {
 		if time.UnixMilli(int64(expDate)).Before(time.Now()) {
 			return nil, fmt.Errorf("error token expired. %w", ErrorTokenExpired)
@@ -109,42 +220,126 @@ func (w *jwtInteractor) User(tokenStr string) (*models.User, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), 2*time.Second)
 	defer cancel()
 
+	if err := w.checkSession(ctx, claims, userId, tokenStr); err != nil {
+		return nil, err
+	}
+
+	users, err := w.usersInteractor.Get(ctx, users.GetParams{
+		Ids: uuid.UUIDs{userId},
+	})
+	if err != nil || len(users) == 0 {
+		return nil, fmt.Errorf("error fetch user from repository. %w", err)
+	}
+
+	return users[0], nil
+}
+
+// checkSession rejects revoked sessions by the denylist and replaced tokens by the session current token hash,
+// so authenticated requests do not hit the database. The token is looked up in the database if the cache is
+// not available, the session current token is not cached yet or the token has no session id
+func (w *jwtInteractor) checkSession(ctx context.Context, claims jwt.MapClaims, userId uuid.UUID, token string) error {
+	sessionId := uuid.Nil
+
+	if sid, ok := claims["sid"].(string); ok {
+		var err error
+
+		sessionId, err = uuid.Parse(sid)
+		if err != nil {
+			return errors.Join(fmt.Errorf("error parse session id. %w", err), ErrorInvalidTokenClaims)
+		}
+
+		if err = w.checkCachedSession(ctx, sessionId, token); err == nil {
+			return nil
+		} else if errors.Is(err, ErrorSessionRevoked) || errors.Is(err, ErrorTokenReplaced) {
+			return err
+		} else if !errors.Is(err, cache.ErrorCacheMiss) {
+			w.log.Warn(
+				"error check session in cache, falling back to database",
+				slog.String("session id", sessionId.String()),
+				logger.Err(err),
+			)
+		}
+	}
+
 	tokens, err := w.authRepository.GetTokens(ctx, auth.GetTokenParams{
 		UserId: userId,
-		Token:  tokenStr,
+		Token:  token,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error fetch token from repository. %w", err)
+		return fmt.Errorf("error fetch token from repository. %w", err)
+	}
+
+	// signed token which is not in the repository was replaced on refresh
+	if tokens.SessionId == uuid.Nil {
+		return fmt.Errorf("error token not found. %w", ErrorTokenReplaced)
 	}
 
 	if tokens.TokenExpiredAt.Before(time.Now()) {
-		return nil, fmt.Errorf("error token expired. %w", ErrorTokenExpired)
+		return fmt.Errorf("error token expired. %w", ErrorTokenExpired)
+	}
+
+	if !tokens.RevokedAt.IsZero() {
+		return ErrorSessionRevoked
 	}
 
 	if tokens.UserId != userId {
-		return nil, errors.Join(fmt.Errorf("error invalid user id. %w", err), ErrorInvalidTokenClaims)
+		return fmt.Errorf("error invalid user id. %w", ErrorInvalidTokenClaims)
 	}
 
-	users, err := w.usersInteractor.Get(ctx, users.GetParams{
-		Ids: uuid.UUIDs{tokens.UserId},
-	})
-	if err != nil || len(users) == 0 {
-		return nil, fmt.Errorf("error fetch user from repository. %w", err)
+	if sessionId != uuid.Nil && tokens.SessionId == sessionId {
+		if err := w.cacheSessionToken(ctx, sessionId, token, tokens.TokenExpiredAt); err != nil {
+			w.log.Warn(
+				"error cache session token",
+				slog.String("session id", sessionId.String()),
+				logger.Err(err),
+			)
+		}
 	}
 
-	return users[0], nil
+	return nil
+}
+
+// checkCachedSession checks the session against the denylist and the session current token hash.
+// Returns cache.ErrorCacheMiss if the session current token is not cached
+func (w *jwtInteractor) checkCachedSession(ctx context.Context, sessionId uuid.UUID, token string) error {
+	revoked, err := w.isRevoked(ctx, sessionId)
+	if err != nil {
+		return err
+	}
+
+	if revoked {
+		return ErrorSessionRevoked
+	}
+
+	var current string
+
+	if err = w.cache.Get(ctx, sessionTokenKey{SessionId: sessionId}, &current); err != nil {
+		if errors.Is(err, cache.ErrorCacheMiss) {
+			return cache.ErrorCacheMiss
+		}
+
+		return fmt.Errorf("error fetch session token from cache. %w", err)
+	}
+
+	if current != tokenHash(token) {
+		return ErrorTokenReplaced
+	}
+
+	return nil
 }
 
 func (w *jwtInteractor) RefreshToken(ctx context.Context, token string, rToken string) (AccessToken, error) {
 	claims := make(jwt.MapClaims)
 
-	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
-		return w.secret, nil
-	})
+	_, err := jwt.ParseWithClaims(token, claims, w.keyFunc)
 	if err != nil {
 		return AccessToken{}, errors.Join(fmt.Errorf("error parse jwt token. %w", err), ErrorInvalidTokenClaims)
 	}
 
+	if typ := tokenType(claims); typ != tokenTypeAccess {
+		return AccessToken{}, fmt.Errorf("error %s token used as access token. %w", typ, ErrorInvalidTokenClaims)
+	}
+
 	var userIdString string
 	var ok bool
 
@@ -157,13 +352,15 @@ func (w *jwtInteractor) RefreshToken(ctx context.Context, token string, rToken s
 		return AccessToken{}, errors.Join(fmt.Errorf("error parse user id. %w", err), ErrorInvalidTokenClaims)
 	}
 
-	_, err = jwt.ParseWithClaims(rToken, claims, func(t *jwt.Token) (interface{}, error) {
-		return w.secret, nil
-	})
+	_, err = jwt.ParseWithClaims(rToken, claims, w.keyFunc)
 	if err != nil {
 		return AccessToken{}, errors.Join(fmt.Errorf("error parse refresh jwt token. %w", err), ErrorInvalidTokenClaims)
 	}
 
+	if typ := tokenType(claims); typ != tokenTypeRefresh {
+		return AccessToken{}, fmt.Errorf("error %s token used as refresh token. %w", typ, ErrorInvalidTokenClaims)
+	}
+
 	if expDate, ok := claims["exp"].(float64); ok {
 		if time.UnixMilli(int64(expDate)).Before(time.Now()) {
 			return AccessToken{}, fmt.Errorf("error refresh token expired. %w", ErrorTokenExpired)
@@ -204,10 +401,11 @@ func (w *jwtInteractor) RefreshToken(ctx context.Context, token string, rToken s
 		return AccessToken{}, fmt.Errorf("error token expired. %w", ErrorTokenExpired)
 	}
 
-	rtHash := sha512.New()
-	rtHash.Write([]byte(tokens.Token))
+	if !tokens.RevokedAt.IsZero() {
+		return AccessToken{}, ErrorSessionRevoked
+	}
 
-	rtHashStringValid := base64.StdEncoding.EncodeToString(rtHash.Sum(nil))
+	rtHashStringValid := tokenHash(tokens.Token)
 
 	rtHashRaw, ok := claims["rt_hash"]
 	if !ok {
@@ -223,11 +421,17 @@ func (w *jwtInteractor) RefreshToken(ctx context.Context, token string, rToken s
 		return AccessToken{}, fmt.Errorf("error refresh token hash corrupted. %w", ErrorInvalidTokenClaims)
 	}
 
-	newTokens, err := w.newTokens(userId, 24*time.Hour)
+	newTokens, err := w.newTokens(userId, tokens.SessionId, 24*time.Hour)
 	if err != nil {
 		return AccessToken{}, fmt.Errorf("error create new tokens. %w", err)
 	}
 
+	// the replaced access token must be rejected right away, so the new token hash is cached before
+	// the session is updated. If the update fails, the client refreshes again with the same tokens
+	if err = w.cacheSessionToken(ctx, tokens.SessionId, newTokens.Token, newTokens.ExpiredAt); err != nil {
+		return AccessToken{}, fmt.Errorf("error cache session token. %w", err)
+	}
+
 	if err = w.authRepository.RefreshToken(ctx, auth.RefreshTokenParams{
 		UserId:                userId,
 		OldToken:              token,
@@ -243,36 +447,28 @@ func (w *jwtInteractor) RefreshToken(ctx context.Context, token string, rToken s
 	return newTokens, nil
 }
 
-func (w *jwtInteractor) newTokens(userId uuid.UUID, duration time.Duration) (AccessToken, error) {
-	token := jwt.New(jwt.SigningMethodHS256)
-
+func (w *jwtInteractor) newTokens(userId, sessionId uuid.UUID, duration time.Duration) (AccessToken, error) {
 	expAt := time.Now().Add(duration)
 
-	claims := token.Claims.(jwt.MapClaims)
-	claims["uid"] = userId.String()
-	claims["exp"] = expAt.UnixMilli()
-
-	secret := w.secret
-
-	tokenString, err := token.SignedString([]byte(secret))
+	tokenString, err := w.sign(jwt.MapClaims{
+		"uid": userId.String(),
+		"sid": sessionId.String(),
+		"exp": expAt.UnixMilli(),
+		"typ": tokenTypeAccess,
+	})
 	if err != nil {
 		return AccessToken{}, fmt.Errorf("error sign token. %w", err)
 	}
 
-	refreshToken := jwt.New(jwt.SigningMethodHS256)
-
-	rtHash := sha512.New()
-
-	rtHash.Write([]byte(tokenString))
-
 	rtExpAt := expAt.Add(time.Hour * 24 * 5)
 
-	claims = refreshToken.Claims.(jwt.MapClaims)
-	claims["uid"] = userId.String()
-	claims["exp"] = rtExpAt.UnixMilli()
-	claims["rt_hash"] = base64.StdEncoding.EncodeToString(rtHash.Sum(nil))
-
-	rtokenString, err := refreshToken.SignedString([]byte(secret))
+	rtokenString, err := w.sign(jwt.MapClaims{
+		"uid":     userId.String(),
+		"sid":     sessionId.String(),
+		"exp":     rtExpAt.UnixMilli(),
+		"typ":     tokenTypeRefresh,
+		"rt_hash": tokenHash(tokenString),
+	})
 	if err != nil {
 		return AccessToken{}, fmt.Errorf("error sign refresh token. %w", err)
 	}
@@ -284,3 +480,193 @@ func (w *jwtInteractor) newTokens(userId uuid.UUID, duration time.Duration) (Acc
 		RTExpiredAt:  rtExpAt,
 	}, nil
 }
+
+func (w *jwtInteractor) Sessions(ctx context.Context) ([]*models.Session, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	current, err := w.currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := w.authRepository.Sessions(ctx, auth.SessionsParams{
+		UserId:     user.Id(),
+		ActiveOnly: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch sessions. %w", err)
+	}
+
+	sessions := make([]*models.Session, len(tokens))
+
+	for i, t := range tokens {
+		sessions[i] = &models.Session{
+			ID:         t.SessionId,
+			UserID:     t.UserId,
+			RemoteAddr: t.RemoteAddr,
+			UserAgent:  t.UserAgent,
+			CreatedAt:  t.CreatedAt,
+			ExpiredAt:  t.RefreshTokenExpiredAt,
+			RevokedAt:  t.RevokedAt,
+			Current:    t.SessionId == current,
+		}
+	}
+
+	return sessions, nil
+}
+
+func (w *jwtInteractor) Logout(ctx context.Context) error {
+	current, err := w.currentSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	return w.RevokeSession(ctx, current)
+}
+
+func (w *jwtInteractor) RevokeSession(ctx context.Context, id uuid.UUID) error {
+	revoked, err := w.revoke(ctx, auth.RevokeSessionsParams{
+		Ids: uuid.UUIDs{id},
+	})
+	if err != nil {
+		return err
+	}
+
+	if revoked == 0 {
+		return ErrorSessionNotFound
+	}
+
+	return nil
+}
+
+func (w *jwtInteractor) RevokeOtherSessions(ctx context.Context) (int, error) {
+	current, err := w.currentSession(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return w.revoke(ctx, auth.RevokeSessionsParams{
+		ExceptIds: uuid.UUIDs{current},
+	})
+}
+
+// revoke revokes the current user sessions and adds them into the denylist
+func (w *jwtInteractor) revoke(ctx context.Context, params auth.RevokeSessionsParams) (int, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	params.UserId = user.Id()
+	params.RevokedAt = time.Now()
+
+	revoked, err := w.authRepository.RevokeSessions(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("error revoke sessions. %w", err)
+	}
+
+	var denylistErr error
+
+	for _, s := range revoked {
+		ttl := time.Until(s.RefreshTokenExpiredAt)
+		if ttl <= 0 {
+			continue
+		}
+
+		// cached sessions access tokens are accepted until the session gets into the denylist
+		if err := w.cache.Cache(ctx, revokedSessionKey{SessionId: s.SessionId}, true, ttl); err != nil {
+			w.log.Error(
+				"error add session into denylist",
+				slog.String("session id", s.SessionId.String()),
+				logger.Err(err),
+			)
+
+			denylistErr = errors.Join(
+				denylistErr,
+				fmt.Errorf("error add session %s into denylist. %w", s.SessionId, err),
+			)
+		}
+	}
+
+	if denylistErr != nil {
+		return len(revoked), denylistErr
+	}
+
+	return len(revoked), nil
+}
+
+// currentSession returns session id of the request access token
+func (w *jwtInteractor) currentSession(ctx context.Context) (uuid.UUID, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	token, err := ctxmeta.AccessToken(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error fetch access token from context. %w", err)
+	}
+
+	tokens, err := w.authRepository.GetTokens(ctx, auth.GetTokenParams{
+		UserId: user.Id(),
+		Token:  token,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error fetch token from repository. %w", err)
+	}
+
+	if tokens.SessionId == uuid.Nil {
+		return uuid.Nil, ErrorSessionNotFound
+	}
+
+	return tokens.SessionId, nil
+}
+
+type revokedSessionKey struct {
+	SessionId uuid.UUID
+}
+
+type sessionTokenKey struct {
+	SessionId uuid.UUID
+}
+
+// tokenHash returns base64 encoded sha512 hash of the token
+func tokenHash(token string) string {
+	h := sha512.New()
+	h.Write([]byte(token))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// cacheSessionToken caches the session current access token hash until the token expires
+func (w *jwtInteractor) cacheSessionToken(
+	ctx context.Context,
+	sessionId uuid.UUID,
+	token string,
+	expiredAt time.Time,
+) error {
+	ttl := time.Until(expiredAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return w.cache.Cache(ctx, sessionTokenKey{SessionId: sessionId}, tokenHash(token), ttl)
+}
+
+// isRevoked reports whether session is in the denylist. Cache miss means the session is not revoked
+func (w *jwtInteractor) isRevoked(ctx context.Context, sessionId uuid.UUID) (bool, error) {
+	var revoked bool
+
+	if err := w.cache.Get(ctx, revokedSessionKey{SessionId: sessionId}, &revoked); err != nil {
+		if errors.Is(err, cache.ErrorCacheMiss) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("error fetch session from denylist. %w", err)
+	}
+
+	return revoked, nil
+}
@@ -0,0 +1,459 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/jwks"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/users"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/auth"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/cache"
+	"github.com/google/uuid"
+)
+
+var errorCacheDown = errors.New("cache is down")
+
+// memoryCache keeps records in memory. Writes fail if failWrites is set
+type memoryCache struct {
+	mu         sync.Mutex
+	records    map[string]any
+	failWrites bool
+}
+
+func (c *memoryCache) Get(_ context.Context, key any, dst any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.records[fmt.Sprintf("%#v", key)]
+	if !ok {
+		return fmt.Errorf("error fetch data from cache. %w", cache.ErrorCacheMiss)
+	}
+
+	reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(v))
+
+	return nil
+}
+
+func (c *memoryCache) Cache(_ context.Context, key any, val any, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.failWrites {
+		return errorCacheDown
+	}
+
+	if c.records == nil {
+		c.records = make(map[string]any)
+	}
+
+	c.records[fmt.Sprintf("%#v", key)] = val
+
+	return nil
+}
+
+// memoryAuth keeps sessions in memory. Nonces and invites are not used by the interactor
+type memoryAuth struct {
+	auth.Repository
+
+	mu       sync.Mutex
+	sessions []*auth.AccessToken
+}
+
+func (r *memoryAuth) AddToken(_ context.Context, params auth.AddTokenParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions = append(r.sessions, &auth.AccessToken{
+		SessionId:             params.SessionId,
+		UserId:                params.UserId,
+		Token:                 params.Token,
+		TokenExpiredAt:        params.TokenExpiredAt,
+		RefreshToken:          params.RefreshToken,
+		RefreshTokenExpiredAt: params.RefreshTokenExpiredAt,
+		CreatedAt:             params.CreatedAt,
+	})
+
+	return nil
+}
+
+func (r *memoryAuth) GetTokens(_ context.Context, params auth.GetTokenParams) (*auth.AccessToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.sessions {
+		if s.UserId == params.UserId && s.Token == params.Token &&
+			(params.RefreshToken == "" || s.RefreshToken == params.RefreshToken) {
+			found := *s
+
+			return &found, nil
+		}
+	}
+
+	return new(auth.AccessToken), nil
+}
+
+func (r *memoryAuth) RefreshToken(_ context.Context, params auth.RefreshTokenParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.sessions {
+		if s.UserId == params.UserId && s.Token == params.OldToken && s.RefreshToken == params.OldRefreshToken &&
+			s.RevokedAt.IsZero() {
+			s.Token = params.Token
+			s.TokenExpiredAt = params.TokenExpiredAt
+			s.RefreshToken = params.RefreshToken
+			s.RefreshTokenExpiredAt = params.RefreshTokenExpiredAt
+
+			return nil
+		}
+	}
+
+	return auth.ErrorSessionNotFound
+}
+
+func (r *memoryAuth) RevokeSessions(_ context.Context, params auth.RevokeSessionsParams) ([]*auth.AccessToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var revoked []*auth.AccessToken
+
+	for _, s := range r.sessions {
+		if s.UserId != params.UserId || !s.RevokedAt.IsZero() ||
+			(len(params.Ids) > 0 && !slices.Contains(params.Ids, s.SessionId)) ||
+			slices.Contains(params.ExceptIds, s.SessionId) {
+			continue
+		}
+
+		s.RevokedAt = params.RevokedAt
+
+		revoked = append(revoked, s)
+	}
+
+	return revoked, nil
+}
+
+type usersStub struct {
+	users.UsersInteractor
+
+	user *models.User
+}
+
+func (s usersStub) Get(_ context.Context, params users.GetParams) ([]*models.User, error) {
+	if slices.Contains(params.Ids, s.user.ID) {
+		return []*models.User{s.user}, nil
+	}
+
+	return nil, nil
+}
+
+type fixture struct {
+	interactor JWTInteractor
+	cache      *memoryCache
+	auth       *memoryAuth
+	user       *models.User
+}
+
+func newKey(t *testing.T) *jwks.Key {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key error: %v", err)
+	}
+
+	key, err := jwks.NewKey(private)
+	if err != nil {
+		t.Fatalf("NewKey() error: %v", err)
+	}
+
+	return key
+}
+
+func newKeyRing(t *testing.T, signing *jwks.Key, verification ...*jwks.Key) *jwks.KeyRing {
+	t.Helper()
+
+	keys, err := jwks.NewKeyRing(signing, verification...)
+	if err != nil {
+		t.Fatalf("NewKeyRing() error: %v", err)
+	}
+
+	return keys
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := &fixture{
+		cache: new(memoryCache),
+		auth:  new(memoryAuth),
+		user:  &models.User{ID: uuid.New()},
+	}
+
+	f.restart(nil, newKeyRing(t, newKey(t)))
+
+	return f
+}
+
+// restart replaces the interactor with the one using another keys, as on deploy of new configuration.
+// Sessions and cache are kept
+func (f *fixture) restart(secret []byte, keys *jwks.KeyRing) {
+	f.interactor = NewJWT(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		secret,
+		keys,
+		f.cache,
+		usersStub{user: f.user},
+		f.auth,
+	)
+}
+
+func (f *fixture) newToken(t *testing.T) AccessToken {
+	t.Helper()
+
+	tokens, err := f.interactor.NewToken(context.Background(), f.user, time.Hour)
+	if err != nil {
+		t.Fatalf("NewToken() error: %v", err)
+	}
+
+	return tokens
+}
+
+// userContext returns context of the request authenticated with the token
+func (f *fixture) userContext(token string) context.Context {
+	return ctxmeta.AccessTokenContext(ctxmeta.UserContext(context.Background(), f.user), token)
+}
+
+func TestRefreshTokenIsNotAccessToken(t *testing.T) {
+	f := newFixture(t)
+	tokens := f.newToken(t)
+
+	if _, err := f.interactor.User(tokens.RefreshToken); !errors.Is(err, ErrorInvalidTokenClaims) {
+		t.Fatalf("User() by refresh token error = %v, want ErrorInvalidTokenClaims", err)
+	}
+
+	user, err := f.interactor.User(tokens.Token)
+	if err != nil {
+		t.Fatalf("User() error: %v", err)
+	}
+
+	if user.ID != f.user.ID {
+		t.Fatalf("User() = %s, want %s", user.ID, f.user.ID)
+	}
+
+	_, err = f.interactor.RefreshToken(context.Background(), tokens.RefreshToken, tokens.RefreshToken)
+	if !errors.Is(err, ErrorInvalidTokenClaims) {
+		t.Fatalf("RefreshToken() with refresh token as access token error = %v, want ErrorInvalidTokenClaims", err)
+	}
+
+	_, err = f.interactor.RefreshToken(context.Background(), tokens.Token, tokens.Token)
+	if !errors.Is(err, ErrorInvalidTokenClaims) {
+		t.Fatalf("RefreshToken() with access token as refresh token error = %v, want ErrorInvalidTokenClaims", err)
+	}
+}
+
+func TestRefreshReplacesAccessToken(t *testing.T) {
+	tests := []struct {
+		name string
+		// warm caches the session token by an authenticated request before refresh
+		warm bool
+		// flush drops cached records after refresh, so the token is checked against the repository
+		flush bool
+	}{
+		{name: "cached session", warm: true},
+		{name: "not cached session"},
+		{name: "cache flushed", warm: true, flush: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			old := f.newToken(t)
+
+			if tt.warm {
+				if _, err := f.interactor.User(old.Token); err != nil {
+					t.Fatalf("User() error: %v", err)
+				}
+			}
+
+			tokens, err := f.interactor.RefreshToken(context.Background(), old.Token, old.RefreshToken)
+			if err != nil {
+				t.Fatalf("RefreshToken() error: %v", err)
+			}
+
+			if tt.flush {
+				f.cache.records = nil
+			}
+
+			if _, err = f.interactor.User(old.Token); !errors.Is(err, ErrorTokenReplaced) {
+				t.Fatalf("User() by replaced token error = %v, want ErrorTokenReplaced", err)
+			}
+
+			if _, err = f.interactor.User(tokens.Token); err != nil {
+				t.Fatalf("User() by new token error: %v", err)
+			}
+
+			if _, err = f.interactor.RefreshToken(context.Background(), old.Token, old.RefreshToken); err == nil {
+				t.Fatalf("RefreshToken() by replaced tokens error is nil")
+			}
+		})
+	}
+}
+
+func TestRefreshFailsWithoutCache(t *testing.T) {
+	f := newFixture(t)
+	old := f.newToken(t)
+
+	if _, err := f.interactor.User(old.Token); err != nil {
+		t.Fatalf("User() error: %v", err)
+	}
+
+	f.cache.failWrites = true
+
+	// the replaced token would stay cached as the session current one
+	_, err := f.interactor.RefreshToken(context.Background(), old.Token, old.RefreshToken)
+	if !errors.Is(err, errorCacheDown) {
+		t.Fatalf("RefreshToken() error = %v, want cache error", err)
+	}
+
+	f.cache.failWrites = false
+
+	if _, err := f.interactor.RefreshToken(context.Background(), old.Token, old.RefreshToken); err != nil {
+		t.Fatalf("RefreshToken() retry error: %v", err)
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	f := newFixture(t)
+	current := f.newToken(t)
+	other := f.newToken(t)
+
+	for _, token := range []string{current.Token, other.Token} {
+		if _, err := f.interactor.User(token); err != nil {
+			t.Fatalf("User() error: %v", err)
+		}
+	}
+
+	revoked, err := f.interactor.RevokeOtherSessions(f.userContext(current.Token))
+	if err != nil {
+		t.Fatalf("RevokeOtherSessions() error: %v", err)
+	}
+
+	if revoked != 1 {
+		t.Fatalf("RevokeOtherSessions() revoked %d sessions, want 1", revoked)
+	}
+
+	// the revoked session token is still cached as the session current one
+	if _, err = f.interactor.User(other.Token); !errors.Is(err, ErrorSessionRevoked) {
+		t.Fatalf("User() by revoked session token error = %v, want ErrorSessionRevoked", err)
+	}
+
+	if _, err = f.interactor.User(current.Token); err != nil {
+		t.Fatalf("User() by current session token error: %v", err)
+	}
+
+	if err = f.interactor.Logout(f.userContext(current.Token)); err != nil {
+		t.Fatalf("Logout() error: %v", err)
+	}
+
+	if _, err = f.interactor.User(current.Token); !errors.Is(err, ErrorSessionRevoked) {
+		t.Fatalf("User() after logout error = %v, want ErrorSessionRevoked", err)
+	}
+}
+
+func TestRevokeSessionDenylistFailure(t *testing.T) {
+	f := newFixture(t)
+	tokens := f.newToken(t)
+
+	if _, err := f.interactor.User(tokens.Token); err != nil {
+		t.Fatalf("User() error: %v", err)
+	}
+
+	f.cache.failWrites = true
+
+	if err := f.interactor.Logout(f.userContext(tokens.Token)); !errors.Is(err, errorCacheDown) {
+		t.Fatalf("Logout() error = %v, want denylist error", err)
+	}
+}
+
+func TestSigningKeyRotation(t *testing.T) {
+	var (
+		f       = newFixture(t)
+		retired = newKey(t)
+		active  = newKey(t)
+	)
+
+	f.restart(nil, newKeyRing(t, retired))
+	old := f.newToken(t)
+
+	// the retired key stays in the ring until tokens signed with it expire
+	f.restart(nil, newKeyRing(t, active, retired))
+
+	if _, err := f.interactor.User(old.Token); err != nil {
+		t.Fatalf("User() by token of the retired key error: %v", err)
+	}
+
+	tokens := f.newToken(t)
+
+	if _, err := f.interactor.User(tokens.Token); err != nil {
+		t.Fatalf("User() by token of the active key error: %v", err)
+	}
+
+	if kids := f.interactor.JWKS().Keys; len(kids) != 2 || kids[0].Kid != active.ID || kids[1].Kid != retired.ID {
+		t.Fatalf("JWKS() = %+v, want active and retired keys", kids)
+	}
+
+	f.restart(nil, newKeyRing(t, active))
+
+	if _, err := f.interactor.User(old.Token); !errors.Is(err, ErrorInvalidTokenClaims) {
+		t.Fatalf("User() by token of the removed key error = %v, want ErrorInvalidTokenClaims", err)
+	}
+
+	if _, err := f.interactor.User(tokens.Token); err != nil {
+		t.Fatalf("User() by token of the active key error: %v", err)
+	}
+}
+
+func TestLegacySecret(t *testing.T) {
+	var (
+		f      = newFixture(t)
+		secret = []byte("legacy secret")
+	)
+
+	f.restart(secret, newKeyRing(t, nil))
+	legacy := f.newToken(t)
+
+	if _, err := f.interactor.User(legacy.Token); err != nil {
+		t.Fatalf("User() by hs256 token error: %v", err)
+	}
+
+	// hs256 tokens are accepted while the secret is configured, new tokens are signed with the key
+	f.restart(secret, newKeyRing(t, newKey(t)))
+
+	if _, err := f.interactor.User(legacy.Token); err != nil {
+		t.Fatalf("User() by hs256 token after migration error: %v", err)
+	}
+
+	f.restart(nil, newKeyRing(t, newKey(t)))
+
+	if _, err := f.interactor.User(legacy.Token); !errors.Is(err, ErrorInvalidTokenClaims) {
+		t.Fatalf("User() by hs256 token without secret error = %v, want ErrorInvalidTokenClaims", err)
+	}
+
+	f.restart(nil, newKeyRing(t, nil))
+
+	if _, err := f.interactor.NewToken(context.Background(), f.user, time.Hour); !errors.Is(err, ErrorNoSigningKey) {
+		t.Fatalf("NewToken() without keys error = %v, want ErrorNoSigningKey", err)
+	}
+}
@@ -0,0 +1,440 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/logger"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/prices"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/ledger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorEntryExists      = ledger.ErrorEntryExists
+	ErrorEntryUnbalanced  = errors.New("ledger entry is unbalanced")
+	ErrorInvalidEntryLine = errors.New("invalid ledger entry line")
+	ErrorAccountNotFound  = errors.New("ledger account not found")
+	ErrorAccountExists    = errors.New("ledger account already exists")
+	ErrorInvalidAccount   = errors.New("invalid ledger account")
+)
+
+// defaultAccounts is the chart of accounts every organization starts with
+var defaultAccounts = []models.LedgerAccount{
+	{Code: models.LedgerAccountMultisigs, Name: "Multisig wallets", Type: models.LedgerAccountTypeAsset},
+	{Code: models.LedgerAccountPayrolls, Name: "Payroll contracts", Type: models.LedgerAccountTypeAsset},
+	{Code: models.LedgerAccountContributions, Name: "Owner contributions", Type: models.LedgerAccountTypeEquity},
+	{Code: models.LedgerAccountExpenses, Name: "Expenses", Type: models.LedgerAccountTypeExpense},
+	{Code: models.LedgerAccountSalaries, Name: "Salaries", Type: models.LedgerAccountTypeExpense},
+}
+
+type PostLine struct {
+	AccountCode string
+	Currency    string
+	Debit       money.Decimal
+	Credit      money.Decimal
+}
+
+// DebitLine returns line debiting the account with the amount in its currency
+func DebitLine(accountCode string, amount money.Amount) PostLine {
+	return PostLine{
+		AccountCode: accountCode,
+		Currency:    amount.Currency.Code,
+		Debit:       amount.Value,
+	}
+}
+
+// CreditLine returns line crediting the account with the amount in its currency
+func CreditLine(accountCode string, amount money.Amount) PostLine {
+	return PostLine{
+		AccountCode: accountCode,
+		Currency:    amount.Currency.Code,
+		Credit:      amount.Value,
+	}
+}
+
+type PostParams struct {
+	OrganizationID uuid.UUID
+	Description    string
+	SourceType     models.LedgerEntrySource
+	SourceID       uuid.UUID
+	// OccurredAt defaults to the current time
+	OccurredAt time.Time
+	CreatedBy  uuid.UUID
+	Lines      []PostLine
+}
+
+type NewAccountParams struct {
+	Code string
+	Name string
+	Type models.LedgerAccountType
+}
+
+type ListAccountsParams struct {
+	OrganizationID uuid.UUID
+}
+
+type NewEntryParams struct {
+	Description string
+	// OccurredAt defaults to the current time
+	OccurredAt time.Time
+	Lines      []PostLine
+}
+
+type LedgerParams struct {
+	OrganizationID uuid.UUID
+	// AsOf limits entries and balances to the ones occurred before it. Defaults to the current time
+	AsOf time.Time
+	// From limits entries to the ones occurred at or after it. Does not affect balances
+	From        time.Time
+	SourceTypes []models.LedgerEntrySource
+	Limit       int64
+}
+
+type Ledger struct {
+	AsOf     time.Time
+	Entries  []*models.LedgerEntry
+	Balances []models.LedgerBalance
+}
+
+// LedgerInteractor keeps double-entry ledger of the organization. Executed transactions, payouts and
+// deposits are posted by the interactors executing them, manual entries are posted by accountants
+type LedgerInteractor interface {
+	// Post saves balanced journal entry. Default chart of accounts is created on the first posting.
+	// Current rates of the entry currencies are snapshotted for entries of the executed transfers.
+	// Post does not authorize the actor, it is called by other interactors once funds are moved.
+	// Returns ErrorEntryExists if entry of the source has already been posted
+	Post(ctx context.Context, params PostParams) (*models.LedgerEntry, error)
+
+	NewAccount(ctx context.Context, params NewAccountParams) (*models.LedgerAccount, error)
+	ListAccounts(ctx context.Context, params ListAccountsParams) ([]*models.LedgerAccount, error)
+
+	// NewEntry posts manual journal entry, e.g. opening balances or off-chain expenses
+	NewEntry(ctx context.Context, params NewEntryParams) (*models.LedgerEntry, error)
+
+	// Ledger returns journal entries and account balances as of the date
+	Ledger(ctx context.Context, params LedgerParams) (*Ledger, error)
+}
+
+type ledgerInteractor struct {
+	log              *slog.Logger
+	ledgerRepo       ledger.Repository
+	pricesInteractor prices.PricesInteractor
+	authorizer       authorizer.Authorizer
+}
+
+func NewLedgerInteractor(
+	log *slog.Logger,
+	ledgerRepo ledger.Repository,
+	pricesInteractor prices.PricesInteractor,
+	authorizer authorizer.Authorizer,
+) LedgerInteractor {
+	return &ledgerInteractor{
+		log:              log,
+		ledgerRepo:       ledgerRepo,
+		pricesInteractor: pricesInteractor,
+		authorizer:       authorizer,
+	}
+}
+
+func (i *ledgerInteractor) Post(ctx context.Context, params PostParams) (*models.LedgerEntry, error) {
+	if err := validateLines(params.Lines); err != nil {
+		return nil, err
+	}
+
+	accounts, err := i.accounts(ctx, params.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountsMap := make(map[string]*models.LedgerAccount, len(accounts))
+
+	for _, a := range accounts {
+		accountsMap[a.Code] = a
+	}
+
+	now := time.Now()
+
+	entry := models.LedgerEntry{
+		ID:             uuid.Must(uuid.NewV7()),
+		OrganizationID: params.OrganizationID,
+		Description:    params.Description,
+		SourceType:     params.SourceType,
+		SourceID:       params.SourceID,
+		OccurredAt:     params.OccurredAt,
+		CreatedBy:      params.CreatedBy,
+		CreatedAt:      now,
+		Lines:          make([]models.LedgerLine, len(params.Lines)),
+	}
+
+	if entry.OccurredAt.IsZero() {
+		entry.OccurredAt = now
+	}
+
+	if entry.SourceID == uuid.Nil {
+		entry.SourceID = entry.ID
+	}
+
+	for n, l := range params.Lines {
+		account, ok := accountsMap[l.AccountCode]
+		if !ok {
+			return nil, fmt.Errorf("error account %s. %w", l.AccountCode, ErrorAccountNotFound)
+		}
+
+		entry.Lines[n] = models.LedgerLine{
+			ID:          uuid.Must(uuid.NewV7()),
+			EntryID:     entry.ID,
+			AccountID:   account.ID,
+			AccountCode: account.Code,
+			Currency:    strings.ToUpper(l.Currency),
+			Debit:       l.Debit,
+			Credit:      l.Credit,
+		}
+	}
+
+	if err = i.ledgerRepo.AddEntry(ctx, entry); err != nil {
+		return nil, fmt.Errorf("error save ledger entry. %w", err)
+	}
+
+	if entry.SourceType != models.LedgerEntrySourceManual {
+		i.snapshotPrices(ctx, &entry)
+	}
+
+	return &entry, nil
+}
+
+// snapshotPrices saves current rates of the entry currencies. Entry is already posted and the price source
+// may be down, so failure is only logged and the entry is reported without fiat equivalent
+func (i *ledgerInteractor) snapshotPrices(ctx context.Context, entry *models.LedgerEntry) {
+	snapshotted := make(map[string]struct{}, 1)
+
+	for _, l := range entry.Lines {
+		if _, ok := snapshotted[l.Currency]; ok {
+			continue
+		}
+
+		snapshotted[l.Currency] = struct{}{}
+
+		if _, err := i.pricesInteractor.Snapshot(ctx, prices.SnapshotParams{
+			OrganizationID: entry.OrganizationID,
+			SourceType:     entry.SourceType,
+			SourceID:       entry.SourceID,
+			Currency:       l.Currency,
+		}); err != nil {
+			i.log.Error(
+				"error snapshot price",
+				slog.String("source type", string(entry.SourceType)),
+				slog.String("source id", entry.SourceID.String()),
+				slog.String("currency", l.Currency),
+				logger.Err(err),
+			)
+		}
+	}
+}
+
+// validateLines checks every line moves positive amount to one side and debits equal credits per currency
+func validateLines(lines []PostLine) error {
+	if len(lines) < 2 {
+		return fmt.Errorf("error entry must have at least two lines. %w", ErrorEntryUnbalanced)
+	}
+
+	totals := make(map[string]money.Decimal)
+
+	for _, l := range lines {
+		if l.AccountCode == "" || l.Currency == "" {
+			return fmt.Errorf("error account and currency are required. %w", ErrorInvalidEntryLine)
+		}
+
+		if l.Debit.Sign() < 0 || l.Credit.Sign() < 0 || (l.Debit.Sign() > 0) == (l.Credit.Sign() > 0) {
+			return fmt.Errorf(
+				"error line of account %s must have either debit or credit. %w",
+				l.AccountCode,
+				ErrorInvalidEntryLine,
+			)
+		}
+
+		currency := strings.ToUpper(l.Currency)
+		totals[currency] = totals[currency].Add(l.Debit).Sub(l.Credit)
+	}
+
+	for currency, diff := range totals {
+		if !diff.IsZero() {
+			return fmt.Errorf("error %s debits differ from credits by %s. %w", currency, diff, ErrorEntryUnbalanced)
+		}
+	}
+
+	return nil
+}
+
+// accounts returns organization chart of accounts, creating default accounts if they are missing
+func (i *ledgerInteractor) accounts(
+	ctx context.Context,
+	organizationID uuid.UUID,
+) ([]*models.LedgerAccount, error) {
+	accounts, err := i.ledgerRepo.ListAccounts(ctx, ledger.ListAccountsParams{
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ledger accounts. %w", err)
+	}
+
+	existing := make(map[string]struct{}, len(accounts))
+
+	for _, a := range accounts {
+		existing[a.Code] = struct{}{}
+	}
+
+	missing := make([]models.LedgerAccount, 0, len(defaultAccounts))
+
+	for _, a := range defaultAccounts {
+		if _, ok := existing[a.Code]; ok {
+			continue
+		}
+
+		a.ID = uuid.Must(uuid.NewV7())
+		a.OrganizationID = organizationID
+		a.CreatedAt = time.Now()
+
+		missing = append(missing, a)
+	}
+
+	if len(missing) == 0 {
+		return accounts, nil
+	}
+
+	if err = i.ledgerRepo.AddAccounts(ctx, missing...); err != nil {
+		return nil, fmt.Errorf("error save default ledger accounts. %w", err)
+	}
+
+	// accounts are fetched again, concurrent posting could have created them first
+	accounts, err = i.ledgerRepo.ListAccounts(ctx, ledger.ListAccountsParams{
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ledger accounts. %w", err)
+	}
+
+	return accounts, nil
+}
+
+func (i *ledgerInteractor) NewAccount(
+	ctx context.Context,
+	params NewAccountParams,
+) (*models.LedgerAccount, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	params.Code = strings.TrimSpace(params.Code)
+	params.Name = strings.TrimSpace(params.Name)
+
+	if params.Code == "" || params.Name == "" || params.Type == models.LedgerAccountTypeUnknown {
+		return nil, ErrorInvalidAccount
+	}
+
+	if _, err = i.authorizer.Authorize(ctx, organizationID, models.PermissionLedgerManage); err != nil {
+		return nil, err
+	}
+
+	accounts, err := i.accounts(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range accounts {
+		if a.Code == params.Code {
+			return nil, ErrorAccountExists
+		}
+	}
+
+	account := models.LedgerAccount{
+		ID:             uuid.Must(uuid.NewV7()),
+		OrganizationID: organizationID,
+		Code:           params.Code,
+		Name:           params.Name,
+		Type:           params.Type,
+		CreatedAt:      time.Now(),
+	}
+
+	if err = i.ledgerRepo.AddAccounts(ctx, account); err != nil {
+		return nil, fmt.Errorf("error save ledger account. %w", err)
+	}
+
+	return &account, nil
+}
+
+func (i *ledgerInteractor) ListAccounts(
+	ctx context.Context,
+	params ListAccountsParams,
+) ([]*models.LedgerAccount, error) {
+	if _, err := i.authorizer.Member(ctx, params.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	return i.accounts(ctx, params.OrganizationID)
+}
+
+func (i *ledgerInteractor) NewEntry(ctx context.Context, params NewEntryParams) (*models.LedgerEntry, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	actor, err := i.authorizer.Authorize(ctx, organizationID, models.PermissionLedgerManage)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.Post(ctx, PostParams{
+		OrganizationID: organizationID,
+		Description:    params.Description,
+		SourceType:     models.LedgerEntrySourceManual,
+		OccurredAt:     params.OccurredAt,
+		CreatedBy:      actor.Id(),
+		Lines:          params.Lines,
+	})
+}
+
+func (i *ledgerInteractor) Ledger(ctx context.Context, params LedgerParams) (*Ledger, error) {
+	if _, err := i.authorizer.Member(ctx, params.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	if params.AsOf.IsZero() {
+		params.AsOf = time.Now()
+	}
+
+	entries, err := i.ledgerRepo.ListEntries(ctx, ledger.ListEntriesParams{
+		OrganizationID: params.OrganizationID,
+		SourceTypes:    params.SourceTypes,
+		From:           params.From,
+		To:             params.AsOf,
+		Limit:          params.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ledger entries. %w", err)
+	}
+
+	balances, err := i.ledgerRepo.Balances(ctx, ledger.BalancesParams{
+		OrganizationID: params.OrganizationID,
+		AsOf:           params.AsOf,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ledger balances. %w", err)
+	}
+
+	return &Ledger{
+		AsOf:     params.AsOf,
+		Entries:  entries,
+		Balances: balances,
+	}, nil
+}
@@ -0,0 +1,223 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	pricesource "github.com/emochka2007/block-accounting/internal/pkg/prices"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/prices"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/ledger"
+	pricesrepo "github.com/emochka2007/block-accounting/internal/usecase/repository/prices"
+	"github.com/google/uuid"
+)
+
+// memoryLedger keeps accounts and entries in memory. Reports queries are not used by Post
+type memoryLedger struct {
+	ledger.Repository
+
+	mu       sync.Mutex
+	accounts []*models.LedgerAccount
+	entries  []models.LedgerEntry
+}
+
+func (r *memoryLedger) AddAccounts(_ context.Context, accounts ...models.LedgerAccount) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, a := range accounts {
+		r.accounts = append(r.accounts, &a)
+	}
+
+	return nil
+}
+
+func (r *memoryLedger) ListAccounts(_ context.Context, params ledger.ListAccountsParams) ([]*models.LedgerAccount, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]*models.LedgerAccount, 0, len(r.accounts))
+
+	for _, a := range r.accounts {
+		if a.OrganizationID == params.OrganizationID {
+			list = append(list, a)
+		}
+	}
+
+	return list, nil
+}
+
+func (r *memoryLedger) AddEntry(_ context.Context, entry models.LedgerEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, e := range r.entries {
+		if e.OrganizationID == entry.OrganizationID && e.SourceType == entry.SourceType && e.SourceID == entry.SourceID {
+			return ledger.ErrorEntryExists
+		}
+	}
+
+	r.entries = append(r.entries, entry)
+
+	return nil
+}
+
+// memoryPrices keeps price snapshots in memory
+type memoryPrices struct {
+	mu     sync.Mutex
+	prices []*models.Price
+}
+
+func (r *memoryPrices) Add(_ context.Context, price models.Price) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, p := range r.prices {
+		if p.SourceType == price.SourceType && p.SourceID == price.SourceID && p.Base == price.Base {
+			return pricesrepo.ErrorPriceExists
+		}
+	}
+
+	r.prices = append(r.prices, &price)
+
+	return nil
+}
+
+func (r *memoryPrices) List(_ context.Context, params pricesrepo.ListParams) ([]*models.Price, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]*models.Price, 0)
+
+	for _, p := range r.prices {
+		if p.SourceType == params.SourceType && slices.Contains(params.SourceIDs, p.SourceID) {
+			list = append(list, p)
+		}
+	}
+
+	return list, nil
+}
+
+func newInteractor(repo ledger.Repository, pricesRepo pricesrepo.Repository) LedgerInteractor {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	source := pricesource.NewFixtureSource(map[string]money.Decimal{
+		"ETH/USD": money.MustParseDecimal("3000"),
+	})
+
+	return NewLedgerInteractor(log, repo, prices.NewPricesInteractor(log, source, pricesRepo), nil)
+}
+
+func TestPostSnapshotsPrices(t *testing.T) {
+	var (
+		repo       = new(memoryLedger)
+		pricesRepo = new(memoryPrices)
+		amount     = money.New(money.MustParseDecimal("0.5"), money.ETH)
+	)
+
+	entry, err := newInteractor(repo, pricesRepo).Post(context.Background(), PostParams{
+		OrganizationID: uuid.New(),
+		Description:    "Hosting",
+		SourceType:     models.LedgerEntrySourceTransaction,
+		SourceID:       uuid.New(),
+		Lines: []PostLine{
+			DebitLine(models.LedgerAccountExpenses, amount),
+			CreditLine(models.LedgerAccountMultisigs, amount),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Post() error: %v", err)
+	}
+
+	if len(repo.accounts) != len(defaultAccounts) {
+		t.Fatalf("Post() created %d accounts, want default %d", len(repo.accounts), len(defaultAccounts))
+	}
+
+	// both lines are in ETH, so one snapshot is taken
+	if len(pricesRepo.prices) != 1 {
+		t.Fatalf("Post() took %d price snapshots, want 1", len(pricesRepo.prices))
+	}
+
+	price := pricesRepo.prices[0]
+
+	if price.SourceType != entry.SourceType || price.SourceID != entry.SourceID || price.Base != "ETH" ||
+		price.Rate.String() != "3000" {
+		t.Fatalf("Post() snapshot = %s %s %s/%s %s, want ETH/USD 3000 of the entry source",
+			price.SourceType, price.SourceID, price.Base, price.Quote, price.Rate)
+	}
+
+	line := entry.Lines[0]
+	line.Rate = &price.Rate
+
+	if debit, _, ok := line.Fiat(); !ok || debit.String() != "1500" {
+		t.Fatalf("fiat debit = %s, %t, want 1500", debit, ok)
+	}
+}
+
+func TestPostManualEntryIsNotPriced(t *testing.T) {
+	var (
+		pricesRepo = new(memoryPrices)
+		amount     = money.New(money.MustParseDecimal("2"), money.ETH)
+	)
+
+	if _, err := newInteractor(new(memoryLedger), pricesRepo).Post(context.Background(), PostParams{
+		OrganizationID: uuid.New(),
+		SourceType:     models.LedgerEntrySourceManual,
+		Lines: []PostLine{
+			DebitLine(models.LedgerAccountMultisigs, amount),
+			CreditLine(models.LedgerAccountContributions, amount),
+		},
+	}); err != nil {
+		t.Fatalf("Post() error: %v", err)
+	}
+
+	if len(pricesRepo.prices) != 0 {
+		t.Fatalf("manual entry is priced")
+	}
+}
+
+func TestPostTwice(t *testing.T) {
+	var (
+		repo   = new(memoryLedger)
+		i      = newInteractor(repo, new(memoryPrices))
+		amount = money.New(money.MustParseDecimal("1"), money.ETH)
+	)
+
+	params := PostParams{
+		OrganizationID: uuid.New(),
+		SourceType:     models.LedgerEntrySourcePayment,
+		SourceID:       uuid.New(),
+		Lines: []PostLine{
+			DebitLine(models.LedgerAccountSalaries, amount),
+			CreditLine(models.LedgerAccountPayrolls, amount),
+		},
+	}
+
+	if _, err := i.Post(context.Background(), params); err != nil {
+		t.Fatalf("Post() error: %v", err)
+	}
+
+	if _, err := i.Post(context.Background(), params); !errors.Is(err, ErrorEntryExists) {
+		t.Fatalf("Post() again error = %v, want ErrorEntryExists", err)
+	}
+}
+
+func TestPostUnbalanced(t *testing.T) {
+	_, err := newInteractor(new(memoryLedger), new(memoryPrices)).Post(context.Background(), PostParams{
+		OrganizationID: uuid.New(),
+		SourceType:     models.LedgerEntrySourceTransaction,
+		Lines: []PostLine{
+			DebitLine(models.LedgerAccountExpenses, money.New(money.MustParseDecimal("1"), money.ETH)),
+			CreditLine(models.LedgerAccountMultisigs, money.New(money.MustParseDecimal("0.9"), money.ETH)),
+		},
+	})
+	if !errors.Is(err, ErrorEntryUnbalanced) {
+		t.Fatalf("Post() error = %v, want ErrorEntryUnbalanced", err)
+	}
+}
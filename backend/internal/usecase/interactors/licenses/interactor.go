@@ -0,0 +1,914 @@
+package licenses
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
+	txinteractor "github.com/emochka2007/block-accounting/internal/usecase/interactors/transactions"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/licenses"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/users"
+	"github.com/google/uuid"
+)
+
+const (
+	JobKindLicenseOperation = "license_operation"
+)
+
+var (
+	ErrorLicenseNotFound            = errors.New("license not found")
+	ErrorLicenseNotDeployed         = errors.New("license is not deployed")
+	ErrorLicenseOperationNotFound   = errors.New("license operation not found")
+	ErrorLicenseOperationNotPending = errors.New("license operation is not pending")
+	ErrorInvalidShares              = errors.New("invalid license shares")
+	ErrorInvalidOracleURL           = errors.New("invalid oracle url")
+	ErrorPayoutContractNotSet       = errors.New("license payout contract is not set")
+)
+
+type Shareholder struct {
+	// ParticipantID if set, participant wallet address is used
+	ParticipantID uuid.UUID
+	Address       []byte
+	// Share in percents
+	Share int
+}
+
+type DeployParams struct {
+	Title        string
+	MultisigID   uuid.UUID
+	Shareholders []Shareholder
+}
+
+type RequestParams struct {
+	LicenseID uuid.UUID
+	URL       string
+}
+
+type SetPayoutContractParams struct {
+	LicenseID uuid.UUID
+	PayrollID uuid.UUID
+}
+
+type PayoutParams struct {
+	LicenseID uuid.UUID
+}
+
+type ListParams struct {
+	OrganizationID uuid.UUID
+	IDs            uuid.UUIDs
+	Limit          int64
+}
+
+type ConfirmOperationParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+type ListOperationsParams struct {
+	OrganizationID uuid.UUID
+	IDs            uuid.UUIDs
+	LicenseIDs     uuid.UUIDs
+	Limit          int64
+}
+
+type InfoParams struct {
+	LicenseID      uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+// LicenseInteractor manages license contracts. Every license contract call goes through the license multisig:
+// operation is created with the actor confirmation and executed in background once confirmations
+// required by the multisig are collected
+type LicenseInteractor interface {
+	Deploy(ctx context.Context, params DeployParams) (*models.LicenseOperation, error)
+	Request(ctx context.Context, params RequestParams) (*models.LicenseOperation, error)
+	SetPayoutContract(ctx context.Context, params SetPayoutContractParams) (*models.LicenseOperation, error)
+	Payout(ctx context.Context, params PayoutParams) (*models.LicenseOperation, error)
+
+	List(ctx context.Context, params ListParams) ([]*models.License, error)
+	Info(ctx context.Context, params InfoParams) (*models.LicenseInfo, error)
+
+	ConfirmOperation(ctx context.Context, params ConfirmOperationParams) (*models.LicenseOperation, error)
+	ListOperations(ctx context.Context, params ListOperationsParams) ([]*models.LicenseOperation, error)
+}
+
+type licenseInteractor struct {
+	log                     *slog.Logger
+	licensesRepo            licenses.Repository
+	txRepo                  transactions.Repository
+	usersRepo               users.Repository
+	orgInteractor           organizations.OrganizationsInteractor
+	chainInteractor         chain.ChainInteractor
+	jobsInteractor          jobs.JobsInteractor
+	confirmationsInteractor confirmations.ConfirmationsInteractor
+	authorizer              authorizer.Authorizer
+}
+
+func NewLicenseInteractor(
+	log *slog.Logger,
+	licensesRepo licenses.Repository,
+	txRepo transactions.Repository,
+	usersRepo users.Repository,
+	orgInteractor organizations.OrganizationsInteractor,
+	chainInteractor chain.ChainInteractor,
+	jobsInteractor jobs.JobsInteractor,
+	confirmationsInteractor confirmations.ConfirmationsInteractor,
+	authorizer authorizer.Authorizer,
+) LicenseInteractor {
+	i := &licenseInteractor{
+		log:                     log,
+		licensesRepo:            licensesRepo,
+		txRepo:                  txRepo,
+		usersRepo:               usersRepo,
+		orgInteractor:           orgInteractor,
+		chainInteractor:         chainInteractor,
+		jobsInteractor:          jobsInteractor,
+		confirmationsInteractor: confirmationsInteractor,
+		authorizer:              authorizer,
+	}
+
+	jobsInteractor.RegisterHandler(JobKindLicenseOperation, i.operationJob)
+
+	confirmationsInteractor.RegisterEntity(models.MultisigConfirmationEntityTypeLicenseOperation, confirmations.EntityHandler{
+		Entity:     i.confirmationEntity,
+		Confirmed:  i.confirmed,
+		Permission: models.PermissionLicenseConfirm,
+	})
+
+	return i
+}
+
+func (i *licenseInteractor) Deploy(ctx context.Context, params DeployParams) (*models.LicenseOperation, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	if _, err = i.authorizer.Authorize(ctx, organizationID, models.PermissionLicenseManage); err != nil {
+		return nil, err
+	}
+
+	multisig, err := i.multisig(ctx, organizationID, params.MultisigID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMultisigOwner(multisig, user.Id()) {
+		return nil, chain.ErrorNotMultisigOwner
+	}
+
+	shareholders, err := i.shareholders(ctx, organizationID, params.Shareholders)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now()
+
+	license := models.License{
+		ID:             uuid.Must(uuid.NewV7()),
+		OrganizationID: organizationID,
+		MultisigID:     multisig.ID,
+		Title:          params.Title,
+		Status:         models.LicenseStatusPending,
+		Shareholders:   shareholders,
+		CreatedBy:      user.Id(),
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	}
+
+	if err = i.licensesRepo.CreateLicense(ctx, license); err != nil {
+		return nil, fmt.Errorf("error create license. %w", err)
+	}
+
+	return i.newOperation(ctx, &license, models.LicenseOperation{
+		Kind: models.LicenseOperationKindDeploy,
+	})
+}
+
+// shareholders resolves shareholders addresses and validates shares. Shares must sum up to 100
+func (i *licenseInteractor) shareholders(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	shareholders []Shareholder,
+) ([]models.LicenseShareholder, error) {
+	if len(shareholders) == 0 {
+		return nil, ErrorInvalidShares
+	}
+
+	participantsIDs := make(uuid.UUIDs, 0, len(shareholders))
+
+	for _, sh := range shareholders {
+		if sh.ParticipantID != uuid.Nil {
+			participantsIDs = append(participantsIDs, sh.ParticipantID)
+		}
+	}
+
+	participantsMap := make(map[uuid.UUID]models.OrganizationParticipant, len(participantsIDs))
+
+	if len(participantsIDs) > 0 {
+		participants, err := i.orgInteractor.Participants(ctx, organizations.ParticipantsParams{
+			IDs:            participantsIDs,
+			OrganizationID: organizationID,
+			ActiveOnly:     true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error fetch shareholders participants. %w", err)
+		}
+
+		for _, p := range participants {
+			participantsMap[p.Id()] = p
+		}
+	}
+
+	out := make([]models.LicenseShareholder, len(shareholders))
+	seen := make(map[string]struct{}, len(shareholders))
+	sum := 0
+
+	for idx, sh := range shareholders {
+		if sh.Share <= 0 {
+			return nil, ErrorInvalidShares
+		}
+
+		address := sh.Address
+
+		if sh.ParticipantID != uuid.Nil {
+			p, ok := participantsMap[sh.ParticipantID]
+			if !ok {
+				return nil, chain.ErrorEmployeeNotFound
+			}
+
+			address = chain.ParticipantAddress(p)
+		}
+
+		if len(address) == 0 {
+			return nil, fmt.Errorf("error shareholder address is empty. %w", ErrorInvalidShares)
+		}
+
+		if _, ok := seen[string(address)]; ok {
+			return nil, fmt.Errorf("error duplicated shareholder. %w", ErrorInvalidShares)
+		}
+
+		seen[string(address)] = struct{}{}
+		sum += sh.Share
+
+		out[idx] = models.LicenseShareholder{
+			ParticipantID: sh.ParticipantID,
+			Address:       address,
+			Share:         sh.Share,
+		}
+	}
+
+	if sum != 100 {
+		return nil, fmt.Errorf("error shares sum up to %d. %w", sum, ErrorInvalidShares)
+	}
+
+	return out, nil
+}
+
+func (i *licenseInteractor) Request(ctx context.Context, params RequestParams) (*models.LicenseOperation, error) {
+	if u, err := url.ParseRequestURI(params.URL); err != nil || u.Host == "" {
+		return nil, ErrorInvalidOracleURL
+	}
+
+	license, err := i.deployedLicense(ctx, params.LicenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.newOperation(ctx, license, models.LicenseOperation{
+		Kind: models.LicenseOperationKindRequest,
+		URL:  params.URL,
+	})
+}
+
+func (i *licenseInteractor) SetPayoutContract(
+	ctx context.Context,
+	params SetPayoutContractParams,
+) (*models.LicenseOperation, error) {
+	license, err := i.deployedLicense(ctx, params.LicenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	payrolls, err := i.chainInteractor.ListPayrolls(ctx, chain.ListPayrollsParams{
+		OrganizationID: license.OrganizationID,
+		IDs:            uuid.UUIDs{params.PayrollID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch payroll. %w", err)
+	}
+
+	if len(payrolls) == 0 {
+		return nil, chain.ErrorPayrollNotFound
+	}
+
+	if payrolls[0].Status != models.PayrollStatusDeployed {
+		return nil, chain.ErrorPayrollNotDeployed
+	}
+
+	return i.newOperation(ctx, license, models.LicenseOperation{
+		Kind:      models.LicenseOperationKindSetPayoutContract,
+		PayrollID: params.PayrollID,
+	})
+}
+
+func (i *licenseInteractor) Payout(ctx context.Context, params PayoutParams) (*models.LicenseOperation, error) {
+	license, err := i.deployedLicense(ctx, params.LicenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if license.PayrollID == uuid.Nil {
+		return nil, ErrorPayoutContractNotSet
+	}
+
+	return i.newOperation(ctx, license, models.LicenseOperation{
+		Kind: models.LicenseOperationKindPayout,
+	})
+}
+
+func (i *licenseInteractor) deployedLicense(ctx context.Context, id uuid.UUID) (*models.License, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	license, err := i.license(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if license.Status != models.LicenseStatusDeployed {
+		return nil, ErrorLicenseNotDeployed
+	}
+
+	return license, nil
+}
+
+// newOperation saves license operation and confirms it on behalf of the actor
+func (i *licenseInteractor) newOperation(
+	ctx context.Context,
+	license *models.License,
+	op models.LicenseOperation,
+) (*models.LicenseOperation, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	actor, err := i.authorizer.Authorize(ctx, license.OrganizationID, models.PermissionLicenseManage)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now()
+
+	op.ID = uuid.Must(uuid.NewV7())
+	op.LicenseID = license.ID
+	op.OrganizationID = license.OrganizationID
+	op.MultisigID = license.MultisigID
+	op.Status = models.LicenseOperationStatusPending
+	op.CreatedBy = user.Id()
+	op.CreatedAt = createdAt
+	op.UpdatedAt = createdAt
+
+	if err = i.licensesRepo.AddOperation(ctx, op); err != nil {
+		return nil, fmt.Errorf("error add license operation. %w", err)
+	}
+
+	// creator without confirm permission leaves the operation awaiting owners confirmations
+	if !i.authorizer.Can(actor, models.PermissionLicenseConfirm) {
+		return i.operation(ctx, op.OrganizationID, op.ID)
+	}
+
+	return i.ConfirmOperation(ctx, ConfirmOperationParams{
+		ID:             op.ID,
+		OrganizationID: license.OrganizationID,
+	})
+}
+
+func (i *licenseInteractor) List(ctx context.Context, params ListParams) ([]*models.License, error) {
+	list, err := i.licensesRepo.ListLicenses(ctx, licenses.ListLicensesParams{
+		IDs:            params.IDs,
+		OrganizationID: params.OrganizationID,
+		Limit:          params.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch licenses. %w", err)
+	}
+
+	return list, nil
+}
+
+func (i *licenseInteractor) license(ctx context.Context, organizationID, id uuid.UUID) (*models.License, error) {
+	list, err := i.List(ctx, ListParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{id},
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, ErrorLicenseNotFound
+	}
+
+	return list[0], nil
+}
+
+func (i *licenseInteractor) Info(ctx context.Context, params InfoParams) (*models.LicenseInfo, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	license, err := i.license(ctx, params.OrganizationID, params.LicenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if license.Status != models.LicenseStatusDeployed {
+		return nil, ErrorLicenseNotDeployed
+	}
+
+	info, err := i.chainInteractor.LicenseInfo(ctx, chain.LicenseInfoParams{
+		Signer:         user,
+		LicenseAddress: license.Address,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch license info. %w", err)
+	}
+
+	for idx, sh := range info.Shareholders {
+		info.Shareholders[idx].LicenseID = license.ID
+
+		for _, known := range license.Shareholders {
+			if string(known.Address) == string(sh.Address) {
+				info.Shareholders[idx].ParticipantID = known.ParticipantID
+			}
+		}
+	}
+
+	return info, nil
+}
+
+func (i *licenseInteractor) ListOperations(
+	ctx context.Context,
+	params ListOperationsParams,
+) ([]*models.LicenseOperation, error) {
+	ops, err := i.licensesRepo.ListOperations(ctx, licenses.ListOperationsParams{
+		IDs:            params.IDs,
+		OrganizationID: params.OrganizationID,
+		LicenseIDs:     params.LicenseIDs,
+		Limit:          params.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch license operations. %w", err)
+	}
+
+	if len(ops) == 0 {
+		return ops, nil
+	}
+
+	ids := make(uuid.UUIDs, len(ops))
+
+	for i, op := range ops {
+		ids[i] = op.ID
+	}
+
+	confirmations, err := i.txRepo.MultisigConfirmations(ctx, transactions.MultisigConfirmationsParams{
+		EntityIDs:  ids,
+		EntityType: models.MultisigConfirmationEntityTypeLicenseOperation,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch license operations confirmations. %w", err)
+	}
+
+	for _, op := range ops {
+		op.ConfirmedBy = confirmations[op.ID]
+		op.Confirmations = len(op.ConfirmedBy)
+	}
+
+	return ops, nil
+}
+
+func (i *licenseInteractor) operation(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*models.LicenseOperation, error) {
+	ops, err := i.ListOperations(ctx, ListOperationsParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{id},
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ops) == 0 {
+		return nil, ErrorLicenseOperationNotFound
+	}
+
+	return ops[0], nil
+}
+
+type operationPayload struct {
+	OperationID uuid.UUID `json:"operation_id"`
+}
+
+func (i *licenseInteractor) ConfirmOperation(
+	ctx context.Context,
+	params ConfirmOperationParams,
+) (*models.LicenseOperation, error) {
+	if _, err := i.confirmationsInteractor.Confirm(ctx, confirmations.ConfirmParams{
+		OrganizationID: params.OrganizationID,
+		EntityType:     models.MultisigConfirmationEntityTypeLicenseOperation,
+		EntityID:       params.ID,
+	}); err != nil {
+		if errors.Is(err, confirmations.ErrorEntityNotPending) {
+			return nil, ErrorLicenseOperationNotPending
+		}
+
+		return nil, err
+	}
+
+	return i.operation(ctx, params.OrganizationID, params.ID)
+}
+
+func (i *licenseInteractor) confirmationEntity(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*confirmations.Entity, error) {
+	op, err := i.operation(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &confirmations.Entity{
+		ID:             op.ID,
+		OrganizationID: op.OrganizationID,
+		MultisigID:     op.MultisigID,
+		Pending:        op.Status == models.LicenseOperationStatusPending,
+	}, nil
+}
+
+// confirmed starts execution of the license operation confirmed by the multisig owners
+func (i *licenseInteractor) confirmed(ctx context.Context, entity *confirmations.Entity) error {
+	if err := i.licensesRepo.UpdateOperation(ctx, licenses.UpdateOperationParams{
+		ID:             entity.ID,
+		OrganizationID: entity.OrganizationID,
+		Status:         models.LicenseOperationStatusConfirmed,
+		FromStatuses:   []models.LicenseOperationStatus{models.LicenseOperationStatusPending},
+		UpdatedAt:      time.Now(),
+	}); err != nil {
+		if errors.Is(err, licenses.ErrorOperationStatusConflict) {
+			return confirmations.ErrorEntityStatusConflict
+		}
+
+		return fmt.Errorf("error mark license operation as confirmed. %w", err)
+	}
+
+	if _, err := i.jobsInteractor.Enqueue(ctx, jobs.EnqueueParams{
+		Kind:           JobKindLicenseOperation,
+		OrganizationID: entity.OrganizationID,
+		Payload: operationPayload{
+			OperationID: entity.ID,
+		},
+	}); err != nil {
+		return fmt.Errorf("error enqueue license operation execution. %w", err)
+	}
+
+	return nil
+}
+
+type OperationResult struct {
+	OperationID uuid.UUID `json:"operation_id"`
+	LicenseID   uuid.UUID `json:"license_id"`
+	TxHash      string    `json:"tx_hash"`
+}
+
+// operationJob submits license contract call to the multisig, confirms it on behalf of the owners
+// who confirmed the operation and executes it. Submitted tx index is saved, so retried job does not submit twice
+func (i *licenseInteractor) operationJob(ctx context.Context, job *models.Job) (result any, err error) {
+	var payload operationPayload
+
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("error unmarshal job payload. %w", err))
+	}
+
+	op, err := i.operation(ctx, job.OrganizationID, payload.OperationID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	if op.Status == models.LicenseOperationStatusExecuted {
+		return OperationResult{OperationID: op.ID, LicenseID: op.LicenseID, TxHash: op.TxHash}, nil
+	}
+
+	license, err := i.license(ctx, job.OrganizationID, op.LicenseID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	defer func() {
+		if !jobs.Failed(job, err) {
+			return
+		}
+
+		if fErr := i.failOperation(ctx, license, op); fErr != nil {
+			err = errors.Join(err, fErr)
+		}
+	}()
+
+	multisig, err := i.multisig(ctx, job.OrganizationID, op.MultisigID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	submitter, confirmers, err := i.signers(ctx, op, multisig)
+	if err != nil {
+		return nil, err
+	}
+
+	if op.Status == models.LicenseOperationStatusConfirmed {
+		submitted, err := i.submit(ctx, submitter, multisig, license, op)
+		if err != nil {
+			return nil, err
+		}
+
+		op.Status = models.LicenseOperationStatusSubmitted
+		op.TxIndex = submitted.TxIndex
+
+		if err = i.licensesRepo.UpdateOperation(ctx, licenses.UpdateOperationParams{
+			ID:             op.ID,
+			OrganizationID: op.OrganizationID,
+			Status:         op.Status,
+			TxIndex:        op.TxIndex,
+			UpdatedAt:      time.Now(),
+		}); err != nil {
+			return nil, fmt.Errorf("error save submitted license operation. %w", err)
+		}
+	}
+
+	if op.Status != models.LicenseOperationStatusSubmitted {
+		return nil, jobs.Permanent(fmt.Errorf("error unexpected license operation status %s", op.Status))
+	}
+
+	for _, confirmer := range confirmers {
+		if _, err = i.chainInteractor.MultisigConfirm(ctx, chain.MultisigTxParams{
+			Signer:          confirmer,
+			MultisigAddress: multisig.Address,
+			TxIndex:         op.TxIndex,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	executeParams := chain.MultisigTxParams{
+		Signer:          submitter,
+		MultisigAddress: multisig.Address,
+		TxIndex:         op.TxIndex,
+	}
+
+	var updateLicense *licenses.UpdateLicenseParams
+
+	switch op.Kind {
+	case models.LicenseOperationKindDeploy:
+		deployed, err := i.chainInteractor.MultisigExecuteDeploy(ctx, executeParams)
+		if err != nil {
+			return nil, err
+		}
+
+		op.TxHash = deployed.TxHash
+		updateLicense = &licenses.UpdateLicenseParams{
+			Status:  models.LicenseStatusDeployed,
+			Address: deployed.DeployedAddress,
+		}
+	default:
+		if op.TxHash, err = i.chainInteractor.MultisigExecute(ctx, executeParams); err != nil {
+			return nil, err
+		}
+
+		switch op.Kind {
+		case models.LicenseOperationKindRequest:
+			updateLicense = &licenses.UpdateLicenseParams{
+				Status:    license.Status,
+				OracleURL: op.URL,
+			}
+		case models.LicenseOperationKindSetPayoutContract:
+			updateLicense = &licenses.UpdateLicenseParams{
+				Status:    license.Status,
+				PayrollID: op.PayrollID,
+			}
+		}
+	}
+
+	executedAt := time.Now()
+
+	// license call is already executed on-chain, retry would fail anyway
+	if updateLicense != nil {
+		updateLicense.ID = license.ID
+		updateLicense.OrganizationID = license.OrganizationID
+		updateLicense.UpdatedAt = executedAt
+
+		if err = i.licensesRepo.UpdateLicense(ctx, *updateLicense); err != nil {
+			return nil, jobs.Permanent(fmt.Errorf("error save license. %w", err))
+		}
+	}
+
+	op.Status = models.LicenseOperationStatusExecuted
+
+	if err = i.licensesRepo.UpdateOperation(ctx, licenses.UpdateOperationParams{
+		ID:             op.ID,
+		OrganizationID: op.OrganizationID,
+		Status:         op.Status,
+		TxHash:         op.TxHash,
+		ExecutedAt:     executedAt,
+		UpdatedAt:      executedAt,
+	}); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("error save executed license operation. %w", err))
+	}
+
+	return OperationResult{OperationID: op.ID, LicenseID: license.ID, TxHash: op.TxHash}, nil
+}
+
+// submit sends license contract call to the multisig
+func (i *licenseInteractor) submit(
+	ctx context.Context,
+	submitter *models.User,
+	multisig *models.Multisig,
+	license *models.License,
+	op *models.LicenseOperation,
+) (*chain.SubmitTransactionResult, error) {
+	if op.Kind == models.LicenseOperationKindDeploy {
+		return i.chainInteractor.LicenseDeploy(ctx, chain.LicenseDeployParams{
+			Signer:          submitter,
+			MultisigAddress: multisig.Address,
+			Shareholders:    license.Shareholders,
+		})
+	}
+
+	params := chain.LicenseCallParams{
+		Signer:          submitter,
+		MultisigAddress: multisig.Address,
+		LicenseAddress:  license.Address,
+		URL:             license.OracleURL,
+	}
+
+	switch op.Kind {
+	case models.LicenseOperationKindRequest:
+		params.URL = op.URL
+
+		return i.chainInteractor.LicenseRequest(ctx, params)
+	case models.LicenseOperationKindSetPayoutContract:
+		payrolls, err := i.chainInteractor.ListPayrolls(ctx, chain.ListPayrollsParams{
+			OrganizationID: op.OrganizationID,
+			IDs:            uuid.UUIDs{op.PayrollID},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error fetch payroll. %w", err)
+		}
+
+		if len(payrolls) == 0 {
+			return nil, jobs.Permanent(chain.ErrorPayrollNotFound)
+		}
+
+		params.PayoutAddress = payrolls[0].Address
+
+		return i.chainInteractor.LicenseSetPayoutContract(ctx, params)
+	case models.LicenseOperationKindPayout:
+		return i.chainInteractor.LicensePayout(ctx, params)
+	default:
+		return nil, jobs.Permanent(fmt.Errorf("error unknown license operation kind %d", op.Kind))
+	}
+}
+
+func (i *licenseInteractor) failOperation(
+	ctx context.Context,
+	license *models.License,
+	op *models.LicenseOperation,
+) error {
+	updatedAt := time.Now()
+
+	if err := i.licensesRepo.UpdateOperation(ctx, licenses.UpdateOperationParams{
+		ID:             op.ID,
+		OrganizationID: op.OrganizationID,
+		Status:         models.LicenseOperationStatusFailed,
+		UpdatedAt:      updatedAt,
+	}); err != nil {
+		return fmt.Errorf("error mark license operation as failed. %w", err)
+	}
+
+	if op.Kind != models.LicenseOperationKindDeploy {
+		return nil
+	}
+
+	if err := i.licensesRepo.UpdateLicense(ctx, licenses.UpdateLicenseParams{
+		ID:             license.ID,
+		OrganizationID: license.OrganizationID,
+		Status:         models.LicenseStatusFailed,
+		UpdatedAt:      updatedAt,
+	}); err != nil {
+		return fmt.Errorf("error mark license as failed. %w", err)
+	}
+
+	return nil
+}
+
+// signers returns operation creator, who submits and executes it,
+// and owners whose confirmations are sent on-chain
+func (i *licenseInteractor) signers(
+	ctx context.Context,
+	op *models.LicenseOperation,
+	multisig *models.Multisig,
+) (*models.User, []*models.User, error) {
+	confirmedBy := op.ConfirmedBy
+	if len(confirmedBy) > multisig.ConfirmationsRequired {
+		confirmedBy = confirmedBy[:multisig.ConfirmationsRequired]
+	}
+
+	usersList, err := i.usersRepo.Get(ctx, users.GetParams{
+		Ids: append(uuid.UUIDs{op.CreatedBy}, confirmedBy...),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetch license operation signers. %w", err)
+	}
+
+	usersMap := make(map[uuid.UUID]*models.User, len(usersList))
+
+	for _, u := range usersList {
+		usersMap[u.Id()] = u
+	}
+
+	submitter, ok := usersMap[op.CreatedBy]
+	if !ok {
+		return nil, nil, jobs.Permanent(fmt.Errorf("error license operation creator not found"))
+	}
+
+	confirmers := make([]*models.User, 0, len(confirmedBy))
+
+	for _, id := range confirmedBy {
+		u, ok := usersMap[id]
+		if !ok {
+			return nil, nil, jobs.Permanent(fmt.Errorf("error license operation confirmer %s not found", id))
+		}
+
+		confirmers = append(confirmers, u)
+	}
+
+	if len(confirmers) < multisig.ConfirmationsRequired {
+		return nil, nil, jobs.Permanent(fmt.Errorf("error not enough license operation confirmations"))
+	}
+
+	return submitter, confirmers, nil
+}
+
+func (i *licenseInteractor) multisig(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*models.Multisig, error) {
+	multisigs, err := i.chainInteractor.ListMultisigs(ctx, chain.ListMultisigsParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{id},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch multisig. %w", err)
+	}
+
+	if len(multisigs) == 0 {
+		return nil, txinteractor.ErrorMultisigNotFound
+	}
+
+	return &multisigs[0], nil
+}
+
+func isMultisigOwner(multisig *models.Multisig, userID uuid.UUID) bool {
+	for _, owner := range multisig.Owners {
+		if owner.Id() == userID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,653 @@
+package licenses
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/licenses"
+	orepo "github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
+	"github.com/google/uuid"
+)
+
+// memoryOrganizations keeps a single organization and its participants in memory
+type memoryOrganizations struct {
+	orepo.Repository
+
+	org          *models.Organization
+	participants []models.OrganizationParticipant
+}
+
+func (r *memoryOrganizations) Get(_ context.Context, params orepo.GetParams) ([]*models.Organization, error) {
+	if !slices.Contains(params.Ids, r.org.ID) {
+		return nil, nil
+	}
+
+	return []*models.Organization{r.org}, nil
+}
+
+func (r *memoryOrganizations) Participants(
+	_ context.Context,
+	params orepo.ParticipantsParams,
+) ([]models.OrganizationParticipant, error) {
+	var participants []models.OrganizationParticipant
+
+	for _, p := range r.participants {
+		if params.OrganizationId != r.org.ID || !slices.Contains(params.Ids, p.Id()) {
+			continue
+		}
+
+		participants = append(participants, p)
+	}
+
+	if len(participants) == 0 {
+		return nil, orepo.ErrorNotFound
+	}
+
+	return participants, nil
+}
+
+// memoryParticipants lists participants of the organizations repository
+type memoryParticipants struct {
+	organizations.OrganizationsInteractor
+
+	orgRepo *memoryOrganizations
+}
+
+func (i *memoryParticipants) Participants(
+	ctx context.Context,
+	params organizations.ParticipantsParams,
+) ([]models.OrganizationParticipant, error) {
+	participants, err := i.orgRepo.Participants(ctx, orepo.ParticipantsParams{
+		Ids:            params.IDs,
+		OrganizationId: params.OrganizationID,
+	})
+	if err != nil && !errors.Is(err, orepo.ErrorNotFound) {
+		return nil, err
+	}
+
+	return participants, nil
+}
+
+// memoryLicenses keeps licenses and their operations in memory
+type memoryLicenses struct {
+	licenses.Repository
+
+	mu       sync.Mutex
+	licenses []*models.License
+	ops      []*models.LicenseOperation
+}
+
+func (r *memoryLicenses) CreateLicense(_ context.Context, license models.License) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.licenses = append(r.licenses, &license)
+
+	return nil
+}
+
+func (r *memoryLicenses) ListLicenses(
+	_ context.Context,
+	params licenses.ListLicensesParams,
+) ([]*models.License, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var list []*models.License
+
+	for _, l := range r.licenses {
+		if l.OrganizationID == params.OrganizationID && slices.Contains(params.IDs, l.ID) {
+			copied := *l
+			list = append(list, &copied)
+		}
+	}
+
+	return list, nil
+}
+
+func (r *memoryLicenses) AddOperation(_ context.Context, op models.LicenseOperation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ops = append(r.ops, &op)
+
+	return nil
+}
+
+func (r *memoryLicenses) ListOperations(
+	_ context.Context,
+	params licenses.ListOperationsParams,
+) ([]*models.LicenseOperation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ops []*models.LicenseOperation
+
+	for _, op := range r.ops {
+		if op.OrganizationID == params.OrganizationID && slices.Contains(params.IDs, op.ID) {
+			copied := *op
+			ops = append(ops, &copied)
+		}
+	}
+
+	return ops, nil
+}
+
+func (r *memoryLicenses) UpdateOperation(_ context.Context, params licenses.UpdateOperationParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, op := range r.ops {
+		if op.ID != params.ID || op.OrganizationID != params.OrganizationID {
+			continue
+		}
+
+		if len(params.FromStatuses) > 0 && !slices.Contains(params.FromStatuses, op.Status) {
+			return licenses.ErrorOperationStatusConflict
+		}
+
+		op.Status = params.Status
+
+		return nil
+	}
+
+	return licenses.ErrorOperationStatusConflict
+}
+
+type decisionKey struct {
+	entityID uuid.UUID
+	ownerID  uuid.UUID
+}
+
+// memoryMultisigs keeps multisigs and owner decisions in memory
+type memoryMultisigs struct {
+	transactions.Repository
+
+	mu        sync.Mutex
+	multisigs []models.Multisig
+	decisions map[decisionKey]bool
+}
+
+func (r *memoryMultisigs) ListMultisig(
+	_ context.Context,
+	params transactions.ListMultisigsParams,
+) ([]models.Multisig, error) {
+	var multisigs []models.Multisig
+
+	for _, m := range r.multisigs {
+		if m.OrganizationID == params.OrganizationID && slices.Contains(params.IDs, m.ID) {
+			multisigs = append(multisigs, m)
+		}
+	}
+
+	return multisigs, nil
+}
+
+func (r *memoryMultisigs) ConfirmMultisig(_ context.Context, params transactions.ConfirmMultisigParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decisions[decisionKey{params.EntityID, params.CinfirmedBy.Id()}] = params.Rejected
+
+	return nil
+}
+
+func (r *memoryMultisigs) MultisigConfirmations(
+	_ context.Context,
+	params transactions.MultisigConfirmationsParams,
+) (map[uuid.UUID]uuid.UUIDs, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	owners := make(map[uuid.UUID]uuid.UUIDs)
+
+	for key, rejected := range r.decisions {
+		if rejected == params.Rejected && slices.Contains(params.EntityIDs, key.entityID) {
+			owners[key.entityID] = append(owners[key.entityID], key.ownerID)
+		}
+	}
+
+	return owners, nil
+}
+
+func (r *memoryMultisigs) MultisigConfirmationsCount(
+	_ context.Context,
+	params transactions.MultisigConfirmationsCountParams,
+) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int
+
+	for key, rejected := range r.decisions {
+		if key.entityID == params.EntityID && !rejected {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// memoryChain keeps payrolls in memory and lists multisigs of the transactions repository
+type memoryChain struct {
+	chain.ChainInteractor
+
+	txRepo   *memoryMultisigs
+	payrolls []models.Payroll
+}
+
+func (i *memoryChain) ListPayrolls(_ context.Context, params chain.ListPayrollsParams) ([]models.Payroll, error) {
+	var payrolls []models.Payroll
+
+	for _, p := range i.payrolls {
+		if p.OrganizationID == params.OrganizationID && slices.Contains(params.IDs, p.ID) {
+			payrolls = append(payrolls, p)
+		}
+	}
+
+	return payrolls, nil
+}
+
+func (i *memoryChain) ListMultisigs(ctx context.Context, params chain.ListMultisigsParams) ([]models.Multisig, error) {
+	return i.txRepo.ListMultisig(ctx, transactions.ListMultisigsParams{
+		OrganizationID: params.OrganizationID,
+		IDs:            params.IDs,
+	})
+}
+
+// memoryJobs records enqueued jobs without running them
+type memoryJobs struct {
+	jobs.JobsInteractor
+
+	enqueued []jobs.EnqueueParams
+}
+
+func (i *memoryJobs) RegisterHandler(string, jobs.Handler) {}
+
+func (i *memoryJobs) Enqueue(_ context.Context, params jobs.EnqueueParams) (*models.Job, error) {
+	i.enqueued = append(i.enqueued, params)
+
+	return &models.Job{ID: uuid.New(), Kind: params.Kind}, nil
+}
+
+type fixture struct {
+	interactor   LicenseInteractor
+	licensesRepo *memoryLicenses
+	jobs         *memoryJobs
+	orgID        uuid.UUID
+	multisigID   uuid.UUID
+	// deployed license without payout contract
+	licenseID uuid.UUID
+	// payrolls deployed and pending
+	payrollID        uuid.UUID
+	pendingPayrollID uuid.UUID
+
+	// multisig owners, 2 confirmations are required
+	owner           *models.OrganizationUser
+	approver        *models.OrganizationUser
+	ownerAccountant *models.OrganizationUser
+	// accountant is not an owner of the license multisig
+	accountant *models.OrganizationUser
+	employee   *models.Employee
+}
+
+func newOrganizationUser(role models.Role) *models.OrganizationUser {
+	return &models.OrganizationUser{
+		User: models.User{
+			ID:        uuid.New(),
+			Activated: true,
+		},
+		OrgRole: role,
+	}
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := &fixture{
+		jobs:             &memoryJobs{},
+		orgID:            uuid.New(),
+		multisigID:       uuid.New(),
+		licenseID:        uuid.New(),
+		payrollID:        uuid.New(),
+		pendingPayrollID: uuid.New(),
+		owner:            newOrganizationUser(models.RoleOwner),
+		approver:         newOrganizationUser(models.RoleApprover),
+		ownerAccountant:  newOrganizationUser(models.RoleAccountant),
+		accountant:       newOrganizationUser(models.RoleAccountant),
+	}
+
+	f.approver.PK = []byte("approver wallet")
+
+	f.employee = &models.Employee{
+		ID:             uuid.New(),
+		OrganizationId: f.orgID,
+		WalletAddress:  []byte("employee wallet"),
+	}
+
+	txRepo := &memoryMultisigs{
+		multisigs: []models.Multisig{{
+			ID:                    f.multisigID,
+			OrganizationID:        f.orgID,
+			Owners:                []models.OrganizationParticipant{f.owner, f.approver, f.ownerAccountant},
+			ConfirmationsRequired: 2,
+		}},
+		decisions: make(map[decisionKey]bool),
+	}
+
+	f.licensesRepo = &memoryLicenses{
+		licenses: []*models.License{{
+			ID:             f.licenseID,
+			OrganizationID: f.orgID,
+			MultisigID:     f.multisigID,
+			Address:        []byte("license"),
+			Status:         models.LicenseStatusDeployed,
+		}},
+	}
+
+	orgRepo := &memoryOrganizations{
+		org: &models.Organization{ID: f.orgID},
+		participants: []models.OrganizationParticipant{
+			f.owner, f.approver, f.ownerAccountant, f.accountant, f.employee,
+		},
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	a := authorizer.NewAuthorizer(log, orgRepo)
+
+	f.interactor = NewLicenseInteractor(
+		log,
+		f.licensesRepo,
+		txRepo,
+		nil,
+		&memoryParticipants{orgRepo: orgRepo},
+		&memoryChain{
+			txRepo: txRepo,
+			payrolls: []models.Payroll{
+				{ID: f.payrollID, OrganizationID: f.orgID, Status: models.PayrollStatusDeployed},
+				{ID: f.pendingPayrollID, OrganizationID: f.orgID, Status: models.PayrollStatusPending},
+			},
+		},
+		f.jobs,
+		confirmations.NewConfirmationsInteractor(log, txRepo, a),
+		a,
+	)
+
+	return f
+}
+
+func (f *fixture) as(user *models.OrganizationUser) context.Context {
+	return ctxmeta.OrganizationIdContext(ctxmeta.UserContext(context.Background(), &user.User), f.orgID)
+}
+
+func TestDeploy(t *testing.T) {
+	f := newFixture(t)
+
+	op, err := f.interactor.Deploy(f.as(f.owner), DeployParams{
+		Title:      "Soundtrack",
+		MultisigID: f.multisigID,
+		Shareholders: []Shareholder{
+			{ParticipantID: f.employee.ID, Share: 60},
+			{ParticipantID: f.approver.ID, Share: 30},
+			{Address: []byte("external wallet"), Share: 10},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Deploy() error: %v", err)
+	}
+
+	// creator allowed to confirm confirms the deploy right away
+	if op.Kind != models.LicenseOperationKindDeploy || op.Status != models.LicenseOperationStatusPending ||
+		!slices.Equal(op.ConfirmedBy, uuid.UUIDs{f.owner.ID}) {
+		t.Fatalf("Deploy() = %+v, want pending deploy confirmed by the creator", op)
+	}
+
+	license := f.licensesRepo.licenses[len(f.licensesRepo.licenses)-1]
+
+	if license.ID != op.LicenseID || license.Status != models.LicenseStatusPending || len(license.Shareholders) != 3 {
+		t.Fatalf("created license = %+v, want pending license with 3 shareholders", license)
+	}
+
+	// participant shareholders are paid to their wallets
+	for i, want := range [][]byte{f.employee.WalletAddress, f.approver.PK, []byte("external wallet")} {
+		if string(license.Shareholders[i].Address) != string(want) {
+			t.Fatalf("shareholder %d address = %x, want %x", i, license.Shareholders[i].Address, want)
+		}
+	}
+}
+
+func TestDeployErrors(t *testing.T) {
+	f := newFixture(t)
+
+	valid := []Shareholder{{Address: []byte("wallet"), Share: 100}}
+
+	tests := []struct {
+		name    string
+		actor   *models.OrganizationUser
+		params  DeployParams
+		wantErr error
+	}{
+		{
+			name:    "not a multisig owner",
+			actor:   f.accountant,
+			params:  DeployParams{MultisigID: f.multisigID, Shareholders: valid},
+			wantErr: chain.ErrorNotMultisigOwner,
+		},
+		{
+			name:    "no manage permission",
+			actor:   f.approver,
+			params:  DeployParams{MultisigID: f.multisigID, Shareholders: valid},
+			wantErr: authorizer.ErrorPermissionDenied,
+		},
+		{
+			name:    "unknown multisig",
+			actor:   f.owner,
+			params:  DeployParams{MultisigID: uuid.New(), Shareholders: valid},
+			wantErr: confirmations.ErrorMultisigNotFound,
+		},
+		{
+			name:    "no shareholders",
+			actor:   f.owner,
+			params:  DeployParams{MultisigID: f.multisigID},
+			wantErr: ErrorInvalidShares,
+		},
+		{
+			name:  "shares do not sum up to 100",
+			actor: f.owner,
+			params: DeployParams{MultisigID: f.multisigID, Shareholders: []Shareholder{
+				{Address: []byte("first"), Share: 50},
+				{Address: []byte("second"), Share: 40},
+			}},
+			wantErr: ErrorInvalidShares,
+		},
+		{
+			name:  "zero share",
+			actor: f.owner,
+			params: DeployParams{MultisigID: f.multisigID, Shareholders: []Shareholder{
+				{Address: []byte("first"), Share: 100},
+				{Address: []byte("second")},
+			}},
+			wantErr: ErrorInvalidShares,
+		},
+		{
+			name:  "duplicated shareholder",
+			actor: f.owner,
+			params: DeployParams{MultisigID: f.multisigID, Shareholders: []Shareholder{
+				{ParticipantID: f.employee.ID, Share: 50},
+				{Address: f.employee.WalletAddress, Share: 50},
+			}},
+			wantErr: ErrorInvalidShares,
+		},
+		{
+			name:  "unknown participant",
+			actor: f.owner,
+			params: DeployParams{MultisigID: f.multisigID, Shareholders: []Shareholder{
+				{ParticipantID: uuid.New(), Share: 100},
+			}},
+			wantErr: chain.ErrorEmployeeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.interactor.Deploy(f.as(tt.actor), tt.params); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Deploy() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if len(f.licensesRepo.licenses) != 1 {
+		t.Fatalf("licenses = %d, want no license created", len(f.licensesRepo.licenses))
+	}
+}
+
+func TestOperations(t *testing.T) {
+	f := newFixture(t)
+
+	tests := []struct {
+		name    string
+		call    func() (*models.LicenseOperation, error)
+		kind    models.LicenseOperationKind
+		wantErr error
+	}{
+		{
+			name: "request",
+			call: func() (*models.LicenseOperation, error) {
+				return f.interactor.Request(f.as(f.ownerAccountant), RequestParams{
+					LicenseID: f.licenseID,
+					URL:       "https://oracle.example.com/streams",
+				})
+			},
+			kind: models.LicenseOperationKindRequest,
+		},
+		{
+			name: "invalid oracle url",
+			call: func() (*models.LicenseOperation, error) {
+				return f.interactor.Request(f.as(f.ownerAccountant), RequestParams{LicenseID: f.licenseID, URL: "streams"})
+			},
+			wantErr: ErrorInvalidOracleURL,
+		},
+		{
+			name: "set payout contract",
+			call: func() (*models.LicenseOperation, error) {
+				return f.interactor.SetPayoutContract(f.as(f.ownerAccountant), SetPayoutContractParams{
+					LicenseID: f.licenseID,
+					PayrollID: f.payrollID,
+				})
+			},
+			kind: models.LicenseOperationKindSetPayoutContract,
+		},
+		{
+			name: "payout contract is not deployed",
+			call: func() (*models.LicenseOperation, error) {
+				return f.interactor.SetPayoutContract(f.as(f.ownerAccountant), SetPayoutContractParams{
+					LicenseID: f.licenseID,
+					PayrollID: f.pendingPayrollID,
+				})
+			},
+			wantErr: chain.ErrorPayrollNotDeployed,
+		},
+		{
+			name: "payout without payout contract",
+			call: func() (*models.LicenseOperation, error) {
+				return f.interactor.Payout(f.as(f.ownerAccountant), PayoutParams{LicenseID: f.licenseID})
+			},
+			wantErr: ErrorPayoutContractNotSet,
+		},
+		{
+			name: "unknown license",
+			call: func() (*models.LicenseOperation, error) {
+				return f.interactor.Payout(f.as(f.ownerAccountant), PayoutParams{LicenseID: uuid.New()})
+			},
+			wantErr: ErrorLicenseNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := tt.call()
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("operation error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("operation error: %v", err)
+			}
+
+			// creator can not confirm the operation, so it awaits owners confirmations
+			if op.Kind != tt.kind || op.LicenseID != f.licenseID || op.MultisigID != f.multisigID ||
+				op.Status != models.LicenseOperationStatusPending || op.Confirmations != 0 {
+				t.Fatalf("operation = %+v, want pending %s operation", op, tt.kind)
+			}
+		})
+	}
+
+	f.licensesRepo.licenses[0].Status = models.LicenseStatusPending
+
+	if _, err := f.interactor.Request(f.as(f.ownerAccountant), RequestParams{
+		LicenseID: f.licenseID,
+		URL:       "https://oracle.example.com/streams",
+	}); !errors.Is(err, ErrorLicenseNotDeployed) {
+		t.Fatalf("Request() of not deployed license error = %v, want ErrorLicenseNotDeployed", err)
+	}
+}
+
+func TestConfirmOperation(t *testing.T) {
+	f := newFixture(t)
+
+	f.licensesRepo.licenses[0].PayrollID = f.payrollID
+
+	op, err := f.interactor.Payout(f.as(f.owner), PayoutParams{LicenseID: f.licenseID})
+	if err != nil {
+		t.Fatalf("Payout() error: %v", err)
+	}
+
+	if op.Confirmations != 1 || len(f.jobs.enqueued) != 0 {
+		t.Fatalf("Payout() = %+v, want operation confirmed by the creator only", op)
+	}
+
+	params := ConfirmOperationParams{ID: op.ID, OrganizationID: f.orgID}
+
+	if op, err = f.interactor.ConfirmOperation(f.as(f.approver), params); err != nil {
+		t.Fatalf("ConfirmOperation() error: %v", err)
+	}
+
+	if op.Status != models.LicenseOperationStatusConfirmed || op.Confirmations != 2 {
+		t.Fatalf("ConfirmOperation() = %+v, want confirmed operation", op)
+	}
+
+	if len(f.jobs.enqueued) != 1 || f.jobs.enqueued[0].Kind != JobKindLicenseOperation ||
+		f.jobs.enqueued[0].Payload.(operationPayload).OperationID != op.ID {
+		t.Fatalf("enqueued jobs = %+v, want single license operation job", f.jobs.enqueued)
+	}
+
+	if _, err = f.interactor.ConfirmOperation(f.as(f.owner), params); !errors.Is(err, ErrorLicenseOperationNotPending) {
+		t.Fatalf("ConfirmOperation() of confirmed operation error = %v, want ErrorLicenseOperationNotPending", err)
+	}
+
+	if _, err = f.interactor.ConfirmOperation(f.as(f.approver), ConfirmOperationParams{
+		ID:             uuid.New(),
+		OrganizationID: f.orgID,
+	}); !errors.Is(err, ErrorLicenseOperationNotFound) {
+		t.Fatalf("ConfirmOperation() of unknown operation error = %v, want ErrorLicenseOperationNotFound", err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/emochka2007/block-accounting/internal/pkg/hdwallet"
 	"github.com/emochka2007/block-accounting/internal/pkg/logger"
 	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/cache"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
 	"github.com/ethereum/go-ethereum/common"
@@ -20,7 +21,18 @@ import (
 )
 
 var (
-	ErrorUnauthorizedAccess = errors.New("unauthorized access")
+	ErrorUnauthorizedAccess  = authorizer.ErrorUnauthorizedAccess
+	ErrorParticipantNotFound = errors.New("participant not found")
+	ErrorInvalidRole         = errors.New("invalid role")
+	ErrorLastOwner           = errors.New("organization must have at least one owner")
+	ErrorInvalidUpdate       = errors.New("invalid participant update")
+
+	ErrorOrganizationNotFound        = errors.New("organization not found")
+	ErrorOwnershipTransferNotFound   = errors.New("ownership transfer not found")
+	ErrorOwnershipTransferNotPending = errors.New("ownership transfer is not pending")
+	ErrorOwnershipTransferExists     = errors.New("organization has pending ownership transfer")
+	ErrorInvalidOwnershipTransfer    = errors.New("invalid ownership transfer")
+	ErrorWalletMnemonicRequired      = errors.New("organization wallet mnemonic required")
 )
 
 type CreateParams struct {
@@ -61,28 +73,52 @@ type ParticipantsParams struct {
 type OrganizationsInteractor interface {
 	Create(ctx context.Context, params CreateParams) (*models.Organization, error)
 	List(ctx context.Context, params ListParams) (*ListResponse, error)
+	// Update renames organization and changes its address
+	Update(ctx context.Context, params UpdateParams) (*models.Organization, error)
+	// Archive switches organization into read only mode or back. Only owners archive organizations
+	Archive(ctx context.Context, params ArchiveParams) (*models.Organization, error)
+
+	// TransferOwnership creates pending transfer of the owner role. Transfer takes effect
+	// once the current owner confirms it
+	TransferOwnership(ctx context.Context, params TransferOwnershipParams) (*models.OwnershipTransfer, error)
+	ConfirmOwnershipTransfer(ctx context.Context, params OwnershipTransferParams) (*models.OwnershipTransfer, error)
+	CancelOwnershipTransfer(ctx context.Context, params OwnershipTransferParams) (*models.OwnershipTransfer, error)
+	ListOwnershipTransfers(ctx context.Context, params ListOwnershipTransfersParams) ([]models.OwnershipTransfer, error)
 
 	Participant(ctx context.Context, params ParticipantParams) (models.OrganizationParticipant, error)
 	Participants(ctx context.Context, params ParticipantsParams) ([]models.OrganizationParticipant, error)
+	// ListParticipants returns participants page with cursor of the next page and total matching participants count
+	ListParticipants(ctx context.Context, params ListParticipantsParams) (*ListParticipantsResult, error)
 	AddEmployee(ctx context.Context, params AddParticipantParams) (models.OrganizationParticipant, error)
 	AddUser(ctx context.Context, params AddUserParams) error
+	// UpdateRole assigns role to the organization user. Only owners grant and revoke owner role
+	UpdateRole(ctx context.Context, params UpdateRoleParams) (models.OrganizationParticipant, error)
+	// UpdateParticipant updates participant position, employee name and wallet address and user role
+	UpdateParticipant(ctx context.Context, params UpdateParticipantParams) (models.OrganizationParticipant, error)
+	// DeleteParticipant soft deletes participant. Multisigs owned by the deleted user are flagged for keys rotation
+	DeleteParticipant(ctx context.Context, params DeleteParticipantParams) (models.OrganizationParticipant, error)
+	// RestoreParticipant restores soft deleted participant
+	RestoreParticipant(ctx context.Context, params DeleteParticipantParams) (models.OrganizationParticipant, error)
 }
 
 type organizationsInteractor struct {
 	log           *slog.Logger
 	orgRepository organizations.Repository
 	cache         cache.Cache
+	authorizer    authorizer.Authorizer
 }
 
 func NewOrganizationsInteractor(
 	log *slog.Logger,
 	orgRepository organizations.Repository,
 	cache cache.Cache,
+	authorizer authorizer.Authorizer,
 ) OrganizationsInteractor {
 	return &organizationsInteractor{
 		log:           log,
 		orgRepository: orgRepository,
 		cache:         cache,
+		authorizer:    authorizer,
 	}
 }
 
@@ -120,6 +156,54 @@ func (c *organizationsListCursor) decode(s string) error {
 	return json.Unmarshal(token, c)
 }
 
+type participantsListCursor struct {
+	UserId     uuid.UUID `json:"user_id"`
+	EmployeeId uuid.UUID `json:"employee_id"`
+}
+
+// newParticipantsListCursor builds cursor pointing to the participant
+func newParticipantsListCursor(participant ...models.OrganizationParticipant) *participantsListCursor {
+	c := new(participantsListCursor)
+
+	if len(participant) == 0 {
+		return c
+	}
+
+	if user := participant[0].GetUser(); user != nil {
+		c.UserId = user.Id()
+
+		if user.Employee != nil {
+			c.EmployeeId = user.Employee.ID
+		}
+	} else {
+		c.EmployeeId = participant[0].Id()
+	}
+
+	return c
+}
+
+func (c *participantsListCursor) encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("couldn't marshal participant cursor. %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+func (c *participantsListCursor) decode(s string) error {
+	if c == nil {
+		return nil
+	}
+
+	token, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("error decode token. %w", err)
+	}
+
+	return json.Unmarshal(token, c)
+}
+
 type ListResponse struct {
 	Organizations models.Organizations
 	NextCursor    string
@@ -220,6 +304,11 @@ func (i *organizationsInteractor) Create(
 	}
 
 	if params.WalletMnemonic == "" {
+		// users joined with wallet signature have no seed to derive organization wallet from
+		if len(user.Seed()) == 0 {
+			return nil, ErrorWalletMnemonicRequired
+		}
+
 		walletSeed = user.Seed()
 	} else {
 		seed, err := hdwallet.NewSeedFromMnemonic(params.WalletMnemonic)
@@ -258,6 +347,10 @@ func (i *organizationsInteractor) Participant(
 		EmployeesOnly:  params.EmployeesOnly,
 	})
 	if err != nil {
+		if !errors.Is(err, ErrorUnauthorizedAccess) && errors.Is(err, organizations.ErrorNotFound) {
+			return nil, ErrorParticipantNotFound
+		}
+
 		return nil, fmt.Errorf("error fetch organization participant. %w", err)
 	}
 
@@ -297,6 +390,7 @@ func (i *organizationsInteractor) Participants(
 		UsersOnly:      params.UsersOnly,
 		EmployeesOnly:  params.EmployeesOnly,
 		ActiveOnly:     params.ActiveOnly,
+		OwnerOnly:      params.OwnerOnly,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error fetch organization participants. %w", err)
@@ -305,6 +399,80 @@ func (i *organizationsInteractor) Participants(
 	return participants, nil
 }
 
+type ListParticipantsParams struct {
+	ParticipantsParams
+
+	// Search matches participant name or position
+	Search string
+	Cursor string
+	Limit  uint8 // Max limit is 50
+}
+
+type ListParticipantsResult struct {
+	Participants []models.OrganizationParticipant
+	NextCursor   string
+	TotalItems   int64
+}
+
+func (i *organizationsInteractor) ListParticipants(
+	ctx context.Context,
+	params ListParticipantsParams,
+) (*ListParticipantsResult, error) {
+	if _, err := i.authorizer.Member(ctx, params.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	if params.Limit <= 0 || params.Limit > 50 {
+		params.Limit = 50
+	}
+
+	cursor := newParticipantsListCursor()
+
+	if params.Cursor != "" {
+		if err := cursor.decode(params.Cursor); err != nil {
+			return nil, fmt.Errorf("error decode cursor value. %w", err)
+		}
+	}
+
+	repoParams := organizations.ParticipantsParams{
+		Ids:              params.IDs,
+		OrganizationId:   params.OrganizationID,
+		PKs:              params.PKs,
+		UsersOnly:        params.UsersOnly,
+		EmployeesOnly:    params.EmployeesOnly,
+		ActiveOnly:       params.ActiveOnly,
+		OwnerOnly:        params.OwnerOnly,
+		Search:           params.Search,
+		CursorUserId:     cursor.UserId,
+		CursorEmployeeId: cursor.EmployeeId,
+		Limit:            int64(params.Limit),
+	}
+
+	participants, err := i.orgRepository.Participants(ctx, repoParams)
+	if err != nil && !errors.Is(err, organizations.ErrorNotFound) {
+		return nil, fmt.Errorf("error fetch organization participants. %w", err)
+	}
+
+	total, err := i.orgRepository.CountParticipants(ctx, repoParams)
+	if err != nil {
+		return nil, fmt.Errorf("error count organization participants. %w", err)
+	}
+
+	var nextCursor string
+
+	if len(participants) >= int(params.Limit) {
+		if nextCursor, err = newParticipantsListCursor(participants[len(participants)-1]).encode(); err != nil {
+			return nil, fmt.Errorf("error encode next page token. %w", err)
+		}
+	}
+
+	return &ListParticipantsResult{
+		Participants: participants,
+		NextCursor:   nextCursor,
+		TotalItems:   total,
+	}, nil
+}
+
 type AddParticipantParams struct {
 	OrganizationID uuid.UUID
 	EmployeeUserID uuid.UUID
@@ -317,23 +485,12 @@ func (i *organizationsInteractor) AddEmployee(
 	ctx context.Context,
 	params AddParticipantParams,
 ) (models.OrganizationParticipant, error) {
-	user, err := ctxmeta.User(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("error fetch user from context. %w", err)
-	}
-
-	actor, err := i.Participant(ctx, ParticipantParams{
-		ID:             user.Id(),
-		OrganizationID: params.OrganizationID,
-		ActiveOnly:     true,
-		UsersOnly:      true,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error fetch actor. %w", err)
-	}
-
-	if !actor.IsAdmin() || !actor.IsOwner() {
-		return nil, fmt.Errorf("error actor not an owner")
+	if _, err := i.authorizer.Authorize(
+		ctx,
+		params.OrganizationID,
+		models.PermissionParticipantsManage,
+	); err != nil {
+		return nil, err
 	}
 
 	if !common.IsHexAddress(params.WalletAddress) {
@@ -343,16 +500,17 @@ func (i *organizationsInteractor) AddEmployee(
 	participantID := uuid.Must(uuid.NewV7())
 
 	empl := models.Employee{
-		ID:             participantID,
-		EmployeeName:   params.Name,
-		UserID:         params.EmployeeUserID,
-		OrganizationId: params.OrganizationID,
-		WalletAddress:  common.FromHex(params.WalletAddress),
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
+		ID:               participantID,
+		EmployeeName:     params.Name,
+		EmployeePosition: params.Position,
+		UserID:           params.EmployeeUserID,
+		OrganizationId:   params.OrganizationID,
+		WalletAddress:    common.FromHex(params.WalletAddress),
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
 	}
 
-	if err = i.orgRepository.AddEmployee(ctx, empl); err != nil {
+	if err := i.orgRepository.AddEmployee(ctx, empl); err != nil {
 		return nil, fmt.Errorf("error add new employee. %w", err)
 	}
 
@@ -360,48 +518,681 @@ func (i *organizationsInteractor) AddEmployee(
 }
 
 type AddUserParams struct {
-	User           *models.User
-	IsAdmin        bool
-	IsOwner        bool
+	User *models.User
+	// Role is RoleViewer if not set
+	Role           models.Role
+	Position       string
 	OrganizationID uuid.UUID
 	SkipRights     bool
 }
 
 func (i *organizationsInteractor) AddUser(ctx context.Context, params AddUserParams) error {
-	if !params.SkipRights {
-		user, err := ctxmeta.User(ctx)
-		if err != nil {
-			return fmt.Errorf("error fetch user from context. %w", err)
-		}
+	if params.Role == models.RoleUnknown {
+		params.Role = models.RoleViewer
+	}
 
-		actor, err := i.Participant(ctx, ParticipantParams{
-			ID:             user.Id(),
-			OrganizationID: params.OrganizationID,
-			ActiveOnly:     true,
-			UsersOnly:      true,
-		})
+	if !params.SkipRights {
+		actor, err := i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionParticipantsManage)
 		if err != nil {
-			return fmt.Errorf("error fetch actor. %w", err)
+			return err
 		}
 
-		if !actor.IsAdmin() || !actor.IsOwner() {
-			return fmt.Errorf("error actor not an owner")
+		if params.Role == models.RoleOwner && !actor.IsOwner() {
+			return fmt.Errorf("error only owner can add owners. %w", authorizer.ErrorPermissionDenied)
 		}
 	}
 
 	i.log.Debug(
 		"add user",
-		slog.Any("params", params),
+		slog.String("user id", params.User.Id().String()),
+		slog.String("organization id", params.OrganizationID.String()),
+		slog.String("role", params.Role.String()),
 	)
 
 	if err := i.orgRepository.AddParticipant(ctx, organizations.AddParticipantParams{
 		OrganizationId: params.OrganizationID,
 		UserId:         params.User.Id(),
-		IsAdmin:        params.IsAdmin,
-		IsOwner:        params.IsOwner,
+		Role:           params.Role,
+		Position:       params.Position,
 	}); err != nil {
 		return fmt.Errorf("error add user into organization. %w", err)
 	}
 
 	return nil
 }
+
+type UpdateRoleParams struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Role           models.Role
+}
+
+func (i *organizationsInteractor) UpdateRole(
+	ctx context.Context,
+	params UpdateRoleParams,
+) (models.OrganizationParticipant, error) {
+	if params.Role == models.RoleUnknown {
+		return nil, ErrorInvalidRole
+	}
+
+	actor, err := i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionRolesAssign)
+	if err != nil {
+		return nil, err
+	}
+
+	target, owners, err := i.activeUser(ctx, params.OrganizationID, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if (params.Role == models.RoleOwner || target.IsOwner()) && !actor.IsOwner() {
+		return nil, fmt.Errorf("error only owner can grant or revoke owner role. %w", authorizer.ErrorPermissionDenied)
+	}
+
+	if target.IsOwner() && params.Role != models.RoleOwner && owners <= 1 {
+		return nil, ErrorLastOwner
+	}
+
+	if err = i.orgRepository.UpdateParticipantRole(ctx, organizations.UpdateParticipantRoleParams{
+		OrganizationId: params.OrganizationID,
+		UserId:         params.UserID,
+		Role:           params.Role,
+		UpdatedAt:      time.Now(),
+	}); err != nil {
+		if errors.Is(err, organizations.ErrorNotFound) {
+			return nil, ErrorParticipantNotFound
+		}
+
+		return nil, fmt.Errorf("error update participant role. %w", err)
+	}
+
+	return i.Participant(ctx, ParticipantParams{
+		ID:             params.UserID,
+		OrganizationID: params.OrganizationID,
+		UsersOnly:      true,
+		ActiveOnly:     true,
+	})
+}
+
+// activeUser returns active organization user and the organization owners count
+func (i *organizationsInteractor) activeUser(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	userID uuid.UUID,
+) (*models.OrganizationUser, int, error) {
+	users, err := i.orgRepository.Participants(ctx, organizations.ParticipantsParams{
+		OrganizationId: organizationID,
+		UsersOnly:      true,
+		ActiveOnly:     true,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetch organization users. %w", err)
+	}
+
+	var (
+		target *models.OrganizationUser
+		owners int
+	)
+
+	for _, p := range users {
+		u := p.GetUser()
+		if u == nil || u.GetEmployee() != nil {
+			continue
+		}
+
+		if u.IsOwner() {
+			owners++
+		}
+
+		if u.Id() == userID {
+			target = u
+		}
+	}
+
+	if target == nil {
+		return nil, 0, ErrorParticipantNotFound
+	}
+
+	return target, owners, nil
+}
+
+// participant returns user or employee participant with the id, including deleted ones
+func (i *organizationsInteractor) participant(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (models.OrganizationParticipant, error) {
+	participants, err := i.orgRepository.Participants(ctx, organizations.ParticipantsParams{
+		Ids:            uuid.UUIDs{id},
+		OrganizationId: organizationID,
+	})
+	if err != nil {
+		if errors.Is(err, organizations.ErrorNotFound) {
+			return nil, ErrorParticipantNotFound
+		}
+
+		return nil, fmt.Errorf("error fetch participant. %w", err)
+	}
+
+	var employee models.OrganizationParticipant
+
+	for _, p := range participants {
+		if p.Id() != id {
+			if e := p.GetEmployee(); e != nil && e.ID == id {
+				employee = e
+			}
+
+			continue
+		}
+
+		if p.GetUser() != nil {
+			return p, nil
+		}
+
+		employee = p
+	}
+
+	if employee == nil {
+		return nil, ErrorParticipantNotFound
+	}
+
+	return employee, nil
+}
+
+type UpdateParticipantParams struct {
+	OrganizationID uuid.UUID
+	ID             uuid.UUID
+
+	// Empty fields are not updated. Name and WalletAddress are employees only
+	Name          string
+	Position      string
+	WalletAddress string
+	Role          models.Role
+}
+
+func (i *organizationsInteractor) UpdateParticipant(
+	ctx context.Context,
+	params UpdateParticipantParams,
+) (models.OrganizationParticipant, error) {
+	if _, err := i.authorizer.Authorize(
+		ctx,
+		params.OrganizationID,
+		models.PermissionParticipantsManage,
+	); err != nil {
+		return nil, err
+	}
+
+	target, err := i.participant(ctx, params.OrganizationID, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	repoParams := organizations.UpdateParticipantParams{
+		OrganizationId: params.OrganizationID,
+		Name:           params.Name,
+		Position:       params.Position,
+		UpdatedAt:      time.Now(),
+	}
+
+	if params.WalletAddress != "" {
+		if !common.IsHexAddress(params.WalletAddress) {
+			return nil, fmt.Errorf("error invalid wallet address. %w", ErrorInvalidUpdate)
+		}
+
+		repoParams.WalletAddress = common.FromHex(params.WalletAddress)
+	}
+
+	if user := target.GetUser(); user != nil {
+		if params.Name != "" || params.WalletAddress != "" {
+			return nil, fmt.Errorf("error user name and wallet are managed by the user. %w", ErrorInvalidUpdate)
+		}
+
+		repoParams.UserId = user.Id()
+	} else {
+		if params.Role != models.RoleUnknown {
+			return nil, fmt.Errorf("error employee without user account has no role. %w", ErrorInvalidUpdate)
+		}
+
+		repoParams.EmployeeId = target.Id()
+	}
+
+	if err = i.orgRepository.UpdateParticipant(ctx, repoParams); err != nil {
+		if errors.Is(err, organizations.ErrorNotFound) {
+			return nil, ErrorParticipantNotFound
+		}
+
+		return nil, fmt.Errorf("error update participant. %w", err)
+	}
+
+	if params.Role != models.RoleUnknown && params.Role != target.Role() {
+		return i.UpdateRole(ctx, UpdateRoleParams{
+			OrganizationID: params.OrganizationID,
+			UserID:         target.Id(),
+			Role:           params.Role,
+		})
+	}
+
+	return i.participant(ctx, params.OrganizationID, params.ID)
+}
+
+type DeleteParticipantParams struct {
+	OrganizationID uuid.UUID
+	ID             uuid.UUID
+}
+
+func (i *organizationsInteractor) DeleteParticipant(
+	ctx context.Context,
+	params DeleteParticipantParams,
+) (models.OrganizationParticipant, error) {
+	actor, err := i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionParticipantsManage)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := i.participant(ctx, params.OrganizationID, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	repoParams := organizations.DeleteParticipantParams{
+		OrganizationId: params.OrganizationID,
+	}
+
+	if user := target.GetUser(); user != nil {
+		if user.IsOwner() {
+			if !actor.IsOwner() {
+				return nil, fmt.Errorf("error only owner can remove owners. %w", authorizer.ErrorPermissionDenied)
+			}
+
+			_, owners, err := i.activeUser(ctx, params.OrganizationID, user.Id())
+			if err != nil {
+				return nil, err
+			}
+
+			if owners <= 1 {
+				return nil, ErrorLastOwner
+			}
+		}
+
+		repoParams.UserId = user.Id()
+	} else {
+		repoParams.EmployeeId = target.Id()
+	}
+
+	if err = i.orgRepository.DeleteParticipant(ctx, repoParams); err != nil {
+		if errors.Is(err, organizations.ErrorNotFound) {
+			return nil, ErrorParticipantNotFound
+		}
+
+		return nil, fmt.Errorf("error delete participant. %w", err)
+	}
+
+	i.log.Info(
+		"participant deleted",
+		slog.String("organization id", params.OrganizationID.String()),
+		slog.String("participant id", params.ID.String()),
+		slog.String("deleted by", actor.Id().String()),
+	)
+
+	return i.participant(ctx, params.OrganizationID, params.ID)
+}
+
+func (i *organizationsInteractor) RestoreParticipant(
+	ctx context.Context,
+	params DeleteParticipantParams,
+) (models.OrganizationParticipant, error) {
+	actor, err := i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionParticipantsManage)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := i.participant(ctx, params.OrganizationID, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	repoParams := organizations.DeleteParticipantParams{
+		OrganizationId: params.OrganizationID,
+	}
+
+	if user := target.GetUser(); user != nil {
+		if user.IsOwner() && !actor.IsOwner() {
+			return nil, fmt.Errorf("error only owner can restore owners. %w", authorizer.ErrorPermissionDenied)
+		}
+
+		repoParams.UserId = user.Id()
+	} else {
+		repoParams.EmployeeId = target.Id()
+	}
+
+	if err = i.orgRepository.RestoreParticipant(ctx, repoParams); err != nil {
+		if errors.Is(err, organizations.ErrorNotFound) {
+			return nil, ErrorParticipantNotFound
+		}
+
+		return nil, fmt.Errorf("error restore participant. %w", err)
+	}
+
+	return i.participant(ctx, params.OrganizationID, params.ID)
+}
+
+// organization returns organization by id
+func (i *organizationsInteractor) organization(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	orgs, err := i.orgRepository.Get(ctx, organizations.GetParams{
+		Ids:   uuid.UUIDs{id},
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization. %w", err)
+	}
+
+	if len(orgs) == 0 {
+		return nil, ErrorOrganizationNotFound
+	}
+
+	return orgs[0], nil
+}
+
+type UpdateParams struct {
+	OrganizationID uuid.UUID
+
+	// Empty fields are not updated
+	Name    string
+	Address string
+}
+
+func (i *organizationsInteractor) Update(ctx context.Context, params UpdateParams) (*models.Organization, error) {
+	if _, err := i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionOrganizationManage); err != nil {
+		return nil, err
+	}
+
+	org, err := i.organization(ctx, params.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Name != "" {
+		org.Name = params.Name
+	}
+
+	if params.Address != "" {
+		org.Address = params.Address
+	}
+
+	org.UpdatedAt = time.Now()
+
+	if err = i.orgRepository.Update(ctx, *org); err != nil {
+		return nil, fmt.Errorf("error update organization. %w", err)
+	}
+
+	return org, nil
+}
+
+type ArchiveParams struct {
+	OrganizationID uuid.UUID
+	// Archived false restores archived organization
+	Archived bool
+}
+
+func (i *organizationsInteractor) Archive(ctx context.Context, params ArchiveParams) (*models.Organization, error) {
+	actor, err := i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionOrganizationManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if !actor.IsOwner() {
+		return nil, fmt.Errorf("error only owner can archive organization. %w", authorizer.ErrorPermissionDenied)
+	}
+
+	org, err := i.organization(ctx, params.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if org.IsArchived() == params.Archived {
+		return org, nil
+	}
+
+	org.UpdatedAt = time.Now()
+	org.ArchivedAt = time.Time{}
+
+	if params.Archived {
+		org.ArchivedAt = org.UpdatedAt
+	}
+
+	if err = i.orgRepository.Update(ctx, *org); err != nil {
+		return nil, fmt.Errorf("error update organization archive state. %w", err)
+	}
+
+	i.log.Info(
+		"organization archive state changed",
+		slog.String("organization id", org.ID.String()),
+		slog.Bool("archived", params.Archived),
+		slog.String("changed by", actor.Id().String()),
+	)
+
+	return org, nil
+}
+
+type TransferOwnershipParams struct {
+	OrganizationID uuid.UUID
+	// FromUserID is the current owner. Caller if not set
+	FromUserID uuid.UUID
+	ToUserID   uuid.UUID
+}
+
+func (i *organizationsInteractor) TransferOwnership(
+	ctx context.Context,
+	params TransferOwnershipParams,
+) (*models.OwnershipTransfer, error) {
+	actor, err := i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionRolesAssign)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.FromUserID == uuid.Nil {
+		params.FromUserID = actor.Id()
+	}
+
+	if params.FromUserID == params.ToUserID {
+		return nil, fmt.Errorf("error transfer ownership to the same user. %w", ErrorInvalidOwnershipTransfer)
+	}
+
+	from, _, err := i.activeUser(ctx, params.OrganizationID, params.FromUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !from.IsOwner() {
+		return nil, fmt.Errorf("error transfer from not an owner. %w", ErrorInvalidOwnershipTransfer)
+	}
+
+	to, _, err := i.activeUser(ctx, params.OrganizationID, params.ToUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if to.IsOwner() {
+		return nil, fmt.Errorf("error transfer to the owner. %w", ErrorInvalidOwnershipTransfer)
+	}
+
+	pending, err := i.orgRepository.ListOwnershipTransfers(ctx, organizations.ListOwnershipTransfersParams{
+		OrganizationID: params.OrganizationID,
+		Statuses:       []models.OwnershipTransferStatus{models.OwnershipTransferStatusPending},
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch pending ownership transfers. %w", err)
+	}
+
+	if len(pending) > 0 {
+		return nil, ErrorOwnershipTransferExists
+	}
+
+	transfer := models.OwnershipTransfer{
+		ID:             uuid.Must(uuid.NewV7()),
+		OrganizationID: params.OrganizationID,
+		FromUserID:     from.Id(),
+		ToUserID:       to.Id(),
+		Status:         models.OwnershipTransferStatusPending,
+		CreatedBy:      actor.Id(),
+		CreatedAt:      time.Now(),
+	}
+
+	transfer.UpdatedAt = transfer.CreatedAt
+
+	if err = i.orgRepository.AddOwnershipTransfer(ctx, transfer); err != nil {
+		return nil, fmt.Errorf("error add ownership transfer. %w", err)
+	}
+
+	return &transfer, nil
+}
+
+type OwnershipTransferParams struct {
+	OrganizationID uuid.UUID
+	ID             uuid.UUID
+}
+
+func (i *organizationsInteractor) ownershipTransfer(
+	ctx context.Context,
+	params OwnershipTransferParams,
+) (*models.OwnershipTransfer, error) {
+	transfers, err := i.orgRepository.ListOwnershipTransfers(ctx, organizations.ListOwnershipTransfersParams{
+		IDs:            uuid.UUIDs{params.ID},
+		OrganizationID: params.OrganizationID,
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ownership transfer. %w", err)
+	}
+
+	if len(transfers) == 0 {
+		return nil, ErrorOwnershipTransferNotFound
+	}
+
+	return &transfers[0], nil
+}
+
+// ConfirmOwnershipTransfer is called by the current owner. New owner gets owner role, former owner becomes admin
+func (i *organizationsInteractor) ConfirmOwnershipTransfer(
+	ctx context.Context,
+	params OwnershipTransferParams,
+) (*models.OwnershipTransfer, error) {
+	actor, err := i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionRolesAssign)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := i.ownershipTransfer(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Status != models.OwnershipTransferStatusPending {
+		return nil, ErrorOwnershipTransferNotPending
+	}
+
+	if transfer.FromUserID != actor.Id() || !actor.IsOwner() {
+		return nil, fmt.Errorf("error only current owner confirms ownership transfer. %w", authorizer.ErrorPermissionDenied)
+	}
+
+	if err = i.orgRepository.ConfirmOwnershipTransfer(ctx, organizations.ConfirmOwnershipTransferParams{
+		ID:             transfer.ID,
+		OrganizationID: transfer.OrganizationID,
+		ConfirmedAt:    time.Now(),
+	}); err != nil {
+		if errors.Is(err, organizations.ErrorOwnershipTransferStatusConflict) {
+			return nil, ErrorOwnershipTransferNotPending
+		}
+
+		if errors.Is(err, organizations.ErrorNotFound) {
+			return nil, ErrorParticipantNotFound
+		}
+
+		return nil, fmt.Errorf("error confirm ownership transfer. %w", err)
+	}
+
+	i.log.Info(
+		"organization ownership transferred",
+		slog.String("organization id", transfer.OrganizationID.String()),
+		slog.String("from", transfer.FromUserID.String()),
+		slog.String("to", transfer.ToUserID.String()),
+	)
+
+	return i.ownershipTransfer(ctx, params)
+}
+
+// CancelOwnershipTransfer is called by the transfer creator, the current or the new owner
+func (i *organizationsInteractor) CancelOwnershipTransfer(
+	ctx context.Context,
+	params OwnershipTransferParams,
+) (*models.OwnershipTransfer, error) {
+	actor, err := i.authorizer.Member(ctx, params.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := i.ownershipTransfer(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Status != models.OwnershipTransferStatusPending {
+		return nil, ErrorOwnershipTransferNotPending
+	}
+
+	switch actor.Id() {
+	case transfer.FromUserID, transfer.ToUserID, transfer.CreatedBy:
+	default:
+		return nil, fmt.Errorf("error cancel foreign ownership transfer. %w", authorizer.ErrorPermissionDenied)
+	}
+
+	if err = i.orgRepository.CancelOwnershipTransfer(ctx, organizations.CancelOwnershipTransferParams{
+		ID:             transfer.ID,
+		OrganizationID: transfer.OrganizationID,
+		CancelledAt:    time.Now(),
+	}); err != nil {
+		if errors.Is(err, organizations.ErrorOwnershipTransferStatusConflict) {
+			return nil, ErrorOwnershipTransferNotPending
+		}
+
+		return nil, fmt.Errorf("error cancel ownership transfer. %w", err)
+	}
+
+	return i.ownershipTransfer(ctx, params)
+}
+
+type ListOwnershipTransfersParams struct {
+	OrganizationID uuid.UUID
+	PendingOnly    bool
+	Limit          uint8
+}
+
+func (i *organizationsInteractor) ListOwnershipTransfers(
+	ctx context.Context,
+	params ListOwnershipTransfersParams,
+) ([]models.OwnershipTransfer, error) {
+	if _, err := i.authorizer.Member(ctx, params.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	if params.Limit <= 0 || params.Limit > 50 {
+		params.Limit = 50
+	}
+
+	repoParams := organizations.ListOwnershipTransfersParams{
+		OrganizationID: params.OrganizationID,
+		Limit:          int64(params.Limit),
+	}
+
+	if params.PendingOnly {
+		repoParams.Statuses = []models.OwnershipTransferStatus{models.OwnershipTransferStatusPending}
+	}
+
+	transfers, err := i.orgRepository.ListOwnershipTransfers(ctx, repoParams)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ownership transfers. %w", err)
+	}
+
+	return transfers, nil
+}
@@ -0,0 +1,1064 @@
+package organizations
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+// participantRow is an organizations_users record. Employee with user account has both ids set
+type participantRow struct {
+	userID     uuid.UUID
+	employeeID uuid.UUID
+	position   string
+	role       models.Role
+	deletedAt  time.Time
+}
+
+// memoryOrganizations keeps organizations of the test in memory. Participants are ordered
+// by (user_id, employee_id) as in the database
+type memoryOrganizations struct {
+	organizations.Repository
+
+	mu        sync.Mutex
+	orgs      map[uuid.UUID]*models.Organization
+	users     map[uuid.UUID]*models.User
+	employees map[uuid.UUID]*models.Employee
+	rows      []*participantRow
+	transfers []*models.OwnershipTransfer
+}
+
+func newMemoryOrganizations() *memoryOrganizations {
+	return &memoryOrganizations{
+		orgs:      make(map[uuid.UUID]*models.Organization),
+		users:     make(map[uuid.UUID]*models.User),
+		employees: make(map[uuid.UUID]*models.Employee),
+	}
+}
+
+func (r *memoryOrganizations) addRow(row *participantRow) {
+	r.rows = append(r.rows, row)
+
+	slices.SortFunc(r.rows, func(a, b *participantRow) int {
+		return compareKeys(a.userID, a.employeeID, b.userID, b.employeeID)
+	})
+}
+
+// compareKeys compares participant keys as postgres compares (user_id, employee_id) tuples
+func compareKeys(userA, employeeA, userB, employeeB uuid.UUID) int {
+	if c := bytes.Compare(userA[:], userB[:]); c != 0 {
+		return c
+	}
+
+	return bytes.Compare(employeeA[:], employeeB[:])
+}
+
+func (r *memoryOrganizations) Get(_ context.Context, params organizations.GetParams) ([]*models.Organization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var orgs []*models.Organization
+
+	for _, id := range params.Ids {
+		if org, ok := r.orgs[id]; ok {
+			found := *org
+
+			orgs = append(orgs, &found)
+		}
+	}
+
+	return orgs, nil
+}
+
+func (r *memoryOrganizations) Update(_ context.Context, org models.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.orgs[org.ID] = &org
+
+	return nil
+}
+
+func (r *memoryOrganizations) matches(row *participantRow, params organizations.ParticipantsParams) bool {
+	if len(params.Ids) > 0 && !slices.Contains(params.Ids, row.userID) && !slices.Contains(params.Ids, row.employeeID) {
+		return false
+	}
+
+	if (params.UsersOnly || params.OwnerOnly) && row.userID == uuid.Nil {
+		return false
+	}
+
+	if params.EmployeesOnly && row.employeeID == uuid.Nil {
+		return false
+	}
+
+	if params.OwnerOnly && row.role != models.RoleOwner {
+		return false
+	}
+
+	if params.ActiveOnly && !row.deletedAt.IsZero() {
+		return false
+	}
+
+	if params.Search != "" {
+		search := strings.ToLower(params.Search)
+		names := []string{row.position}
+
+		if u, ok := r.users[row.userID]; ok {
+			names = append(names, u.Name)
+		}
+
+		if e, ok := r.employees[row.employeeID]; ok {
+			names = append(names, e.EmployeeName)
+		}
+
+		if !slices.ContainsFunc(names, func(name string) bool {
+			return strings.Contains(strings.ToLower(name), search)
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *memoryOrganizations) participant(row *participantRow, params organizations.ParticipantsParams) models.OrganizationParticipant {
+	var employee *models.Employee
+
+	if e, ok := r.employees[row.employeeID]; ok && !params.UsersOnly {
+		found := *e
+		employee = &found
+	}
+
+	if row.userID == uuid.Nil {
+		if employee == nil {
+			return nil
+		}
+
+		employee.EmployeePosition = row.position
+		employee.DeletedAt = row.deletedAt
+
+		return employee
+	}
+
+	u, ok := r.users[row.userID]
+	if !ok || params.EmployeesOnly {
+		return nil
+	}
+
+	return &models.OrganizationUser{
+		User:        *u,
+		OrgPosition: row.position,
+		OrgRole:     row.role,
+		Employee:    employee,
+		DeletedAt:   row.deletedAt,
+	}
+}
+
+func (r *memoryOrganizations) Participants(
+	_ context.Context,
+	params organizations.ParticipantsParams,
+) ([]models.OrganizationParticipant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var participants []models.OrganizationParticipant
+
+	for _, row := range r.rows {
+		if !r.matches(row, params) {
+			continue
+		}
+
+		if (params.CursorUserId != uuid.Nil || params.CursorEmployeeId != uuid.Nil) &&
+			compareKeys(row.userID, row.employeeID, params.CursorUserId, params.CursorEmployeeId) <= 0 {
+			continue
+		}
+
+		if params.Limit > 0 && int64(len(participants)) == params.Limit {
+			break
+		}
+
+		if p := r.participant(row, params); p != nil {
+			participants = append(participants, p)
+		}
+	}
+
+	if len(participants) == 0 {
+		return nil, organizations.ErrorNotFound
+	}
+
+	return participants, nil
+}
+
+func (r *memoryOrganizations) CountParticipants(_ context.Context, params organizations.ParticipantsParams) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+
+	for _, row := range r.rows {
+		if r.matches(row, params) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// participantRows returns rows of the user or the employee without user account
+func (r *memoryOrganizations) participantRows(userID, employeeID uuid.UUID, deleted bool) []*participantRow {
+	var rows []*participantRow
+
+	for _, row := range r.rows {
+		if userID != uuid.Nil && row.userID != userID {
+			continue
+		}
+
+		if userID == uuid.Nil && (row.userID != uuid.Nil || row.employeeID != employeeID) {
+			continue
+		}
+
+		if row.deletedAt.IsZero() == deleted {
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func (r *memoryOrganizations) UpdateParticipant(_ context.Context, params organizations.UpdateParticipantParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows := r.participantRows(params.UserId, params.EmployeeId, false)
+	if len(rows) == 0 {
+		return organizations.ErrorNotFound
+	}
+
+	for _, row := range rows {
+		if params.Position != "" {
+			row.position = params.Position
+		}
+	}
+
+	if e, ok := r.employees[params.EmployeeId]; ok {
+		if params.Name != "" {
+			e.EmployeeName = params.Name
+		}
+
+		if len(params.WalletAddress) > 0 {
+			e.WalletAddress = params.WalletAddress
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryOrganizations) DeleteParticipant(_ context.Context, params organizations.DeleteParticipantParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows := r.participantRows(params.UserId, params.EmployeeId, false)
+	if len(rows) == 0 {
+		return organizations.ErrorNotFound
+	}
+
+	for _, row := range rows {
+		row.deletedAt = time.Now()
+	}
+
+	return nil
+}
+
+func (r *memoryOrganizations) RestoreParticipant(_ context.Context, params organizations.DeleteParticipantParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows := r.participantRows(params.UserId, params.EmployeeId, true)
+	if len(rows) == 0 {
+		return organizations.ErrorNotFound
+	}
+
+	for _, row := range rows {
+		row.deletedAt = time.Time{}
+	}
+
+	return nil
+}
+
+func (r *memoryOrganizations) UpdateParticipantRole(
+	_ context.Context,
+	params organizations.UpdateParticipantRoleParams,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows := r.participantRows(params.UserId, uuid.Nil, false)
+	if len(rows) == 0 {
+		return organizations.ErrorNotFound
+	}
+
+	for _, row := range rows {
+		row.role = params.Role
+	}
+
+	return nil
+}
+
+func (r *memoryOrganizations) AddOwnershipTransfer(_ context.Context, transfer models.OwnershipTransfer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.transfers = append(r.transfers, &transfer)
+
+	return nil
+}
+
+func (r *memoryOrganizations) ListOwnershipTransfers(
+	_ context.Context,
+	params organizations.ListOwnershipTransfersParams,
+) ([]models.OwnershipTransfer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var transfers []models.OwnershipTransfer
+
+	// newest first
+	for i := len(r.transfers) - 1; i >= 0; i-- {
+		t := r.transfers[i]
+
+		if t.OrganizationID != params.OrganizationID ||
+			(len(params.IDs) > 0 && !slices.Contains(params.IDs, t.ID)) ||
+			(len(params.Statuses) > 0 && !slices.Contains(params.Statuses, t.Status)) {
+			continue
+		}
+
+		if params.Limit > 0 && int64(len(transfers)) == params.Limit {
+			break
+		}
+
+		transfers = append(transfers, *t)
+	}
+
+	return transfers, nil
+}
+
+// pendingTransfer returns pending transfer of the organization by id
+func (r *memoryOrganizations) pendingTransfer(id, organizationID uuid.UUID) (*models.OwnershipTransfer, error) {
+	for _, t := range r.transfers {
+		if t.ID == id && t.OrganizationID == organizationID && t.Status == models.OwnershipTransferStatusPending {
+			return t, nil
+		}
+	}
+
+	return nil, organizations.ErrorOwnershipTransferStatusConflict
+}
+
+func (r *memoryOrganizations) CancelOwnershipTransfer(
+	_ context.Context,
+	params organizations.CancelOwnershipTransferParams,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, err := r.pendingTransfer(params.ID, params.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	t.Status = models.OwnershipTransferStatusCancelled
+	t.CancelledAt = params.CancelledAt
+
+	return nil
+}
+
+func (r *memoryOrganizations) ConfirmOwnershipTransfer(
+	_ context.Context,
+	params organizations.ConfirmOwnershipTransferParams,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, err := r.pendingTransfer(params.ID, params.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	to := r.participantRows(t.ToUserID, uuid.Nil, false)
+	from := r.participantRows(t.FromUserID, uuid.Nil, false)
+
+	if len(to) == 0 || len(from) == 0 {
+		return organizations.ErrorNotFound
+	}
+
+	for _, row := range to {
+		row.role = models.RoleOwner
+	}
+
+	for _, row := range from {
+		row.role = models.RoleAdmin
+	}
+
+	t.Status = models.OwnershipTransferStatusConfirmed
+	t.ConfirmedAt = params.ConfirmedAt
+
+	return nil
+}
+
+type fixture struct {
+	interactor OrganizationsInteractor
+	repo       *memoryOrganizations
+	org        *models.Organization
+
+	owner    *models.User
+	admin    *models.User
+	viewer   *models.User
+	outsider *models.User
+	employee *models.Employee
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	f := &fixture{
+		repo:     newMemoryOrganizations(),
+		org:      &models.Organization{ID: uuid.New(), Name: "Acme"},
+		owner:    newUser("Olivia"),
+		admin:    newUser("Adam"),
+		viewer:   newUser("Victor"),
+		outsider: newUser("Oscar"),
+	}
+
+	f.employee = &models.Employee{
+		ID:             uuid.New(),
+		EmployeeName:   "Emma",
+		OrganizationId: f.org.ID,
+		WalletAddress:  common.FromHex("0x1000000000000000000000000000000000000001"),
+	}
+
+	f.repo.orgs[f.org.ID] = f.org
+	f.repo.employees[f.employee.ID] = f.employee
+
+	for _, u := range []*models.User{f.owner, f.admin, f.viewer, f.outsider} {
+		f.repo.users[u.ID] = u
+	}
+
+	f.repo.addRow(&participantRow{userID: f.owner.ID, role: models.RoleOwner})
+	f.repo.addRow(&participantRow{userID: f.admin.ID, role: models.RoleAdmin, position: "CFO"})
+	f.repo.addRow(&participantRow{userID: f.viewer.ID, role: models.RoleViewer, position: "Auditor"})
+	f.repo.addRow(&participantRow{employeeID: f.employee.ID, position: "Designer"})
+
+	f.interactor = NewOrganizationsInteractor(log, f.repo, nil, authorizer.NewAuthorizer(log, f.repo))
+
+	return f
+}
+
+func newUser(name string) *models.User {
+	return &models.User{
+		ID:        uuid.New(),
+		Name:      name,
+		Activated: true,
+	}
+}
+
+// as returns context of the request made by the user
+func as(user *models.User) context.Context {
+	return ctxmeta.UserContext(context.Background(), user)
+}
+
+func TestParticipant(t *testing.T) {
+	f := newFixture(t)
+
+	p, err := f.interactor.Participant(as(f.viewer), ParticipantParams{
+		ID:             f.employee.ID,
+		OrganizationID: f.org.ID,
+	})
+	if err != nil {
+		t.Fatalf("Participant() error: %v", err)
+	}
+
+	if p.GetEmployee() == nil || p.Position() != "Designer" || p.ParticipantName() != "Emma" {
+		t.Fatalf("Participant() = %+v, want employee", p)
+	}
+
+	if _, err = f.interactor.Participant(as(f.viewer), ParticipantParams{
+		ID:             uuid.New(),
+		OrganizationID: f.org.ID,
+	}); !errors.Is(err, ErrorParticipantNotFound) {
+		t.Fatalf("Participant() of unknown id error = %v, want ErrorParticipantNotFound", err)
+	}
+
+	if _, err = f.interactor.Participant(as(f.outsider), ParticipantParams{
+		ID:             f.owner.ID,
+		OrganizationID: f.org.ID,
+	}); !errors.Is(err, ErrorUnauthorizedAccess) {
+		t.Fatalf("Participant() by not a participant error = %v, want ErrorUnauthorizedAccess", err)
+	}
+}
+
+func TestUpdateParticipant(t *testing.T) {
+	wallet := "0x2000000000000000000000000000000000000002"
+
+	tests := []struct {
+		name    string
+		actor   func(f *fixture) *models.User
+		params  func(f *fixture) UpdateParticipantParams
+		wantErr error
+		check   func(t *testing.T, f *fixture, p models.OrganizationParticipant)
+	}{
+		{
+			name:  "employee",
+			actor: func(f *fixture) *models.User { return f.admin },
+			params: func(f *fixture) UpdateParticipantParams {
+				return UpdateParticipantParams{ID: f.employee.ID, Name: "Emma Stone", Position: "Lead", WalletAddress: wallet}
+			},
+			check: func(t *testing.T, f *fixture, p models.OrganizationParticipant) {
+				e := p.GetEmployee()
+
+				if e == nil || e.EmployeeName != "Emma Stone" || e.Position() != "Lead" ||
+					common.BytesToAddress(e.WalletAddress).Hex() != wallet {
+					t.Fatalf("UpdateParticipant() = %+v", p)
+				}
+			},
+		},
+		{
+			name:  "user position and role",
+			actor: func(f *fixture) *models.User { return f.admin },
+			params: func(f *fixture) UpdateParticipantParams {
+				return UpdateParticipantParams{ID: f.viewer.ID, Position: "Chief auditor", Role: models.RoleApprover}
+			},
+			check: func(t *testing.T, f *fixture, p models.OrganizationParticipant) {
+				if p.Position() != "Chief auditor" || p.Role() != models.RoleApprover {
+					t.Fatalf("UpdateParticipant() position %q, role %s", p.Position(), p.Role())
+				}
+			},
+		},
+		{
+			name:  "user name",
+			actor: func(f *fixture) *models.User { return f.admin },
+			params: func(f *fixture) UpdateParticipantParams {
+				return UpdateParticipantParams{ID: f.viewer.ID, Name: "Mallory"}
+			},
+			wantErr: ErrorInvalidUpdate,
+		},
+		{
+			name:  "employee role",
+			actor: func(f *fixture) *models.User { return f.admin },
+			params: func(f *fixture) UpdateParticipantParams {
+				return UpdateParticipantParams{ID: f.employee.ID, Role: models.RoleAdmin}
+			},
+			wantErr: ErrorInvalidUpdate,
+		},
+		{
+			name:  "invalid wallet",
+			actor: func(f *fixture) *models.User { return f.admin },
+			params: func(f *fixture) UpdateParticipantParams {
+				return UpdateParticipantParams{ID: f.employee.ID, WalletAddress: "0xnot"}
+			},
+			wantErr: ErrorInvalidUpdate,
+		},
+		{
+			name:  "owner role by admin",
+			actor: func(f *fixture) *models.User { return f.admin },
+			params: func(f *fixture) UpdateParticipantParams {
+				return UpdateParticipantParams{ID: f.viewer.ID, Role: models.RoleOwner}
+			},
+			wantErr: authorizer.ErrorPermissionDenied,
+		},
+		{
+			name:  "by viewer",
+			actor: func(f *fixture) *models.User { return f.viewer },
+			params: func(f *fixture) UpdateParticipantParams {
+				return UpdateParticipantParams{ID: f.employee.ID, Position: "Intern"}
+			},
+			wantErr: authorizer.ErrorPermissionDenied,
+		},
+		{
+			name:  "unknown participant",
+			actor: func(f *fixture) *models.User { return f.admin },
+			params: func(f *fixture) UpdateParticipantParams {
+				return UpdateParticipantParams{ID: uuid.New(), Position: "Intern"}
+			},
+			wantErr: ErrorParticipantNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+
+			params := tt.params(f)
+			params.OrganizationID = f.org.ID
+
+			p, err := f.interactor.UpdateParticipant(as(tt.actor(f)), params)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("UpdateParticipant() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("UpdateParticipant() error: %v", err)
+			}
+
+			tt.check(t, f, p)
+		})
+	}
+}
+
+func TestUpdateRole(t *testing.T) {
+	f := newFixture(t)
+
+	update := func(actor *models.User, userID uuid.UUID, role models.Role) error {
+		_, err := f.interactor.UpdateRole(as(actor), UpdateRoleParams{
+			OrganizationID: f.org.ID,
+			UserID:         userID,
+			Role:           role,
+		})
+
+		return err
+	}
+
+	if err := update(f.admin, f.viewer.ID, models.RoleUnknown); !errors.Is(err, ErrorInvalidRole) {
+		t.Fatalf("UpdateRole() to unknown role error = %v, want ErrorInvalidRole", err)
+	}
+
+	if err := update(f.owner, f.owner.ID, models.RoleAdmin); !errors.Is(err, ErrorLastOwner) {
+		t.Fatalf("UpdateRole() of the last owner error = %v, want ErrorLastOwner", err)
+	}
+
+	if err := update(f.admin, f.viewer.ID, models.RoleOwner); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("UpdateRole() to owner by admin error = %v, want ErrorPermissionDenied", err)
+	}
+
+	if err := update(f.owner, f.admin.ID, models.RoleOwner); err != nil {
+		t.Fatalf("UpdateRole() to owner by owner error: %v", err)
+	}
+
+	if err := update(f.owner, f.owner.ID, models.RoleAdmin); err != nil {
+		t.Fatalf("UpdateRole() of one of the owners error: %v", err)
+	}
+
+	// former owner is an admin now and can not revoke owner role
+	if err := update(f.owner, f.admin.ID, models.RoleViewer); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("UpdateRole() of owner by admin error = %v, want ErrorPermissionDenied", err)
+	}
+}
+
+func TestDeleteParticipant(t *testing.T) {
+	f := newFixture(t)
+
+	remove := func(actor *models.User, id uuid.UUID) (models.OrganizationParticipant, error) {
+		return f.interactor.DeleteParticipant(as(actor), DeleteParticipantParams{
+			OrganizationID: f.org.ID,
+			ID:             id,
+		})
+	}
+
+	if _, err := remove(f.viewer, f.employee.ID); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("DeleteParticipant() by viewer error = %v, want ErrorPermissionDenied", err)
+	}
+
+	if _, err := remove(f.admin, f.owner.ID); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("DeleteParticipant() of owner by admin error = %v, want ErrorPermissionDenied", err)
+	}
+
+	if _, err := remove(f.owner, f.owner.ID); !errors.Is(err, ErrorLastOwner) {
+		t.Fatalf("DeleteParticipant() of the last owner error = %v, want ErrorLastOwner", err)
+	}
+
+	deleted, err := remove(f.admin, f.viewer.ID)
+	if err != nil {
+		t.Fatalf("DeleteParticipant() error: %v", err)
+	}
+
+	if deleted.DeletedDate().IsZero() {
+		t.Fatalf("DeleteParticipant() returned not deleted participant")
+	}
+
+	if _, err = remove(f.admin, f.viewer.ID); !errors.Is(err, ErrorParticipantNotFound) {
+		t.Fatalf("DeleteParticipant() of deleted participant error = %v, want ErrorParticipantNotFound", err)
+	}
+
+	// deleted user has no access to the organization
+	if _, err = f.interactor.ListParticipants(as(f.viewer), ListParticipantsParams{
+		ParticipantsParams: ParticipantsParams{OrganizationID: f.org.ID},
+	}); !errors.Is(err, ErrorUnauthorizedAccess) {
+		t.Fatalf("ListParticipants() by deleted user error = %v, want ErrorUnauthorizedAccess", err)
+	}
+
+	restored, err := f.interactor.RestoreParticipant(as(f.admin), DeleteParticipantParams{
+		OrganizationID: f.org.ID,
+		ID:             f.viewer.ID,
+	})
+	if err != nil {
+		t.Fatalf("RestoreParticipant() error: %v", err)
+	}
+
+	if !restored.DeletedDate().IsZero() || restored.Role() != models.RoleViewer {
+		t.Fatalf("RestoreParticipant() = %+v", restored)
+	}
+
+	if _, err = f.interactor.RestoreParticipant(as(f.admin), DeleteParticipantParams{
+		OrganizationID: f.org.ID,
+		ID:             f.employee.ID,
+	}); !errors.Is(err, ErrorParticipantNotFound) {
+		t.Fatalf("RestoreParticipant() of active participant error = %v, want ErrorParticipantNotFound", err)
+	}
+
+	if _, err = remove(f.admin, f.employee.ID); err != nil {
+		t.Fatalf("DeleteParticipant() of employee error: %v", err)
+	}
+}
+
+func TestListParticipantsPages(t *testing.T) {
+	f := newFixture(t)
+
+	var (
+		ids    uuid.UUIDs
+		cursor string
+		pages  int
+	)
+
+	for {
+		page, err := f.interactor.ListParticipants(as(f.viewer), ListParticipantsParams{
+			ParticipantsParams: ParticipantsParams{OrganizationID: f.org.ID},
+			Cursor:             cursor,
+			Limit:              3,
+		})
+		if err != nil {
+			t.Fatalf("ListParticipants() error: %v", err)
+		}
+
+		if page.TotalItems != 4 {
+			t.Fatalf("ListParticipants() total = %d, want 4", page.TotalItems)
+		}
+
+		for _, p := range page.Participants {
+			ids = append(ids, p.Id())
+		}
+
+		pages++
+
+		if page.NextCursor == "" {
+			break
+		}
+
+		if pages > 4 {
+			t.Fatalf("ListParticipants() returns next cursor forever")
+		}
+
+		cursor = page.NextCursor
+	}
+
+	if pages != 2 || len(ids) != 4 {
+		t.Fatalf("ListParticipants() returned %d participants in %d pages, want 4 in 2", len(ids), pages)
+	}
+
+	for _, id := range []uuid.UUID{f.owner.ID, f.admin.ID, f.viewer.ID, f.employee.ID} {
+		if !slices.Contains(ids, id) {
+			t.Fatalf("ListParticipants() pages have no participant %s", id)
+		}
+	}
+
+	if _, err := f.interactor.ListParticipants(as(f.viewer), ListParticipantsParams{
+		ParticipantsParams: ParticipantsParams{OrganizationID: f.org.ID},
+		Cursor:             "not a cursor",
+	}); err == nil {
+		t.Fatalf("ListParticipants() with invalid cursor error is nil")
+	}
+
+	if _, err := f.interactor.ListParticipants(as(f.outsider), ListParticipantsParams{
+		ParticipantsParams: ParticipantsParams{OrganizationID: f.org.ID},
+	}); !errors.Is(err, ErrorUnauthorizedAccess) {
+		t.Fatalf("ListParticipants() by not a participant error = %v, want ErrorUnauthorizedAccess", err)
+	}
+}
+
+func TestListParticipantsFilters(t *testing.T) {
+	f := newFixture(t)
+
+	if _, err := f.interactor.DeleteParticipant(as(f.admin), DeleteParticipantParams{
+		OrganizationID: f.org.ID,
+		ID:             f.employee.ID,
+	}); err != nil {
+		t.Fatalf("DeleteParticipant() error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		params ListParticipantsParams
+		want   func(f *fixture) uuid.UUIDs
+	}{
+		{
+			name: "all",
+			want: func(f *fixture) uuid.UUIDs { return uuid.UUIDs{f.owner.ID, f.admin.ID, f.viewer.ID, f.employee.ID} },
+		},
+		{
+			name:   "active",
+			params: ListParticipantsParams{ParticipantsParams: ParticipantsParams{ActiveOnly: true}},
+			want:   func(f *fixture) uuid.UUIDs { return uuid.UUIDs{f.owner.ID, f.admin.ID, f.viewer.ID} },
+		},
+		{
+			name:   "employees",
+			params: ListParticipantsParams{ParticipantsParams: ParticipantsParams{EmployeesOnly: true}},
+			want:   func(f *fixture) uuid.UUIDs { return uuid.UUIDs{f.employee.ID} },
+		},
+		{
+			name:   "owners",
+			params: ListParticipantsParams{ParticipantsParams: ParticipantsParams{OwnerOnly: true}},
+			want:   func(f *fixture) uuid.UUIDs { return uuid.UUIDs{f.owner.ID} },
+		},
+		{
+			name:   "search by position",
+			params: ListParticipantsParams{Search: "AUDIT"},
+			want:   func(f *fixture) uuid.UUIDs { return uuid.UUIDs{f.viewer.ID} },
+		},
+		{
+			name:   "search by name",
+			params: ListParticipantsParams{Search: "emm"},
+			want:   func(f *fixture) uuid.UUIDs { return uuid.UUIDs{f.employee.ID} },
+		},
+		{
+			name:   "nothing found",
+			params: ListParticipantsParams{Search: "nobody"},
+			want:   func(f *fixture) uuid.UUIDs { return nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			params.OrganizationID = f.org.ID
+
+			page, err := f.interactor.ListParticipants(as(f.viewer), params)
+			if err != nil {
+				t.Fatalf("ListParticipants() error: %v", err)
+			}
+
+			want := tt.want(f)
+
+			var got uuid.UUIDs
+
+			for _, p := range page.Participants {
+				got = append(got, p.Id())
+			}
+
+			if len(got) != len(want) || page.TotalItems != int64(len(want)) || page.NextCursor != "" {
+				t.Fatalf("ListParticipants() = %v, total %d, cursor %q, want %v", got, page.TotalItems, page.NextCursor, want)
+			}
+
+			for _, id := range want {
+				if !slices.Contains(got, id) {
+					t.Fatalf("ListParticipants() = %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateOrganization(t *testing.T) {
+	f := newFixture(t)
+
+	org, err := f.interactor.Update(as(f.admin), UpdateParams{
+		OrganizationID: f.org.ID,
+		Address:        "Baker street",
+	})
+	if err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+
+	if org.Name != "Acme" || org.Address != "Baker street" || org.UpdatedAt.IsZero() {
+		t.Fatalf("Update() = %+v", org)
+	}
+
+	if _, err = f.interactor.Update(as(f.viewer), UpdateParams{
+		OrganizationID: f.org.ID,
+		Name:           "Evil corp",
+	}); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("Update() by viewer error = %v, want ErrorPermissionDenied", err)
+	}
+
+	if got := f.repo.orgs[f.org.ID]; got.Name != "Acme" || got.Address != "Baker street" {
+		t.Fatalf("stored organization = %+v", got)
+	}
+}
+
+func TestArchiveOrganization(t *testing.T) {
+	f := newFixture(t)
+
+	archive := func(actor *models.User, archived bool) (*models.Organization, error) {
+		return f.interactor.Archive(as(actor), ArchiveParams{
+			OrganizationID: f.org.ID,
+			Archived:       archived,
+		})
+	}
+
+	if _, err := archive(f.admin, true); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("Archive() by admin error = %v, want ErrorPermissionDenied", err)
+	}
+
+	org, err := archive(f.owner, true)
+	if err != nil {
+		t.Fatalf("Archive() error: %v", err)
+	}
+
+	if !org.IsArchived() {
+		t.Fatalf("Archive() returned not archived organization")
+	}
+
+	// archived organization settings and participants are still managed
+	if _, err = f.interactor.Update(as(f.admin), UpdateParams{
+		OrganizationID: f.org.ID,
+		Name:           "Acme archive",
+	}); err != nil {
+		t.Fatalf("Update() of archived organization error: %v", err)
+	}
+
+	if _, err = f.interactor.UpdateRole(as(f.admin), UpdateRoleParams{
+		OrganizationID: f.org.ID,
+		UserID:         f.viewer.ID,
+		Role:           models.RoleAccountant,
+	}); err != nil {
+		t.Fatalf("UpdateRole() in archived organization error: %v", err)
+	}
+
+	if org, err = archive(f.owner, false); err != nil || org.IsArchived() {
+		t.Fatalf("Archive() restore = %+v, %v", org, err)
+	}
+
+	if org.Name != "Acme archive" {
+		t.Fatalf("Archive() restore lost organization update, name %q", org.Name)
+	}
+}
+
+func TestOwnershipTransfer(t *testing.T) {
+	f := newFixture(t)
+
+	transfer := func(actor *models.User, to uuid.UUID) (*models.OwnershipTransfer, error) {
+		return f.interactor.TransferOwnership(as(actor), TransferOwnershipParams{
+			OrganizationID: f.org.ID,
+			ToUserID:       to,
+		})
+	}
+
+	if _, err := transfer(f.owner, f.owner.ID); !errors.Is(err, ErrorInvalidOwnershipTransfer) {
+		t.Fatalf("TransferOwnership() to self error = %v, want ErrorInvalidOwnershipTransfer", err)
+	}
+
+	if _, err := transfer(f.admin, f.viewer.ID); !errors.Is(err, ErrorInvalidOwnershipTransfer) {
+		t.Fatalf("TransferOwnership() from admin error = %v, want ErrorInvalidOwnershipTransfer", err)
+	}
+
+	if _, err := transfer(f.owner, f.outsider.ID); !errors.Is(err, ErrorParticipantNotFound) {
+		t.Fatalf("TransferOwnership() to not a participant error = %v, want ErrorParticipantNotFound", err)
+	}
+
+	pending, err := transfer(f.owner, f.admin.ID)
+	if err != nil {
+		t.Fatalf("TransferOwnership() error: %v", err)
+	}
+
+	if pending.Status != models.OwnershipTransferStatusPending || pending.FromUserID != f.owner.ID {
+		t.Fatalf("TransferOwnership() = %+v", pending)
+	}
+
+	if _, err = transfer(f.owner, f.viewer.ID); !errors.Is(err, ErrorOwnershipTransferExists) {
+		t.Fatalf("second TransferOwnership() error = %v, want ErrorOwnershipTransferExists", err)
+	}
+
+	params := OwnershipTransferParams{
+		OrganizationID: f.org.ID,
+		ID:             pending.ID,
+	}
+
+	// the new owner does not confirm the transfer
+	if _, err = f.interactor.ConfirmOwnershipTransfer(as(f.admin), params); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("ConfirmOwnershipTransfer() by the new owner error = %v, want ErrorPermissionDenied", err)
+	}
+
+	if _, err = f.interactor.CancelOwnershipTransfer(as(f.viewer), params); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("CancelOwnershipTransfer() by other participant error = %v, want ErrorPermissionDenied", err)
+	}
+
+	confirmed, err := f.interactor.ConfirmOwnershipTransfer(as(f.owner), params)
+	if err != nil {
+		t.Fatalf("ConfirmOwnershipTransfer() error: %v", err)
+	}
+
+	if confirmed.Status != models.OwnershipTransferStatusConfirmed {
+		t.Fatalf("ConfirmOwnershipTransfer() status = %s", confirmed.Status)
+	}
+
+	for user, want := range map[*models.User]models.Role{f.admin: models.RoleOwner, f.owner: models.RoleAdmin} {
+		p, err := f.interactor.Participant(as(f.viewer), ParticipantParams{ID: user.ID, OrganizationID: f.org.ID})
+		if err != nil {
+			t.Fatalf("Participant() error: %v", err)
+		}
+
+		if p.Role() != want {
+			t.Fatalf("%s role after transfer = %s, want %s", user.Name, p.Role(), want)
+		}
+	}
+
+	if _, err = f.interactor.CancelOwnershipTransfer(as(f.owner), params); !errors.Is(err, ErrorOwnershipTransferNotPending) {
+		t.Fatalf("CancelOwnershipTransfer() of confirmed transfer error = %v, want ErrorOwnershipTransferNotPending", err)
+	}
+
+	// the new owner transfers ownership back and the receiver cancels it
+	back, err := transfer(f.admin, f.owner.ID)
+	if err != nil {
+		t.Fatalf("TransferOwnership() back error: %v", err)
+	}
+
+	cancelled, err := f.interactor.CancelOwnershipTransfer(as(f.owner), OwnershipTransferParams{
+		OrganizationID: f.org.ID,
+		ID:             back.ID,
+	})
+	if err != nil {
+		t.Fatalf("CancelOwnershipTransfer() error: %v", err)
+	}
+
+	if cancelled.Status != models.OwnershipTransferStatusCancelled {
+		t.Fatalf("CancelOwnershipTransfer() status = %s", cancelled.Status)
+	}
+
+	transfers, err := f.interactor.ListOwnershipTransfers(as(f.viewer), ListOwnershipTransfersParams{
+		OrganizationID: f.org.ID,
+	})
+	if err != nil {
+		t.Fatalf("ListOwnershipTransfers() error: %v", err)
+	}
+
+	if len(transfers) != 2 || transfers[0].ID != back.ID || transfers[1].ID != pending.ID {
+		t.Fatalf("ListOwnershipTransfers() = %+v", transfers)
+	}
+
+	if transfers, err = f.interactor.ListOwnershipTransfers(as(f.viewer), ListOwnershipTransfersParams{
+		OrganizationID: f.org.ID,
+		PendingOnly:    true,
+	}); err != nil || len(transfers) != 0 {
+		t.Fatalf("ListOwnershipTransfers() pending = %+v, %v, want none", transfers, err)
+	}
+}
@@ -0,0 +1,873 @@
+package payouts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/logger"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/ledger"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/payouts"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/users"
+	"github.com/google/uuid"
+)
+
+const (
+	JobKindPayoutExecute  = "payout_execute"
+	JobKindPayrollDeposit = "payroll_deposit"
+)
+
+var (
+	ErrorPayoutRunNotFound    = errors.New("payout run not found")
+	ErrorPayoutRunNotPending  = errors.New("payout run is not pending")
+	ErrorNoSalariesDue        = errors.New("no salaries due")
+	ErrorInvalidDepositAmount = chain.ErrorInvalidDepositAmount
+)
+
+type CreateRunParams struct {
+	PayrollID uuid.UUID
+	// EmployeeIDs limits payout to the given employees. If empty, all employees with salaries are paid
+	EmployeeIDs uuid.UUIDs
+	// DepositAmount in ETH deposited to the payroll contract before payout
+	DepositAmount money.Amount
+}
+
+type ListRunsParams struct {
+	OrganizationID uuid.UUID
+	IDs            uuid.UUIDs
+	PayrollIDs     uuid.UUIDs
+	Limit          int64
+}
+
+type ConfirmRunParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+type ListPaymentsParams struct {
+	OrganizationID uuid.UUID
+	PayoutRunIDs   uuid.UUIDs
+	PayrollIDs     uuid.UUIDs
+	EmployeeIDs    uuid.UUIDs
+	Limit          int64
+}
+
+type DepositParams struct {
+	PayrollID uuid.UUID
+	// Amount in ETH
+	Amount money.Amount
+}
+
+type PayoutsInteractor interface {
+	// CreateRun snapshots salaries due for the payroll. Run creator confirmation is added right away
+	CreateRun(ctx context.Context, params CreateRunParams) (*models.PayoutRun, error)
+	ListRuns(ctx context.Context, params ListRunsParams) ([]*models.PayoutRun, error)
+	// ConfirmRun adds multisig owner confirmation. Once confirmations required by the payroll multisig
+	// are collected, run is executed in background
+	ConfirmRun(ctx context.Context, params ConfirmRunParams) (*models.PayoutRun, error)
+
+	ListPayments(ctx context.Context, params ListPaymentsParams) ([]models.Payment, error)
+
+	Deposit(ctx context.Context, params DepositParams) (*models.Job, error)
+}
+
+type payoutsInteractor struct {
+	log                     *slog.Logger
+	payoutsRepo             payouts.Repository
+	txRepo                  transactions.Repository
+	usersRepo               users.Repository
+	orgInteractor           organizations.OrganizationsInteractor
+	chainInteractor         chain.ChainInteractor
+	jobsInteractor          jobs.JobsInteractor
+	confirmationsInteractor confirmations.ConfirmationsInteractor
+	ledgerInteractor        ledger.LedgerInteractor
+	authorizer              authorizer.Authorizer
+}
+
+func NewPayoutsInteractor(
+	log *slog.Logger,
+	payoutsRepo payouts.Repository,
+	txRepo transactions.Repository,
+	usersRepo users.Repository,
+	orgInteractor organizations.OrganizationsInteractor,
+	chainInteractor chain.ChainInteractor,
+	jobsInteractor jobs.JobsInteractor,
+	confirmationsInteractor confirmations.ConfirmationsInteractor,
+	ledgerInteractor ledger.LedgerInteractor,
+	authorizer authorizer.Authorizer,
+) PayoutsInteractor {
+	i := &payoutsInteractor{
+		log:                     log,
+		payoutsRepo:             payoutsRepo,
+		txRepo:                  txRepo,
+		usersRepo:               usersRepo,
+		orgInteractor:           orgInteractor,
+		chainInteractor:         chainInteractor,
+		jobsInteractor:          jobsInteractor,
+		confirmationsInteractor: confirmationsInteractor,
+		ledgerInteractor:        ledgerInteractor,
+		authorizer:              authorizer,
+	}
+
+	jobsInteractor.RegisterHandler(JobKindPayoutExecute, i.executeRunJob)
+	jobsInteractor.RegisterHandler(JobKindPayrollDeposit, i.depositJob)
+
+	confirmationsInteractor.RegisterEntity(models.MultisigConfirmationEntityTypePayoutRun, confirmations.EntityHandler{
+		Entity:     i.confirmationEntity,
+		Confirmed:  i.confirmed,
+		Permission: models.PermissionPayoutConfirm,
+	})
+
+	return i
+}
+
+func (i *payoutsInteractor) CreateRun(ctx context.Context, params CreateRunParams) (*models.PayoutRun, error) {
+	user, err := ctxmeta.User(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch user from context. %w", err)
+	}
+
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	if params.DepositAmount.Sign() < 0 {
+		return nil, ErrorInvalidDepositAmount
+	}
+
+	if params.DepositAmount.IsPositive() {
+		if _, err = params.DepositAmount.Wei(); err != nil {
+			return nil, errors.Join(ErrorInvalidDepositAmount, err)
+		}
+	}
+
+	actor, err := i.authorizer.Authorize(ctx, organizationID, models.PermissionPayoutCreate)
+	if err != nil {
+		return nil, err
+	}
+
+	payroll, multisig, err := i.payrollWithMultisig(ctx, organizationID, params.PayrollID)
+	if err != nil {
+		return nil, err
+	}
+
+	// run creator submits payouts to the multisig, so the creator must be one of the owners
+	if !isMultisigOwner(multisig, user.Id()) {
+		return nil, chain.ErrorNotMultisigOwner
+	}
+
+	salaries, err := i.chainInteractor.ListSalaries(ctx, chain.ListSalariesParams{
+		OrganizationID: organizationID,
+		PayrollIDs:     uuid.UUIDs{payroll.ID},
+		EmployeeIDs:    params.EmployeeIDs,
+		Limit:          1000,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch payroll salaries. %w", err)
+	}
+
+	createdAt := time.Now()
+
+	run := models.PayoutRun{
+		ID:             uuid.Must(uuid.NewV7()),
+		OrganizationID: organizationID,
+		PayrollID:      payroll.ID,
+		MultisigID:     multisig.ID,
+		Status:         models.PayoutRunStatusPending,
+		DepositAmount:  params.DepositAmount,
+		CreatedBy:      user.Id(),
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	}
+
+	// salaries are sorted by creation date desc, so the first salary is the actual one
+	seen := make(map[uuid.UUID]struct{}, len(salaries))
+
+	for _, s := range salaries {
+		if _, ok := seen[s.EmployeeID]; ok {
+			continue
+		}
+
+		// only salaries set on-chain are paid by the payroll contract
+		if s.Status != models.SalaryStatusExecuted {
+			continue
+		}
+
+		seen[s.EmployeeID] = struct{}{}
+
+		run.Payments = append(run.Payments, models.Payment{
+			ID:              uuid.Must(uuid.NewV7()),
+			PayoutRunID:     run.ID,
+			OrganizationID:  organizationID,
+			PayrollID:       payroll.ID,
+			SalaryID:        s.ID,
+			EmployeeID:      s.EmployeeID,
+			EmployeeAddress: s.EmployeeAddress,
+			Amount:          s.Amount,
+			Status:          models.PaymentStatusPending,
+			CreatedAt:       createdAt,
+			UpdatedAt:       createdAt,
+		})
+	}
+
+	if len(run.Payments) == 0 {
+		return nil, ErrorNoSalariesDue
+	}
+
+	if err = i.payoutsRepo.CreateRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("error create payout run. %w", err)
+	}
+
+	// creator without confirm permission leaves the run awaiting owners confirmations
+	if !i.authorizer.Can(actor, models.PermissionPayoutConfirm) {
+		return i.run(ctx, organizationID, run.ID)
+	}
+
+	return i.ConfirmRun(ctx, ConfirmRunParams{
+		ID:             run.ID,
+		OrganizationID: organizationID,
+	})
+}
+
+func (i *payoutsInteractor) ListRuns(ctx context.Context, params ListRunsParams) ([]*models.PayoutRun, error) {
+	runs, err := i.payoutsRepo.ListRuns(ctx, payouts.ListRunsParams{
+		IDs:            params.IDs,
+		OrganizationID: params.OrganizationID,
+		PayrollIDs:     params.PayrollIDs,
+		Limit:          params.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch payout runs. %w", err)
+	}
+
+	if len(runs) == 0 {
+		return runs, nil
+	}
+
+	ids := make(uuid.UUIDs, len(runs))
+
+	for i, run := range runs {
+		ids[i] = run.ID
+	}
+
+	confirmations, err := i.txRepo.MultisigConfirmations(ctx, transactions.MultisigConfirmationsParams{
+		EntityIDs:  ids,
+		EntityType: models.MultisigConfirmationEntityTypePayoutRun,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch payout runs confirmations. %w", err)
+	}
+
+	for _, run := range runs {
+		run.ConfirmedBy = confirmations[run.ID]
+		run.Confirmations = len(run.ConfirmedBy)
+	}
+
+	return runs, nil
+}
+
+func (i *payoutsInteractor) run(ctx context.Context, organizationID, id uuid.UUID) (*models.PayoutRun, error) {
+	runs, err := i.ListRuns(ctx, ListRunsParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{id},
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(runs) == 0 {
+		return nil, ErrorPayoutRunNotFound
+	}
+
+	return runs[0], nil
+}
+
+type executeRunPayload struct {
+	PayoutRunID uuid.UUID `json:"payout_run_id"`
+}
+
+func (i *payoutsInteractor) ConfirmRun(ctx context.Context, params ConfirmRunParams) (*models.PayoutRun, error) {
+	if _, err := i.confirmationsInteractor.Confirm(ctx, confirmations.ConfirmParams{
+		OrganizationID: params.OrganizationID,
+		EntityType:     models.MultisigConfirmationEntityTypePayoutRun,
+		EntityID:       params.ID,
+	}); err != nil {
+		if errors.Is(err, confirmations.ErrorEntityNotPending) {
+			return nil, ErrorPayoutRunNotPending
+		}
+
+		return nil, err
+	}
+
+	return i.run(ctx, params.OrganizationID, params.ID)
+}
+
+func (i *payoutsInteractor) confirmationEntity(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*confirmations.Entity, error) {
+	run, err := i.run(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &confirmations.Entity{
+		ID:             run.ID,
+		OrganizationID: run.OrganizationID,
+		MultisigID:     run.MultisigID,
+		Pending:        run.Status == models.PayoutRunStatusPending,
+	}, nil
+}
+
+// confirmed starts execution of the payout run confirmed by the payroll multisig owners
+func (i *payoutsInteractor) confirmed(ctx context.Context, entity *confirmations.Entity) error {
+	if err := i.payoutsRepo.UpdateRun(ctx, payouts.UpdateRunParams{
+		ID:             entity.ID,
+		OrganizationID: entity.OrganizationID,
+		Status:         models.PayoutRunStatusConfirmed,
+		FromStatuses:   []models.PayoutRunStatus{models.PayoutRunStatusPending},
+		UpdatedAt:      time.Now(),
+	}); err != nil {
+		if errors.Is(err, payouts.ErrorRunStatusConflict) {
+			return confirmations.ErrorEntityStatusConflict
+		}
+
+		return fmt.Errorf("error mark payout run as confirmed. %w", err)
+	}
+
+	if _, err := i.jobsInteractor.Enqueue(ctx, jobs.EnqueueParams{
+		Kind:           JobKindPayoutExecute,
+		OrganizationID: entity.OrganizationID,
+		Payload: executeRunPayload{
+			PayoutRunID: entity.ID,
+		},
+	}); err != nil {
+		return fmt.Errorf("error enqueue payout run execution. %w", err)
+	}
+
+	return nil
+}
+
+// executeRunJob deposits payroll contract if needed, then submits payout for every employee to the
+// payroll multisig, confirms it on behalf of the owners who confirmed the run and executes it.
+// Every step is saved, so retried job continues from the failed step.
+func (i *payoutsInteractor) executeRunJob(ctx context.Context, job *models.Job) (result any, err error) {
+	var payload executeRunPayload
+
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("error unmarshal job payload. %w", err))
+	}
+
+	run, err := i.run(ctx, job.OrganizationID, payload.PayoutRunID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	if run.Status == models.PayoutRunStatusExecuted {
+		return payoutRunResult(run), nil
+	}
+
+	log := i.log.With(slog.String("payout run id", run.ID.String()))
+
+	defer func() {
+		if !jobs.Failed(job, err) {
+			return
+		}
+
+		if fErr := i.failRun(ctx, run); fErr != nil {
+			err = errors.Join(err, fErr)
+		}
+	}()
+
+	payroll, multisig, err := i.payrollWithMultisig(ctx, job.OrganizationID, run.PayrollID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	submitter, confirmers, err := i.signers(ctx, run, multisig)
+	if err != nil {
+		return nil, err
+	}
+
+	if run.DepositAmount.IsPositive() && run.DepositTxHash == "" {
+		if run.DepositTxHash, err = i.deposit(ctx, depositParams{
+			Signer:      submitter,
+			Payroll:     payroll,
+			PayoutRunID: run.ID,
+			Amount:      run.DepositAmount,
+		}); err != nil {
+			return nil, err
+		}
+
+		if err = i.payoutsRepo.UpdateRun(ctx, payouts.UpdateRunParams{
+			ID:             run.ID,
+			OrganizationID: run.OrganizationID,
+			Status:         run.Status,
+			DepositTxHash:  run.DepositTxHash,
+			UpdatedAt:      time.Now(),
+		}); err != nil {
+			return nil, fmt.Errorf("error save payout run deposit. %w", err)
+		}
+	}
+
+	for idx := range run.Payments {
+		payment := &run.Payments[idx]
+
+		if err = i.pay(ctx, payment, payroll, multisig, submitter, confirmers); err != nil {
+			return nil, fmt.Errorf("error pay employee %s. %w", payment.EmployeeID, err)
+		}
+
+		log.Info(
+			"employee paid",
+			slog.String("employee id", payment.EmployeeID.String()),
+			slog.String("tx hash", payment.TxHash),
+		)
+	}
+
+	run.Status = models.PayoutRunStatusExecuted
+	run.ExecutedAt = time.Now()
+
+	if err = i.payoutsRepo.UpdateRun(ctx, payouts.UpdateRunParams{
+		ID:             run.ID,
+		OrganizationID: run.OrganizationID,
+		Status:         run.Status,
+		ExecutedAt:     run.ExecutedAt,
+		UpdatedAt:      run.ExecutedAt,
+	}); err != nil {
+		return nil, fmt.Errorf("error mark payout run as executed. %w", err)
+	}
+
+	return payoutRunResult(run), nil
+}
+
+// pay moves payment through submitted -> confirmed -> paid states
+func (i *payoutsInteractor) pay(
+	ctx context.Context,
+	payment *models.Payment,
+	payroll *models.Payroll,
+	multisig *models.Multisig,
+	submitter *models.User,
+	confirmers []*models.User,
+) error {
+	if payment.Status == models.PaymentStatusPending {
+		submitted, err := i.chainInteractor.PayrollPayout(ctx, chain.PayrollPayoutParams{
+			Signer:          submitter,
+			MultisigAddress: multisig.Address,
+			PayrollAddress:  payroll.Address,
+			EmployeeAddress: payment.EmployeeAddress,
+		})
+		if err != nil {
+			return err
+		}
+
+		payment.Status = models.PaymentStatusSubmitted
+		payment.TxIndex = submitted.TxIndex
+
+		if err = i.payoutsRepo.UpdatePayment(ctx, payouts.UpdatePaymentParams{
+			ID:        payment.ID,
+			Status:    payment.Status,
+			TxIndex:   payment.TxIndex,
+			UpdatedAt: time.Now(),
+		}); err != nil {
+			return fmt.Errorf("error save submitted payment. %w", err)
+		}
+	}
+
+	if payment.Status == models.PaymentStatusSubmitted {
+		for _, confirmer := range confirmers {
+			if _, err := i.chainInteractor.MultisigConfirm(ctx, chain.MultisigTxParams{
+				Signer:          confirmer,
+				MultisigAddress: multisig.Address,
+				TxIndex:         payment.TxIndex,
+			}); err != nil {
+				return err
+			}
+		}
+
+		payment.Status = models.PaymentStatusConfirmed
+
+		if err := i.payoutsRepo.UpdatePayment(ctx, payouts.UpdatePaymentParams{
+			ID:        payment.ID,
+			Status:    payment.Status,
+			UpdatedAt: time.Now(),
+		}); err != nil {
+			return fmt.Errorf("error save confirmed payment. %w", err)
+		}
+	}
+
+	if payment.Status == models.PaymentStatusConfirmed {
+		txHash, err := i.chainInteractor.MultisigExecute(ctx, chain.MultisigTxParams{
+			Signer:          submitter,
+			MultisigAddress: multisig.Address,
+			TxIndex:         payment.TxIndex,
+		})
+		if err != nil {
+			return err
+		}
+
+		payment.Status = models.PaymentStatusPaid
+		payment.TxHash = txHash
+		payment.PaidAt = time.Now()
+
+		if err = i.payoutsRepo.UpdatePayment(ctx, payouts.UpdatePaymentParams{
+			ID:        payment.ID,
+			Status:    payment.Status,
+			TxHash:    payment.TxHash,
+			PaidAt:    payment.PaidAt,
+			UpdatedAt: payment.PaidAt,
+		}); err != nil {
+			return fmt.Errorf("error save paid payment. %w", err)
+		}
+
+		i.post(ctx, ledger.PostParams{
+			OrganizationID: payment.OrganizationID,
+			Description:    "Salary payout",
+			SourceType:     models.LedgerEntrySourcePayment,
+			SourceID:       payment.ID,
+			OccurredAt:     payment.PaidAt,
+			CreatedBy:      submitter.Id(),
+			Lines: []ledger.PostLine{
+				ledger.DebitLine(models.LedgerAccountSalaries, payment.Amount),
+				ledger.CreditLine(models.LedgerAccountPayrolls, payment.Amount),
+			},
+		})
+	}
+
+	return nil
+}
+
+// signers returns run creator, who submits and executes multisig transactions,
+// and owners whose confirmations are sent on-chain
+func (i *payoutsInteractor) signers(
+	ctx context.Context,
+	run *models.PayoutRun,
+	multisig *models.Multisig,
+) (*models.User, []*models.User, error) {
+	confirmedBy := run.ConfirmedBy
+	if len(confirmedBy) > multisig.ConfirmationsRequired {
+		confirmedBy = confirmedBy[:multisig.ConfirmationsRequired]
+	}
+
+	usersList, err := i.usersRepo.Get(ctx, users.GetParams{
+		Ids: append(uuid.UUIDs{run.CreatedBy}, confirmedBy...),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetch payout run signers. %w", err)
+	}
+
+	usersMap := make(map[uuid.UUID]*models.User, len(usersList))
+
+	for _, u := range usersList {
+		usersMap[u.Id()] = u
+	}
+
+	submitter, ok := usersMap[run.CreatedBy]
+	if !ok {
+		return nil, nil, jobs.Permanent(fmt.Errorf("error payout run creator not found"))
+	}
+
+	confirmers := make([]*models.User, 0, len(confirmedBy))
+
+	for _, id := range confirmedBy {
+		u, ok := usersMap[id]
+		if !ok {
+			return nil, nil, jobs.Permanent(fmt.Errorf("error payout run confirmer %s not found", id))
+		}
+
+		confirmers = append(confirmers, u)
+	}
+
+	if len(confirmers) < multisig.ConfirmationsRequired {
+		return nil, nil, jobs.Permanent(fmt.Errorf("error not enough payout run confirmations"))
+	}
+
+	return submitter, confirmers, nil
+}
+
+func (i *payoutsInteractor) failRun(ctx context.Context, run *models.PayoutRun) error {
+	updatedAt := time.Now()
+
+	for _, p := range run.Payments {
+		if p.Status == models.PaymentStatusPaid {
+			continue
+		}
+
+		if err := i.payoutsRepo.UpdatePayment(ctx, payouts.UpdatePaymentParams{
+			ID:        p.ID,
+			Status:    models.PaymentStatusFailed,
+			UpdatedAt: updatedAt,
+		}); err != nil {
+			return fmt.Errorf("error mark payment as failed. %w", err)
+		}
+	}
+
+	if err := i.payoutsRepo.UpdateRun(ctx, payouts.UpdateRunParams{
+		ID:             run.ID,
+		OrganizationID: run.OrganizationID,
+		Status:         models.PayoutRunStatusFailed,
+		UpdatedAt:      updatedAt,
+	}); err != nil {
+		return fmt.Errorf("error mark payout run as failed. %w", err)
+	}
+
+	i.log.Error(
+		"payout run failed",
+		slog.String("payout run id", run.ID.String()),
+	)
+
+	return nil
+}
+
+type PayoutRunResult struct {
+	PayoutRunID uuid.UUID         `json:"payout_run_id"`
+	Payments    map[string]string `json:"payments"`
+}
+
+func payoutRunResult(run *models.PayoutRun) PayoutRunResult {
+	res := PayoutRunResult{
+		PayoutRunID: run.ID,
+		Payments:    make(map[string]string, len(run.Payments)),
+	}
+
+	for _, p := range run.Payments {
+		res.Payments[p.EmployeeID.String()] = p.TxHash
+	}
+
+	return res
+}
+
+func (i *payoutsInteractor) ListPayments(
+	ctx context.Context,
+	params ListPaymentsParams,
+) ([]models.Payment, error) {
+	if params.Limit <= 0 {
+		params.Limit = 100
+	}
+
+	payments, err := i.payoutsRepo.ListPayments(ctx, payouts.ListPaymentsParams{
+		OrganizationID: params.OrganizationID,
+		PayoutRunIDs:   params.PayoutRunIDs,
+		PayrollIDs:     params.PayrollIDs,
+		EmployeeIDs:    params.EmployeeIDs,
+		Limit:          params.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch payments. %w", err)
+	}
+
+	return payments, nil
+}
+
+type depositPayload struct {
+	PayrollID uuid.UUID `json:"payroll_id"`
+	// Amount in ETH
+	Amount money.Decimal `json:"amount"`
+}
+
+type DepositResult struct {
+	PayrollID uuid.UUID `json:"payroll_id"`
+	TxHash    string    `json:"tx_hash"`
+}
+
+func (i *payoutsInteractor) Deposit(ctx context.Context, params DepositParams) (*models.Job, error) {
+	organizationID, err := ctxmeta.OrganizationId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch organization id from context. %w", err)
+	}
+
+	if !params.Amount.IsPositive() {
+		return nil, ErrorInvalidDepositAmount
+	}
+
+	if _, err = params.Amount.Wei(); err != nil {
+		return nil, errors.Join(ErrorInvalidDepositAmount, err)
+	}
+
+	if _, err = i.authorizer.Authorize(ctx, organizationID, models.PermissionPayoutCreate); err != nil {
+		return nil, err
+	}
+
+	if _, _, err = i.payrollWithMultisig(ctx, organizationID, params.PayrollID); err != nil {
+		return nil, err
+	}
+
+	job, err := i.jobsInteractor.Enqueue(ctx, jobs.EnqueueParams{
+		Kind:           JobKindPayrollDeposit,
+		OrganizationID: organizationID,
+		// deposit is not idempotent, retry could send funds twice
+		MaxAttempts: 1,
+		Payload: depositPayload{
+			PayrollID: params.PayrollID,
+			Amount:    params.Amount.Value,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error enqueue payroll deposit job. %w", err)
+	}
+
+	return job, nil
+}
+
+func (i *payoutsInteractor) depositJob(ctx context.Context, job *models.Job) (any, error) {
+	var payload depositPayload
+
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("error unmarshal job payload. %w", err))
+	}
+
+	payroll, _, err := i.payrollWithMultisig(ctx, job.OrganizationID, payload.PayrollID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	signers, err := i.usersRepo.Get(ctx, users.GetParams{
+		Ids: uuid.UUIDs{job.CreatedBy},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch job creator. %w", err)
+	}
+
+	if len(signers) == 0 {
+		return nil, jobs.Permanent(fmt.Errorf("error job creator not found"))
+	}
+
+	txHash, err := i.deposit(ctx, depositParams{
+		Signer:  signers[0],
+		Payroll: payroll,
+		Amount:  money.New(payload.Amount, money.ETH),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return DepositResult{
+		PayrollID: payroll.ID,
+		TxHash:    txHash,
+	}, nil
+}
+
+type depositParams struct {
+	Signer      *models.User
+	Payroll     *models.Payroll
+	PayoutRunID uuid.UUID
+	Amount      money.Amount
+}
+
+func (i *payoutsInteractor) deposit(ctx context.Context, params depositParams) (string, error) {
+	txHash, err := i.chainInteractor.PayrollDeposit(ctx, chain.PayrollDepositParams{
+		Signer:         params.Signer,
+		PayrollAddress: params.Payroll.Address,
+		Amount:         params.Amount,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	deposit := models.PayrollDeposit{
+		ID:             uuid.Must(uuid.NewV7()),
+		OrganizationID: params.Payroll.OrganizationID,
+		PayrollID:      params.Payroll.ID,
+		PayoutRunID:    params.PayoutRunID,
+		Amount:         params.Amount,
+		TxHash:         txHash,
+		CreatedBy:      params.Signer.Id(),
+		CreatedAt:      time.Now(),
+	}
+
+	// deposit is already sent, so failing to save it must not lead to retry
+	if err = i.payoutsRepo.AddDeposit(ctx, deposit); err != nil {
+		i.log.Error(
+			"error save payroll deposit",
+			slog.String("tx hash", txHash),
+			logger.Err(err),
+		)
+	}
+
+	i.post(ctx, ledger.PostParams{
+		OrganizationID: deposit.OrganizationID,
+		Description:    "Payroll deposit",
+		SourceType:     models.LedgerEntrySourcePayrollDeposit,
+		SourceID:       deposit.ID,
+		OccurredAt:     deposit.CreatedAt,
+		CreatedBy:      deposit.CreatedBy,
+		Lines: []ledger.PostLine{
+			ledger.DebitLine(models.LedgerAccountPayrolls, deposit.Amount),
+			ledger.CreditLine(models.LedgerAccountContributions, deposit.Amount),
+		},
+	})
+
+	return txHash, nil
+}
+
+// post posts funds moved on-chain to the ledger. Funds are already moved, so posting failure is only logged
+func (i *payoutsInteractor) post(ctx context.Context, params ledger.PostParams) {
+	if _, err := i.ledgerInteractor.Post(ctx, params); err != nil && !errors.Is(err, ledger.ErrorEntryExists) {
+		i.log.Error(
+			"error post to ledger",
+			slog.String("source type", string(params.SourceType)),
+			slog.String("source id", params.SourceID.String()),
+			logger.Err(err),
+		)
+	}
+}
+
+func (i *payoutsInteractor) payrollWithMultisig(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	payrollID uuid.UUID,
+) (*models.Payroll, *models.Multisig, error) {
+	payrolls, err := i.chainInteractor.ListPayrolls(ctx, chain.ListPayrollsParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{payrollID},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetch payroll. %w", err)
+	}
+
+	if len(payrolls) == 0 {
+		return nil, nil, chain.ErrorPayrollNotFound
+	}
+
+	if payrolls[0].Status != models.PayrollStatusDeployed {
+		return nil, nil, chain.ErrorPayrollNotDeployed
+	}
+
+	multisigs, err := i.chainInteractor.ListMultisigs(ctx, chain.ListMultisigsParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{payrolls[0].MultisigID},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetch payroll multisig. %w", err)
+	}
+
+	if len(multisigs) == 0 {
+		return nil, nil, fmt.Errorf("error payroll multisig not found")
+	}
+
+	return &payrolls[0], &multisigs[0], nil
+}
+
+func isMultisigOwner(multisig *models.Multisig, userID uuid.UUID) bool {
+	for _, owner := range multisig.Owners {
+		if owner.Id() == userID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,493 @@
+package payouts
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/payouts"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
+	"github.com/google/uuid"
+)
+
+// memoryOrganizations keeps a single organization and its participants in memory
+type memoryOrganizations struct {
+	organizations.Repository
+
+	org          *models.Organization
+	participants []models.OrganizationParticipant
+}
+
+func (r *memoryOrganizations) Get(_ context.Context, params organizations.GetParams) ([]*models.Organization, error) {
+	if !slices.Contains(params.Ids, r.org.ID) {
+		return nil, nil
+	}
+
+	return []*models.Organization{r.org}, nil
+}
+
+func (r *memoryOrganizations) Participants(
+	_ context.Context,
+	params organizations.ParticipantsParams,
+) ([]models.OrganizationParticipant, error) {
+	var participants []models.OrganizationParticipant
+
+	for _, p := range r.participants {
+		if params.OrganizationId != r.org.ID || !slices.Contains(params.Ids, p.Id()) {
+			continue
+		}
+
+		participants = append(participants, p)
+	}
+
+	if len(participants) == 0 {
+		return nil, organizations.ErrorNotFound
+	}
+
+	return participants, nil
+}
+
+// memoryRuns keeps payout runs in memory
+type memoryRuns struct {
+	payouts.Repository
+
+	mu   sync.Mutex
+	runs []*models.PayoutRun
+}
+
+func (r *memoryRuns) CreateRun(_ context.Context, run models.PayoutRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.runs = append(r.runs, &run)
+
+	return nil
+}
+
+func (r *memoryRuns) ListRuns(_ context.Context, params payouts.ListRunsParams) ([]*models.PayoutRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var runs []*models.PayoutRun
+
+	for _, run := range r.runs {
+		if run.OrganizationID == params.OrganizationID && slices.Contains(params.IDs, run.ID) {
+			copied := *run
+			runs = append(runs, &copied)
+		}
+	}
+
+	return runs, nil
+}
+
+func (r *memoryRuns) UpdateRun(_ context.Context, params payouts.UpdateRunParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, run := range r.runs {
+		if run.ID != params.ID || run.OrganizationID != params.OrganizationID {
+			continue
+		}
+
+		if len(params.FromStatuses) > 0 && !slices.Contains(params.FromStatuses, run.Status) {
+			return payouts.ErrorRunStatusConflict
+		}
+
+		run.Status = params.Status
+
+		return nil
+	}
+
+	return payouts.ErrorRunStatusConflict
+}
+
+type decisionKey struct {
+	entityID uuid.UUID
+	ownerID  uuid.UUID
+}
+
+// memoryMultisigs keeps multisigs and owner decisions in memory
+type memoryMultisigs struct {
+	transactions.Repository
+
+	mu        sync.Mutex
+	multisigs []models.Multisig
+	decisions map[decisionKey]bool
+}
+
+func (r *memoryMultisigs) ListMultisig(
+	_ context.Context,
+	params transactions.ListMultisigsParams,
+) ([]models.Multisig, error) {
+	var multisigs []models.Multisig
+
+	for _, m := range r.multisigs {
+		if m.OrganizationID == params.OrganizationID && slices.Contains(params.IDs, m.ID) {
+			multisigs = append(multisigs, m)
+		}
+	}
+
+	return multisigs, nil
+}
+
+func (r *memoryMultisigs) ConfirmMultisig(_ context.Context, params transactions.ConfirmMultisigParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decisions[decisionKey{params.EntityID, params.CinfirmedBy.Id()}] = params.Rejected
+
+	return nil
+}
+
+func (r *memoryMultisigs) MultisigConfirmations(
+	_ context.Context,
+	params transactions.MultisigConfirmationsParams,
+) (map[uuid.UUID]uuid.UUIDs, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	owners := make(map[uuid.UUID]uuid.UUIDs)
+
+	for key, rejected := range r.decisions {
+		if rejected == params.Rejected && slices.Contains(params.EntityIDs, key.entityID) {
+			owners[key.entityID] = append(owners[key.entityID], key.ownerID)
+		}
+	}
+
+	return owners, nil
+}
+
+func (r *memoryMultisigs) MultisigConfirmationsCount(
+	_ context.Context,
+	params transactions.MultisigConfirmationsCountParams,
+) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int
+
+	for key, rejected := range r.decisions {
+		if key.entityID == params.EntityID && !rejected {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// memoryChain keeps payrolls and salaries in memory and lists multisigs of the transactions repository
+type memoryChain struct {
+	chain.ChainInteractor
+
+	txRepo   *memoryMultisigs
+	payrolls []models.Payroll
+	// salaries are sorted by creation date desc
+	salaries []models.Salary
+}
+
+func (i *memoryChain) ListPayrolls(_ context.Context, params chain.ListPayrollsParams) ([]models.Payroll, error) {
+	var payrolls []models.Payroll
+
+	for _, p := range i.payrolls {
+		if p.OrganizationID == params.OrganizationID && slices.Contains(params.IDs, p.ID) {
+			payrolls = append(payrolls, p)
+		}
+	}
+
+	return payrolls, nil
+}
+
+func (i *memoryChain) ListSalaries(_ context.Context, params chain.ListSalariesParams) ([]models.Salary, error) {
+	var salaries []models.Salary
+
+	for _, s := range i.salaries {
+		if !slices.Contains(params.PayrollIDs, s.PayrollID) ||
+			(len(params.EmployeeIDs) > 0 && !slices.Contains(params.EmployeeIDs, s.EmployeeID)) {
+			continue
+		}
+
+		salaries = append(salaries, s)
+	}
+
+	return salaries, nil
+}
+
+func (i *memoryChain) ListMultisigs(ctx context.Context, params chain.ListMultisigsParams) ([]models.Multisig, error) {
+	return i.txRepo.ListMultisig(ctx, transactions.ListMultisigsParams{
+		OrganizationID: params.OrganizationID,
+		IDs:            params.IDs,
+	})
+}
+
+// memoryJobs records enqueued jobs without running them
+type memoryJobs struct {
+	jobs.JobsInteractor
+
+	enqueued []jobs.EnqueueParams
+}
+
+func (i *memoryJobs) RegisterHandler(string, jobs.Handler) {}
+
+func (i *memoryJobs) Enqueue(_ context.Context, params jobs.EnqueueParams) (*models.Job, error) {
+	i.enqueued = append(i.enqueued, params)
+
+	return &models.Job{ID: uuid.New(), Kind: params.Kind}, nil
+}
+
+type fixture struct {
+	interactor PayoutsInteractor
+	jobs       *memoryJobs
+	orgID      uuid.UUID
+	payrollID  uuid.UUID
+	employees  uuid.UUIDs
+
+	// multisig owners, 2 confirmations are required
+	owner           *models.OrganizationUser
+	approver        *models.OrganizationUser
+	ownerAccountant *models.OrganizationUser
+	// accountant is not an owner of the payroll multisig
+	accountant *models.OrganizationUser
+}
+
+func newOrganizationUser(role models.Role) *models.OrganizationUser {
+	return &models.OrganizationUser{
+		User: models.User{
+			ID:        uuid.New(),
+			Activated: true,
+		},
+		OrgRole: role,
+	}
+}
+
+func usd(s string) money.Amount {
+	return money.New(money.MustParseDecimal(s), money.USD)
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := &fixture{
+		jobs:            &memoryJobs{},
+		orgID:           uuid.New(),
+		payrollID:       uuid.New(),
+		employees:       uuid.UUIDs{uuid.New(), uuid.New(), uuid.New()},
+		owner:           newOrganizationUser(models.RoleOwner),
+		approver:        newOrganizationUser(models.RoleApprover),
+		ownerAccountant: newOrganizationUser(models.RoleAccountant),
+		accountant:      newOrganizationUser(models.RoleAccountant),
+	}
+
+	multisig := models.Multisig{
+		ID:                    uuid.New(),
+		OrganizationID:        f.orgID,
+		Owners:                []models.OrganizationParticipant{f.owner, f.approver, f.ownerAccountant},
+		ConfirmationsRequired: 2,
+	}
+
+	txRepo := &memoryMultisigs{
+		multisigs: []models.Multisig{multisig},
+		decisions: make(map[decisionKey]bool),
+	}
+
+	salary := func(employee uuid.UUID, amount string, status models.SalaryStatus) models.Salary {
+		return models.Salary{
+			ID:             uuid.New(),
+			OrganizationID: f.orgID,
+			PayrollID:      f.payrollID,
+			EmployeeID:     employee,
+			Amount:         usd(amount),
+			Status:         status,
+		}
+	}
+
+	chainInteractor := &memoryChain{
+		txRepo: txRepo,
+		payrolls: []models.Payroll{{
+			ID:             f.payrollID,
+			OrganizationID: f.orgID,
+			MultisigID:     multisig.ID,
+			Status:         models.PayrollStatusDeployed,
+		}},
+		salaries: []models.Salary{
+			salary(f.employees[0], "1200", models.SalaryStatusExecuted),
+			salary(f.employees[1], "900", models.SalaryStatusPending),
+			salary(f.employees[0], "1000", models.SalaryStatusExecuted),
+			salary(f.employees[2], "800", models.SalaryStatusExecuted),
+		},
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	a := authorizer.NewAuthorizer(log, &memoryOrganizations{
+		org: &models.Organization{ID: f.orgID},
+		participants: []models.OrganizationParticipant{
+			f.owner, f.approver, f.ownerAccountant, f.accountant,
+		},
+	})
+
+	f.interactor = NewPayoutsInteractor(
+		log,
+		&memoryRuns{},
+		txRepo,
+		nil,
+		nil,
+		chainInteractor,
+		f.jobs,
+		confirmations.NewConfirmationsInteractor(log, txRepo, a),
+		nil,
+		a,
+	)
+
+	return f
+}
+
+func (f *fixture) as(user *models.OrganizationUser) context.Context {
+	return ctxmeta.OrganizationIdContext(ctxmeta.UserContext(context.Background(), &user.User), f.orgID)
+}
+
+func TestCreateRun(t *testing.T) {
+	f := newFixture(t)
+
+	run, err := f.interactor.CreateRun(f.as(f.ownerAccountant), CreateRunParams{PayrollID: f.payrollID})
+	if err != nil {
+		t.Fatalf("CreateRun() error: %v", err)
+	}
+
+	// creator can not confirm the run, so it awaits owners confirmations
+	if run.Status != models.PayoutRunStatusPending || run.Confirmations != 0 || run.CreatedBy != f.ownerAccountant.ID {
+		t.Fatalf("CreateRun() = %+v, want pending run without confirmations", run)
+	}
+
+	// only actual salaries set on-chain are paid
+	if len(run.Payments) != 2 {
+		t.Fatalf("CreateRun() payments = %+v, want 2 payments", run.Payments)
+	}
+
+	for i, want := range []struct {
+		employee uuid.UUID
+		amount   string
+	}{
+		{f.employees[0], "1200"},
+		{f.employees[2], "800"},
+	} {
+		p := run.Payments[i]
+
+		if p.EmployeeID != want.employee || p.Amount.String() != usd(want.amount).String() ||
+			p.PayoutRunID != run.ID || p.Status != models.PaymentStatusPending {
+			t.Fatalf("CreateRun() payment %d = %+v, want %s to %s", i, p, want.amount, want.employee)
+		}
+	}
+
+	if len(f.jobs.enqueued) != 0 {
+		t.Fatalf("enqueued jobs = %+v, want none", f.jobs.enqueued)
+	}
+}
+
+func TestCreateRunErrors(t *testing.T) {
+	f := newFixture(t)
+
+	tests := []struct {
+		name    string
+		actor   *models.OrganizationUser
+		params  CreateRunParams
+		wantErr error
+	}{
+		{
+			name:    "not a multisig owner",
+			actor:   f.accountant,
+			params:  CreateRunParams{PayrollID: f.payrollID},
+			wantErr: chain.ErrorNotMultisigOwner,
+		},
+		{
+			name:    "no create permission",
+			actor:   f.approver,
+			params:  CreateRunParams{PayrollID: f.payrollID},
+			wantErr: authorizer.ErrorPermissionDenied,
+		},
+		{
+			name:    "unknown payroll",
+			actor:   f.owner,
+			params:  CreateRunParams{PayrollID: uuid.New()},
+			wantErr: chain.ErrorPayrollNotFound,
+		},
+		{
+			name:    "salary is not set on-chain",
+			actor:   f.owner,
+			params:  CreateRunParams{PayrollID: f.payrollID, EmployeeIDs: uuid.UUIDs{f.employees[1]}},
+			wantErr: ErrorNoSalariesDue,
+		},
+		{
+			name:  "negative deposit",
+			actor: f.owner,
+			params: CreateRunParams{
+				PayrollID:     f.payrollID,
+				DepositAmount: money.New(money.MustParseDecimal("-1"), money.ETH),
+			},
+			wantErr: ErrorInvalidDepositAmount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.interactor.CreateRun(f.as(tt.actor), tt.params); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateRun() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfirmRun(t *testing.T) {
+	f := newFixture(t)
+
+	// creator allowed to confirm confirms the run right away
+	run, err := f.interactor.CreateRun(f.as(f.owner), CreateRunParams{PayrollID: f.payrollID})
+	if err != nil {
+		t.Fatalf("CreateRun() error: %v", err)
+	}
+
+	if run.Status != models.PayoutRunStatusPending || !slices.Equal(run.ConfirmedBy, uuid.UUIDs{f.owner.ID}) {
+		t.Fatalf("CreateRun() = %+v, want run confirmed by the creator", run)
+	}
+
+	params := ConfirmRunParams{ID: run.ID, OrganizationID: f.orgID}
+
+	if _, err = f.interactor.ConfirmRun(f.as(f.ownerAccountant), params); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("ConfirmRun() by accountant error = %v, want ErrorPermissionDenied", err)
+	}
+
+	if run, err = f.interactor.ConfirmRun(f.as(f.approver), params); err != nil {
+		t.Fatalf("ConfirmRun() error: %v", err)
+	}
+
+	if run.Status != models.PayoutRunStatusConfirmed || run.Confirmations != 2 {
+		t.Fatalf("ConfirmRun() = %+v, want confirmed run", run)
+	}
+
+	if len(f.jobs.enqueued) != 1 || f.jobs.enqueued[0].Kind != JobKindPayoutExecute ||
+		f.jobs.enqueued[0].Payload.(executeRunPayload).PayoutRunID != run.ID {
+		t.Fatalf("enqueued jobs = %+v, want single payout execution job", f.jobs.enqueued)
+	}
+
+	if _, err = f.interactor.ConfirmRun(f.as(f.owner), params); !errors.Is(err, ErrorPayoutRunNotPending) {
+		t.Fatalf("ConfirmRun() of confirmed run error = %v, want ErrorPayoutRunNotPending", err)
+	}
+
+	if _, err = f.interactor.ConfirmRun(f.as(f.approver), ConfirmRunParams{
+		ID:             uuid.New(),
+		OrganizationID: f.orgID,
+	}); !errors.Is(err, ErrorPayoutRunNotFound) {
+		t.Fatalf("ConfirmRun() of unknown run error = %v, want ErrorPayoutRunNotFound", err)
+	}
+}
@@ -0,0 +1,134 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/emochka2007/block-accounting/internal/pkg/prices"
+	pricesrepo "github.com/emochka2007/block-accounting/internal/usecase/repository/prices"
+	"github.com/google/uuid"
+)
+
+// providerFiat is the provider of fiat currency snapshots, whose rate is always 1
+const providerFiat = "fiat"
+
+type SnapshotParams struct {
+	OrganizationID uuid.UUID
+	SourceType     models.LedgerEntrySource
+	SourceID       uuid.UUID
+	// Currency is the code of the moved currency, e.g. ETH or the asset symbol
+	Currency string
+}
+
+type ListParams struct {
+	OrganizationID uuid.UUID
+	SourceType     models.LedgerEntrySource
+	SourceIDs      uuid.UUIDs
+}
+
+// PricesInteractor keeps exchange rates used when funds were moved, so fiat equivalents of transactions,
+// payouts and deposits are reported at the rate of the moment they were executed
+type PricesInteractor interface {
+	// Snapshot fetches current rate of the currency in models.FiatCurrency and saves it for the source entity.
+	// Rate of the source is taken once, repeated calls return the saved snapshot.
+	// Snapshot does not authorize the actor, it is called once funds are moved
+	Snapshot(ctx context.Context, params SnapshotParams) (*models.Price, error)
+	// List returns snapshots of the source entities. List does not authorize the actor
+	List(ctx context.Context, params ListParams) ([]*models.Price, error)
+}
+
+type pricesInteractor struct {
+	log        *slog.Logger
+	source     prices.Source
+	pricesRepo pricesrepo.Repository
+}
+
+func NewPricesInteractor(
+	log *slog.Logger,
+	source prices.Source,
+	pricesRepo pricesrepo.Repository,
+) PricesInteractor {
+	return &pricesInteractor{
+		log:        log,
+		source:     source,
+		pricesRepo: pricesRepo,
+	}
+}
+
+func (i *pricesInteractor) Snapshot(ctx context.Context, params SnapshotParams) (*models.Price, error) {
+	base := strings.ToUpper(params.Currency)
+
+	quote := &prices.Quote{
+		Base:       base,
+		Quote:      models.FiatCurrency.Code,
+		Rate:       money.NewFromInt(1),
+		Provider:   providerFiat,
+		ObservedAt: time.Now(),
+	}
+
+	if base != models.FiatCurrency.Code {
+		var err error
+
+		if quote, err = i.source.Price(ctx, base, models.FiatCurrency.Code); err != nil {
+			return nil, fmt.Errorf("error fetch %s price. %w", base, err)
+		}
+	}
+
+	price := models.Price{
+		ID:             uuid.Must(uuid.NewV7()),
+		OrganizationID: params.OrganizationID,
+		SourceType:     params.SourceType,
+		SourceID:       params.SourceID,
+		Base:           base,
+		Quote:          quote.Quote,
+		Rate:           quote.Rate,
+		Provider:       quote.Provider,
+		ObservedAt:     quote.ObservedAt,
+		CreatedAt:      time.Now(),
+	}
+
+	err := i.pricesRepo.Add(ctx, price)
+	if err == nil {
+		return &price, nil
+	}
+
+	if !errors.Is(err, pricesrepo.ErrorPriceExists) {
+		return nil, fmt.Errorf("error save price. %w", err)
+	}
+
+	existing, err := i.pricesRepo.List(ctx, pricesrepo.ListParams{
+		OrganizationID: params.OrganizationID,
+		SourceType:     params.SourceType,
+		SourceIDs:      uuid.UUIDs{params.SourceID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch price. %w", err)
+	}
+
+	for _, p := range existing {
+		if p.Base == price.Base && p.Quote == price.Quote {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("error %s price of %s %s not found", base, params.SourceType, params.SourceID)
+}
+
+func (i *pricesInteractor) List(ctx context.Context, params ListParams) ([]*models.Price, error) {
+	list, err := i.pricesRepo.List(ctx, pricesrepo.ListParams{
+		OrganizationID: params.OrganizationID,
+		SourceType:     params.SourceType,
+		SourceIDs:      params.SourceIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch prices. %w", err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,159 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/emochka2007/block-accounting/internal/pkg/prices"
+	pricesrepo "github.com/emochka2007/block-accounting/internal/usecase/repository/prices"
+	"github.com/google/uuid"
+)
+
+// memoryRepo keeps prices in memory with the same uniqueness as the prices table
+type memoryRepo struct {
+	mu     sync.Mutex
+	prices []*models.Price
+}
+
+func (r *memoryRepo) Add(_ context.Context, price models.Price) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, p := range r.prices {
+		if p.OrganizationID == price.OrganizationID && p.SourceType == price.SourceType &&
+			p.SourceID == price.SourceID && p.Base == price.Base && p.Quote == price.Quote {
+			return pricesrepo.ErrorPriceExists
+		}
+	}
+
+	r.prices = append(r.prices, &price)
+
+	return nil
+}
+
+func (r *memoryRepo) List(_ context.Context, params pricesrepo.ListParams) ([]*models.Price, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]*models.Price, 0)
+
+	for _, p := range r.prices {
+		if p.OrganizationID == params.OrganizationID && p.SourceType == params.SourceType &&
+			slices.Contains(params.SourceIDs, p.SourceID) {
+			list = append(list, p)
+		}
+	}
+
+	return list, nil
+}
+
+func newInteractor(repo pricesrepo.Repository, rates map[string]string) PricesInteractor {
+	fixture := make(map[string]money.Decimal, len(rates))
+
+	for pair, rate := range rates {
+		fixture[pair] = money.MustParseDecimal(rate)
+	}
+
+	return NewPricesInteractor(slog.New(slog.NewTextHandler(io.Discard, nil)), prices.NewFixtureSource(fixture), repo)
+}
+
+func TestSnapshot(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		rate     string
+		provider string
+	}{
+		{name: "eth", currency: "ETH", rate: "3000.25", provider: prices.KindFixture},
+		{name: "lower case asset", currency: "usdc", rate: "0.9998", provider: prices.KindFixture},
+		{name: "fiat at par", currency: "USD", rate: "1", provider: providerFiat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(memoryRepo)
+			i := newInteractor(repo, map[string]string{"ETH/USD": "3000.25", "USDC/USD": "0.9998"})
+
+			params := SnapshotParams{
+				OrganizationID: uuid.New(),
+				SourceType:     models.LedgerEntrySourceTransaction,
+				SourceID:       uuid.New(),
+				Currency:       tt.currency,
+			}
+
+			price, err := i.Snapshot(context.Background(), params)
+			if err != nil {
+				t.Fatalf("Snapshot() error: %v", err)
+			}
+
+			if price.Rate.String() != tt.rate || price.Provider != tt.provider || price.Quote != models.FiatCurrency.Code {
+				t.Fatalf("Snapshot() = %s/%s %s by %s, want rate %s by %s",
+					price.Base, price.Quote, price.Rate, price.Provider, tt.rate, tt.provider)
+			}
+
+			saved, err := i.List(context.Background(), ListParams{
+				OrganizationID: params.OrganizationID,
+				SourceType:     params.SourceType,
+				SourceIDs:      uuid.UUIDs{params.SourceID},
+			})
+			if err != nil {
+				t.Fatalf("List() error: %v", err)
+			}
+
+			if len(saved) != 1 || saved[0].ID != price.ID {
+				t.Fatalf("List() = %v, want the snapshot", saved)
+			}
+		})
+	}
+}
+
+func TestSnapshotTakenOnce(t *testing.T) {
+	repo := new(memoryRepo)
+
+	params := SnapshotParams{
+		OrganizationID: uuid.New(),
+		SourceType:     models.LedgerEntrySourcePayment,
+		SourceID:       uuid.New(),
+		Currency:       "ETH",
+	}
+
+	first, err := newInteractor(repo, map[string]string{"ETH/USD": "3000"}).Snapshot(context.Background(), params)
+	if err != nil {
+		t.Fatalf("Snapshot() error: %v", err)
+	}
+
+	// rate moved after the funds, source keeps the rate of the moment they were moved
+	again, err := newInteractor(repo, map[string]string{"ETH/USD": "3500"}).Snapshot(context.Background(), params)
+	if err != nil {
+		t.Fatalf("Snapshot() again error: %v", err)
+	}
+
+	if again.ID != first.ID || again.Rate.String() != "3000" {
+		t.Fatalf("Snapshot() again = %s, want the first snapshot at 3000", again.Rate)
+	}
+}
+
+func TestSnapshotUnavailable(t *testing.T) {
+	repo := new(memoryRepo)
+
+	_, err := newInteractor(repo, nil).Snapshot(context.Background(), SnapshotParams{
+		OrganizationID: uuid.New(),
+		SourceType:     models.LedgerEntrySourceTransaction,
+		SourceID:       uuid.New(),
+		Currency:       "ETH",
+	})
+	if !errors.Is(err, prices.ErrorPriceUnavailable) {
+		t.Fatalf("Snapshot() error = %v, want ErrorPriceUnavailable", err)
+	}
+
+	if len(repo.prices) != 0 {
+		t.Fatalf("unavailable price is saved")
+	}
+}
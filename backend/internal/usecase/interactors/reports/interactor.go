@@ -0,0 +1,527 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/ledger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorInvalidPeriod    = errors.New("invalid report period")
+	ErrorUnknownStatement = errors.New("unknown financial statement")
+)
+
+type Statement string
+
+const (
+	StatementBalanceSheet Statement = "balance_sheet"
+	StatementIncome       Statement = "income_statement"
+	StatementCashFlow     Statement = "cash_flow"
+)
+
+// Categories of the computed lines, other lines are categorized by account code or entry source
+const (
+	categoryRetainedEarnings = "retained_earnings"
+	categoryTotal            = "total"
+)
+
+// Statements are all financial statements in the order of the full report
+var Statements = []Statement{StatementBalanceSheet, StatementIncome, StatementCashFlow}
+
+func (s Statement) Title() string {
+	switch s {
+	case StatementBalanceSheet:
+		return "Balance sheet"
+	case StatementIncome:
+		return "Income statement"
+	case StatementCashFlow:
+		return "Cash flow statement"
+	default:
+		return string(s)
+	}
+}
+
+// cashAccounts hold organization funds on-chain, cash flow is their movement
+var cashAccounts = []string{models.LedgerAccountMultisigs, models.LedgerAccountPayrolls}
+
+type cashFlowActivity struct {
+	name    string
+	sources []models.LedgerEntrySource
+}
+
+// cashFlowActivities classify cash movements by the ledger entry source
+var cashFlowActivities = []cashFlowActivity{
+	{
+		name: "Operating activities",
+		sources: []models.LedgerEntrySource{
+			models.LedgerEntrySourceTransaction,
+			models.LedgerEntrySourcePayment,
+		},
+	},
+	{
+		name: "Financing activities",
+		sources: []models.LedgerEntrySource{
+			models.LedgerEntrySourceMultisigDeposit,
+			models.LedgerEntrySourcePayrollDeposit,
+		},
+	},
+	{
+		name:    "Other",
+		sources: []models.LedgerEntrySource{models.LedgerEntrySourceManual},
+	},
+}
+
+var sourceNames = map[models.LedgerEntrySource]string{
+	models.LedgerEntrySourceTransaction:     "Multisig transfers",
+	models.LedgerEntrySourcePayment:         "Salary payouts",
+	models.LedgerEntrySourceMultisigDeposit: "Multisig deposits",
+	models.LedgerEntrySourcePayrollDeposit:  "Payroll deposits",
+	models.LedgerEntrySourceManual:          "Manual entries",
+}
+
+type ReportParams struct {
+	OrganizationID uuid.UUID
+	// Statements defaults to all statements
+	Statements []Statement
+	// From is the start of the income statement and cash flow period. Optional
+	From time.Time
+	// To is the end of the period and the balance sheet date. Defaults to the current time
+	To time.Time
+}
+
+// Line is an amount of the category in one currency
+type Line struct {
+	// Category is the ledger account code or the entry source type the amount is broken down by
+	Category string
+	Name     string
+	Currency string
+	Amount   money.Decimal
+	// Fiat is the amount in models.FiatCurrency at the rates snapshotted when funds were moved
+	Fiat money.Decimal
+	// UnpricedLines is the number of ledger lines without price snapshot, they are not included in Fiat
+	UnpricedLines int64
+}
+
+func (l Line) neg() Line {
+	l.Amount = l.Amount.Neg()
+	l.Fiat = l.Fiat.Neg()
+
+	return l
+}
+
+type Section struct {
+	Name  string
+	Lines []Line
+	// Totals are section totals per currency
+	Totals []Line
+	// FiatTotal sums section lines of all currencies in models.FiatCurrency
+	FiatTotal money.Decimal
+}
+
+type StatementReport struct {
+	Statement Statement
+	Sections  []*Section
+	// Summary holds the bottom lines of the statement, e.g. net income or closing cash
+	Summary []Line
+}
+
+// Unposted is an executed transfer or payout the statements miss, because it has not been posted to the ledger
+type Unposted struct {
+	SourceType  models.LedgerEntrySource
+	SourceID    uuid.UUID
+	Description string
+	Currency    string
+	Amount      money.Decimal
+	OccurredAt  time.Time
+}
+
+type Report struct {
+	OrganizationID uuid.UUID
+	From           time.Time
+	To             time.Time
+	FiatCurrency   string
+	Statements     []*StatementReport
+	// Unposted lists transfers and payouts executed before To but missing from the ledger
+	Unposted []Unposted
+}
+
+// ReportsInteractor builds financial statements from the ledger, which executed transactions, payouts and
+// deposits are posted to. Amounts are broken down by ledger accounts and entry sources and reported per
+// currency with fiat equivalents at the rates of the moments funds were moved. Ledger is reconciled against
+// transactions and payouts, executed ones without journal entry are reported as unposted
+type ReportsInteractor interface {
+	// Report returns statements of the organization. Balance sheet is as of To, income statement
+	// and cash flow cover entries occurred in [From, To)
+	Report(ctx context.Context, params ReportParams) (*Report, error)
+}
+
+type reportsInteractor struct {
+	log        *slog.Logger
+	ledgerRepo ledger.Repository
+	authorizer authorizer.Authorizer
+}
+
+func NewReportsInteractor(
+	log *slog.Logger,
+	ledgerRepo ledger.Repository,
+	authorizer authorizer.Authorizer,
+) ReportsInteractor {
+	return &reportsInteractor{
+		log:        log,
+		ledgerRepo: ledgerRepo,
+		authorizer: authorizer,
+	}
+}
+
+func (i *reportsInteractor) Report(ctx context.Context, params ReportParams) (*Report, error) {
+	if params.To.IsZero() {
+		params.To = time.Now()
+	}
+
+	if !params.From.IsZero() && !params.From.Before(params.To) {
+		return nil, fmt.Errorf("error period start is not before its end. %w", ErrorInvalidPeriod)
+	}
+
+	if len(params.Statements) == 0 {
+		params.Statements = Statements
+	}
+
+	if _, err := i.authorizer.Member(ctx, params.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		OrganizationID: params.OrganizationID,
+		From:           params.From,
+		To:             params.To,
+		FiatCurrency:   models.FiatCurrency.Code,
+		Statements:     make([]*StatementReport, len(params.Statements)),
+	}
+
+	for n, s := range params.Statements {
+		var (
+			statement *StatementReport
+			err       error
+		)
+
+		switch s {
+		case StatementBalanceSheet:
+			statement, err = i.balanceSheet(ctx, params)
+		case StatementIncome:
+			statement, err = i.incomeStatement(ctx, params)
+		case StatementCashFlow:
+			statement, err = i.cashFlow(ctx, params)
+		default:
+			return nil, fmt.Errorf("error statement %s. %w", s, ErrorUnknownStatement)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		report.Statements[n] = statement
+	}
+
+	unposted, err := i.ledgerRepo.Unposted(ctx, ledger.UnpostedParams{
+		OrganizationID: params.OrganizationID,
+		AsOf:           params.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconcile ledger. %w", err)
+	}
+
+	report.Unposted = make([]Unposted, len(unposted))
+
+	for n, u := range unposted {
+		report.Unposted[n] = Unposted(u)
+	}
+
+	return report, nil
+}
+
+// balanceSheet reports assets, liabilities and equity as of the period end. Income and expenses to date
+// are reported as retained earnings, so assets equal liabilities and equity in every currency
+func (i *reportsInteractor) balanceSheet(ctx context.Context, params ReportParams) (*StatementReport, error) {
+	balances, err := i.ledgerRepo.Balances(ctx, ledger.BalancesParams{
+		OrganizationID: params.OrganizationID,
+		AsOf:           params.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ledger balances. %w", err)
+	}
+
+	var (
+		assets      = &Section{Name: "Assets"}
+		liabilities = &Section{Name: "Liabilities"}
+		equity      = &Section{Name: "Equity"}
+		earnings    = new(sums)
+	)
+
+	for _, b := range balances {
+		line := balanceLine(b)
+
+		switch b.Account.Type {
+		case models.LedgerAccountTypeAsset:
+			assets.Lines = append(assets.Lines, line)
+		case models.LedgerAccountTypeLiability:
+			liabilities.Lines = append(liabilities.Lines, line)
+		case models.LedgerAccountTypeEquity:
+			equity.Lines = append(equity.Lines, line)
+		case models.LedgerAccountTypeIncome, models.LedgerAccountTypeExpense:
+			line.Category = categoryRetainedEarnings
+			line.Name = "Retained earnings"
+
+			if b.Account.Type == models.LedgerAccountTypeExpense {
+				line = line.neg()
+			}
+
+			earnings.add(line)
+		}
+	}
+
+	equity.Lines = append(equity.Lines, earnings.result()...)
+
+	sections := []*Section{assets, liabilities, equity}
+	liabilitiesAndEquity := new(sums)
+
+	for _, s := range sections {
+		s.total()
+	}
+
+	for _, s := range []*Section{liabilities, equity} {
+		for _, t := range s.Totals {
+			t.Category = categoryTotal
+			t.Name = "Total liabilities and equity"
+
+			liabilitiesAndEquity.add(t)
+		}
+	}
+
+	return &StatementReport{
+		Statement: StatementBalanceSheet,
+		Sections:  sections,
+		Summary:   liabilitiesAndEquity.result(),
+	}, nil
+}
+
+// incomeStatement reports income and expenses of the period
+func (i *reportsInteractor) incomeStatement(ctx context.Context, params ReportParams) (*StatementReport, error) {
+	balances, err := i.ledgerRepo.Balances(ctx, ledger.BalancesParams{
+		OrganizationID: params.OrganizationID,
+		From:           params.From,
+		AsOf:           params.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ledger balances. %w", err)
+	}
+
+	var (
+		income    = &Section{Name: "Income"}
+		expenses  = &Section{Name: "Expenses"}
+		netIncome = new(sums)
+	)
+
+	for _, b := range balances {
+		switch b.Account.Type {
+		case models.LedgerAccountTypeIncome:
+			income.Lines = append(income.Lines, balanceLine(b))
+		case models.LedgerAccountTypeExpense:
+			expenses.Lines = append(expenses.Lines, balanceLine(b))
+		}
+	}
+
+	income.total()
+	expenses.total()
+
+	for _, t := range income.Totals {
+		netIncome.add(t)
+	}
+
+	for _, t := range expenses.Totals {
+		netIncome.add(t.neg())
+	}
+
+	summary := netIncome.result()
+
+	for n := range summary {
+		summary[n].Category = categoryTotal
+		summary[n].Name = "Net income"
+	}
+
+	return &StatementReport{
+		Statement: StatementIncome,
+		Sections:  []*Section{income, expenses},
+		Summary:   summary,
+	}, nil
+}
+
+// cashFlow reports movements of the on-chain funds in the period by activity and entry source
+func (i *reportsInteractor) cashFlow(ctx context.Context, params ReportParams) (*StatementReport, error) {
+	sections := make([]*Section, 0, len(cashFlowActivities))
+	netChange := new(sums)
+
+	for _, activity := range cashFlowActivities {
+		section := &Section{Name: activity.name}
+
+		for _, source := range activity.sources {
+			balances, err := i.ledgerRepo.Balances(ctx, ledger.BalancesParams{
+				OrganizationID: params.OrganizationID,
+				From:           params.From,
+				AsOf:           params.To,
+				SourceTypes:    []models.LedgerEntrySource{source},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error fetch ledger balances. %w", err)
+			}
+
+			movements := new(sums)
+
+			for _, b := range cashBalances(balances) {
+				line := balanceLine(b)
+				line.Category = string(source)
+				line.Name = sourceNames[source]
+
+				movements.add(line)
+			}
+
+			section.Lines = append(section.Lines, movements.result()...)
+		}
+
+		section.total()
+
+		for _, t := range section.Totals {
+			t.Name = "Net change in cash"
+			netChange.add(t)
+		}
+
+		sections = append(sections, section)
+	}
+
+	opening, err := i.cash(ctx, params.OrganizationID, params.From, "Opening cash")
+	if err != nil {
+		return nil, err
+	}
+
+	closing, err := i.cash(ctx, params.OrganizationID, params.To, "Closing cash")
+	if err != nil {
+		return nil, err
+	}
+
+	return &StatementReport{
+		Statement: StatementCashFlow,
+		Sections:  sections,
+		Summary:   append(append(opening, netChange.result()...), closing...),
+	}, nil
+}
+
+// cash returns on-chain funds per currency as of the date. Nothing is held before the first entry,
+// so zero date returns no lines
+func (i *reportsInteractor) cash(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	asOf time.Time,
+	name string,
+) ([]Line, error) {
+	if asOf.IsZero() {
+		return nil, nil
+	}
+
+	balances, err := i.ledgerRepo.Balances(ctx, ledger.BalancesParams{
+		OrganizationID: organizationID,
+		AsOf:           asOf,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ledger balances. %w", err)
+	}
+
+	cash := new(sums)
+
+	for _, b := range cashBalances(balances) {
+		line := balanceLine(b)
+		line.Category = categoryTotal
+		line.Name = name
+
+		cash.add(line)
+	}
+
+	return cash.result(), nil
+}
+
+func cashBalances(balances []models.LedgerBalance) []models.LedgerBalance {
+	cash := make([]models.LedgerBalance, 0, len(balances))
+
+	for _, b := range balances {
+		for _, code := range cashAccounts {
+			if b.Account.Code == code {
+				cash = append(cash, b)
+			}
+		}
+	}
+
+	return cash
+}
+
+func balanceLine(b models.LedgerBalance) Line {
+	return Line{
+		Category:      b.Account.Code,
+		Name:          b.Account.Name,
+		Currency:      b.Currency,
+		Amount:        b.Balance(),
+		Fiat:          b.FiatBalance(),
+		UnpricedLines: b.UnpricedLines,
+	}
+}
+
+// total sets section totals per currency and in fiat
+func (s *Section) total() {
+	totals := new(sums)
+
+	for _, l := range s.Lines {
+		l.Category = categoryTotal
+		l.Name = "Total " + strings.ToLower(s.Name)
+
+		totals.add(l)
+
+		s.FiatTotal = s.FiatTotal.Add(l.Fiat)
+	}
+
+	s.Totals = totals.result()
+}
+
+// sums adds up lines of the same category and currency, keeping the order they first appeared in
+type sums struct {
+	lines []*Line
+}
+
+func (s *sums) add(l Line) {
+	for _, e := range s.lines {
+		if e.Category == l.Category && e.Currency == l.Currency {
+			e.Amount = e.Amount.Add(l.Amount)
+			e.Fiat = e.Fiat.Add(l.Fiat)
+			e.UnpricedLines += l.UnpricedLines
+
+			return
+		}
+	}
+
+	s.lines = append(s.lines, &l)
+}
+
+func (s *sums) result() []Line {
+	lines := make([]Line, len(s.lines))
+
+	for n, l := range s.lines {
+		lines[n] = *l
+	}
+
+	return lines
+}
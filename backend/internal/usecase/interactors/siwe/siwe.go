@@ -0,0 +1,159 @@
+package siwe
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/auth"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrorNonceNotFound  = auth.ErrorNonceNotFound
+	ErrorInvalidAddress = errors.New("invalid address")
+	ErrorInvalidSig     = errors.New("invalid signature")
+)
+
+const nonceTTL = 5 * time.Minute
+
+// SIWEInteractor implements Sign-In with Ethereum (EIP-4361) challenge-response.
+// Wallets sign the issued message with personal_sign (EIP-191), the mnemonic never leaves the client
+type SIWEInteractor interface {
+	// Nonce issues single use sign in message for the address
+	Nonce(ctx context.Context, params NonceParams) (*models.AuthNonce, error)
+	// Verify consumes nonce and checks the message is signed by the address
+	Verify(ctx context.Context, params VerifyParams) (common.Address, error)
+}
+
+type siweInteractor struct {
+	log      *slog.Logger
+	chainID  int64
+	authRepo auth.Repository
+}
+
+func NewSIWEInteractor(
+	log *slog.Logger,
+	chainID int64,
+	authRepo auth.Repository,
+) SIWEInteractor {
+	return &siweInteractor{
+		log:      log,
+		chainID:  chainID,
+		authRepo: authRepo,
+	}
+}
+
+type NonceParams struct {
+	Address string
+	// Domain and URI are the server origin the message is bound to
+	Domain string
+	URI    string
+}
+
+func (i *siweInteractor) Nonce(ctx context.Context, params NonceParams) (*models.AuthNonce, error) {
+	address, err := ParseAddress(params.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, 16)
+
+	if _, err = rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("error generate nonce. %w", err)
+	}
+
+	createdAt := time.Now()
+
+	nonce := &models.AuthNonce{
+		Nonce:     hex.EncodeToString(raw),
+		Address:   address.Bytes(),
+		CreatedAt: createdAt,
+		ExpiredAt: createdAt.Add(nonceTTL),
+	}
+
+	nonce.Message = i.message(params, address, nonce)
+
+	if err = i.authRepo.AddNonce(ctx, nonce); err != nil {
+		return nil, fmt.Errorf("error save nonce. %w", err)
+	}
+
+	return nonce, nil
+}
+
+// message builds EIP-4361 message
+func (i *siweInteractor) message(params NonceParams, address common.Address, nonce *models.AuthNonce) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s wants you to sign in with your Ethereum account:\n", params.Domain)
+	fmt.Fprintf(&b, "%s\n\n", address.Hex())
+	fmt.Fprintf(&b, "Sign in to blockd\n\n")
+	fmt.Fprintf(&b, "URI: %s\n", params.URI)
+	fmt.Fprintf(&b, "Version: 1\n")
+	fmt.Fprintf(&b, "Chain ID: %d\n", i.chainID)
+	fmt.Fprintf(&b, "Nonce: %s\n", nonce.Nonce)
+	fmt.Fprintf(&b, "Issued At: %s\n", nonce.CreatedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Expiration Time: %s", nonce.ExpiredAt.UTC().Format(time.RFC3339))
+
+	return b.String()
+}
+
+type VerifyParams struct {
+	Address string
+	Nonce   string
+	// Signature is hex encoded 65 bytes [R || S || V] signature, V is 0/1 or 27/28
+	Signature string
+}
+
+func (i *siweInteractor) Verify(ctx context.Context, params VerifyParams) (common.Address, error) {
+	address, err := ParseAddress(params.Address)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	sig, err := hex.DecodeString(strings.TrimPrefix(params.Signature, "0x"))
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("error decode signature. %w", ErrorInvalidSig)
+	}
+
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	nonce, err := i.authRepo.ConsumeNonce(ctx, auth.ConsumeNonceParams{
+		Nonce:   params.Nonce,
+		Address: address.Bytes(),
+		UsedAt:  time.Now(),
+	})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error consume nonce. %w", err)
+	}
+
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(nonce.Message)), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error recover public key. %w", ErrorInvalidSig)
+	}
+
+	if crypto.PubkeyToAddress(*pub) != address {
+		return common.Address{}, fmt.Errorf("error signer does not match address. %w", ErrorInvalidSig)
+	}
+
+	return address, nil
+}
+
+// ParseAddress parses hex encoded wallet address
+func ParseAddress(s string) (common.Address, error) {
+	if !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("error parse address %q. %w", s, ErrorInvalidAddress)
+	}
+
+	return common.HexToAddress(s), nil
+}
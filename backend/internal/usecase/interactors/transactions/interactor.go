@@ -4,18 +4,41 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
-	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/erc20"
+	"github.com/emochka2007/block-accounting/internal/pkg/logger"
 	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/assets"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/ledger"
 	"github.com/emochka2007/block-accounting/internal/usecase/interactors/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/prices"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/users"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 )
 
+const (
+	JobKindTxExecute = "tx_execute"
+)
+
+var (
+	ErrorTransactionNotFound   = errors.New("transaction not found")
+	ErrorTransactionNotPending = errors.New("transaction is not pending")
+	ErrorMultisigNotFound      = confirmations.ErrorMultisigNotFound
+	ErrorInvalidAmount         = errors.New("invalid transaction amount")
+)
+
 type ListParams struct {
 	IDs            uuid.UUIDs
 	OrganizationID uuid.UUID
@@ -35,6 +58,16 @@ type ConfirmParams struct {
 	OrganizationID uuid.UUID
 }
 
+type RevokeParams struct {
+	TxID           uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+type RejectParams struct {
+	TxID           uuid.UUID
+	OrganizationID uuid.UUID
+}
+
 type CancelParams struct {
 	TxID           uuid.UUID
 	OrganizationID uuid.UUID
@@ -45,28 +78,62 @@ type TransactionsInteractor interface {
 	List(ctx context.Context, params ListParams) (*ListResult, error)
 	Create(ctx context.Context, params CreateParams) (*models.Transaction, error)
 	Confirm(ctx context.Context, params ConfirmParams) (*models.Transaction, error)
+	Revoke(ctx context.Context, params RevokeParams) (*models.Transaction, error)
+	Reject(ctx context.Context, params RejectParams) (*models.Transaction, error)
 	Cancel(ctx context.Context, params CancelParams) (*models.Transaction, error)
 }
 
 type transactionsInteractor struct {
-	log             *slog.Logger
-	txRepo          transactions.Repository
-	orgInteractor   organizations.OrganizationsInteractor
-	chainInteractor chain.ChainInteractor
+	log                     *slog.Logger
+	txRepo                  transactions.Repository
+	usersRepo               users.Repository
+	orgInteractor           organizations.OrganizationsInteractor
+	chainInteractor         chain.ChainInteractor
+	jobsInteractor          jobs.JobsInteractor
+	confirmationsInteractor confirmations.ConfirmationsInteractor
+	ledgerInteractor        ledger.LedgerInteractor
+	assetsInteractor        assets.AssetsInteractor
+	pricesInteractor        prices.PricesInteractor
+	authorizer              authorizer.Authorizer
 }
 
 func NewTransactionsInteractor(
 	log *slog.Logger,
 	txRepo transactions.Repository,
+	usersRepo users.Repository,
 	orgInteractor organizations.OrganizationsInteractor,
 	chainInteractor chain.ChainInteractor,
+	jobsInteractor jobs.JobsInteractor,
+	confirmationsInteractor confirmations.ConfirmationsInteractor,
+	ledgerInteractor ledger.LedgerInteractor,
+	assetsInteractor assets.AssetsInteractor,
+	pricesInteractor prices.PricesInteractor,
+	authorizer authorizer.Authorizer,
 ) TransactionsInteractor {
-	return &transactionsInteractor{
-		log:             log,
-		txRepo:          txRepo,
-		orgInteractor:   orgInteractor,
-		chainInteractor: chainInteractor,
+	i := &transactionsInteractor{
+		log:                     log,
+		txRepo:                  txRepo,
+		usersRepo:               usersRepo,
+		orgInteractor:           orgInteractor,
+		chainInteractor:         chainInteractor,
+		jobsInteractor:          jobsInteractor,
+		confirmationsInteractor: confirmationsInteractor,
+		ledgerInteractor:        ledgerInteractor,
+		assetsInteractor:        assetsInteractor,
+		pricesInteractor:        pricesInteractor,
+		authorizer:              authorizer,
 	}
+
+	jobsInteractor.RegisterHandler(JobKindTxExecute, i.executeJob)
+
+	confirmationsInteractor.RegisterEntity(models.MultisigConfirmationEntityTypeTransaction, confirmations.EntityHandler{
+		Entity:     i.confirmationEntity,
+		Confirmed:  i.confirmed,
+		Rejected:   i.rejected,
+		Permission: models.PermissionTxConfirm,
+	})
+
+	return i
 }
 
 type txsListCursor struct {
@@ -132,6 +199,14 @@ func (i *transactionsInteractor) List(ctx context.Context, params ListParams) (*
 		return nil, fmt.Errorf("error fetch transaction from repository. %w", err)
 	}
 
+	if err = i.fillConfirmations(ctx, txs); err != nil {
+		return nil, err
+	}
+
+	if err = i.fillPrices(ctx, params.OrganizationID, txs); err != nil {
+		return nil, err
+	}
+
 	var nextCursor string
 
 	if len(txs) >= 50 || len(txs) >= int(params.Limit) {
@@ -151,28 +226,46 @@ func (i *transactionsInteractor) Create(
 	ctx context.Context,
 	params CreateParams,
 ) (*models.Transaction, error) {
-	user, err := ctxmeta.User(ctx)
+	participant, err := i.authorizer.Authorize(ctx, params.OrganizationId, models.PermissionTxCreate)
 	if err != nil {
-		return nil, fmt.Errorf("error fetch user from context. %w", err)
+		return nil, err
 	}
 
 	tx := params.Tx
 
+	if tx.AssetID != uuid.Nil {
+		if tx.Asset, err = i.assetsInteractor.Transferable(ctx, params.OrganizationId, tx.AssetID); err != nil {
+			return nil, err
+		}
+
+		tx.Amount = money.New(tx.Amount.Value, tx.Asset.Currency())
+	}
+
+	if _, err = transferParams(&tx); err != nil {
+		return nil, err
+	}
+
 	if tx.Id == uuid.Nil {
 		tx.Id = uuid.Must(uuid.NewV7())
 	}
 
-	participant, err := i.orgInteractor.Participant(ctx, organizations.ParticipantParams{
-		ID:             user.Id(),
-		OrganizationID: params.OrganizationId,
-		ActiveOnly:     true,
-		UsersOnly:      true,
-	})
+	multisig, err := i.multisig(ctx, params.OrganizationId, tx.MultisigID)
 	if err != nil {
-		return nil, fmt.Errorf("error fetch actor prticipant. %w", err)
+		return nil, err
 	}
 
-	tx.CreatedBy = participant.GetUser()
+	// transaction creator submits it to the multisig, so the creator must be one of the owners
+	if !isMultisigOwner(multisig, participant.Id()) {
+		return nil, chain.ErrorNotMultisigOwner
+	}
+
+	// multisig does not execute transaction with less confirmations than it requires
+	if tx.ConfirmationsRequired < multisig.ConfirmationsRequired {
+		tx.ConfirmationsRequired = multisig.ConfirmationsRequired
+	}
+
+	tx.CreatedBy = participant
+	tx.Status = models.TransactionStatusPending
 	tx.CreatedAt = time.Now()
 	tx.UpdatedAt = tx.CreatedAt
 
@@ -183,72 +276,505 @@ func (i *transactionsInteractor) Create(
 	return &tx, nil
 }
 
+// Confirm adds multisig owner confirmation to the transaction. Once confirmations_required is reached,
+// transaction is submitted, confirmed and executed through the multisig in background
 func (i *transactionsInteractor) Confirm(ctx context.Context, params ConfirmParams) (*models.Transaction, error) {
-	user, err := ctxmeta.User(ctx)
+	if _, err := i.confirmationsInteractor.Confirm(ctx, confirmations.ConfirmParams{
+		OrganizationID: params.OrganizationID,
+		EntityType:     models.MultisigConfirmationEntityTypeTransaction,
+		EntityID:       params.TxID,
+	}); err != nil {
+		if errors.Is(err, confirmations.ErrorEntityNotPending) {
+			return nil, ErrorTransactionNotPending
+		}
+
+		return nil, err
+	}
+
+	return i.transaction(ctx, params.OrganizationID, params.TxID)
+}
+
+// Revoke withdraws multisig owner confirmation of the not executed transaction. If transaction is already
+// submitted, confirmation is revoked on-chain too. Confirmed transaction can be revoked until its execution
+// job starts. Transaction returns to the pending status once it lacks confirmations
+func (i *transactionsInteractor) Revoke(ctx context.Context, params RevokeParams) (*models.Transaction, error) {
+	tx, err := i.transaction(ctx, params.OrganizationID, params.TxID)
 	if err != nil {
-		return nil, fmt.Errorf("error fetch user from context. %w", err)
+		return nil, err
 	}
 
-	participant, err := i.orgInteractor.Participant(ctx, organizations.ParticipantParams{
-		ID:             user.Id(),
-		OrganizationID: params.OrganizationID,
-		ActiveOnly:     true,
-		UsersOnly:      true,
+	switch tx.Status {
+	case models.TransactionStatusPending:
+		if _, err = i.confirmationsInteractor.Revoke(ctx, confirmations.RevokeParams{
+			OrganizationID: params.OrganizationID,
+			EntityType:     models.MultisigConfirmationEntityTypeTransaction,
+			EntityID:       params.TxID,
+		}); err != nil {
+			if errors.Is(err, confirmations.ErrorEntityNotPending) {
+				return nil, ErrorTransactionNotPending
+			}
+
+			return nil, err
+		}
+	case models.TransactionStatusConfirmed:
+		if err = i.revokeConfirmed(ctx, tx); err != nil {
+			return nil, err
+		}
+	case models.TransactionStatusSubmitted:
+		if err = i.revokeSubmitted(ctx, tx); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrorTransactionNotPending
+	}
+
+	return i.transaction(ctx, params.OrganizationID, params.TxID)
+}
+
+// revokeConfirmed revokes actor confirmation of the confirmed transaction and cancels its queued execution
+func (i *transactionsInteractor) revokeConfirmed(ctx context.Context, tx *models.Transaction) error {
+	user, err := i.authorizer.Authorize(ctx, tx.OrganizationId, models.PermissionTxConfirm)
+	if err != nil {
+		return err
+	}
+
+	multisig, err := i.multisig(ctx, tx.OrganizationId, tx.MultisigID)
+	if err != nil {
+		return err
+	}
+
+	if !isMultisigOwner(multisig, user.Id()) {
+		return chain.ErrorNotMultisigOwner
+	}
+
+	if err = i.txRepo.RevokeConfirmedTransaction(ctx, transactions.RevokeConfirmedTransactionParams{
+		TxId:           tx.Id,
+		OrganizationId: tx.OrganizationId,
+		MultisigID:     multisig.ID,
+		OwnerID:        user.Id(),
+		ExecuteJobKind: JobKindTxExecute,
+		RevokedAt:      time.Now(),
+	}); err != nil {
+		if errors.Is(err, transactions.ErrorConfirmationNotFound) {
+			return confirmations.ErrorConfirmationNotFound
+		}
+
+		if errors.Is(err, transactions.ErrorTransactionStatusConflict) {
+			return ErrorTransactionNotPending
+		}
+
+		return fmt.Errorf("error revoke confirmed transaction. %w", err)
+	}
+
+	return nil
+}
+
+// revokeSubmitted revokes actor confirmation of the transaction submitted to the multisig
+func (i *transactionsInteractor) revokeSubmitted(ctx context.Context, tx *models.Transaction) error {
+	user, err := i.authorizer.Authorize(ctx, tx.OrganizationId, models.PermissionTxConfirm)
+	if err != nil {
+		return err
+	}
+
+	multisig, err := i.multisig(ctx, tx.OrganizationId, tx.MultisigID)
+	if err != nil {
+		return err
+	}
+
+	if !isMultisigOwner(multisig, user.Id()) {
+		return chain.ErrorNotMultisigOwner
+	}
+
+	if !slices.Contains(tx.ConfirmedBy, user.Id()) {
+		return confirmations.ErrorConfirmationNotFound
+	}
+
+	signers, err := i.usersRepo.Get(ctx, users.GetParams{
+		Ids: uuid.UUIDs{user.Id()},
+	})
+	if err != nil {
+		return fmt.Errorf("error fetch signer. %w", err)
+	}
+
+	if len(signers) == 0 {
+		return fmt.Errorf("error signer not found")
+	}
+
+	// on-chain confirmation goes first, otherwise execution job could send it after revocation
+	if _, err = i.chainInteractor.MultisigRevoke(ctx, chain.MultisigTxParams{
+		Signer:          signers[0],
+		MultisigAddress: multisig.Address,
+		TxIndex:         tx.TxIndex,
+	}); err != nil {
+		return err
+	}
+
+	if err = i.txRepo.RevokeMultisigConfirmation(ctx, transactions.RevokeMultisigConfirmationParams{
+		MultisigID: multisig.ID,
+		OwnerID:    user.Id(),
+		RevokedAt:  time.Now(),
+		EntityID:   tx.Id,
+		EntityType: models.MultisigConfirmationEntityTypeTransaction,
+	}); err != nil {
+		if errors.Is(err, transactions.ErrorConfirmationNotFound) {
+			return confirmations.ErrorConfirmationNotFound
+		}
+
+		return fmt.Errorf("error revoke transaction confirmation. %w", err)
+	}
+
+	count, err := i.txRepo.MultisigConfirmationsCount(ctx, transactions.MultisigConfirmationsCountParams{
+		MultisigID: multisig.ID,
+		EntityID:   tx.Id,
+		EntityType: models.MultisigConfirmationEntityTypeTransaction,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error fetch actor prticipant. %w", err)
+		return fmt.Errorf("error fetch transaction confirmations count. %w", err)
 	}
 
-	if !participant.IsAdmin() {
-		return nil, fmt.Errorf("error not enouth rights. %w", organizations.ErrorUnauthorizedAccess)
+	if count >= tx.ConfirmationsRequired {
+		return nil
+	}
+
+	// submitted transaction keeps its index and is not submitted again once confirmed
+	if err = i.txRepo.UpdateTransaction(ctx, transactions.UpdateTransactionParams{
+		TxId:           tx.Id,
+		OrganizationId: tx.OrganizationId,
+		Status:         models.TransactionStatusPending,
+		FromStatuses:   []models.TransactionStatus{models.TransactionStatusSubmitted},
+		UpdatedAt:      time.Now(),
+	}); err != nil {
+		if errors.Is(err, transactions.ErrorTransactionStatusConflict) {
+			return ErrorTransactionNotPending
+		}
+
+		return fmt.Errorf("error return transaction to pending. %w", err)
+	}
+
+	return nil
+}
+
+// Reject adds multisig owner rejection to the pending transaction. Transaction is cancelled once
+// not rejected owners are not enough to confirm it
+func (i *transactionsInteractor) Reject(ctx context.Context, params RejectParams) (*models.Transaction, error) {
+	if _, err := i.confirmationsInteractor.Reject(ctx, confirmations.RejectParams{
+		OrganizationID: params.OrganizationID,
+		EntityType:     models.MultisigConfirmationEntityTypeTransaction,
+		EntityID:       params.TxID,
+	}); err != nil {
+		if errors.Is(err, confirmations.ErrorEntityNotPending) {
+			return nil, ErrorTransactionNotPending
+		}
+
+		return nil, err
+	}
+
+	return i.transaction(ctx, params.OrganizationID, params.TxID)
+}
+
+func (i *transactionsInteractor) confirmationEntity(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*confirmations.Entity, error) {
+	tx, err := i.transaction(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
 	}
 
+	return &confirmations.Entity{
+		ID:                    tx.Id,
+		OrganizationID:        tx.OrganizationId,
+		MultisigID:            tx.MultisigID,
+		ConfirmationsRequired: tx.ConfirmationsRequired,
+		Pending:               tx.Status == models.TransactionStatusPending && tx.CancelledAt.IsZero(),
+	}, nil
+}
+
+// confirmed starts execution of the transaction confirmed by the multisig owners
+func (i *transactionsInteractor) confirmed(ctx context.Context, entity *confirmations.Entity) error {
 	if err := i.txRepo.ConfirmTransaction(ctx, transactions.ConfirmTransactionParams{
-		TxId:           params.TxID,
-		OrganizationId: params.OrganizationID,
-		UserId:         participant.Id(),
+		TxId:           entity.ID,
+		OrganizationId: entity.OrganizationID,
+	}); err != nil {
+		if errors.Is(err, transactions.ErrorTransactionStatusConflict) {
+			return confirmations.ErrorEntityStatusConflict
+		}
+
+		return fmt.Errorf("error confirm transaction. %w", err)
+	}
+
+	if _, err := i.jobsInteractor.Enqueue(ctx, jobs.EnqueueParams{
+		Kind:           JobKindTxExecute,
+		OrganizationID: entity.OrganizationID,
+		Payload: executePayload{
+			TxID: entity.ID,
+		},
 	}); err != nil {
-		return nil, fmt.Errorf("error confirm transaction. %w", err)
+		return fmt.Errorf("error enqueue transaction execution. %w", err)
 	}
 
-	// TODO confirm tx via chain-api
+	return nil
+}
 
-	tx, err := i.txRepo.GetTransactions(ctx, transactions.GetTransactionsParams{
-		Ids:            uuid.UUIDs{params.TxID},
-		OrganizationId: params.OrganizationID,
-		Limit:          1,
+// rejected cancels transaction rejected by the multisig owners
+func (i *transactionsInteractor) rejected(ctx context.Context, entity *confirmations.Entity) error {
+	if err := i.txRepo.CancelTransaction(ctx, transactions.CancelTransactionParams{
+		TxId:           entity.ID,
+		OrganizationId: entity.OrganizationID,
+	}); err != nil {
+		if errors.Is(err, transactions.ErrorTransactionStatusConflict) {
+			return confirmations.ErrorEntityStatusConflict
+		}
+
+		return fmt.Errorf("error cancel rejected transaction. %w", err)
+	}
+
+	return nil
+}
+
+type executePayload struct {
+	TxID uuid.UUID `json:"tx_id"`
+}
+
+type ExecuteResult struct {
+	TxID    uuid.UUID `json:"tx_id"`
+	TxIndex int64     `json:"tx_index"`
+	TxHash  string    `json:"tx_hash"`
+}
+
+// executeJob submits confirmed transaction to the multisig, confirms it on behalf of the owners who
+// confirmed the transaction and executes it. Submitted tx index is saved, so neither retried job nor
+// transaction confirmed again after revocation submits it twice
+func (i *transactionsInteractor) executeJob(ctx context.Context, job *models.Job) (result any, err error) {
+	var payload executePayload
+
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("error unmarshal job payload. %w", err))
+	}
+
+	tx, err := i.transaction(ctx, job.OrganizationID, payload.TxID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	if tx.Status == models.TransactionStatusExecuted {
+		return ExecuteResult{TxID: tx.Id, TxIndex: tx.TxIndex, TxHash: tx.TxHash}, nil
+	}
+
+	// confirmation was revoked, transaction awaits confirmations again
+	if tx.Status == models.TransactionStatusPending {
+		return nil, jobs.Permanent(fmt.Errorf("error transaction confirmation revoked"))
+	}
+
+	defer func() {
+		if !jobs.Failed(job, err) {
+			return
+		}
+
+		// transaction returned to pending by revocation must not be failed
+		if fErr := i.txRepo.UpdateTransaction(ctx, transactions.UpdateTransactionParams{
+			TxId:           tx.Id,
+			OrganizationId: tx.OrganizationId,
+			Status:         models.TransactionStatusFailed,
+			FromStatuses: []models.TransactionStatus{
+				models.TransactionStatusConfirmed,
+				models.TransactionStatusSubmitted,
+			},
+			UpdatedAt: time.Now(),
+		}); fErr != nil && !errors.Is(fErr, transactions.ErrorTransactionStatusConflict) {
+			err = errors.Join(err, fmt.Errorf("error mark transaction as failed. %w", fErr))
+		}
+	}()
+
+	multisig, err := i.multisig(ctx, tx.OrganizationId, tx.MultisigID)
+	if err != nil {
+		return nil, jobs.Permanent(err)
+	}
+
+	submitter, confirmers, err := i.signers(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Status == models.TransactionStatusConfirmed {
+		// revocation may have returned transaction to pending while signers were fetched
+		current, err := i.transaction(ctx, tx.OrganizationId, tx.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		if current.Status != models.TransactionStatusConfirmed {
+			return nil, jobs.Permanent(fmt.Errorf("error transaction status changed to %d", current.Status))
+		}
+
+		if tx.SubmittedAt.IsZero() {
+			params, err := transferParams(tx)
+			if err != nil {
+				return nil, jobs.Permanent(err)
+			}
+
+			params.Signer = submitter
+			params.MultisigAddress = multisig.Address
+
+			submitted, err := i.chainInteractor.MultisigSubmit(ctx, params)
+			if err != nil {
+				return nil, err
+			}
+
+			tx.TxIndex = submitted.TxIndex
+			tx.SubmittedAt = time.Now()
+		}
+
+		tx.Status = models.TransactionStatusSubmitted
+
+		if err = i.txRepo.UpdateTransaction(ctx, transactions.UpdateTransactionParams{
+			TxId:           tx.Id,
+			OrganizationId: tx.OrganizationId,
+			Status:         tx.Status,
+			TxIndex:        tx.TxIndex,
+			SubmittedAt:    tx.SubmittedAt,
+			UpdatedAt:      time.Now(),
+		}); err != nil {
+			return nil, fmt.Errorf("error save submitted transaction. %w", err)
+		}
+	}
+
+	if tx.Status != models.TransactionStatusSubmitted {
+		return nil, jobs.Permanent(fmt.Errorf("error unexpected transaction status %s", tx.Status))
+	}
+
+	for _, confirmer := range confirmers {
+		if _, err = i.chainInteractor.MultisigConfirm(ctx, chain.MultisigTxParams{
+			Signer:          confirmer,
+			MultisigAddress: multisig.Address,
+			TxIndex:         tx.TxIndex,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	txHash, err := i.chainInteractor.MultisigExecute(ctx, chain.MultisigTxParams{
+		Signer:          submitter,
+		MultisigAddress: multisig.Address,
+		TxIndex:         tx.TxIndex,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error fetch transaction. %w", err)
+		return nil, err
+	}
+
+	commitedAt := time.Now()
+
+	if err = i.txRepo.UpdateTransaction(ctx, transactions.UpdateTransactionParams{
+		TxId:           tx.Id,
+		OrganizationId: tx.OrganizationId,
+		Status:         models.TransactionStatusExecuted,
+		TxHash:         txHash,
+		CommitedAt:     commitedAt,
+		UpdatedAt:      commitedAt,
+	}); err != nil {
+		// transaction is already executed on-chain, retry would fail anyway
+		return nil, jobs.Permanent(fmt.Errorf("error save executed transaction. %w", err))
 	}
 
-	if len(tx) == 0 {
-		return nil, fmt.Errorf("error tx not found")
+	i.postExecuted(ctx, tx, commitedAt)
+
+	return ExecuteResult{TxID: tx.Id, TxIndex: tx.TxIndex, TxHash: txHash}, nil
+}
+
+// postExecuted posts ETH sent by the multisig as organization expense. Funds are already moved on-chain,
+// so posting failure is only logged
+func (i *transactionsInteractor) postExecuted(ctx context.Context, tx *models.Transaction, commitedAt time.Time) {
+	if !tx.Amount.IsPositive() {
+		return
+	}
+
+	var createdBy uuid.UUID
+	if tx.CreatedBy != nil {
+		createdBy = tx.CreatedBy.Id()
 	}
 
-	return tx[0], nil
+	if _, err := i.ledgerInteractor.Post(ctx, ledger.PostParams{
+		OrganizationID: tx.OrganizationId,
+		Description:    tx.Description,
+		SourceType:     models.LedgerEntrySourceTransaction,
+		SourceID:       tx.Id,
+		OccurredAt:     commitedAt,
+		CreatedBy:      createdBy,
+		Lines: []ledger.PostLine{
+			ledger.DebitLine(models.LedgerAccountExpenses, tx.Amount),
+			ledger.CreditLine(models.LedgerAccountMultisigs, tx.Amount),
+		},
+	}); err != nil && !errors.Is(err, ledger.ErrorEntryExists) {
+		i.log.Error(
+			"error post executed transaction to ledger",
+			slog.String("tx id", tx.Id.String()),
+			logger.Err(err),
+		)
+	}
 }
 
-// TODO Execute()
+// signers returns transaction creator, who submits and executes it,
+// and owners whose confirmations are sent on-chain
+func (i *transactionsInteractor) signers(
+	ctx context.Context,
+	tx *models.Transaction,
+) (*models.User, []*models.User, error) {
+	confirmedBy := tx.ConfirmedBy
+	if len(confirmedBy) > tx.ConfirmationsRequired {
+		confirmedBy = confirmedBy[:tx.ConfirmationsRequired]
+	}
+
+	usersList, err := i.usersRepo.Get(ctx, users.GetParams{
+		Ids: append(uuid.UUIDs{tx.CreatedBy.Id()}, confirmedBy...),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetch transaction signers. %w", err)
+	}
+
+	usersMap := make(map[uuid.UUID]*models.User, len(usersList))
+
+	for _, u := range usersList {
+		usersMap[u.Id()] = u
+	}
+
+	submitter, ok := usersMap[tx.CreatedBy.Id()]
+	if !ok {
+		return nil, nil, jobs.Permanent(fmt.Errorf("error transaction creator not found"))
+	}
+
+	confirmers := make([]*models.User, 0, len(confirmedBy))
+
+	for _, id := range confirmedBy {
+		u, ok := usersMap[id]
+		if !ok {
+			return nil, nil, jobs.Permanent(fmt.Errorf("error transaction confirmer %s not found", id))
+		}
+
+		confirmers = append(confirmers, u)
+	}
+
+	if len(confirmers) < tx.ConfirmationsRequired {
+		return nil, nil, jobs.Permanent(fmt.Errorf("error not enough transaction confirmations"))
+	}
+
+	return submitter, confirmers, nil
+}
 
 func (i *transactionsInteractor) Cancel(ctx context.Context, params CancelParams) (*models.Transaction, error) {
-	user, err := ctxmeta.User(ctx)
+	participant, err := i.authorizer.Authorize(ctx, params.OrganizationID, models.PermissionTxCancel)
 	if err != nil {
-		return nil, fmt.Errorf("error fetch user from context. %w", err)
+		return nil, err
 	}
 
-	participant, err := i.orgInteractor.Participant(ctx, organizations.ParticipantParams{
-		ID:             user.Id(),
-		OrganizationID: params.OrganizationID,
-		ActiveOnly:     true,
-		UsersOnly:      true,
-	})
+	tx, err := i.transaction(ctx, params.OrganizationID, params.TxID)
 	if err != nil {
-		return nil, fmt.Errorf("error fetch actor prticipant. %w", err)
+		return nil, err
 	}
 
-	if !participant.IsAdmin() {
-		return nil, fmt.Errorf("error not enouth rights. %w", organizations.ErrorUnauthorizedAccess)
+	// confirmed transaction is already being executed on-chain
+	if tx.Status != models.TransactionStatusPending {
+		return nil, ErrorTransactionNotPending
 	}
 
 	if err := i.txRepo.CancelTransaction(ctx, transactions.CancelTransactionParams{
@@ -256,21 +782,187 @@ func (i *transactionsInteractor) Cancel(ctx context.Context, params CancelParams
 		OrganizationId: params.OrganizationID,
 		UserId:         participant.Id(),
 	}); err != nil {
-		return nil, fmt.Errorf("error confirm transaction. %w", err)
+		if errors.Is(err, transactions.ErrorTransactionStatusConflict) {
+			return nil, ErrorTransactionNotPending
+		}
+
+		return nil, fmt.Errorf("error cancel transaction. %w", err)
 	}
 
-	tx, err := i.txRepo.GetTransactions(ctx, transactions.GetTransactionsParams{
-		Ids:            uuid.UUIDs{params.TxID},
-		OrganizationId: params.OrganizationID,
+	return i.transaction(ctx, params.OrganizationID, params.TxID)
+}
+
+func (i *transactionsInteractor) transaction(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*models.Transaction, error) {
+	txs, err := i.txRepo.GetTransactions(ctx, transactions.GetTransactionsParams{
+		Ids:            uuid.UUIDs{id},
+		OrganizationId: organizationID,
 		Limit:          1,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error fetch transaction. %w", err)
 	}
 
-	if len(tx) == 0 {
-		return nil, fmt.Errorf("error tx not found")
+	if len(txs) == 0 {
+		return nil, ErrorTransactionNotFound
+	}
+
+	if err = i.fillConfirmations(ctx, txs); err != nil {
+		return nil, err
+	}
+
+	if err = i.fillPrices(ctx, organizationID, txs); err != nil {
+		return nil, err
+	}
+
+	return txs[0], nil
+}
+
+// fillConfirmations sets owners confirmed the transactions
+func (i *transactionsInteractor) fillConfirmations(ctx context.Context, txs []*models.Transaction) error {
+	if len(txs) == 0 {
+		return nil
+	}
+
+	ids := make(uuid.UUIDs, len(txs))
+
+	for i, tx := range txs {
+		ids[i] = tx.Id
+	}
+
+	confirmations, err := i.txRepo.MultisigConfirmations(ctx, transactions.MultisigConfirmationsParams{
+		EntityIDs:  ids,
+		EntityType: models.MultisigConfirmationEntityTypeTransaction,
+	})
+	if err != nil {
+		return fmt.Errorf("error fetch transactions confirmations. %w", err)
+	}
+
+	rejections, err := i.txRepo.MultisigConfirmations(ctx, transactions.MultisigConfirmationsParams{
+		EntityIDs:  ids,
+		EntityType: models.MultisigConfirmationEntityTypeTransaction,
+		Rejected:   true,
+	})
+	if err != nil {
+		return fmt.Errorf("error fetch transactions rejections. %w", err)
+	}
+
+	for _, tx := range txs {
+		tx.ConfirmedBy = confirmations[tx.Id]
+		tx.Confirmations = len(tx.ConfirmedBy)
+		tx.RejectedBy = rejections[tx.Id]
+	}
+
+	return nil
+}
+
+// fillPrices sets rates snapshotted when the transactions were executed
+func (i *transactionsInteractor) fillPrices(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	txs []*models.Transaction,
+) error {
+	ids := make(uuid.UUIDs, 0, len(txs))
+
+	for _, tx := range txs {
+		if tx.Status == models.TransactionStatusExecuted {
+			ids = append(ids, tx.Id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	pricesList, err := i.pricesInteractor.List(ctx, prices.ListParams{
+		OrganizationID: organizationID,
+		SourceType:     models.LedgerEntrySourceTransaction,
+		SourceIDs:      ids,
+	})
+	if err != nil {
+		return fmt.Errorf("error fetch transactions prices. %w", err)
+	}
+
+	for _, tx := range txs {
+		for _, p := range pricesList {
+			if p.SourceID == tx.Id && p.Base == tx.Amount.Currency.Code {
+				tx.Price = p
+			}
+		}
+	}
+
+	return nil
+}
+
+func (i *transactionsInteractor) multisig(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	id uuid.UUID,
+) (*models.Multisig, error) {
+	if id == uuid.Nil {
+		return nil, ErrorMultisigNotFound
+	}
+
+	multisigs, err := i.chainInteractor.ListMultisigs(ctx, chain.ListMultisigsParams{
+		OrganizationID: organizationID,
+		IDs:            uuid.UUIDs{id},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch multisig. %w", err)
 	}
 
-	return tx[0], nil
+	if len(multisigs) == 0 {
+		return nil, ErrorMultisigNotFound
+	}
+
+	return &multisigs[0], nil
+}
+
+func isMultisigOwner(multisig *models.Multisig, userID uuid.UUID) bool {
+	for _, owner := range multisig.Owners {
+		if owner.Id() == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// transferParams returns multisig call sending the transaction amount. ETH is sent to the recipient as value,
+// tokens are sent by transfer(address,uint256) call of the asset contract. Amount must not be negative
+// or have more decimals than the currency base unit
+func transferParams(tx *models.Transaction) (chain.MultisigSubmitParams, error) {
+	if tx.Amount.Sign() < 0 {
+		return chain.MultisigSubmitParams{}, fmt.Errorf("error negative transaction amount. %w", ErrorInvalidAmount)
+	}
+
+	if tx.Asset == nil {
+		wei, err := tx.Amount.Wei()
+		if err != nil {
+			return chain.MultisigSubmitParams{}, errors.Join(ErrorInvalidAmount, err)
+		}
+
+		return chain.MultisigSubmitParams{
+			Destination: tx.ToAddr,
+			Value:       wei,
+		}, nil
+	}
+
+	units, err := tx.Amount.Units()
+	if err != nil {
+		return chain.MultisigSubmitParams{}, errors.Join(ErrorInvalidAmount, err)
+	}
+
+	data, err := erc20.TransferData(common.BytesToAddress(tx.ToAddr), units)
+	if err != nil {
+		return chain.MultisigSubmitParams{}, err
+	}
+
+	return chain.MultisigSubmitParams{
+		Destination: tx.Asset.ContractAddress,
+		Data:        data,
+	}, nil
 }
@@ -0,0 +1,515 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/emochka2007/block-accounting/internal/pkg/ctxmeta"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/authorizer"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/chain"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/confirmations"
+	"github.com/emochka2007/block-accounting/internal/usecase/interactors/jobs"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
+	"github.com/emochka2007/block-accounting/internal/usecase/repository/transactions"
+	"github.com/google/uuid"
+)
+
+// memoryOrganizations keeps a single organization and its participants in memory
+type memoryOrganizations struct {
+	organizations.Repository
+
+	org          *models.Organization
+	participants []models.OrganizationParticipant
+}
+
+func (r *memoryOrganizations) Get(_ context.Context, params organizations.GetParams) ([]*models.Organization, error) {
+	if !slices.Contains(params.Ids, r.org.ID) {
+		return nil, nil
+	}
+
+	return []*models.Organization{r.org}, nil
+}
+
+func (r *memoryOrganizations) Participants(
+	_ context.Context,
+	params organizations.ParticipantsParams,
+) ([]models.OrganizationParticipant, error) {
+	var participants []models.OrganizationParticipant
+
+	for _, p := range r.participants {
+		if params.OrganizationId != r.org.ID || !slices.Contains(params.Ids, p.Id()) {
+			continue
+		}
+
+		participants = append(participants, p)
+	}
+
+	if len(participants) == 0 {
+		return nil, organizations.ErrorNotFound
+	}
+
+	return participants, nil
+}
+
+type decisionKey struct {
+	entityID uuid.UUID
+	ownerID  uuid.UUID
+}
+
+// memoryTransactions keeps transactions, multisigs and owner decisions in memory
+type memoryTransactions struct {
+	transactions.Repository
+
+	mu        sync.Mutex
+	txs       map[uuid.UUID]*models.Transaction
+	multisigs []models.Multisig
+	decisions map[decisionKey]bool
+	// executing is set once the execution job is started and can not be cancelled
+	executing bool
+}
+
+func (r *memoryTransactions) GetTransactions(
+	_ context.Context,
+	params transactions.GetTransactionsParams,
+) ([]*models.Transaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var txs []*models.Transaction
+
+	for _, id := range params.Ids {
+		if tx, ok := r.txs[id]; ok && tx.OrganizationId == params.OrganizationId {
+			copied := *tx
+			txs = append(txs, &copied)
+		}
+	}
+
+	return txs, nil
+}
+
+func (r *memoryTransactions) ConfirmTransaction(_ context.Context, params transactions.ConfirmTransactionParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx, ok := r.txs[params.TxId]
+	if !ok || tx.Status != models.TransactionStatusPending || !tx.CancelledAt.IsZero() {
+		return transactions.ErrorTransactionStatusConflict
+	}
+
+	tx.Status = models.TransactionStatusConfirmed
+	tx.ConfirmedAt = time.Now()
+
+	return nil
+}
+
+func (r *memoryTransactions) CancelTransaction(_ context.Context, params transactions.CancelTransactionParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx, ok := r.txs[params.TxId]
+	if !ok || tx.Status != models.TransactionStatusPending || !tx.CancelledAt.IsZero() {
+		return transactions.ErrorTransactionStatusConflict
+	}
+
+	tx.CancelledAt = time.Now()
+
+	return nil
+}
+
+func (r *memoryTransactions) RevokeConfirmedTransaction(
+	_ context.Context,
+	params transactions.RevokeConfirmedTransactionParams,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx, ok := r.txs[params.TxId]
+	if !ok || tx.Status != models.TransactionStatusConfirmed || r.executing {
+		return transactions.ErrorTransactionStatusConflict
+	}
+
+	key := decisionKey{params.TxId, params.OwnerID}
+
+	if _, ok = r.decisions[key]; !ok {
+		return transactions.ErrorConfirmationNotFound
+	}
+
+	delete(r.decisions, key)
+
+	tx.Status = models.TransactionStatusPending
+	tx.ConfirmedAt = time.Time{}
+
+	return nil
+}
+
+func (r *memoryTransactions) ListMultisig(
+	_ context.Context,
+	params transactions.ListMultisigsParams,
+) ([]models.Multisig, error) {
+	var multisigs []models.Multisig
+
+	for _, m := range r.multisigs {
+		if m.OrganizationID == params.OrganizationID && slices.Contains(params.IDs, m.ID) {
+			multisigs = append(multisigs, m)
+		}
+	}
+
+	return multisigs, nil
+}
+
+func (r *memoryTransactions) ConfirmMultisig(_ context.Context, params transactions.ConfirmMultisigParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decisions[decisionKey{params.EntityID, params.CinfirmedBy.Id()}] = params.Rejected
+
+	return nil
+}
+
+func (r *memoryTransactions) RevokeMultisigConfirmation(
+	_ context.Context,
+	params transactions.RevokeMultisigConfirmationParams,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := decisionKey{params.EntityID, params.OwnerID}
+
+	if _, ok := r.decisions[key]; !ok {
+		return transactions.ErrorConfirmationNotFound
+	}
+
+	delete(r.decisions, key)
+
+	return nil
+}
+
+func (r *memoryTransactions) MultisigConfirmations(
+	_ context.Context,
+	params transactions.MultisigConfirmationsParams,
+) (map[uuid.UUID]uuid.UUIDs, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	owners := make(map[uuid.UUID]uuid.UUIDs)
+
+	for key, rejected := range r.decisions {
+		if rejected == params.Rejected && slices.Contains(params.EntityIDs, key.entityID) {
+			owners[key.entityID] = append(owners[key.entityID], key.ownerID)
+		}
+	}
+
+	return owners, nil
+}
+
+func (r *memoryTransactions) MultisigConfirmationsCount(
+	_ context.Context,
+	params transactions.MultisigConfirmationsCountParams,
+) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int
+
+	for key, rejected := range r.decisions {
+		if key.entityID == params.EntityID && !rejected {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// memoryJobs records enqueued jobs without running them
+type memoryJobs struct {
+	jobs.JobsInteractor
+
+	enqueued []jobs.EnqueueParams
+}
+
+func (i *memoryJobs) RegisterHandler(string, jobs.Handler) {}
+
+func (i *memoryJobs) Enqueue(_ context.Context, params jobs.EnqueueParams) (*models.Job, error) {
+	i.enqueued = append(i.enqueued, params)
+
+	return &models.Job{ID: uuid.New(), Kind: params.Kind}, nil
+}
+
+// memoryChain lists multisigs of the transactions repository
+type memoryChain struct {
+	chain.ChainInteractor
+
+	txRepo *memoryTransactions
+}
+
+func (i *memoryChain) ListMultisigs(ctx context.Context, params chain.ListMultisigsParams) ([]models.Multisig, error) {
+	return i.txRepo.ListMultisig(ctx, transactions.ListMultisigsParams{
+		OrganizationID: params.OrganizationID,
+		IDs:            params.IDs,
+	})
+}
+
+type fixture struct {
+	interactor TransactionsInteractor
+	txRepo     *memoryTransactions
+	jobs       *memoryJobs
+	orgID      uuid.UUID
+	txID       uuid.UUID
+
+	// owners are approvers owning the 2 of 3 multisig
+	owners     []*models.OrganizationUser
+	accountant *models.OrganizationUser
+}
+
+func newOrganizationUser(role models.Role) *models.OrganizationUser {
+	return &models.OrganizationUser{
+		User: models.User{
+			ID:        uuid.New(),
+			Activated: true,
+		},
+		OrgRole: role,
+	}
+}
+
+func as(user *models.OrganizationUser) context.Context {
+	return ctxmeta.UserContext(context.Background(), &user.User)
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := &fixture{
+		orgID: uuid.New(),
+		txID:  uuid.New(),
+		owners: []*models.OrganizationUser{
+			newOrganizationUser(models.RoleApprover),
+			newOrganizationUser(models.RoleApprover),
+			newOrganizationUser(models.RoleApprover),
+		},
+		accountant: newOrganizationUser(models.RoleAccountant),
+		jobs:       &memoryJobs{},
+	}
+
+	multisig := models.Multisig{
+		ID:                    uuid.New(),
+		OrganizationID:        f.orgID,
+		ConfirmationsRequired: 2,
+	}
+
+	participants := []models.OrganizationParticipant{f.accountant}
+
+	for _, o := range f.owners {
+		multisig.Owners = append(multisig.Owners, o)
+		participants = append(participants, o)
+	}
+
+	f.txRepo = &memoryTransactions{
+		txs: map[uuid.UUID]*models.Transaction{
+			f.txID: {
+				Id:             f.txID,
+				OrganizationId: f.orgID,
+				CreatedBy:      f.accountant,
+				MultisigID:     multisig.ID,
+				Status:         models.TransactionStatusPending,
+			},
+		},
+		multisigs: []models.Multisig{multisig},
+		decisions: make(map[decisionKey]bool),
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	a := authorizer.NewAuthorizer(log, &memoryOrganizations{
+		org:          &models.Organization{ID: f.orgID},
+		participants: participants,
+	})
+
+	f.interactor = NewTransactionsInteractor(
+		log,
+		f.txRepo,
+		nil,
+		nil,
+		&memoryChain{txRepo: f.txRepo},
+		f.jobs,
+		confirmations.NewConfirmationsInteractor(log, f.txRepo, a),
+		nil,
+		nil,
+		nil,
+		a,
+	)
+
+	return f
+}
+
+func (f *fixture) confirm(user *models.OrganizationUser) (*models.Transaction, error) {
+	return f.interactor.Confirm(as(user), ConfirmParams{TxID: f.txID, OrganizationID: f.orgID})
+}
+
+func (f *fixture) reject(user *models.OrganizationUser) (*models.Transaction, error) {
+	return f.interactor.Reject(as(user), RejectParams{TxID: f.txID, OrganizationID: f.orgID})
+}
+
+func (f *fixture) revoke(user *models.OrganizationUser) (*models.Transaction, error) {
+	return f.interactor.Revoke(as(user), RevokeParams{TxID: f.txID, OrganizationID: f.orgID})
+}
+
+func TestConfirm(t *testing.T) {
+	f := newFixture(t)
+
+	tx, err := f.confirm(f.owners[0])
+	if err != nil {
+		t.Fatalf("Confirm() error: %v", err)
+	}
+
+	if tx.Status != models.TransactionStatusPending || tx.Confirmations != 1 || len(f.jobs.enqueued) != 0 {
+		t.Fatalf("Confirm() = %+v, want pending transaction with a single confirmation", tx)
+	}
+
+	if _, err = f.confirm(f.accountant); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("Confirm() by accountant error = %v, want ErrorPermissionDenied", err)
+	}
+
+	if tx, err = f.confirm(f.owners[1]); err != nil {
+		t.Fatalf("Confirm() error: %v", err)
+	}
+
+	if tx.Status != models.TransactionStatusConfirmed || tx.Confirmations != 2 {
+		t.Fatalf("Confirm() = %+v, want confirmed transaction", tx)
+	}
+
+	if len(f.jobs.enqueued) != 1 || f.jobs.enqueued[0].Kind != JobKindTxExecute ||
+		f.jobs.enqueued[0].Payload.(executePayload).TxID != f.txID {
+		t.Fatalf("enqueued jobs = %+v, want single execution job", f.jobs.enqueued)
+	}
+
+	if _, err = f.confirm(f.owners[2]); !errors.Is(err, ErrorTransactionNotPending) {
+		t.Fatalf("Confirm() of confirmed transaction error = %v, want ErrorTransactionNotPending", err)
+	}
+
+	if _, err = f.interactor.Confirm(as(f.owners[0]), ConfirmParams{
+		TxID:           uuid.New(),
+		OrganizationID: f.orgID,
+	}); !errors.Is(err, ErrorTransactionNotFound) {
+		t.Fatalf("Confirm() of unknown transaction error = %v, want ErrorTransactionNotFound", err)
+	}
+}
+
+func TestReject(t *testing.T) {
+	f := newFixture(t)
+
+	tx, err := f.reject(f.owners[0])
+	if err != nil {
+		t.Fatalf("Reject() error: %v", err)
+	}
+
+	if !tx.CancelledAt.IsZero() || !slices.Equal(tx.RejectedBy, uuid.UUIDs{f.owners[0].Id()}) {
+		t.Fatalf("Reject() = %+v, want pending transaction with a single rejection", tx)
+	}
+
+	if tx, err = f.reject(f.owners[1]); err != nil {
+		t.Fatalf("Reject() error: %v", err)
+	}
+
+	// single owner left can not confirm the transaction
+	if tx.CancelledAt.IsZero() || len(tx.RejectedBy) != 2 || len(f.jobs.enqueued) != 0 {
+		t.Fatalf("Reject() = %+v, want cancelled transaction", tx)
+	}
+
+	if _, err = f.confirm(f.owners[2]); !errors.Is(err, ErrorTransactionNotPending) {
+		t.Fatalf("Confirm() of rejected transaction error = %v, want ErrorTransactionNotPending", err)
+	}
+
+	if _, err = f.revoke(f.owners[0]); !errors.Is(err, ErrorTransactionNotPending) {
+		t.Fatalf("Revoke() of rejected transaction error = %v, want ErrorTransactionNotPending", err)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	f := newFixture(t)
+
+	if _, err := f.revoke(f.owners[0]); !errors.Is(err, confirmations.ErrorConfirmationNotFound) {
+		t.Fatalf("Revoke() without confirmation error = %v, want ErrorConfirmationNotFound", err)
+	}
+
+	if _, err := f.confirm(f.owners[0]); err != nil {
+		t.Fatalf("Confirm() error: %v", err)
+	}
+
+	tx, err := f.revoke(f.owners[0])
+	if err != nil {
+		t.Fatalf("Revoke() error: %v", err)
+	}
+
+	if tx.Status != models.TransactionStatusPending || tx.Confirmations != 0 {
+		t.Fatalf("Revoke() = %+v, want pending transaction without confirmations", tx)
+	}
+}
+
+func TestRevokeConfirmed(t *testing.T) {
+	f := newFixture(t)
+
+	for _, o := range f.owners[:2] {
+		if _, err := f.confirm(o); err != nil {
+			t.Fatalf("Confirm() error: %v", err)
+		}
+	}
+
+	if _, err := f.revoke(f.owners[2]); !errors.Is(err, confirmations.ErrorConfirmationNotFound) {
+		t.Fatalf("Revoke() without confirmation error = %v, want ErrorConfirmationNotFound", err)
+	}
+
+	tx, err := f.revoke(f.owners[1])
+	if err != nil {
+		t.Fatalf("Revoke() error: %v", err)
+	}
+
+	if tx.Status != models.TransactionStatusPending || !tx.ConfirmedAt.IsZero() ||
+		!slices.Equal(tx.ConfirmedBy, uuid.UUIDs{f.owners[0].Id()}) {
+		t.Fatalf("Revoke() = %+v, want pending transaction confirmed by the first owner", tx)
+	}
+
+	// confirmed again, but execution job is already running
+	if _, err = f.confirm(f.owners[1]); err != nil {
+		t.Fatalf("Confirm() error: %v", err)
+	}
+
+	f.txRepo.executing = true
+
+	if _, err = f.revoke(f.owners[1]); !errors.Is(err, ErrorTransactionNotPending) {
+		t.Fatalf("Revoke() of executing transaction error = %v, want ErrorTransactionNotPending", err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	f := newFixture(t)
+
+	if _, err := f.interactor.Cancel(as(f.owners[0]), CancelParams{
+		TxID:           f.txID,
+		OrganizationID: f.orgID,
+	}); !errors.Is(err, authorizer.ErrorPermissionDenied) {
+		t.Fatalf("Cancel() by approver error = %v, want ErrorPermissionDenied", err)
+	}
+
+	tx, err := f.interactor.Cancel(as(f.accountant), CancelParams{TxID: f.txID, OrganizationID: f.orgID})
+	if err != nil {
+		t.Fatalf("Cancel() error: %v", err)
+	}
+
+	if tx.CancelledAt.IsZero() {
+		t.Fatalf("Cancel() = %+v, want cancelled transaction", tx)
+	}
+
+	if _, err = f.interactor.Cancel(as(f.accountant), CancelParams{
+		TxID:           f.txID,
+		OrganizationID: f.orgID,
+	}); !errors.Is(err, ErrorTransactionNotPending) {
+		t.Fatalf("Cancel() of cancelled transaction error = %v, want ErrorTransactionNotPending", err)
+	}
+}
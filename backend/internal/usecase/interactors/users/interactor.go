@@ -24,13 +24,17 @@ type CreateParams struct {
 	Phone    string
 	Tg       string
 	Mnemonic string
-	Activate bool
-	Owner    bool
-	Admin    bool
+	// PublicKey is the wallet address of users joined with wallet signature. Used if Mnemonic is empty,
+	// such users have no custodial seed
+	PublicKey []byte
+	Activate  bool
+	Owner     bool
+	Admin     bool
 }
 
 type GetParams struct {
 	Ids            uuid.UUIDs
+	PKs            [][]byte
 	OrganizationId uuid.UUID
 	Mnemonic       string
 	Seed           []byte
@@ -73,6 +77,10 @@ func NewUsersInteractor(
 }
 
 func (i *usersInteractor) Create(ctx context.Context, params CreateParams) (*models.User, error) {
+	if params.Mnemonic == "" && len(params.PublicKey) > 0 {
+		return i.createWalletUser(ctx, params)
+	}
+
 	seed, err := hdwallet.NewSeedFromMnemonic(params.Mnemonic)
 	if err != nil {
 		return nil, fmt.Errorf("error convert mnemonic into a seed. %w", err)
@@ -109,6 +117,30 @@ func (i *usersInteractor) Create(ctx context.Context, params CreateParams) (*mod
 	return user, nil
 }
 
+func (i *usersInteractor) createWalletUser(ctx context.Context, params CreateParams) (*models.User, error) {
+	user := models.NewUser(
+		uuid.Must(uuid.NewV7()),
+		nil,
+		params.Activate,
+		time.Now(),
+	)
+
+	user.Name = params.Name
+	user.PK = params.PublicKey
+
+	user.Credentails = &models.UserCredentials{
+		Email:    params.Email,
+		Phone:    params.Phone,
+		Telegram: params.Tg,
+	}
+
+	if err := i.usersRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("error create new wallet user. %w", err)
+	}
+
+	return user, nil
+}
+
 func (i *usersInteractor) Update(ctx context.Context, newState models.User) error {
 	return nil
 }
@@ -120,6 +152,7 @@ func (i *usersInteractor) Activate(ctx context.Context, params ActivateParams) e
 func (i *usersInteractor) Get(ctx context.Context, params GetParams) ([]*models.User, error) {
 	users, err := i.usersRepo.Get(ctx, users.GetParams{
 		Ids:            params.Ids,
+		PKs:            params.PKs,
 		OrganizationId: params.OrganizationId,
 		Seed:           params.Seed,
 	})
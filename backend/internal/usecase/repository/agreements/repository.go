@@ -0,0 +1,477 @@
+package agreements
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	sqltools "github.com/emochka2007/block-accounting/internal/pkg/sqlutils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorOperationStatusConflict = errors.New("agreement operation status has been changed")
+)
+
+type ListAgreementsParams struct {
+	IDs            uuid.UUIDs
+	OrganizationID uuid.UUID
+	Limit          int64
+}
+
+type UpdateAgreementParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Status         models.AgreementStatus
+	Address        []byte
+	RequestURL     string
+	// Outcome is saved when ResolvedAt is set
+	Outcome    bool
+	ResolvedAt time.Time
+	UpdatedAt  time.Time
+}
+
+type ListOperationsParams struct {
+	IDs            uuid.UUIDs
+	OrganizationID uuid.UUID
+	AgreementIDs   uuid.UUIDs
+	Limit          int64
+}
+
+type UpdateOperationParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Status         models.AgreementOperationStatus
+	// FromStatuses if set, operation is updated only if its current status is one of them.
+	// Otherwise ErrorOperationStatusConflict is returned
+	FromStatuses []models.AgreementOperationStatus
+	// TxIndex is saved with AgreementOperationStatusSubmitted status
+	TxIndex    int64
+	TxHash     string
+	ExecutedAt time.Time
+	UpdatedAt  time.Time
+}
+
+type Repository interface {
+	CreateAgreement(ctx context.Context, agreement models.Agreement) error
+	ListAgreements(ctx context.Context, params ListAgreementsParams) ([]*models.Agreement, error)
+	UpdateAgreement(ctx context.Context, params UpdateAgreementParams) error
+
+	AddOperation(ctx context.Context, op models.AgreementOperation) error
+	ListOperations(ctx context.Context, params ListOperationsParams) ([]*models.AgreementOperation, error)
+	UpdateOperation(ctx context.Context, params UpdateOperationParams) error
+}
+
+type repositorySQL struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repositorySQL{
+		db: db,
+	}
+}
+
+func (s *repositorySQL) Conn(ctx context.Context) sqltools.DBTX {
+	if tx, ok := ctx.Value(sqltools.TxCtxKey).(*sql.Tx); ok {
+		return tx
+	}
+
+	return s.db
+}
+
+func (r *repositorySQL) CreateAgreement(ctx context.Context, agreement models.Agreement) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Insert("agreements").
+			Columns(
+				"id",
+				"organization_id",
+				"multisig_id",
+				"title",
+				"status",
+				"created_by",
+				"created_at",
+				"updated_at",
+			).
+			Values(
+				agreement.ID,
+				agreement.OrganizationID,
+				agreement.MultisigID,
+				agreement.Title,
+				agreement.Status,
+				agreement.CreatedBy,
+				agreement.CreatedAt,
+				agreement.UpdatedAt,
+			).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error insert agreement. %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) ListAgreements(
+	ctx context.Context,
+	params ListAgreementsParams,
+) ([]*models.Agreement, error) {
+	agreements := make([]*models.Agreement, 0, len(params.IDs))
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"organization_id",
+			"multisig_id",
+			"title",
+			"address",
+			"status",
+			"request_url",
+			"outcome",
+			"resolved_at",
+			"created_by",
+			"created_at",
+			"updated_at",
+		).From("agreements").
+			Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			}).
+			OrderBy("created_at desc").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.IDs) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.IDs,
+			})
+		}
+
+		if params.Limit <= 0 {
+			params.Limit = 100
+		}
+
+		query = query.Limit(uint64(params.Limit))
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch agreements from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var (
+				id             uuid.UUID
+				organizationID uuid.UUID
+				multisigID     uuid.UUID
+				title          string
+				address        []byte
+				status         int
+				requestURL     sql.NullString
+				outcome        sql.NullBool
+				resolvedAt     sql.NullTime
+				createdBy      uuid.UUID
+				createdAt      time.Time
+				updatedAt      time.Time
+			)
+
+			if err = rows.Scan(
+				&id,
+				&organizationID,
+				&multisigID,
+				&title,
+				&address,
+				&status,
+				&requestURL,
+				&outcome,
+				&resolvedAt,
+				&createdBy,
+				&createdAt,
+				&updatedAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			agreements = append(agreements, &models.Agreement{
+				ID:             id,
+				OrganizationID: organizationID,
+				MultisigID:     multisigID,
+				Title:          title,
+				Address:        address,
+				Status:         models.AgreementStatus(status),
+				RequestURL:     requestURL.String,
+				Outcome:        outcome.Bool,
+				ResolvedAt:     resolvedAt.Time,
+				CreatedBy:      createdBy,
+				CreatedAt:      createdAt,
+				UpdatedAt:      updatedAt,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return agreements, nil
+}
+
+func (r *repositorySQL) UpdateAgreement(ctx context.Context, params UpdateAgreementParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		values := sq.Eq{
+			"status":     params.Status,
+			"updated_at": params.UpdatedAt,
+		}
+
+		if len(params.Address) > 0 {
+			values["address"] = params.Address
+		}
+
+		if params.RequestURL != "" {
+			values["request_url"] = params.RequestURL
+		}
+
+		if !params.ResolvedAt.IsZero() {
+			values["outcome"] = params.Outcome
+			values["resolved_at"] = params.ResolvedAt
+		}
+
+		query := sq.Update("agreements").
+			SetMap(values).
+			Where(sq.Eq{
+				"id":              params.ID,
+				"organization_id": params.OrganizationID,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error update agreement. %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) AddOperation(ctx context.Context, op models.AgreementOperation) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		columns := []string{
+			"id",
+			"agreement_id",
+			"organization_id",
+			"multisig_id",
+			"kind",
+			"status",
+			"created_by",
+			"created_at",
+			"updated_at",
+		}
+
+		values := []any{
+			op.ID,
+			op.AgreementID,
+			op.OrganizationID,
+			op.MultisigID,
+			op.Kind,
+			op.Status,
+			op.CreatedBy,
+			op.CreatedAt,
+			op.UpdatedAt,
+		}
+
+		if op.URL != "" {
+			columns = append(columns, "url")
+			values = append(values, op.URL)
+		}
+
+		query := sq.Insert("agreement_operations").
+			Columns(columns...).
+			Values(values...).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error insert agreement operation. %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) ListOperations(
+	ctx context.Context,
+	params ListOperationsParams,
+) ([]*models.AgreementOperation, error) {
+	ops := make([]*models.AgreementOperation, 0, len(params.IDs))
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"agreement_id",
+			"organization_id",
+			"multisig_id",
+			"kind",
+			"status",
+			"url",
+			"tx_index",
+			"tx_hash",
+			"created_by",
+			"created_at",
+			"updated_at",
+			"executed_at",
+		).From("agreement_operations").
+			Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			}).
+			OrderBy("created_at desc").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.IDs) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.IDs,
+			})
+		}
+
+		if len(params.AgreementIDs) > 0 {
+			query = query.Where(sq.Eq{
+				"agreement_id": params.AgreementIDs,
+			})
+		}
+
+		if params.Limit <= 0 {
+			params.Limit = 100
+		}
+
+		query = query.Limit(uint64(params.Limit))
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch agreement operations from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var (
+				id             uuid.UUID
+				agreementID    uuid.UUID
+				organizationID uuid.UUID
+				multisigID     uuid.UUID
+				kind           int
+				status         int
+				url            sql.NullString
+				txIndex        sql.NullInt64
+				txHash         sql.NullString
+				createdBy      uuid.UUID
+				createdAt      time.Time
+				updatedAt      time.Time
+				executedAt     sql.NullTime
+			)
+
+			if err = rows.Scan(
+				&id,
+				&agreementID,
+				&organizationID,
+				&multisigID,
+				&kind,
+				&status,
+				&url,
+				&txIndex,
+				&txHash,
+				&createdBy,
+				&createdAt,
+				&updatedAt,
+				&executedAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			ops = append(ops, &models.AgreementOperation{
+				ID:             id,
+				AgreementID:    agreementID,
+				OrganizationID: organizationID,
+				MultisigID:     multisigID,
+				Kind:           models.AgreementOperationKind(kind),
+				Status:         models.AgreementOperationStatus(status),
+				URL:            url.String,
+				TxIndex:        txIndex.Int64,
+				TxHash:         txHash.String,
+				CreatedBy:      createdBy,
+				CreatedAt:      createdAt,
+				UpdatedAt:      updatedAt,
+				ExecutedAt:     executedAt.Time,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
+
+func (r *repositorySQL) UpdateOperation(ctx context.Context, params UpdateOperationParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		values := sq.Eq{
+			"status":     params.Status,
+			"updated_at": params.UpdatedAt,
+		}
+
+		if params.Status == models.AgreementOperationStatusSubmitted {
+			values["tx_index"] = params.TxIndex
+		}
+
+		if params.TxHash != "" {
+			values["tx_hash"] = params.TxHash
+		}
+
+		if !params.ExecutedAt.IsZero() {
+			values["executed_at"] = params.ExecutedAt
+		}
+
+		query := sq.Update("agreement_operations").
+			SetMap(values).
+			Where(sq.Eq{
+				"id":              params.ID,
+				"organization_id": params.OrganizationID,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.FromStatuses) > 0 {
+			query = query.Where(sq.Eq{
+				"status": params.FromStatuses,
+			})
+		}
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error update agreement operation. %w", err)
+		}
+
+		if len(params.FromStatuses) == 0 {
+			return nil
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorOperationStatusConflict
+		}
+
+		return nil
+	})
+}
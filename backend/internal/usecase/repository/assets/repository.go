@@ -0,0 +1,136 @@
+package assets
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	sqltools "github.com/emochka2007/block-accounting/internal/pkg/sqlutils"
+	"github.com/google/uuid"
+)
+
+type ListParams struct {
+	OrganizationID uuid.UUID
+	IDs            uuid.UUIDs
+}
+
+type Repository interface {
+	Add(ctx context.Context, asset models.Asset) error
+	List(ctx context.Context, params ListParams) ([]*models.Asset, error)
+}
+
+type repositorySQL struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repositorySQL{
+		db: db,
+	}
+}
+
+func (s *repositorySQL) Conn(ctx context.Context) sqltools.DBTX {
+	if tx, ok := ctx.Value(sqltools.TxCtxKey).(*sql.Tx); ok {
+		return tx
+	}
+
+	return s.db
+}
+
+func (r *repositorySQL) Add(ctx context.Context, asset models.Asset) error {
+	query := sq.Insert("assets").
+		Columns(
+			"id",
+			"organization_id",
+			"symbol",
+			"contract_address",
+			"decimals",
+			"chain_id",
+			"created_by",
+			"created_at",
+		).
+		Values(
+			asset.ID,
+			asset.OrganizationID,
+			asset.Symbol,
+			asset.ContractAddress,
+			asset.Decimals,
+			asset.ChainID,
+			asset.CreatedBy,
+			asset.CreatedAt,
+		).
+		PlaceholderFormat(sq.Dollar)
+
+	if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+		return fmt.Errorf("error insert asset. %w", err)
+	}
+
+	return nil
+}
+
+func (r *repositorySQL) List(ctx context.Context, params ListParams) ([]*models.Asset, error) {
+	assets := make([]*models.Asset, 0)
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"organization_id",
+			"symbol",
+			"contract_address",
+			"decimals",
+			"chain_id",
+			"created_by",
+			"created_at",
+		).From("assets").
+			Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			}).
+			OrderBy("symbol").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.IDs) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.IDs,
+			})
+		}
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch assets from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			a := new(models.Asset)
+
+			if err = rows.Scan(
+				&a.ID,
+				&a.OrganizationID,
+				&a.Symbol,
+				&a.ContractAddress,
+				&a.Decimals,
+				&a.ChainID,
+				&a.CreatedBy,
+				&a.CreatedAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			assets = append(assets, a)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
@@ -3,5 +3,10 @@ package auth
 import "errors"
 
 var (
-	ErrorInviteLinkExpired = errors.New("invite link expired")
+	ErrorInviteLinkExpired   = errors.New("invite link expired")
+	ErrorInviteNotFound      = errors.New("invite not found")
+	ErrorInviteRevoked       = errors.New("invite revoked")
+	ErrorInviteUsesExhausted = errors.New("invite uses exhausted")
+	ErrorSessionNotFound     = errors.New("session not found")
+	ErrorNonceNotFound       = errors.New("nonce not found")
 )
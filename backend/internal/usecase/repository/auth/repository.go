@@ -14,7 +14,8 @@ import (
 )
 
 type AddTokenParams struct {
-	UserId uuid.UUID
+	SessionId uuid.UUID
+	UserId    uuid.UUID
 
 	Token          string
 	TokenExpiredAt time.Time
@@ -25,6 +26,7 @@ type AddTokenParams struct {
 	CreatedAt time.Time
 
 	RemoteAddr string
+	UserAgent  string
 }
 
 type GetTokenParams struct {
@@ -46,7 +48,8 @@ type RefreshTokenParams struct {
 }
 
 type AccessToken struct {
-	UserId uuid.UUID
+	SessionId uuid.UUID
+	UserId    uuid.UUID
 
 	Token          string
 	TokenExpiredAt time.Time
@@ -55,15 +58,33 @@ type AccessToken struct {
 	RefreshTokenExpiredAt time.Time
 
 	CreatedAt time.Time
+	RevokedAt time.Time
+
+	RemoteAddr string
+	UserAgent  string
 }
 
 type Repository interface {
 	AddToken(ctx context.Context, params AddTokenParams) error
 	GetTokens(ctx context.Context, params GetTokenParams) (*AccessToken, error)
+	// RefreshToken replaces session tokens. Returns ErrorSessionNotFound if session is revoked
 	RefreshToken(ctx context.Context, params RefreshTokenParams) error
+	Sessions(ctx context.Context, params SessionsParams) ([]*AccessToken, error)
+	// RevokeSessions revokes active user sessions and returns revoked ones
+	RevokeSessions(ctx context.Context, params RevokeSessionsParams) ([]*AccessToken, error)
+
+	AddNonce(ctx context.Context, nonce *models.AuthNonce) error
+	// ConsumeNonce marks not expired nonce issued for the address as used.
+	// Returns ErrorNonceNotFound if there is no such nonce or it is already used
+	ConsumeNonce(ctx context.Context, params ConsumeNonceParams) (*models.AuthNonce, error)
 
 	AddInvite(ctx context.Context, params AddInviteParams) error
-	MarkAsUsedLink(ctx context.Context, linkHash string, usedAt time.Time) (uuid.UUID, error)
+	// MarkAsUsedLink counts invite use. Returns ErrorInviteNotFound, ErrorInviteRevoked,
+	// ErrorInviteLinkExpired or ErrorInviteUsesExhausted if invite can not be used
+	MarkAsUsedLink(ctx context.Context, linkHash string, usedAt time.Time) (*models.Invite, error)
+	Invites(ctx context.Context, params InvitesParams) ([]*models.Invite, error)
+	// RevokeInvite revokes not revoked invite. Returns ErrorInviteNotFound if there is no such invite
+	RevokeInvite(ctx context.Context, params RevokeInviteParams) error
 }
 
 type repositorySQL struct {
@@ -74,20 +95,26 @@ func (r *repositorySQL) AddToken(ctx context.Context, params AddTokenParams) err
 	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
 		query := sq.Insert("access_tokens").
 			Columns(
+				"id",
 				"user_id",
 				"token",
 				"refresh_token",
 				"token_expired_at",
 				"refresh_token_expired_at",
+				"created_at",
 				"remote_addr",
+				"user_agent",
 			).
 			Values(
+				params.SessionId,
 				params.UserId,
 				params.Token,
 				params.RefreshToken,
 				params.TokenExpiredAt,
 				params.RefreshTokenExpiredAt,
+				params.CreatedAt,
 				params.RemoteAddr,
+				params.UserAgent,
 			).PlaceholderFormat(sq.Dollar)
 
 		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
@@ -115,12 +142,23 @@ func (r *repositorySQL) RefreshToken(ctx context.Context, params RefreshTokenPar
 				"user_id":       params.UserId,
 				"token":         params.OldToken,
 				"refresh_token": params.OldRefreshToken,
+				"revoked_at":    nil,
 			}).PlaceholderFormat(sq.Dollar)
 
-		if _, err := updateQuery.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+		res, err := updateQuery.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
 			return fmt.Errorf("error update tokens. %w", err)
 		}
 
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorSessionNotFound
+		}
+
 		return nil
 	}); err != nil {
 		return err
@@ -133,18 +171,11 @@ func (r *repositorySQL) GetTokens(ctx context.Context, params GetTokenParams) (*
 	var token *AccessToken = new(AccessToken)
 
 	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
-		query := sq.Select(
-			"user_id",
-			"token",
-			"token_expired_at",
-			"refresh_token",
-			"refresh_token_expired_at",
-			"created_at",
-		).From("access_tokens").
+		query := tokensSelect().
 			Where(sq.Eq{
 				"token":   params.Token,
 				"user_id": params.UserId,
-			}).PlaceholderFormat(sq.Dollar)
+			})
 
 		if params.RefreshToken != "" {
 			query = query.Where(sq.Eq{
@@ -152,28 +183,122 @@ func (r *repositorySQL) GetTokens(ctx context.Context, params GetTokenParams) (*
 			})
 		}
 
+		tokens, err := r.scanTokens(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		if len(tokens) > 0 {
+			token = tokens[0]
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+type SessionsParams struct {
+	UserId uuid.UUID
+	Ids    uuid.UUIDs
+	// ActiveOnly filters out revoked and expired sessions
+	ActiveOnly bool
+}
+
+func (r *repositorySQL) Sessions(ctx context.Context, params SessionsParams) ([]*AccessToken, error) {
+	var sessions []*AccessToken
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := tokensSelect().
+			Where(sq.Eq{
+				"user_id": params.UserId,
+			}).
+			OrderBy("created_at desc")
+
+		if len(params.Ids) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.Ids,
+			})
+		}
+
+		if params.ActiveOnly {
+			query = query.Where(sq.Eq{
+				"revoked_at": nil,
+			}).Where(sq.Gt{
+				"refresh_token_expired_at": time.Now(),
+			})
+		}
+
+		sessions, err = r.scanTokens(ctx, query)
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+type RevokeSessionsParams struct {
+	UserId uuid.UUID
+	// Ids limits revoked sessions, all user sessions are revoked if empty
+	Ids       uuid.UUIDs
+	ExceptIds uuid.UUIDs
+	RevokedAt time.Time
+}
+
+func (r *repositorySQL) RevokeSessions(ctx context.Context, params RevokeSessionsParams) ([]*AccessToken, error) {
+	var sessions []*AccessToken
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Update("access_tokens").
+			Set("revoked_at", params.RevokedAt).
+			Where(sq.Eq{
+				"user_id":    params.UserId,
+				"revoked_at": nil,
+			}).
+			Suffix("returning id, user_id, refresh_token_expired_at").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.Ids) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.Ids,
+			})
+		}
+
+		if len(params.ExceptIds) > 0 {
+			query = query.Where(sq.NotEq{
+				"id": params.ExceptIds,
+			})
+		}
+
 		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
 		if err != nil {
-			return fmt.Errorf("error fetch token from database. %w", err)
+			return fmt.Errorf("error revoke sessions. %w", err)
 		}
 
 		defer func() {
-			if cErr := rows.Close(); cErr != nil {
-				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			if closeErr := rows.Close(); closeErr != nil {
+				err = errors.Join(fmt.Errorf("error close rows. %w", closeErr), err)
 			}
 		}()
 
 		for rows.Next() {
-			if err := rows.Scan(
-				&token.UserId,
-				&token.Token,
-				&token.TokenExpiredAt,
-				&token.RefreshToken,
-				&token.RefreshTokenExpiredAt,
-				&token.CreatedAt,
+			session := &AccessToken{
+				RevokedAt: params.RevokedAt,
+			}
+
+			if err = rows.Scan(
+				&session.SessionId,
+				&session.UserId,
+				&session.RefreshTokenExpiredAt,
 			); err != nil {
 				return fmt.Errorf("error scan row. %w", err)
 			}
+
+			sessions = append(sessions, session)
 		}
 
 		return nil
@@ -181,13 +306,152 @@ func (r *repositorySQL) GetTokens(ctx context.Context, params GetTokenParams) (*
 		return nil, err
 	}
 
-	return token, nil
+	return sessions, nil
+}
+
+func (r *repositorySQL) AddNonce(ctx context.Context, nonce *models.AuthNonce) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Insert("auth_nonces").
+			Columns(
+				"nonce",
+				"address",
+				"message",
+				"created_at",
+				"expired_at",
+			).
+			Values(
+				nonce.Nonce,
+				nonce.Address,
+				nonce.Message,
+				nonce.CreatedAt,
+				nonce.ExpiredAt,
+			).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error add auth nonce. %w", err)
+		}
+
+		return nil
+	})
+}
+
+type ConsumeNonceParams struct {
+	Nonce   string
+	Address []byte
+	UsedAt  time.Time
+}
+
+func (r *repositorySQL) ConsumeNonce(ctx context.Context, params ConsumeNonceParams) (*models.AuthNonce, error) {
+	nonce := &models.AuthNonce{
+		Nonce:   params.Nonce,
+		Address: params.Address,
+		UsedAt:  params.UsedAt,
+	}
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Update("auth_nonces").
+			Set("used_at", params.UsedAt).
+			Where(sq.Eq{
+				"nonce":   params.Nonce,
+				"address": params.Address,
+				"used_at": nil,
+			}).
+			Where(sq.Gt{
+				"expired_at": params.UsedAt,
+			}).
+			Suffix("returning message, created_at, expired_at").
+			PlaceholderFormat(sq.Dollar)
+
+		err := query.RunWith(r.Conn(ctx)).QueryRowContext(ctx).Scan(
+			&nonce.Message,
+			&nonce.CreatedAt,
+			&nonce.ExpiredAt,
+		)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorNonceNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("error consume auth nonce. %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return nonce, nil
+}
+
+func tokensSelect() sq.SelectBuilder {
+	return sq.Select(
+		"id",
+		"user_id",
+		"token",
+		"token_expired_at",
+		"refresh_token",
+		"refresh_token_expired_at",
+		"created_at",
+		"revoked_at",
+		"remote_addr",
+		"user_agent",
+	).From("access_tokens").PlaceholderFormat(sq.Dollar)
+}
+
+func (r *repositorySQL) scanTokens(ctx context.Context, query sq.SelectBuilder) (tokens []*AccessToken, err error) {
+	rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch tokens from database. %w", err)
+	}
+
+	defer func() {
+		if cErr := rows.Close(); cErr != nil {
+			err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			token      = new(AccessToken)
+			revokedAt  sql.NullTime
+			remoteAddr sql.NullString
+			userAgent  sql.NullString
+		)
+
+		if err = rows.Scan(
+			&token.SessionId,
+			&token.UserId,
+			&token.Token,
+			&token.TokenExpiredAt,
+			&token.RefreshToken,
+			&token.RefreshTokenExpiredAt,
+			&token.CreatedAt,
+			&revokedAt,
+			&remoteAddr,
+			&userAgent,
+		); err != nil {
+			return nil, fmt.Errorf("error scan row. %w", err)
+		}
+
+		token.RevokedAt = revokedAt.Time
+		token.RemoteAddr = remoteAddr.String
+		token.UserAgent = userAgent.String
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
 }
 
 type AddInviteParams struct {
 	LinkHash       string
 	OrganizationID uuid.UUID
 	CreatedBy      models.User
+	Role           models.Role
+	Position       string
+	PublicKey      []byte
+	MaxUses        int
 	CreatedAt      time.Time
 	ExpiredAt      time.Time
 }
@@ -201,12 +465,20 @@ func (r *repositorySQL) AddInvite(
 			"link_hash",
 			"organization_id",
 			"created_by",
+			"role",
+			"position",
+			"public_key",
+			"max_uses",
 			"created_at",
 			"expired_at",
 		).Values(
 			params.LinkHash,
 			params.OrganizationID,
 			params.CreatedBy.Id(),
+			params.Role,
+			params.Position,
+			params.PublicKey,
+			params.MaxUses,
 			params.CreatedAt,
 			params.ExpiredAt,
 		).PlaceholderFormat(sq.Dollar)
@@ -223,38 +495,211 @@ func (r *repositorySQL) MarkAsUsedLink(
 	ctx context.Context,
 	linkHash string,
 	usedAt time.Time,
-) (uuid.UUID, error) {
-	var orgID uuid.UUID
+) (*models.Invite, error) {
+	var invite *models.Invite
 
 	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
-		query := sq.Select("organization_id", "expired_at").From("invites").Where(sq.Eq{
-			"link_hash": linkHash,
-		}).Limit(1).PlaceholderFormat(sq.Dollar)
-
-		var expAt time.Time
+		invites, err := r.Invites(ctx, InvitesParams{
+			LinkHashes: []string{linkHash},
+			ForUpdate:  true,
+		})
+		if err != nil {
+			return fmt.Errorf("error fetch invite. %w", err)
+		}
 
-		if err := query.RunWith(r.Conn(ctx)).QueryRowContext(ctx).Scan(&orgID, &expAt); err != nil {
-			return fmt.Errorf("error fetch expiration date from database. %w", err)
+		if len(invites) == 0 {
+			return ErrorInviteNotFound
 		}
 
-		if expAt.Before(time.Now()) {
+		invite = invites[0]
+
+		switch {
+		case !invite.RevokedAt.IsZero():
+			return ErrorInviteRevoked
+		case !invite.ExpiredAt.After(usedAt):
 			return ErrorInviteLinkExpired
+		case invite.Uses >= invite.MaxUses:
+			return ErrorInviteUsesExhausted
 		}
 
-		updateQuery := sq.Update("invites").SetMap(sq.Eq{
-			"used_at": usedAt,
-		}).PlaceholderFormat(sq.Dollar)
+		updateQuery := sq.Update("invites").
+			Set("uses", sq.Expr("uses + 1")).
+			Set("used_at", usedAt).
+			Where(sq.Eq{
+				"link_hash": linkHash,
+			}).
+			PlaceholderFormat(sq.Dollar)
 
 		if _, err := updateQuery.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
-			return fmt.Errorf("error add invite link. %w", err)
+			return fmt.Errorf("error mark invite link as used. %w", err)
 		}
 
+		invite.Uses++
+		invite.UsedAt = usedAt
+
 		return nil
 	}); err != nil {
-		return uuid.Nil, err
+		return nil, err
 	}
 
-	return orgID, nil
+	return invite, nil
+}
+
+type InvitesParams struct {
+	LinkHashes     []string
+	OrganizationID uuid.UUID
+	// PublicKey filters invites bound to the wallet
+	PublicKey []byte
+	// ActiveOnly filters out revoked, expired and exhausted invites
+	ActiveOnly bool
+	// ForUpdate locks selected invites until the end of transaction
+	ForUpdate bool
+	Limit     int64
+}
+
+func (r *repositorySQL) Invites(ctx context.Context, params InvitesParams) ([]*models.Invite, error) {
+	invites := make([]*models.Invite, 0, len(params.LinkHashes))
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"link_hash",
+			"organization_id",
+			"created_by",
+			"role",
+			"position",
+			"public_key",
+			"max_uses",
+			"uses",
+			"created_at",
+			"expired_at",
+			"used_at",
+			"revoked_at",
+		).From("invites").
+			OrderBy("created_at desc").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.LinkHashes) > 0 {
+			query = query.Where(sq.Eq{
+				"link_hash": params.LinkHashes,
+			})
+		}
+
+		if params.OrganizationID != uuid.Nil {
+			query = query.Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			})
+		}
+
+		if len(params.PublicKey) > 0 {
+			query = query.Where(sq.Eq{
+				"public_key": params.PublicKey,
+			})
+		}
+
+		if params.ActiveOnly {
+			query = query.Where(sq.Eq{
+				"revoked_at": nil,
+			}).Where(sq.Gt{
+				"expired_at": time.Now(),
+			}).Where("uses < max_uses")
+		}
+
+		if params.Limit > 0 {
+			query = query.Limit(uint64(params.Limit))
+		}
+
+		if params.ForUpdate {
+			query = query.Suffix("for update")
+		}
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch invites from database. %w", err)
+		}
+
+		defer func() {
+			if closeErr := rows.Close(); closeErr != nil {
+				err = errors.Join(fmt.Errorf("error close rows. %w", closeErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var (
+				invite    = new(models.Invite)
+				role      int
+				position  sql.NullString
+				expiredAt sql.NullTime
+				usedAt    sql.NullTime
+				revokedAt sql.NullTime
+			)
+
+			if err = rows.Scan(
+				&invite.LinkHash,
+				&invite.OrganizationID,
+				&invite.CreatedBy,
+				&role,
+				&position,
+				&invite.PublicKey,
+				&invite.MaxUses,
+				&invite.Uses,
+				&invite.CreatedAt,
+				&expiredAt,
+				&usedAt,
+				&revokedAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			invite.Role = models.Role(role)
+			invite.Position = position.String
+			invite.ExpiredAt = expiredAt.Time
+			invite.UsedAt = usedAt.Time
+			invite.RevokedAt = revokedAt.Time
+
+			invites = append(invites, invite)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+type RevokeInviteParams struct {
+	LinkHash       string
+	OrganizationID uuid.UUID
+	RevokedAt      time.Time
+}
+
+func (r *repositorySQL) RevokeInvite(ctx context.Context, params RevokeInviteParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Update("invites").
+			Set("revoked_at", params.RevokedAt).
+			Where(sq.Eq{
+				"link_hash":       params.LinkHash,
+				"organization_id": params.OrganizationID,
+				"revoked_at":      nil,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error revoke invite. %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorInviteNotFound
+		}
+
+		return nil
+	})
 }
 
 func NewRepository(db *sql.DB) Repository {
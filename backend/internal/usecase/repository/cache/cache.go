@@ -12,6 +12,9 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// ErrorCacheMiss is returned by Get if there is no record by the key
+var ErrorCacheMiss = redis.Nil
+
 type Cache interface {
 	// NOTE: dst MUST be a pointer
 	Get(ctx context.Context, key any, dst any) error
@@ -0,0 +1,325 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/emochka2007/block-accounting/internal/pkg/secrets"
+	sqltools "github.com/emochka2007/block-accounting/internal/pkg/sqlutils"
+	"github.com/google/uuid"
+)
+
+const batchSize = 100
+
+// MigrateResult holds number of updated rows per table
+type MigrateResult struct {
+	Users         int64
+	Organizations int64
+}
+
+// Migrator brings secrets stored in database to the active master key
+type Migrator interface {
+	// Migrate encrypts plaintext mnemonics and seeds, rewraps values encrypted with previous master keys
+	// and recomputes seed digests. Safe to run repeatedly
+	Migrate(ctx context.Context) (*MigrateResult, error)
+}
+
+type migratorSQL struct {
+	log    *slog.Logger
+	db     *sql.DB
+	keeper *secrets.Keeper
+}
+
+func NewMigrator(
+	log *slog.Logger,
+	db *sql.DB,
+	keeper *secrets.Keeper,
+) Migrator {
+	return &migratorSQL{
+		log:    log,
+		db:     db,
+		keeper: keeper,
+	}
+}
+
+func (s *migratorSQL) Conn(ctx context.Context) sqltools.DBTX {
+	if tx, ok := ctx.Value(sqltools.TxCtxKey).(*sql.Tx); ok {
+		return tx
+	}
+
+	return s.db
+}
+
+// column is an encrypted column. Digest of the plaintext is kept in hash column if set
+type column struct {
+	name string
+	hash string
+}
+
+type table struct {
+	name    string
+	columns []column
+}
+
+var (
+	usersTable = table{
+		name: "users",
+		columns: []column{
+			{name: "seed", hash: "seed_hash"},
+			{name: "mnemonic"},
+		},
+	}
+	organizationsTable = table{
+		name: "organizations",
+		columns: []column{
+			{name: "wallet_seed"},
+		},
+	}
+)
+
+func (m *migratorSQL) Migrate(ctx context.Context) (*MigrateResult, error) {
+	if err := m.migrateSchema(ctx); err != nil {
+		return nil, fmt.Errorf("error migrate schema. %w", err)
+	}
+
+	var (
+		result = new(MigrateResult)
+		err    error
+	)
+
+	if result.Users, err = m.migrateTable(ctx, usersTable); err != nil {
+		return nil, fmt.Errorf("error migrate users secrets. %w", err)
+	}
+
+	if result.Organizations, err = m.migrateTable(ctx, organizationsTable); err != nil {
+		return nil, fmt.Errorf("error migrate organizations secrets. %w", err)
+	}
+
+	return result, nil
+}
+
+// migrateSchema upgrades databases created before secrets encryption
+func (m *migratorSQL) migrateSchema(ctx context.Context) error {
+	return sqltools.Transaction(ctx, m.db, func(ctx context.Context) error {
+		if _, err := m.Conn(ctx).ExecContext(
+			ctx,
+			"alter table users add column if not exists seed_hash bytea default null unique",
+		); err != nil {
+			return fmt.Errorf("error add seed_hash column. %w", err)
+		}
+
+		var dataType string
+
+		if err := m.Conn(ctx).QueryRowContext(
+			ctx,
+			"select data_type from information_schema.columns where table_name = 'users' and column_name = 'mnemonic'",
+		).Scan(&dataType); err != nil {
+			return fmt.Errorf("error fetch mnemonic column type. %w", err)
+		}
+
+		if dataType == "bytea" {
+			return nil
+		}
+
+		if _, err := m.Conn(ctx).ExecContext(
+			ctx,
+			"alter table users alter column mnemonic type bytea using convert_to(mnemonic, 'UTF8')",
+		); err != nil {
+			return fmt.Errorf("error convert mnemonic column. %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (m *migratorSQL) migrateTable(ctx context.Context, t table) (int64, error) {
+	var (
+		cursor  uuid.UUID
+		updated int64
+	)
+
+	for {
+		var fetched int
+
+		if err := sqltools.Transaction(ctx, m.db, func(ctx context.Context) (err error) {
+			rows, err := m.fetchBatch(ctx, t, cursor)
+			if err != nil {
+				return err
+			}
+
+			fetched = len(rows)
+
+			for _, row := range rows {
+				cursor = row.id
+
+				values, changed, err := m.migrateRow(t, row)
+				if err != nil {
+					return fmt.Errorf("error migrate %s %s. %w", t.name, row.id, err)
+				}
+
+				if !changed {
+					continue
+				}
+
+				query := sq.Update(t.name).
+					SetMap(values).
+					Where(sq.Eq{
+						"id": row.id,
+					}).
+					PlaceholderFormat(sq.Dollar)
+
+				if _, err := query.RunWith(m.Conn(ctx)).ExecContext(ctx); err != nil {
+					return fmt.Errorf("error update %s %s. %w", t.name, row.id, err)
+				}
+
+				updated++
+			}
+
+			return nil
+		}); err != nil {
+			return updated, err
+		}
+
+		m.log.Info(
+			"secrets batch migrated",
+			slog.String("table", t.name),
+			slog.Int("rows", fetched),
+			slog.Int64("updated", updated),
+		)
+
+		if fetched < batchSize {
+			return updated, nil
+		}
+	}
+}
+
+type row struct {
+	id     uuid.UUID
+	values map[string][]byte
+}
+
+func (m *migratorSQL) fetchBatch(ctx context.Context, t table, cursor uuid.UUID) (out []row, err error) {
+	columns := []string{"id"}
+
+	for _, c := range t.columns {
+		columns = append(columns, c.name)
+
+		if c.hash != "" {
+			columns = append(columns, c.hash)
+		}
+	}
+
+	query := sq.Select(columns...).
+		From(t.name).
+		Where(sq.Gt{
+			"id": cursor,
+		}).
+		OrderBy("id").
+		Limit(batchSize).
+		Suffix("for update").
+		PlaceholderFormat(sq.Dollar)
+
+	rows, err := query.RunWith(m.Conn(ctx)).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch %s. %w", t.name, err)
+	}
+
+	defer func() {
+		if cErr := rows.Close(); cErr != nil {
+			err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			id   uuid.UUID
+			raw  = make([][]byte, len(columns)-1)
+			dest = make([]any, len(columns))
+		)
+
+		dest[0] = &id
+
+		for i := range raw {
+			dest[i+1] = &raw[i]
+		}
+
+		if err = rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("error scan row. %w", err)
+		}
+
+		r := row{
+			id:     id,
+			values: make(map[string][]byte, len(raw)),
+		}
+
+		for i, name := range columns[1:] {
+			r.values[name] = raw[i]
+		}
+
+		out = append(out, r)
+	}
+
+	return out, nil
+}
+
+// migrateRow returns updated column values of the row
+func (m *migratorSQL) migrateRow(t table, r row) (sq.Eq, bool, error) {
+	var (
+		values  = sq.Eq{}
+		changed bool
+	)
+
+	for _, c := range t.columns {
+		value := r.values[c.name]
+		if len(value) == 0 {
+			continue
+		}
+
+		var (
+			plain []byte
+			err   error
+		)
+
+		if secrets.IsEncrypted(value) {
+			if plain, err = m.keeper.Decrypt(value); err != nil {
+				return nil, false, fmt.Errorf("error decrypt %s. %w", c.name, err)
+			}
+
+			rewrapped, ok, err := m.keeper.Rewrap(value)
+			if err != nil {
+				return nil, false, fmt.Errorf("error rewrap %s. %w", c.name, err)
+			}
+
+			if ok {
+				values[c.name] = rewrapped
+				changed = true
+			}
+		} else {
+			plain = value
+
+			encrypted, err := m.keeper.Encrypt(plain)
+			if err != nil {
+				return nil, false, fmt.Errorf("error encrypt %s. %w", c.name, err)
+			}
+
+			values[c.name] = encrypted
+			changed = true
+		}
+
+		if c.hash == "" {
+			continue
+		}
+
+		if digest := m.keeper.Digest(plain); !bytes.Equal(digest, r.values[c.hash]) {
+			values[c.hash] = digest
+			changed = true
+		}
+	}
+
+	return values, changed, nil
+}
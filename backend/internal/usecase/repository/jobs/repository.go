@@ -0,0 +1,487 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	sqltools "github.com/emochka2007/block-accounting/internal/pkg/sqlutils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorLeaseLost = errors.New("job lease lost")
+)
+
+type GetParams struct {
+	IDs            uuid.UUIDs
+	OrganizationID uuid.UUID
+	Kinds          []string
+	Limit          int64
+}
+
+type AcquireParams struct {
+	Kinds []string
+	Limit int64
+	// Worker is stored as the lease owner of the acquired jobs
+	Worker      uuid.UUID
+	LockedUntil time.Time
+}
+
+type HeartbeatParams struct {
+	ID          uuid.UUID
+	Worker      uuid.UUID
+	LockedUntil time.Time
+}
+
+type CompleteParams struct {
+	ID         uuid.UUID
+	Worker     uuid.UUID
+	Result     []byte
+	FinishedAt time.Time
+}
+
+type FailParams struct {
+	ID     uuid.UUID
+	Worker uuid.UUID
+	Error  string
+
+	// if RetryAt is zero, job marked as failed permanently
+	RetryAt    time.Time
+	FinishedAt time.Time
+}
+
+type Repository interface {
+	Create(ctx context.Context, job models.Job) error
+	Get(ctx context.Context, params GetParams) ([]*models.Job, error)
+
+	// Acquire locks queued jobs that are ready to run, as well as running jobs
+	// whose lease has expired (the worker that took them died), and marks them as running.
+	// Expired jobs without attempts left are marked as failed instead.
+	Acquire(ctx context.Context, params AcquireParams) ([]*models.Job, error)
+
+	// Heartbeat, Complete and Fail update the job only while the worker holds its lease.
+	// ErrorLeaseLost is returned if the lease has expired or the job is reclaimed by another worker
+	Heartbeat(ctx context.Context, params HeartbeatParams) error
+	Complete(ctx context.Context, params CompleteParams) error
+	Fail(ctx context.Context, params FailParams) error
+}
+
+type repositorySQL struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repositorySQL{
+		db: db,
+	}
+}
+
+func (s *repositorySQL) Conn(ctx context.Context) sqltools.DBTX {
+	if tx, ok := ctx.Value(sqltools.TxCtxKey).(*sql.Tx); ok {
+		return tx
+	}
+
+	return s.db
+}
+
+func (r *repositorySQL) Create(ctx context.Context, job models.Job) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Insert("jobs").Columns(
+			"id",
+			"organization_id",
+			"created_by",
+			"kind",
+			"payload",
+			"status",
+			"attempts",
+			"max_attempts",
+			"run_at",
+			"created_at",
+			"updated_at",
+		).Values(
+			job.ID,
+			job.OrganizationID,
+			job.CreatedBy,
+			job.Kind,
+			job.Payload,
+			job.Status,
+			job.Attempts,
+			job.MaxAttempts,
+			job.RunAt,
+			job.CreatedAt,
+			job.UpdatedAt,
+		).PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error insert new job. %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) Get(ctx context.Context, params GetParams) ([]*models.Job, error) {
+	jobs := make([]*models.Job, 0, len(params.IDs))
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := buildSelectJobsQuery()
+
+		if len(params.IDs) > 0 {
+			query = query.Where(sq.Eq{
+				"j.id": params.IDs,
+			})
+		}
+
+		if params.OrganizationID != uuid.Nil {
+			query = query.Where(sq.Eq{
+				"j.organization_id": params.OrganizationID,
+			})
+		}
+
+		if len(params.Kinds) > 0 {
+			query = query.Where(sq.Eq{
+				"j.kind": params.Kinds,
+			})
+		}
+
+		if params.Limit > 0 {
+			query = query.Limit(uint64(params.Limit))
+		}
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch jobs from database. %w", err)
+		}
+
+		defer func() {
+			if closeErr := rows.Close(); closeErr != nil {
+				err = errors.Join(fmt.Errorf("error close rows. %w", closeErr), err)
+			}
+		}()
+
+		jobs, err = scanJobs(rows)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *repositorySQL) Acquire(ctx context.Context, params AcquireParams) ([]*models.Job, error) {
+	jobs := make([]*models.Job, 0, params.Limit)
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		now := time.Now()
+
+		if err = r.failExhausted(ctx, params.Kinds, now); err != nil {
+			return err
+		}
+
+		query := buildSelectJobsQuery().
+			Where(sq.Or{
+				sq.And{
+					sq.Eq{"j.status": models.JobStatusQueued},
+					sq.LtOrEq{"j.run_at": now},
+				},
+				sq.And{
+					sq.Eq{"j.status": models.JobStatusRunning},
+					sq.Lt{"j.locked_until": now},
+					sq.Expr("j.attempts < j.max_attempts"),
+				},
+			}).
+			OrderBy("j.run_at").
+			Limit(uint64(params.Limit)).
+			Suffix("for update skip locked")
+
+		if len(params.Kinds) > 0 {
+			query = query.Where(sq.Eq{
+				"j.kind": params.Kinds,
+			})
+		}
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch ready jobs from database. %w", err)
+		}
+
+		jobs, err = scanJobs(rows)
+		if closeErr := rows.Close(); closeErr != nil {
+			err = errors.Join(fmt.Errorf("error close rows. %w", closeErr), err)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for _, j := range jobs {
+			j.Status = models.JobStatusRunning
+			j.Attempts++
+			j.LeaseOwner = params.Worker
+			j.LockedUntil = params.LockedUntil
+			j.StartedAt = now
+			j.UpdatedAt = now
+
+			updateQuery := sq.Update("jobs").
+				SetMap(sq.Eq{
+					"status":       j.Status,
+					"attempts":     j.Attempts,
+					"lease_owner":  j.LeaseOwner,
+					"locked_until": j.LockedUntil,
+					"started_at":   j.StartedAt,
+					"updated_at":   j.UpdatedAt,
+				}).
+				Where(sq.Eq{
+					"id": j.ID,
+				}).
+				PlaceholderFormat(sq.Dollar)
+
+			if _, err := updateQuery.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+				return fmt.Errorf("error mark job as running. %w", err)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// failExhausted marks as failed running jobs whose lease has expired on the last attempt,
+// so a job crashing the worker is not retried forever
+func (r *repositorySQL) failExhausted(ctx context.Context, kinds []string, now time.Time) error {
+	query := sq.Update("jobs").
+		SetMap(sq.Eq{
+			"status":       models.JobStatusFailed,
+			"last_error":   "job lease expired on the last attempt",
+			"lease_owner":  nil,
+			"locked_until": nil,
+			"finished_at":  now,
+			"updated_at":   now,
+		}).
+		Where(sq.Eq{
+			"status": models.JobStatusRunning,
+		}).
+		Where(sq.Lt{
+			"locked_until": now,
+		}).
+		Where("attempts >= max_attempts").
+		PlaceholderFormat(sq.Dollar)
+
+	if len(kinds) > 0 {
+		query = query.Where(sq.Eq{
+			"kind": kinds,
+		})
+	}
+
+	if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+		return fmt.Errorf("error mark exhausted jobs as failed. %w", err)
+	}
+
+	return nil
+}
+
+func (r *repositorySQL) Heartbeat(ctx context.Context, params HeartbeatParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Update("jobs").
+			SetMap(sq.Eq{
+				"locked_until": params.LockedUntil,
+				"updated_at":   time.Now(),
+			}).
+			Where(leaseHeld(params.ID, params.Worker)).
+			PlaceholderFormat(sq.Dollar)
+
+		result, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error extend job lease. %w", err)
+		}
+
+		return checkLeaseHeld(result)
+	})
+}
+
+func (r *repositorySQL) Complete(ctx context.Context, params CompleteParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Update("jobs").
+			SetMap(sq.Eq{
+				"status":       models.JobStatusSucceeded,
+				"result":       params.Result,
+				"last_error":   nil,
+				"lease_owner":  nil,
+				"locked_until": nil,
+				"finished_at":  params.FinishedAt,
+				"updated_at":   params.FinishedAt,
+			}).
+			Where(leaseHeld(params.ID, params.Worker)).
+			PlaceholderFormat(sq.Dollar)
+
+		result, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error mark job as succeeded. %w", err)
+		}
+
+		return checkLeaseHeld(result)
+	})
+}
+
+func (r *repositorySQL) Fail(ctx context.Context, params FailParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		values := sq.Eq{
+			"last_error":   params.Error,
+			"lease_owner":  nil,
+			"locked_until": nil,
+			"updated_at":   params.FinishedAt,
+		}
+
+		if params.RetryAt.IsZero() {
+			values["status"] = models.JobStatusFailed
+			values["finished_at"] = params.FinishedAt
+		} else {
+			values["status"] = models.JobStatusQueued
+			values["run_at"] = params.RetryAt
+		}
+
+		query := sq.Update("jobs").
+			SetMap(values).
+			Where(leaseHeld(params.ID, params.Worker)).
+			PlaceholderFormat(sq.Dollar)
+
+		result, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error mark job as failed. %w", err)
+		}
+
+		return checkLeaseHeld(result)
+	})
+}
+
+// leaseHeld matches the running job while its lease is held by the worker
+func leaseHeld(id, worker uuid.UUID) sq.And {
+	return sq.And{
+		sq.Eq{
+			"id":          id,
+			"status":      models.JobStatusRunning,
+			"lease_owner": worker,
+		},
+		sq.Gt{
+			"locked_until": time.Now(),
+		},
+	}
+}
+
+func checkLeaseHeld(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error fetch affected rows. %w", err)
+	}
+
+	if affected == 0 {
+		return ErrorLeaseLost
+	}
+
+	return nil
+}
+
+func buildSelectJobsQuery() sq.SelectBuilder {
+	return sq.Select(
+		"j.id",
+		"j.organization_id",
+		"j.created_by",
+		"j.kind",
+		"j.payload",
+		"j.result",
+		"j.status",
+		"j.attempts",
+		"j.max_attempts",
+		"j.last_error",
+		"j.run_at",
+		"j.lease_owner",
+		"j.locked_until",
+		"j.created_at",
+		"j.updated_at",
+		"j.started_at",
+		"j.finished_at",
+	).From("jobs as j").
+		PlaceholderFormat(sq.Dollar)
+}
+
+func scanJobs(rows *sql.Rows) ([]*models.Job, error) {
+	jobs := make([]*models.Job, 0)
+
+	for rows.Next() {
+		var (
+			id             uuid.UUID
+			organizationID uuid.UUID
+			createdBy      uuid.UUID
+			kind           string
+			payload        []byte
+			result         []byte
+			status         int
+			attempts       int
+			maxAttempts    int
+			lastError      sql.NullString
+			runAt          time.Time
+			leaseOwner     uuid.NullUUID
+			lockedUntil    sql.NullTime
+			createdAt      time.Time
+			updatedAt      time.Time
+			startedAt      sql.NullTime
+			finishedAt     sql.NullTime
+		)
+
+		if err := rows.Scan(
+			&id,
+			&organizationID,
+			&createdBy,
+			&kind,
+			&payload,
+			&result,
+			&status,
+			&attempts,
+			&maxAttempts,
+			&lastError,
+			&runAt,
+			&leaseOwner,
+			&lockedUntil,
+			&createdAt,
+			&updatedAt,
+			&startedAt,
+			&finishedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scan row. %w", err)
+		}
+
+		jobs = append(jobs, &models.Job{
+			ID:             id,
+			OrganizationID: organizationID,
+			CreatedBy:      createdBy,
+			Kind:           kind,
+			Payload:        payload,
+			Result:         result,
+			Status:         models.JobStatus(status),
+			Attempts:       attempts,
+			MaxAttempts:    maxAttempts,
+			LastError:      lastError.String,
+			RunAt:          runAt,
+			LeaseOwner:     leaseOwner.UUID,
+			LockedUntil:    lockedUntil.Time,
+			CreatedAt:      createdAt,
+			UpdatedAt:      updatedAt,
+			StartedAt:      startedAt.Time,
+			FinishedAt:     finishedAt.Time,
+		})
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,662 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	sqltools "github.com/emochka2007/block-accounting/internal/pkg/sqlutils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorEntryExists = errors.New("ledger entry has already been posted")
+)
+
+type ListAccountsParams struct {
+	OrganizationID uuid.UUID
+	IDs            uuid.UUIDs
+	Codes          []string
+}
+
+type ListEntriesParams struct {
+	OrganizationID uuid.UUID
+	IDs            uuid.UUIDs
+	SourceTypes    []models.LedgerEntrySource
+	// From includes entries occurred at or after it. Optional
+	From time.Time
+	// To includes entries occurred before it. Optional
+	To    time.Time
+	Limit int64
+}
+
+// pricesJoin joins price snapshots of the line currency in fiat. Lines in fiat are priced at par
+const pricesJoin = "prices as p on p.organization_id = e.organization_id and p.source_type = e.source_type " +
+	"and p.source_id = e.source_id and p.base = l.currency and p.quote = ?"
+
+type BalancesParams struct {
+	OrganizationID uuid.UUID
+	// AsOf includes entries occurred before it. If zero, all entries are included
+	AsOf time.Time
+	// From includes entries occurred at or after it, so balances are the period movements. Optional
+	From        time.Time
+	SourceTypes []models.LedgerEntrySource
+}
+
+type UnpostedParams struct {
+	OrganizationID uuid.UUID
+	// AsOf includes transfers and payouts executed before it. If zero, all of them are included
+	AsOf time.Time
+}
+
+type Repository interface {
+	// AddAccounts saves accounts. Accounts with codes already used in the organization are skipped
+	AddAccounts(ctx context.Context, accounts ...models.LedgerAccount) error
+	ListAccounts(ctx context.Context, params ListAccountsParams) ([]*models.LedgerAccount, error)
+
+	// AddEntry saves journal entry with its lines. Returns ErrorEntryExists if entry of the source
+	// has already been saved
+	AddEntry(ctx context.Context, entry models.LedgerEntry) error
+	ListEntries(ctx context.Context, params ListEntriesParams) ([]*models.LedgerEntry, error)
+
+	// Balances returns debit and credit totals of accounts per currency with their fiat equivalents
+	Balances(ctx context.Context, params BalancesParams) ([]models.LedgerBalance, error)
+	// Unposted reconciles the ledger against transactions and payments. Returns executed transfers and paid
+	// payouts without journal entry, oldest first
+	Unposted(ctx context.Context, params UnpostedParams) ([]models.LedgerUnposted, error)
+}
+
+type repositorySQL struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repositorySQL{
+		db: db,
+	}
+}
+
+func (s *repositorySQL) Conn(ctx context.Context) sqltools.DBTX {
+	if tx, ok := ctx.Value(sqltools.TxCtxKey).(*sql.Tx); ok {
+		return tx
+	}
+
+	return s.db
+}
+
+func (r *repositorySQL) AddAccounts(ctx context.Context, accounts ...models.LedgerAccount) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	query := sq.Insert("ledger_accounts").
+		Columns(
+			"id",
+			"organization_id",
+			"code",
+			"name",
+			"type",
+			"created_at",
+		).
+		Suffix("on conflict (organization_id, code) do nothing").
+		PlaceholderFormat(sq.Dollar)
+
+	for _, a := range accounts {
+		query = query.Values(
+			a.ID,
+			a.OrganizationID,
+			a.Code,
+			a.Name,
+			a.Type,
+			a.CreatedAt,
+		)
+	}
+
+	if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+		return fmt.Errorf("error insert ledger accounts. %w", err)
+	}
+
+	return nil
+}
+
+func (r *repositorySQL) ListAccounts(
+	ctx context.Context,
+	params ListAccountsParams,
+) ([]*models.LedgerAccount, error) {
+	accounts := make([]*models.LedgerAccount, 0)
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"organization_id",
+			"code",
+			"name",
+			"type",
+			"created_at",
+		).From("ledger_accounts").
+			Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			}).
+			OrderBy("code").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.IDs) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.IDs,
+			})
+		}
+
+		if len(params.Codes) > 0 {
+			query = query.Where(sq.Eq{
+				"code": params.Codes,
+			})
+		}
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch ledger accounts from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			a := new(models.LedgerAccount)
+
+			if err = rows.Scan(
+				&a.ID,
+				&a.OrganizationID,
+				&a.Code,
+				&a.Name,
+				&a.Type,
+				&a.CreatedAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			accounts = append(accounts, a)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (r *repositorySQL) AddEntry(ctx context.Context, entry models.LedgerEntry) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		var createdBy uuid.NullUUID
+
+		if entry.CreatedBy != uuid.Nil {
+			createdBy = uuid.NullUUID{UUID: entry.CreatedBy, Valid: true}
+		}
+
+		query := sq.Insert("ledger_entries").
+			Columns(
+				"id",
+				"organization_id",
+				"description",
+				"source_type",
+				"source_id",
+				"occurred_at",
+				"created_by",
+				"created_at",
+			).
+			Values(
+				entry.ID,
+				entry.OrganizationID,
+				entry.Description,
+				entry.SourceType,
+				entry.SourceID,
+				entry.OccurredAt,
+				createdBy,
+				entry.CreatedAt,
+			).
+			Suffix("on conflict (organization_id, source_type, source_id) do nothing").
+			PlaceholderFormat(sq.Dollar)
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error insert ledger entry. %w", err)
+		}
+
+		inserted, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch inserted ledger entries count. %w", err)
+		}
+
+		if inserted == 0 {
+			return ErrorEntryExists
+		}
+
+		linesQuery := sq.Insert("ledger_lines").
+			Columns(
+				"id",
+				"entry_id",
+				"account_id",
+				"currency",
+				"debit",
+				"credit",
+			).
+			PlaceholderFormat(sq.Dollar)
+
+		for _, l := range entry.Lines {
+			linesQuery = linesQuery.Values(
+				l.ID,
+				entry.ID,
+				l.AccountID,
+				l.Currency,
+				l.Debit,
+				l.Credit,
+			)
+		}
+
+		if _, err = linesQuery.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error insert ledger lines. %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) ListEntries(
+	ctx context.Context,
+	params ListEntriesParams,
+) ([]*models.LedgerEntry, error) {
+	entries := make([]*models.LedgerEntry, 0)
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"organization_id",
+			"description",
+			"source_type",
+			"source_id",
+			"occurred_at",
+			"created_by",
+			"created_at",
+		).From("ledger_entries").
+			Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			}).
+			OrderBy("occurred_at desc", "created_at desc").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.IDs) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.IDs,
+			})
+		}
+
+		if len(params.SourceTypes) > 0 {
+			query = query.Where(sq.Eq{
+				"source_type": params.SourceTypes,
+			})
+		}
+
+		if !params.From.IsZero() {
+			query = query.Where(sq.GtOrEq{
+				"occurred_at": params.From,
+			})
+		}
+
+		if !params.To.IsZero() {
+			query = query.Where(sq.Lt{
+				"occurred_at": params.To,
+			})
+		}
+
+		if params.Limit <= 0 {
+			params.Limit = 100
+		}
+
+		query = query.Limit(uint64(params.Limit))
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch ledger entries from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		ids := make(uuid.UUIDs, 0)
+		entriesMap := make(map[uuid.UUID]*models.LedgerEntry)
+
+		for rows.Next() {
+			var (
+				e           = new(models.LedgerEntry)
+				description sql.NullString
+				createdBy   uuid.NullUUID
+			)
+
+			if err = rows.Scan(
+				&e.ID,
+				&e.OrganizationID,
+				&description,
+				&e.SourceType,
+				&e.SourceID,
+				&e.OccurredAt,
+				&createdBy,
+				&e.CreatedAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			e.Description = description.String
+			e.CreatedBy = createdBy.UUID
+
+			entries = append(entries, e)
+			entriesMap[e.ID] = e
+			ids = append(ids, e.ID)
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		lines, err := r.lines(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, l := range lines {
+			if e, ok := entriesMap[l.EntryID]; ok {
+				e.Lines = append(e.Lines, l)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *repositorySQL) lines(ctx context.Context, entryIDs uuid.UUIDs) (lines []models.LedgerLine, err error) {
+	query := sq.Select(
+		"l.id",
+		"l.entry_id",
+		"l.account_id",
+		"a.code",
+		"l.currency",
+		"l.debit",
+		"l.credit",
+	).Column(
+		"coalesce(p.rate, case when l.currency = ? then 1 end)", models.FiatCurrency.Code,
+	).From("ledger_lines as l").
+		InnerJoin("ledger_entries as e on e.id = l.entry_id").
+		InnerJoin("ledger_accounts as a on a.id = l.account_id").
+		LeftJoin(pricesJoin, models.FiatCurrency.Code).
+		Where(sq.Eq{
+			"l.entry_id": entryIDs,
+		}).
+		OrderBy("l.entry_id", "l.debit desc", "a.code").
+		PlaceholderFormat(sq.Dollar)
+
+	rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch ledger lines from database. %w", err)
+	}
+
+	defer func() {
+		if cErr := rows.Close(); cErr != nil {
+			err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			l    models.LedgerLine
+			rate sql.Null[money.Decimal]
+		)
+
+		if err = rows.Scan(
+			&l.ID,
+			&l.EntryID,
+			&l.AccountID,
+			&l.AccountCode,
+			&l.Currency,
+			&l.Debit,
+			&l.Credit,
+			&rate,
+		); err != nil {
+			return nil, fmt.Errorf("error scan row. %w", err)
+		}
+
+		if rate.Valid {
+			l.Rate = &rate.V
+		}
+
+		lines = append(lines, l)
+	}
+
+	return lines, nil
+}
+
+func (r *repositorySQL) Balances(ctx context.Context, params BalancesParams) ([]models.LedgerBalance, error) {
+	balances := make([]models.LedgerBalance, 0)
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"a.id",
+			"a.organization_id",
+			"a.code",
+			"a.name",
+			"a.type",
+			"a.created_at",
+			"l.currency",
+			"coalesce(sum(l.debit), 0)",
+			"coalesce(sum(l.credit), 0)",
+		).Column(
+			"coalesce(sum(l.debit * coalesce(p.rate, case when l.currency = ? then 1 end)), 0)",
+			models.FiatCurrency.Code,
+		).Column(
+			"coalesce(sum(l.credit * coalesce(p.rate, case when l.currency = ? then 1 end)), 0)",
+			models.FiatCurrency.Code,
+		).Column(
+			"count(*) filter (where p.rate is null and l.currency <> ?)",
+			models.FiatCurrency.Code,
+		).From("ledger_lines as l").
+			InnerJoin("ledger_entries as e on e.id = l.entry_id").
+			InnerJoin("ledger_accounts as a on a.id = l.account_id").
+			LeftJoin(pricesJoin, models.FiatCurrency.Code).
+			Where(sq.Eq{
+				"e.organization_id": params.OrganizationID,
+			}).
+			GroupBy("a.id", "l.currency").
+			OrderBy("a.code", "l.currency").
+			PlaceholderFormat(sq.Dollar)
+
+		if !params.AsOf.IsZero() {
+			query = query.Where(sq.Lt{
+				"e.occurred_at": params.AsOf,
+			})
+		}
+
+		if !params.From.IsZero() {
+			query = query.Where(sq.GtOrEq{
+				"e.occurred_at": params.From,
+			})
+		}
+
+		if len(params.SourceTypes) > 0 {
+			query = query.Where(sq.Eq{
+				"e.source_type": params.SourceTypes,
+			})
+		}
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch ledger balances from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var (
+				a = new(models.LedgerAccount)
+				b models.LedgerBalance
+			)
+
+			if err = rows.Scan(
+				&a.ID,
+				&a.OrganizationID,
+				&a.Code,
+				&a.Name,
+				&a.Type,
+				&a.CreatedAt,
+				&b.Currency,
+				&b.Debit,
+				&b.Credit,
+				&b.FiatDebit,
+				&b.FiatCredit,
+				&b.UnpricedLines,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			b.Account = a
+
+			balances = append(balances, b)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return balances, nil
+}
+
+// notPosted filters rows of the source without journal entry
+const notPosted = "not exists (select 1 from ledger_entries as e where e.organization_id = ? " +
+	"and e.source_type = ? and e.source_id = %s)"
+
+func (r *repositorySQL) Unposted(ctx context.Context, params UnpostedParams) ([]models.LedgerUnposted, error) {
+	unposted := make([]models.LedgerUnposted, 0)
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// transactions without asset are ETH transfers, only positive amounts are posted
+		txs := sq.Select(
+			"t.id",
+			"t.description",
+			"t.amount",
+			"t.commited_at",
+		).Column(
+			"coalesce(a.symbol, ?)", money.ETH.Code,
+		).Column(
+			"?::varchar", models.LedgerEntrySourceTransaction,
+		).From("transactions as t").
+			LeftJoin("assets as a on a.id = t.asset_id").
+			Where(sq.Eq{
+				"t.organization_id": params.OrganizationID,
+				"t.status":          models.TransactionStatusExecuted,
+			}).
+			Where(sq.Gt{
+				"t.amount": 0,
+			}).
+			Where(
+				fmt.Sprintf(notPosted, "t.id"),
+				params.OrganizationID,
+				models.LedgerEntrySourceTransaction,
+			)
+
+		payments := sq.Select(
+			"p.id",
+		).Column(
+			"?::text", "Salary payout",
+		).Column(
+			"p.amount",
+		).Column(
+			"p.paid_at",
+		).Column(
+			"?::varchar", money.USD.Code,
+		).Column(
+			"?::varchar", models.LedgerEntrySourcePayment,
+		).From("payments as p").
+			Where(sq.Eq{
+				"p.organization_id": params.OrganizationID,
+				"p.status":          models.PaymentStatusPaid,
+			}).
+			Where(
+				fmt.Sprintf(notPosted, "p.id"),
+				params.OrganizationID,
+				models.LedgerEntrySourcePayment,
+			)
+
+		if !params.AsOf.IsZero() {
+			txs = txs.Where(sq.Lt{"t.commited_at": params.AsOf})
+			payments = payments.Where(sq.Lt{"p.paid_at": params.AsOf})
+		}
+
+		paymentsSQL, paymentsArgs, err := payments.ToSql()
+		if err != nil {
+			return fmt.Errorf("error build unposted payments query. %w", err)
+		}
+
+		query := txs.
+			Suffix("union all "+paymentsSQL, paymentsArgs...).
+			Suffix("order by 4").
+			PlaceholderFormat(sq.Dollar)
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch unposted transfers from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var (
+				u           models.LedgerUnposted
+				description sql.NullString
+				occurredAt  sql.NullTime
+			)
+
+			if err = rows.Scan(
+				&u.SourceID,
+				&description,
+				&u.Amount,
+				&occurredAt,
+				&u.Currency,
+				&u.SourceType,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			u.Description = description.String
+			u.OccurredAt = occurredAt.Time
+
+			unposted = append(unposted, u)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return unposted, nil
+}
@@ -0,0 +1,570 @@
+package licenses
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	sqltools "github.com/emochka2007/block-accounting/internal/pkg/sqlutils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorOperationStatusConflict = errors.New("license operation status has been changed")
+)
+
+type ListLicensesParams struct {
+	IDs            uuid.UUIDs
+	OrganizationID uuid.UUID
+	Limit          int64
+}
+
+type UpdateLicenseParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Status         models.LicenseStatus
+	Address        []byte
+	OracleURL      string
+	PayrollID      uuid.UUID
+	UpdatedAt      time.Time
+}
+
+type ListOperationsParams struct {
+	IDs            uuid.UUIDs
+	OrganizationID uuid.UUID
+	LicenseIDs     uuid.UUIDs
+	Limit          int64
+}
+
+type UpdateOperationParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Status         models.LicenseOperationStatus
+	// FromStatuses if set, operation is updated only if its current status is one of them.
+	// Otherwise ErrorOperationStatusConflict is returned
+	FromStatuses []models.LicenseOperationStatus
+	// TxIndex is saved with LicenseOperationStatusSubmitted status
+	TxIndex    int64
+	TxHash     string
+	ExecutedAt time.Time
+	UpdatedAt  time.Time
+}
+
+type Repository interface {
+	// CreateLicense saves license with its shareholders
+	CreateLicense(ctx context.Context, license models.License) error
+	ListLicenses(ctx context.Context, params ListLicensesParams) ([]*models.License, error)
+	UpdateLicense(ctx context.Context, params UpdateLicenseParams) error
+
+	AddOperation(ctx context.Context, op models.LicenseOperation) error
+	ListOperations(ctx context.Context, params ListOperationsParams) ([]*models.LicenseOperation, error)
+	UpdateOperation(ctx context.Context, params UpdateOperationParams) error
+}
+
+type repositorySQL struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repositorySQL{
+		db: db,
+	}
+}
+
+func (s *repositorySQL) Conn(ctx context.Context) sqltools.DBTX {
+	if tx, ok := ctx.Value(sqltools.TxCtxKey).(*sql.Tx); ok {
+		return tx
+	}
+
+	return s.db
+}
+
+func (r *repositorySQL) CreateLicense(ctx context.Context, license models.License) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Insert("licenses").
+			Columns(
+				"id",
+				"organization_id",
+				"multisig_id",
+				"title",
+				"status",
+				"created_by",
+				"created_at",
+				"updated_at",
+			).
+			Values(
+				license.ID,
+				license.OrganizationID,
+				license.MultisigID,
+				license.Title,
+				license.Status,
+				license.CreatedBy,
+				license.CreatedAt,
+				license.UpdatedAt,
+			).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error insert license. %w", err)
+		}
+
+		for _, sh := range license.Shareholders {
+			columns := []string{
+				"license_id",
+				"address",
+				"share",
+			}
+
+			values := []any{
+				license.ID,
+				sh.Address,
+				sh.Share,
+			}
+
+			if sh.ParticipantID != uuid.Nil {
+				columns = append(columns, "participant_id")
+				values = append(values, sh.ParticipantID)
+			}
+
+			shareholderQuery := sq.Insert("license_shareholders").
+				Columns(columns...).
+				Values(values...).
+				PlaceholderFormat(sq.Dollar)
+
+			if _, err := shareholderQuery.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+				return fmt.Errorf("error insert license shareholder. %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) ListLicenses(ctx context.Context, params ListLicensesParams) ([]*models.License, error) {
+	licenses := make([]*models.License, 0, len(params.IDs))
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"organization_id",
+			"multisig_id",
+			"title",
+			"address",
+			"status",
+			"oracle_url",
+			"payroll_id",
+			"created_by",
+			"created_at",
+			"updated_at",
+		).From("licenses").
+			Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			}).
+			OrderBy("created_at desc").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.IDs) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.IDs,
+			})
+		}
+
+		if params.Limit <= 0 {
+			params.Limit = 100
+		}
+
+		query = query.Limit(uint64(params.Limit))
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch licenses from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var (
+				id             uuid.UUID
+				organizationID uuid.UUID
+				multisigID     uuid.UUID
+				title          string
+				address        []byte
+				status         int
+				oracleURL      sql.NullString
+				payrollID      uuid.NullUUID
+				createdBy      uuid.UUID
+				createdAt      time.Time
+				updatedAt      time.Time
+			)
+
+			if err = rows.Scan(
+				&id,
+				&organizationID,
+				&multisigID,
+				&title,
+				&address,
+				&status,
+				&oracleURL,
+				&payrollID,
+				&createdBy,
+				&createdAt,
+				&updatedAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			licenses = append(licenses, &models.License{
+				ID:             id,
+				OrganizationID: organizationID,
+				MultisigID:     multisigID,
+				Title:          title,
+				Address:        address,
+				Status:         models.LicenseStatus(status),
+				OracleURL:      oracleURL.String,
+				PayrollID:      payrollID.UUID,
+				CreatedBy:      createdBy,
+				CreatedAt:      createdAt,
+				UpdatedAt:      updatedAt,
+			})
+		}
+
+		if len(licenses) == 0 {
+			return nil
+		}
+
+		return r.fetchShareholders(ctx, licenses)
+	}); err != nil {
+		return nil, err
+	}
+
+	return licenses, nil
+}
+
+func (r *repositorySQL) fetchShareholders(ctx context.Context, licenses []*models.License) (err error) {
+	ids := make(uuid.UUIDs, len(licenses))
+	licensesMap := make(map[uuid.UUID]*models.License, len(licenses))
+
+	for i, l := range licenses {
+		ids[i] = l.ID
+		licensesMap[l.ID] = l
+	}
+
+	query := sq.Select(
+		"license_id",
+		"participant_id",
+		"address",
+		"share",
+	).From("license_shareholders").
+		Where(sq.Eq{
+			"license_id": ids,
+		}).
+		PlaceholderFormat(sq.Dollar)
+
+	rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error fetch license shareholders from database. %w", err)
+	}
+
+	defer func() {
+		if cErr := rows.Close(); cErr != nil {
+			err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			licenseID     uuid.UUID
+			participantID uuid.NullUUID
+			address       []byte
+			share         int
+		)
+
+		if err = rows.Scan(
+			&licenseID,
+			&participantID,
+			&address,
+			&share,
+		); err != nil {
+			return fmt.Errorf("error scan row. %w", err)
+		}
+
+		if l, ok := licensesMap[licenseID]; ok {
+			l.Shareholders = append(l.Shareholders, models.LicenseShareholder{
+				LicenseID:     licenseID,
+				ParticipantID: participantID.UUID,
+				Address:       address,
+				Share:         share,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (r *repositorySQL) UpdateLicense(ctx context.Context, params UpdateLicenseParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		values := sq.Eq{
+			"status":     params.Status,
+			"updated_at": params.UpdatedAt,
+		}
+
+		if len(params.Address) > 0 {
+			values["address"] = params.Address
+		}
+
+		if params.OracleURL != "" {
+			values["oracle_url"] = params.OracleURL
+		}
+
+		if params.PayrollID != uuid.Nil {
+			values["payroll_id"] = params.PayrollID
+		}
+
+		query := sq.Update("licenses").
+			SetMap(values).
+			Where(sq.Eq{
+				"id":              params.ID,
+				"organization_id": params.OrganizationID,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error update license. %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) AddOperation(ctx context.Context, op models.LicenseOperation) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		columns := []string{
+			"id",
+			"license_id",
+			"organization_id",
+			"multisig_id",
+			"kind",
+			"status",
+			"created_by",
+			"created_at",
+			"updated_at",
+		}
+
+		values := []any{
+			op.ID,
+			op.LicenseID,
+			op.OrganizationID,
+			op.MultisigID,
+			op.Kind,
+			op.Status,
+			op.CreatedBy,
+			op.CreatedAt,
+			op.UpdatedAt,
+		}
+
+		if op.URL != "" {
+			columns = append(columns, "url")
+			values = append(values, op.URL)
+		}
+
+		if op.PayrollID != uuid.Nil {
+			columns = append(columns, "payroll_id")
+			values = append(values, op.PayrollID)
+		}
+
+		query := sq.Insert("license_operations").
+			Columns(columns...).
+			Values(values...).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error insert license operation. %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) ListOperations(
+	ctx context.Context,
+	params ListOperationsParams,
+) ([]*models.LicenseOperation, error) {
+	ops := make([]*models.LicenseOperation, 0, len(params.IDs))
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"license_id",
+			"organization_id",
+			"multisig_id",
+			"kind",
+			"status",
+			"url",
+			"payroll_id",
+			"tx_index",
+			"tx_hash",
+			"created_by",
+			"created_at",
+			"updated_at",
+			"executed_at",
+		).From("license_operations").
+			Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			}).
+			OrderBy("created_at desc").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.IDs) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.IDs,
+			})
+		}
+
+		if len(params.LicenseIDs) > 0 {
+			query = query.Where(sq.Eq{
+				"license_id": params.LicenseIDs,
+			})
+		}
+
+		if params.Limit <= 0 {
+			params.Limit = 100
+		}
+
+		query = query.Limit(uint64(params.Limit))
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch license operations from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var (
+				id             uuid.UUID
+				licenseID      uuid.UUID
+				organizationID uuid.UUID
+				multisigID     uuid.UUID
+				kind           int
+				status         int
+				url            sql.NullString
+				payrollID      uuid.NullUUID
+				txIndex        sql.NullInt64
+				txHash         sql.NullString
+				createdBy      uuid.UUID
+				createdAt      time.Time
+				updatedAt      time.Time
+				executedAt     sql.NullTime
+			)
+
+			if err = rows.Scan(
+				&id,
+				&licenseID,
+				&organizationID,
+				&multisigID,
+				&kind,
+				&status,
+				&url,
+				&payrollID,
+				&txIndex,
+				&txHash,
+				&createdBy,
+				&createdAt,
+				&updatedAt,
+				&executedAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			ops = append(ops, &models.LicenseOperation{
+				ID:             id,
+				LicenseID:      licenseID,
+				OrganizationID: organizationID,
+				MultisigID:     multisigID,
+				Kind:           models.LicenseOperationKind(kind),
+				Status:         models.LicenseOperationStatus(status),
+				URL:            url.String,
+				PayrollID:      payrollID.UUID,
+				TxIndex:        txIndex.Int64,
+				TxHash:         txHash.String,
+				CreatedBy:      createdBy,
+				CreatedAt:      createdAt,
+				UpdatedAt:      updatedAt,
+				ExecutedAt:     executedAt.Time,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
+
+func (r *repositorySQL) UpdateOperation(ctx context.Context, params UpdateOperationParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		values := sq.Eq{
+			"status":     params.Status,
+			"updated_at": params.UpdatedAt,
+		}
+
+		if params.Status == models.LicenseOperationStatusSubmitted {
+			values["tx_index"] = params.TxIndex
+		}
+
+		if params.TxHash != "" {
+			values["tx_hash"] = params.TxHash
+		}
+
+		if !params.ExecutedAt.IsZero() {
+			values["executed_at"] = params.ExecutedAt
+		}
+
+		query := sq.Update("license_operations").
+			SetMap(values).
+			Where(sq.Eq{
+				"id":              params.ID,
+				"organization_id": params.OrganizationID,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.FromStatuses) > 0 {
+			query = query.Where(sq.Eq{
+				"status": params.FromStatuses,
+			})
+		}
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error update license operation. %w", err)
+		}
+
+		if len(params.FromStatuses) == 0 {
+			return nil
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorOperationStatusConflict
+		}
+
+		return nil
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/secrets"
 	sqltools "github.com/emochka2007/block-accounting/internal/pkg/sqlutils"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/users"
 	"github.com/google/uuid"
@@ -16,7 +17,8 @@ import (
 )
 
 var (
-	ErrorNotFound = errors.New("not found")
+	ErrorNotFound                        = errors.New("not found")
+	ErrorOwnershipTransferStatusConflict = errors.New("ownership transfer status conflict")
 )
 
 type GetParams struct {
@@ -37,14 +39,42 @@ type ParticipantsParams struct {
 	UsersOnly     bool
 	ActiveOnly    bool
 	EmployeesOnly bool
+	OwnerOnly     bool
+	// Search matches participant name or position, case insensitive
+	Search string
+
+	// Participants are ordered by (user_id, employee_id). Cursor is the last fetched participant key
+	CursorUserId     uuid.UUID
+	CursorEmployeeId uuid.UUID
+	Limit            int64
 }
 
 type AddParticipantParams struct {
 	OrganizationId uuid.UUID
 	UserId         uuid.UUID
 	EmployeeId     uuid.UUID
-	IsAdmin        bool
-	IsOwner        bool
+	Role           models.Role
+	Position       string
+}
+
+type UpdateParticipantRoleParams struct {
+	OrganizationId uuid.UUID
+	UserId         uuid.UUID
+	Role           models.Role
+	UpdatedAt      time.Time
+}
+
+type UpdateParticipantParams struct {
+	OrganizationId uuid.UUID
+	UserId         uuid.UUID
+	EmployeeId     uuid.UUID
+
+	// Empty fields are not updated. Name and WalletAddress are updated for employees only
+	Name          string
+	Position      string
+	WalletAddress []byte
+
+	UpdatedAt time.Time
 }
 
 type DeleteParticipantParams struct {
@@ -53,6 +83,25 @@ type DeleteParticipantParams struct {
 	EmployeeId     uuid.UUID
 }
 
+type ListOwnershipTransfersParams struct {
+	IDs            uuid.UUIDs
+	OrganizationID uuid.UUID
+	Statuses       []models.OwnershipTransferStatus
+	Limit          int64
+}
+
+type CancelOwnershipTransferParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	CancelledAt    time.Time
+}
+
+type ConfirmOwnershipTransferParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	ConfirmedAt    time.Time
+}
+
 type Repository interface {
 	Create(ctx context.Context, org models.Organization) error
 	Get(ctx context.Context, params GetParams) ([]*models.Organization, error)
@@ -60,23 +109,46 @@ type Repository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	AddParticipant(ctx context.Context, params AddParticipantParams) error
 	Participants(ctx context.Context, params ParticipantsParams) ([]models.OrganizationParticipant, error)
+	// CountParticipants returns number of participants matching params filters. Cursor and limit are ignored
+	CountParticipants(ctx context.Context, params ParticipantsParams) (int64, error)
 	CreateAndAdd(ctx context.Context, org models.Organization, user *models.User) error
+	// UpdateParticipant updates active participant. Returns ErrorNotFound if there is no such participant
+	UpdateParticipant(ctx context.Context, params UpdateParticipantParams) error
+	// DeleteParticipant soft deletes active participant and flags multisigs owned by the deleted user
+	// as requiring keys rotation. Returns ErrorNotFound if there is no such participant
 	DeleteParticipant(ctx context.Context, params DeleteParticipantParams) error
+	// RestoreParticipant restores deleted participant and clears multisigs rotation flag
+	// if the multisig has no other deleted owners. Returns ErrorNotFound if there is no such participant
+	RestoreParticipant(ctx context.Context, params DeleteParticipantParams) error
+	// UpdateParticipantRole sets role of the active organization user. Returns ErrorNotFound if there is no such user
+	UpdateParticipantRole(ctx context.Context, params UpdateParticipantRoleParams) error
 	AddEmployee(ctx context.Context, employee models.Employee) error
+
+	AddOwnershipTransfer(ctx context.Context, transfer models.OwnershipTransfer) error
+	ListOwnershipTransfers(ctx context.Context, params ListOwnershipTransfersParams) ([]models.OwnershipTransfer, error)
+	// CancelOwnershipTransfer cancels pending transfer. Returns ErrorOwnershipTransferStatusConflict if transfer is not pending
+	CancelOwnershipTransfer(ctx context.Context, params CancelOwnershipTransferParams) error
+	// ConfirmOwnershipTransfer confirms pending transfer, grants owner role to the new owner and admin role
+	// to the former one. Returns ErrorOwnershipTransferStatusConflict if transfer is not pending and
+	// ErrorNotFound if any of the users is not an active participant
+	ConfirmOwnershipTransfer(ctx context.Context, params ConfirmOwnershipTransferParams) error
 }
 
 type repositorySQL struct {
 	db              *sql.DB
 	usersRepository users.Repository
+	keeper          *secrets.Keeper
 }
 
 func NewRepository(
 	db *sql.DB,
 	usersRepository users.Repository,
+	keeper *secrets.Keeper,
 ) Repository {
 	return &repositorySQL{
 		db:              db,
 		usersRepository: usersRepository,
+		keeper:          keeper,
 	}
 }
 
@@ -89,6 +161,11 @@ func (s *repositorySQL) Conn(ctx context.Context) sqltools.DBTX {
 }
 
 func (r *repositorySQL) Create(ctx context.Context, org models.Organization) error {
+	walletSeed, err := r.keeper.Encrypt(org.WalletSeed)
+	if err != nil {
+		return fmt.Errorf("error encrypt organization wallet seed. %w", err)
+	}
+
 	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
 		query := sq.Insert("organizations").Columns(
 			"id",
@@ -101,7 +178,7 @@ func (r *repositorySQL) Create(ctx context.Context, org models.Organization) err
 			org.ID,
 			org.Name,
 			org.Address,
-			org.WalletSeed,
+			walletSeed,
 			org.CreatedAt,
 			org.UpdatedAt,
 		).PlaceholderFormat(sq.Dollar)
@@ -129,6 +206,7 @@ func (r *repositorySQL) Get(ctx context.Context, params GetParams) ([]*models.Or
 			"o.wallet_seed",
 			"o.created_at",
 			"o.updated_at",
+			"o.archived_at",
 		).From("organizations as o").
 			Limit(uint64(params.Limit)).
 			PlaceholderFormat(sq.Dollar)
@@ -136,7 +214,8 @@ func (r *repositorySQL) Get(ctx context.Context, params GetParams) ([]*models.Or
 		if params.UserId != uuid.Nil {
 			query = query.InnerJoin("organizations_users as ou on o.id = ou.organization_id").
 				Where(sq.Eq{
-					"ou.user_id": params.UserId,
+					"ou.user_id":    params.UserId,
+					"ou.deleted_at": nil,
 				})
 		}
 
@@ -177,6 +256,7 @@ func (r *repositorySQL) Get(ctx context.Context, params GetParams) ([]*models.Or
 				walletSeed []byte
 				createdAt  time.Time
 				updatedAt  time.Time
+				archivedAt sql.NullTime
 			)
 
 			if err = rows.Scan(
@@ -186,10 +266,15 @@ func (r *repositorySQL) Get(ctx context.Context, params GetParams) ([]*models.Or
 				&walletSeed,
 				&createdAt,
 				&updatedAt,
+				&archivedAt,
 			); err != nil {
 				return fmt.Errorf("error scan row. %w", err)
 			}
 
+			if walletSeed, err = r.keeper.Decrypt(walletSeed); err != nil {
+				return fmt.Errorf("error decrypt organization wallet seed. %w", err)
+			}
+
 			organizations = append(organizations, &models.Organization{
 				ID:         id,
 				Name:       name,
@@ -197,6 +282,7 @@ func (r *repositorySQL) Get(ctx context.Context, params GetParams) ([]*models.Or
 				WalletSeed: walletSeed,
 				CreatedAt:  createdAt,
 				UpdatedAt:  updatedAt,
+				ArchivedAt: archivedAt.Time,
 			})
 		}
 
@@ -209,17 +295,28 @@ func (r *repositorySQL) Get(ctx context.Context, params GetParams) ([]*models.Or
 }
 
 func (r *repositorySQL) Update(ctx context.Context, org models.Organization) error {
+	walletSeed, err := r.keeper.Encrypt(org.WalletSeed)
+	if err != nil {
+		return fmt.Errorf("error encrypt organization wallet seed. %w", err)
+	}
+
 	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
-		query := sq.Update("organizations as o").
+		archivedAt := sql.NullTime{
+			Time:  org.ArchivedAt,
+			Valid: !org.ArchivedAt.IsZero(),
+		}
+
+		query := sq.Update("organizations").
 			SetMap(sq.Eq{
-				"o.name":        org.Name,
-				"o.address":     org.Address,
-				"o.wallet_seed": org.WalletSeed,
-				"o.created_at":  org.CreatedAt,
-				"o.updated_at":  org.UpdatedAt,
+				"name":        org.Name,
+				"address":     org.Address,
+				"wallet_seed": walletSeed,
+				"created_at":  org.CreatedAt,
+				"updated_at":  org.UpdatedAt,
+				"archived_at": archivedAt,
 			}).
 			Where(sq.Eq{
-				"o.id": org.ID,
+				"id": org.ID,
 			}).
 			PlaceholderFormat(sq.Dollar)
 
@@ -264,8 +361,7 @@ func (r *repositorySQL) CreateAndAdd(ctx context.Context, org models.Organizatio
 		if err := r.AddParticipant(ctx, AddParticipantParams{
 			OrganizationId: org.ID,
 			UserId:         user.Id(),
-			IsAdmin:        true,
-			IsOwner:        true,
+			Role:           models.RoleOwner,
 		}); err != nil {
 			return fmt.Errorf("error add user to newly created organization. %w", err)
 		}
@@ -287,8 +383,8 @@ func (r *repositorySQL) AddParticipant(ctx context.Context, params AddParticipan
 				"employee_id",
 				"added_at",
 				"updated_at",
-				"is_admin",
-				"is_owner",
+				"role",
+				"position",
 			).
 			Values(
 				params.OrganizationId,
@@ -296,8 +392,8 @@ func (r *repositorySQL) AddParticipant(ctx context.Context, params AddParticipan
 				params.EmployeeId,
 				time.Now(),
 				time.Now(),
-				params.IsAdmin,
-				params.IsOwner,
+				params.Role,
+				params.Position,
 			).
 			PlaceholderFormat(sq.Dollar)
 
@@ -313,43 +409,234 @@ func (r *repositorySQL) AddParticipant(ctx context.Context, params AddParticipan
 	return nil
 }
 
+func (r *repositorySQL) UpdateParticipant(ctx context.Context, params UpdateParticipantParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Update("organizations_users").
+			Set("updated_at", params.UpdatedAt).
+			Where(sq.Eq{
+				"organization_id": params.OrganizationId,
+				"deleted_at":      nil,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		if params.Position != "" {
+			query = query.Set("position", params.Position)
+		}
+
+		query = participantFilter(query, params.UserId, params.EmployeeId)
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error update participant. %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorNotFound
+		}
+
+		if params.EmployeeId == uuid.Nil || (params.Name == "" && len(params.WalletAddress) == 0) {
+			return nil
+		}
+
+		employeeQuery := sq.Update("employees").
+			Set("updated_at", params.UpdatedAt).
+			Where(sq.Eq{
+				"id":              params.EmployeeId,
+				"organization_id": params.OrganizationId,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		if params.Name != "" {
+			employeeQuery = employeeQuery.Set("name", params.Name)
+		}
+
+		if len(params.WalletAddress) > 0 {
+			employeeQuery = employeeQuery.Set("wallet_address", params.WalletAddress)
+		}
+
+		if _, err := employeeQuery.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error update employee. %w", err)
+		}
+
+		return nil
+	})
+}
+
 func (r *repositorySQL) DeleteParticipant(ctx context.Context, params DeleteParticipantParams) error {
-	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
 		deletedAt := time.Now()
 
-		query := sq.Update("organizations_users as ou").
+		query := sq.Update("organizations_users").
 			SetMap(sq.Eq{
 				"updated_at": deletedAt,
 				"deleted_at": deletedAt,
-				"is_admin":   false,
 			}).
 			Where(sq.Eq{
-				"ou.organization_id": params.OrganizationId,
+				"organization_id": params.OrganizationId,
+				"deleted_at":      nil,
 			}).
 			PlaceholderFormat(sq.Dollar)
 
-		if params.EmployeeId != uuid.Nil {
-			query = query.Where(sq.Eq{
-				"ou.employee_id": params.EmployeeId,
-			})
+		query = participantFilter(query, params.UserId, params.EmployeeId)
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error delete participant from organization. %w", err)
 		}
 
-		if params.UserId != uuid.Nil {
-			query = query.Where(sq.Eq{
-				"ou.user_id": params.UserId,
-			})
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
 		}
 
-		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
-			return fmt.Errorf("error delete participant from organization. %w", err)
+		if affected == 0 {
+			return ErrorNotFound
+		}
+
+		if params.UserId == uuid.Nil {
+			return nil
+		}
+
+		flagQuery := sq.Update("multisigs").
+			SetMap(sq.Eq{
+				"rotation_required_at": deletedAt,
+				"updated_at":           deletedAt,
+			}).
+			Where(sq.Eq{
+				"organization_id":      params.OrganizationId,
+				"rotation_required_at": nil,
+			}).
+			Where(
+				sq.Expr("id in (select mo.multisig_id from multisig_owners as mo where mo.owner_id = ?)", params.UserId),
+			).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := flagQuery.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error flag deleted owner multisigs. %w", err)
 		}
 
 		return nil
-	}); err != nil {
-		return err
+	})
+}
+
+func (r *repositorySQL) RestoreParticipant(ctx context.Context, params DeleteParticipantParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		restoredAt := time.Now()
+
+		query := sq.Update("organizations_users").
+			SetMap(sq.Eq{
+				"updated_at": restoredAt,
+				"deleted_at": nil,
+			}).
+			Where(sq.Eq{
+				"organization_id": params.OrganizationId,
+			}).
+			Where(sq.NotEq{
+				"deleted_at": nil,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		query = participantFilter(query, params.UserId, params.EmployeeId)
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error restore participant. %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorNotFound
+		}
+
+		if params.UserId == uuid.Nil {
+			return nil
+		}
+
+		unflagQuery := sq.Update("multisigs as m").
+			SetMap(sq.Eq{
+				"rotation_required_at": nil,
+				"updated_at":           restoredAt,
+			}).
+			Where(sq.Eq{
+				"m.organization_id": params.OrganizationId,
+			}).
+			Where(
+				sq.Expr("m.id in (select mo.multisig_id from multisig_owners as mo where mo.owner_id = ?)", params.UserId),
+			).
+			Where(sq.Expr(
+				`not exists (
+					select 1 from multisig_owners as mo 
+					inner join organizations_users as ou 
+						on ou.user_id = mo.owner_id and ou.organization_id = m.organization_id
+					where mo.multisig_id = m.id and ou.deleted_at is not null
+				)`,
+			)).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := unflagQuery.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error clear restored owner multisigs flag. %w", err)
+		}
+
+		return nil
+	})
+}
+
+// participantFilter narrows organizations_users update to the user or employee records
+func participantFilter(query sq.UpdateBuilder, userID, employeeID uuid.UUID) sq.UpdateBuilder {
+	if employeeID != uuid.Nil {
+		query = query.Where(sq.Eq{
+			"employee_id": employeeID,
+		})
 	}
 
-	return nil
+	if userID != uuid.Nil {
+		query = query.Where(sq.Eq{
+			"user_id": userID,
+		})
+	}
+
+	return query
+}
+
+func (r *repositorySQL) UpdateParticipantRole(ctx context.Context, params UpdateParticipantRoleParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Update("organizations_users").
+			SetMap(sq.Eq{
+				"role":       params.Role,
+				"updated_at": params.UpdatedAt,
+			}).
+			Where(sq.Eq{
+				"organization_id": params.OrganizationId,
+				"user_id":         params.UserId,
+				"deleted_at":      nil,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error update participant role. %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorNotFound
+		}
+
+		return nil
+	})
 }
 
 func (r *repositorySQL) Participants(
@@ -369,30 +656,21 @@ func (r *repositorySQL) Participants(
 			"ou.added_at",
 			"ou.updated_at",
 			"ou.deleted_at",
-			"ou.is_admin",
-			"ou.is_owner",
-		).Where(sq.Eq{
-			"ou.organization_id": params.OrganizationId,
-		}).From("organizations_users as ou").
+			"ou.role",
+		).From("organizations_users as ou").
+			OrderBy("ou.user_id", "ou.employee_id").
 			PlaceholderFormat(sq.Dollar)
 
-		if len(params.Ids) > 0 {
+		ouQuery = participantsFilter(ouQuery, params)
+
+		if params.CursorUserId != uuid.Nil || params.CursorEmployeeId != uuid.Nil {
 			ouQuery = ouQuery.Where(
-				sq.Or{
-					sq.Eq{
-						"ou.user_id": params.Ids,
-					},
-					sq.Eq{
-						"ou.employee_id": params.Ids,
-					},
-				},
+				sq.Expr("(ou.user_id, ou.employee_id) > (?, ?)", params.CursorUserId, params.CursorEmployeeId),
 			)
 		}
 
-		if len(params.PKs) > 0 {
-			ouQuery = ouQuery.InnerJoin("users as u on u.id = ou.user_id").Where(sq.Eq{
-				"u.public_key": params.PKs,
-			})
+		if params.Limit > 0 {
+			ouQuery = ouQuery.Limit(uint64(params.Limit))
 		}
 
 		rows, err := ouQuery.RunWith(r.Conn(ctx)).QueryContext(ctx)
@@ -415,8 +693,7 @@ func (r *repositorySQL) Participants(
 				addedAt        time.Time
 				updatedAt      time.Time
 				deletedAt      sql.NullTime
-				isAdmin        bool
-				isOwner        bool
+				role           int
 			)
 
 			if err = rows.Scan(
@@ -427,24 +704,11 @@ func (r *repositorySQL) Participants(
 				&addedAt,
 				&updatedAt,
 				&deletedAt,
-				&isAdmin,
-				&isOwner,
+				&role,
 			); err != nil {
 				return fmt.Errorf("error scan row. %w", err)
 			}
 
-			if params.EmployeesOnly && employeeID == uuid.Nil {
-				continue
-			}
-
-			if params.UsersOnly && userID == uuid.Nil {
-				continue
-			}
-
-			if params.ActiveOnly && deletedAt.Valid {
-				continue
-			}
-
 			orgUsersModels = append(orgUsersModels, fetchOrganizationUsersModel{
 				organizationID: organizationID,
 				userID:         userID,
@@ -453,8 +717,7 @@ func (r *repositorySQL) Participants(
 				addedAt:        addedAt,
 				updatedAt:      updatedAt,
 				deletedAt:      deletedAt.Time,
-				isAdmin:        isAdmin,
-				isOwner:        isOwner,
+				role:           models.Role(role),
 			})
 		}
 
@@ -583,6 +846,9 @@ func (r *repositorySQL) Participants(
 			}
 
 			if ou.userID == uuid.Nil && employee != nil {
+				employee.EmployeePosition = ou.position
+				employee.DeletedAt = ou.deletedAt
+
 				participants = append(participants, employee)
 			}
 
@@ -591,8 +857,7 @@ func (r *repositorySQL) Participants(
 					participants = append(participants, &models.OrganizationUser{
 						User:        *u,
 						OrgPosition: ou.position,
-						Admin:       ou.isAdmin,
-						Owner:       ou.isOwner,
+						OrgRole:     ou.role,
 						Employee:    employee,
 						CreatedAt:   ou.addedAt,
 						UpdatedAt:   ou.updatedAt,
@@ -616,6 +881,83 @@ func (r *repositorySQL) Participants(
 	return participants, nil
 }
 
+func (r *repositorySQL) CountParticipants(ctx context.Context, params ParticipantsParams) (int64, error) {
+	var count int64
+
+	query := participantsFilter(
+		sq.Select("count(*)").From("organizations_users as ou").PlaceholderFormat(sq.Dollar),
+		params,
+	)
+
+	if err := query.RunWith(r.Conn(ctx)).QueryRowContext(ctx).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error count organization participants. %w", err)
+	}
+
+	return count, nil
+}
+
+// participantsFilter applies ParticipantsParams filters to organizations_users query
+func participantsFilter(query sq.SelectBuilder, params ParticipantsParams) sq.SelectBuilder {
+	query = query.Where(sq.Eq{
+		"ou.organization_id": params.OrganizationId,
+	})
+
+	if len(params.Ids) > 0 {
+		query = query.Where(
+			sq.Or{
+				sq.Eq{
+					"ou.user_id": params.Ids,
+				},
+				sq.Eq{
+					"ou.employee_id": params.Ids,
+				},
+			},
+		)
+	}
+
+	if len(params.PKs) > 0 {
+		query = query.InnerJoin("users as u on u.id = ou.user_id").Where(sq.Eq{
+			"u.public_key": params.PKs,
+		})
+	}
+
+	if params.UsersOnly || params.OwnerOnly {
+		query = query.Where(sq.NotEq{
+			"ou.user_id": uuid.Nil,
+		})
+	}
+
+	if params.EmployeesOnly {
+		query = query.Where(sq.NotEq{
+			"ou.employee_id": uuid.Nil,
+		})
+	}
+
+	if params.OwnerOnly {
+		query = query.Where(sq.Eq{
+			"ou.role": models.RoleOwner,
+		})
+	}
+
+	if params.ActiveOnly {
+		query = query.Where(sq.Eq{
+			"ou.deleted_at": nil,
+		})
+	}
+
+	if params.Search != "" {
+		pattern := "%" + params.Search + "%"
+
+		query = query.Where(sq.Or{
+			sq.ILike{"ou.position": pattern},
+			sq.Expr("exists (select 1 from users as su where su.id = ou.user_id and su.name ilike ?)", pattern),
+			sq.Expr("exists (select 1 from employees as se where se.id = ou.employee_id and se.name ilike ?)", pattern),
+		})
+	}
+
+	return query
+}
+
 type fetchOrganizationUsersModel struct {
 	organizationID uuid.UUID
 	userID         uuid.UUID
@@ -624,8 +966,7 @@ type fetchOrganizationUsersModel struct {
 	addedAt        time.Time
 	updatedAt      time.Time
 	deletedAt      time.Time
-	isAdmin        bool
-	isOwner        bool
+	role           models.Role
 }
 
 func (r *repositorySQL) fetchOrganizationUsers(
@@ -643,8 +984,7 @@ func (r *repositorySQL) fetchOrganizationUsers(
 			"ou.added_at",
 			"ou.updated_at",
 			"ou.deleted_at",
-			"ou.is_admin",
-			"ou.is_owner",
+			"ou.role",
 		).Where(sq.Eq{
 			"ou.organization_id": params.OrganizationId,
 		}).From("organizations_users as ou").
@@ -676,8 +1016,7 @@ func (r *repositorySQL) fetchOrganizationUsers(
 				addedAt        time.Time
 				updatedAt      time.Time
 				deletedAt      sql.NullTime
-				isAdmin        bool
-				isOwner        bool
+				role           int
 			)
 
 			if err = rows.Scan(
@@ -688,8 +1027,7 @@ func (r *repositorySQL) fetchOrganizationUsers(
 				&addedAt,
 				&updatedAt,
 				&deletedAt,
-				&isAdmin,
-				&isOwner,
+				&role,
 			); err != nil {
 				return fmt.Errorf("error scan row. %w", err)
 			}
@@ -714,8 +1052,7 @@ func (r *repositorySQL) fetchOrganizationUsers(
 				addedAt:        addedAt,
 				updatedAt:      updatedAt,
 				deletedAt:      deletedAt.Time,
-				isAdmin:        isAdmin,
-				isOwner:        isAdmin,
+				role:           models.Role(role),
 			})
 		}
 
@@ -811,6 +1148,7 @@ func (r *repositorySQL) AddEmployee(ctx context.Context, employee models.Employe
 	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
 		query := sq.Insert("employees").Columns(
 			"id",
+			"name",
 			"user_id",
 			"organization_id",
 			"wallet_address",
@@ -818,6 +1156,7 @@ func (r *repositorySQL) AddEmployee(ctx context.Context, employee models.Employe
 			"updated_at",
 		).Values(
 			employee.ID,
+			employee.EmployeeName,
 			employee.UserID,
 			employee.OrganizationId,
 			employee.WalletAddress,
@@ -833,6 +1172,7 @@ func (r *repositorySQL) AddEmployee(ctx context.Context, employee models.Employe
 			OrganizationId: employee.OrganizationId,
 			UserId:         employee.UserID,
 			EmployeeId:     employee.ID,
+			Position:       employee.EmployeePosition,
 		}); err != nil {
 			return fmt.Errorf("error add employee to organization. %w", err)
 		}
@@ -844,3 +1184,202 @@ func (r *repositorySQL) AddEmployee(ctx context.Context, employee models.Employe
 
 	return nil
 }
+
+func (r *repositorySQL) AddOwnershipTransfer(ctx context.Context, transfer models.OwnershipTransfer) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Insert("ownership_transfers").Columns(
+			"id",
+			"organization_id",
+			"from_user_id",
+			"to_user_id",
+			"status",
+			"created_by",
+			"created_at",
+			"updated_at",
+		).Values(
+			transfer.ID,
+			transfer.OrganizationID,
+			transfer.FromUserID,
+			transfer.ToUserID,
+			transfer.Status,
+			transfer.CreatedBy,
+			transfer.CreatedAt,
+			transfer.UpdatedAt,
+		).PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error insert ownership transfer. %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) ListOwnershipTransfers(
+	ctx context.Context,
+	params ListOwnershipTransfersParams,
+) ([]models.OwnershipTransfer, error) {
+	transfers := make([]models.OwnershipTransfer, 0, len(params.IDs))
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"organization_id",
+			"from_user_id",
+			"to_user_id",
+			"status",
+			"created_by",
+			"created_at",
+			"updated_at",
+			"confirmed_at",
+			"cancelled_at",
+		).From("ownership_transfers").
+			Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			}).
+			OrderBy("created_at desc").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.IDs) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.IDs,
+			})
+		}
+
+		if len(params.Statuses) > 0 {
+			query = query.Where(sq.Eq{
+				"status": params.Statuses,
+			})
+		}
+
+		if params.Limit > 0 {
+			query = query.Limit(uint64(params.Limit))
+		}
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch ownership transfers from database. %w", err)
+		}
+
+		defer func() {
+			if closeErr := rows.Close(); closeErr != nil {
+				err = errors.Join(fmt.Errorf("error close rows. %w", closeErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var (
+				t           models.OwnershipTransfer
+				confirmedAt sql.NullTime
+				cancelledAt sql.NullTime
+			)
+
+			if err = rows.Scan(
+				&t.ID,
+				&t.OrganizationID,
+				&t.FromUserID,
+				&t.ToUserID,
+				&t.Status,
+				&t.CreatedBy,
+				&t.CreatedAt,
+				&t.UpdatedAt,
+				&confirmedAt,
+				&cancelledAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			t.ConfirmedAt = confirmedAt.Time
+			t.CancelledAt = cancelledAt.Time
+
+			transfers = append(transfers, t)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (r *repositorySQL) CancelOwnershipTransfer(ctx context.Context, params CancelOwnershipTransferParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Update("ownership_transfers").
+			SetMap(sq.Eq{
+				"status":       models.OwnershipTransferStatusCancelled,
+				"cancelled_at": params.CancelledAt,
+				"updated_at":   params.CancelledAt,
+			}).
+			Where(sq.Eq{
+				"id":              params.ID,
+				"organization_id": params.OrganizationID,
+				"status":          models.OwnershipTransferStatusPending,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error cancel ownership transfer. %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorOwnershipTransferStatusConflict
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) ConfirmOwnershipTransfer(ctx context.Context, params ConfirmOwnershipTransferParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Update("ownership_transfers").
+			SetMap(sq.Eq{
+				"status":       models.OwnershipTransferStatusConfirmed,
+				"confirmed_at": params.ConfirmedAt,
+				"updated_at":   params.ConfirmedAt,
+			}).
+			Where(sq.Eq{
+				"id":              params.ID,
+				"organization_id": params.OrganizationID,
+				"status":          models.OwnershipTransferStatusPending,
+			}).
+			Suffix("returning from_user_id, to_user_id").
+			PlaceholderFormat(sq.Dollar)
+
+		var fromUserID, toUserID uuid.UUID
+
+		if err := query.RunWith(r.Conn(ctx)).QueryRowContext(ctx).Scan(&fromUserID, &toUserID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrorOwnershipTransferStatusConflict
+			}
+
+			return fmt.Errorf("error confirm ownership transfer. %w", err)
+		}
+
+		if err := r.UpdateParticipantRole(ctx, UpdateParticipantRoleParams{
+			OrganizationId: params.OrganizationID,
+			UserId:         toUserID,
+			Role:           models.RoleOwner,
+			UpdatedAt:      params.ConfirmedAt,
+		}); err != nil {
+			return fmt.Errorf("error grant owner role. %w", err)
+		}
+
+		if err := r.UpdateParticipantRole(ctx, UpdateParticipantRoleParams{
+			OrganizationId: params.OrganizationID,
+			UserId:         fromUserID,
+			Role:           models.RoleAdmin,
+			UpdatedAt:      params.ConfirmedAt,
+		}); err != nil {
+			return fmt.Errorf("error revoke owner role. %w", err)
+		}
+
+		return nil
+	})
+}
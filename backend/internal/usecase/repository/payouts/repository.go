@@ -0,0 +1,634 @@
+package payouts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	sqltools "github.com/emochka2007/block-accounting/internal/pkg/sqlutils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorRunStatusConflict = errors.New("payout run status has been changed")
+)
+
+type ListRunsParams struct {
+	IDs            uuid.UUIDs
+	OrganizationID uuid.UUID
+	PayrollIDs     uuid.UUIDs
+	Limit          int64
+}
+
+type UpdateRunParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Status         models.PayoutRunStatus
+	// FromStatuses if set, run is updated only if its current status is one of them.
+	// Otherwise ErrorRunStatusConflict is returned
+	FromStatuses  []models.PayoutRunStatus
+	DepositTxHash string
+	ExecutedAt    time.Time
+	UpdatedAt     time.Time
+}
+
+type ListPaymentsParams struct {
+	IDs            uuid.UUIDs
+	OrganizationID uuid.UUID
+	PayoutRunIDs   uuid.UUIDs
+	PayrollIDs     uuid.UUIDs
+	EmployeeIDs    uuid.UUIDs
+	Limit          int64
+}
+
+type UpdatePaymentParams struct {
+	ID        uuid.UUID
+	Status    models.PaymentStatus
+	TxIndex   int64
+	TxHash    string
+	PaidAt    time.Time
+	UpdatedAt time.Time
+}
+
+type ListDepositsParams struct {
+	OrganizationID uuid.UUID
+	PayrollIDs     uuid.UUIDs
+	Limit          int64
+}
+
+type Repository interface {
+	// CreateRun saves payout run with its payments
+	CreateRun(ctx context.Context, run models.PayoutRun) error
+	ListRuns(ctx context.Context, params ListRunsParams) ([]*models.PayoutRun, error)
+	UpdateRun(ctx context.Context, params UpdateRunParams) error
+
+	ListPayments(ctx context.Context, params ListPaymentsParams) ([]models.Payment, error)
+	UpdatePayment(ctx context.Context, params UpdatePaymentParams) error
+
+	AddDeposit(ctx context.Context, deposit models.PayrollDeposit) error
+	ListDeposits(ctx context.Context, params ListDepositsParams) ([]models.PayrollDeposit, error)
+}
+
+type repositorySQL struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repositorySQL{
+		db: db,
+	}
+}
+
+func (s *repositorySQL) Conn(ctx context.Context) sqltools.DBTX {
+	if tx, ok := ctx.Value(sqltools.TxCtxKey).(*sql.Tx); ok {
+		return tx
+	}
+
+	return s.db
+}
+
+func (r *repositorySQL) CreateRun(ctx context.Context, run models.PayoutRun) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Insert("payout_runs").
+			Columns(
+				"id",
+				"organization_id",
+				"payroll_id",
+				"multisig_id",
+				"status",
+				"deposit_amount",
+				"created_by",
+				"created_at",
+				"updated_at",
+			).
+			Values(
+				run.ID,
+				run.OrganizationID,
+				run.PayrollID,
+				run.MultisigID,
+				run.Status,
+				run.DepositAmount.Value,
+				run.CreatedBy,
+				run.CreatedAt,
+				run.UpdatedAt,
+			).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error insert payout run. %w", err)
+		}
+
+		for _, p := range run.Payments {
+			paymentQuery := sq.Insert("payments").
+				Columns(
+					"id",
+					"payout_run_id",
+					"organization_id",
+					"payroll_id",
+					"salary_id",
+					"employee_id",
+					"employee_address",
+					"amount",
+					"status",
+					"created_at",
+					"updated_at",
+				).
+				Values(
+					p.ID,
+					run.ID,
+					run.OrganizationID,
+					run.PayrollID,
+					p.SalaryID,
+					p.EmployeeID,
+					p.EmployeeAddress,
+					p.Amount.Value,
+					p.Status,
+					p.CreatedAt,
+					p.UpdatedAt,
+				).
+				PlaceholderFormat(sq.Dollar)
+
+			if _, err := paymentQuery.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+				return fmt.Errorf("error insert payment. %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) ListRuns(ctx context.Context, params ListRunsParams) ([]*models.PayoutRun, error) {
+	runs := make([]*models.PayoutRun, 0, len(params.IDs))
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"organization_id",
+			"payroll_id",
+			"multisig_id",
+			"status",
+			"deposit_amount",
+			"deposit_tx_hash",
+			"created_by",
+			"created_at",
+			"updated_at",
+			"executed_at",
+		).From("payout_runs").
+			Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			}).
+			OrderBy("created_at desc").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.IDs) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.IDs,
+			})
+		}
+
+		if len(params.PayrollIDs) > 0 {
+			query = query.Where(sq.Eq{
+				"payroll_id": params.PayrollIDs,
+			})
+		}
+
+		if params.Limit <= 0 {
+			params.Limit = 100
+		}
+
+		query = query.Limit(uint64(params.Limit))
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch payout runs from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var (
+				id             uuid.UUID
+				organizationID uuid.UUID
+				payrollID      uuid.UUID
+				multisigID     uuid.UUID
+				status         int
+				depositAmount  money.Decimal
+				depositTxHash  sql.NullString
+				createdBy      uuid.UUID
+				createdAt      time.Time
+				updatedAt      time.Time
+				executedAt     sql.NullTime
+			)
+
+			if err = rows.Scan(
+				&id,
+				&organizationID,
+				&payrollID,
+				&multisigID,
+				&status,
+				&depositAmount,
+				&depositTxHash,
+				&createdBy,
+				&createdAt,
+				&updatedAt,
+				&executedAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			runs = append(runs, &models.PayoutRun{
+				ID:             id,
+				OrganizationID: organizationID,
+				PayrollID:      payrollID,
+				MultisigID:     multisigID,
+				Status:         models.PayoutRunStatus(status),
+				DepositAmount:  money.New(depositAmount, money.ETH),
+				DepositTxHash:  depositTxHash.String,
+				CreatedBy:      createdBy,
+				CreatedAt:      createdAt,
+				UpdatedAt:      updatedAt,
+				ExecutedAt:     executedAt.Time,
+			})
+		}
+
+		if len(runs) == 0 {
+			return nil
+		}
+
+		runsIDs := make(uuid.UUIDs, len(runs))
+		runsMap := make(map[uuid.UUID]*models.PayoutRun, len(runs))
+
+		for i, run := range runs {
+			runsIDs[i] = run.ID
+			runsMap[run.ID] = run
+		}
+
+		payments, err := r.ListPayments(ctx, ListPaymentsParams{
+			OrganizationID: params.OrganizationID,
+			PayoutRunIDs:   runsIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("error fetch payout runs payments. %w", err)
+		}
+
+		for _, p := range payments {
+			if run, ok := runsMap[p.PayoutRunID]; ok {
+				run.Payments = append(run.Payments, p)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+func (r *repositorySQL) UpdateRun(ctx context.Context, params UpdateRunParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		values := sq.Eq{
+			"status":     params.Status,
+			"updated_at": params.UpdatedAt,
+		}
+
+		if params.DepositTxHash != "" {
+			values["deposit_tx_hash"] = params.DepositTxHash
+		}
+
+		if !params.ExecutedAt.IsZero() {
+			values["executed_at"] = params.ExecutedAt
+		}
+
+		query := sq.Update("payout_runs").
+			SetMap(values).
+			Where(sq.Eq{
+				"id":              params.ID,
+				"organization_id": params.OrganizationID,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.FromStatuses) > 0 {
+			query = query.Where(sq.Eq{
+				"status": params.FromStatuses,
+			})
+		}
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error update payout run. %w", err)
+		}
+
+		if len(params.FromStatuses) == 0 {
+			return nil
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorRunStatusConflict
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) ListPayments(ctx context.Context, params ListPaymentsParams) ([]models.Payment, error) {
+	payments := make([]models.Payment, 0, len(params.IDs))
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"payout_run_id",
+			"organization_id",
+			"payroll_id",
+			"salary_id",
+			"employee_id",
+			"employee_address",
+			"amount",
+			"status",
+			"tx_index",
+			"tx_hash",
+			"created_at",
+			"updated_at",
+			"paid_at",
+		).From("payments").
+			Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			}).
+			OrderBy("created_at desc").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.IDs) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.IDs,
+			})
+		}
+
+		if len(params.PayoutRunIDs) > 0 {
+			query = query.Where(sq.Eq{
+				"payout_run_id": params.PayoutRunIDs,
+			})
+		}
+
+		if len(params.PayrollIDs) > 0 {
+			query = query.Where(sq.Eq{
+				"payroll_id": params.PayrollIDs,
+			})
+		}
+
+		if len(params.EmployeeIDs) > 0 {
+			query = query.Where(sq.Eq{
+				"employee_id": params.EmployeeIDs,
+			})
+		}
+
+		if params.Limit > 0 {
+			query = query.Limit(uint64(params.Limit))
+		}
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch payments from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var (
+				id              uuid.UUID
+				payoutRunID     uuid.UUID
+				organizationID  uuid.UUID
+				payrollID       uuid.UUID
+				salaryID        uuid.UUID
+				employeeID      uuid.UUID
+				employeeAddress []byte
+				amount          money.Decimal
+				status          int
+				txIndex         sql.NullInt64
+				txHash          sql.NullString
+				createdAt       time.Time
+				updatedAt       time.Time
+				paidAt          sql.NullTime
+			)
+
+			if err = rows.Scan(
+				&id,
+				&payoutRunID,
+				&organizationID,
+				&payrollID,
+				&salaryID,
+				&employeeID,
+				&employeeAddress,
+				&amount,
+				&status,
+				&txIndex,
+				&txHash,
+				&createdAt,
+				&updatedAt,
+				&paidAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			payments = append(payments, models.Payment{
+				ID:              id,
+				PayoutRunID:     payoutRunID,
+				OrganizationID:  organizationID,
+				PayrollID:       payrollID,
+				SalaryID:        salaryID,
+				EmployeeID:      employeeID,
+				EmployeeAddress: employeeAddress,
+				Amount:          money.New(amount, money.USD),
+				Status:          models.PaymentStatus(status),
+				TxIndex:         txIndex.Int64,
+				TxHash:          txHash.String,
+				CreatedAt:       createdAt,
+				UpdatedAt:       updatedAt,
+				PaidAt:          paidAt.Time,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+func (r *repositorySQL) UpdatePayment(ctx context.Context, params UpdatePaymentParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		values := sq.Eq{
+			"status":     params.Status,
+			"updated_at": params.UpdatedAt,
+		}
+
+		if params.Status == models.PaymentStatusSubmitted {
+			values["tx_index"] = params.TxIndex
+		}
+
+		if params.TxHash != "" {
+			values["tx_hash"] = params.TxHash
+		}
+
+		if !params.PaidAt.IsZero() {
+			values["paid_at"] = params.PaidAt
+		}
+
+		query := sq.Update("payments").
+			SetMap(values).
+			Where(sq.Eq{
+				"id": params.ID,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error update payment. %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) AddDeposit(ctx context.Context, deposit models.PayrollDeposit) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		columns := []string{
+			"id",
+			"organization_id",
+			"payroll_id",
+			"amount",
+			"tx_hash",
+			"created_by",
+			"created_at",
+		}
+
+		values := []any{
+			deposit.ID,
+			deposit.OrganizationID,
+			deposit.PayrollID,
+			deposit.Amount.Value,
+			deposit.TxHash,
+			deposit.CreatedBy,
+			deposit.CreatedAt,
+		}
+
+		if deposit.PayoutRunID != uuid.Nil {
+			columns = append(columns, "payout_run_id")
+			values = append(values, deposit.PayoutRunID)
+		}
+
+		query := sq.Insert("payroll_deposits").
+			Columns(columns...).
+			Values(values...).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error insert payroll deposit. %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) ListDeposits(ctx context.Context, params ListDepositsParams) ([]models.PayrollDeposit, error) {
+	deposits := make([]models.PayrollDeposit, 0)
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"organization_id",
+			"payroll_id",
+			"payout_run_id",
+			"amount",
+			"tx_hash",
+			"created_by",
+			"created_at",
+		).From("payroll_deposits").
+			Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			}).
+			OrderBy("created_at desc").
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.PayrollIDs) > 0 {
+			query = query.Where(sq.Eq{
+				"payroll_id": params.PayrollIDs,
+			})
+		}
+
+		if params.Limit > 0 {
+			query = query.Limit(uint64(params.Limit))
+		}
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch payroll deposits from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var (
+				id             uuid.UUID
+				organizationID uuid.UUID
+				payrollID      uuid.UUID
+				payoutRunID    uuid.NullUUID
+				amount         money.Decimal
+				txHash         string
+				createdBy      uuid.UUID
+				createdAt      time.Time
+			)
+
+			if err = rows.Scan(
+				&id,
+				&organizationID,
+				&payrollID,
+				&payoutRunID,
+				&amount,
+				&txHash,
+				&createdBy,
+				&createdAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			deposits = append(deposits, models.PayrollDeposit{
+				ID:             id,
+				OrganizationID: organizationID,
+				PayrollID:      payrollID,
+				PayoutRunID:    payoutRunID.UUID,
+				Amount:         money.New(amount, money.ETH),
+				TxHash:         txHash,
+				CreatedBy:      createdBy,
+				CreatedAt:      createdAt,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return deposits, nil
+}
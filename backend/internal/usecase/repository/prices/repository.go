@@ -0,0 +1,167 @@
+package prices
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	sqltools "github.com/emochka2007/block-accounting/internal/pkg/sqlutils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorPriceExists = errors.New("price snapshot has already been taken")
+)
+
+type ListParams struct {
+	OrganizationID uuid.UUID
+	SourceType     models.LedgerEntrySource
+	SourceIDs      uuid.UUIDs
+}
+
+type Repository interface {
+	// Add saves price snapshot. Returns ErrorPriceExists if the source already has snapshot of the pair
+	Add(ctx context.Context, price models.Price) error
+	List(ctx context.Context, params ListParams) ([]*models.Price, error)
+}
+
+type repositorySQL struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repositorySQL{
+		db: db,
+	}
+}
+
+func (s *repositorySQL) Conn(ctx context.Context) sqltools.DBTX {
+	if tx, ok := ctx.Value(sqltools.TxCtxKey).(*sql.Tx); ok {
+		return tx
+	}
+
+	return s.db
+}
+
+func (r *repositorySQL) Add(ctx context.Context, price models.Price) error {
+	query := sq.Insert("prices").
+		Columns(
+			"id",
+			"organization_id",
+			"source_type",
+			"source_id",
+			"base",
+			"quote",
+			"rate",
+			"provider",
+			"observed_at",
+			"created_at",
+		).
+		Values(
+			price.ID,
+			price.OrganizationID,
+			price.SourceType,
+			price.SourceID,
+			price.Base,
+			price.Quote,
+			price.Rate,
+			price.Provider,
+			price.ObservedAt,
+			price.CreatedAt,
+		).
+		Suffix("on conflict (organization_id, source_type, source_id, base, quote) do nothing").
+		PlaceholderFormat(sq.Dollar)
+
+	res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error insert price. %w", err)
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error fetch inserted prices count. %w", err)
+	}
+
+	if inserted == 0 {
+		return ErrorPriceExists
+	}
+
+	return nil
+}
+
+func (r *repositorySQL) List(ctx context.Context, params ListParams) ([]*models.Price, error) {
+	prices := make([]*models.Price, 0)
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"organization_id",
+			"source_type",
+			"source_id",
+			"base",
+			"quote",
+			"rate",
+			"provider",
+			"observed_at",
+			"created_at",
+		).From("prices").
+			Where(sq.Eq{
+				"organization_id": params.OrganizationID,
+			}).
+			OrderBy("observed_at").
+			PlaceholderFormat(sq.Dollar)
+
+		if params.SourceType != "" {
+			query = query.Where(sq.Eq{
+				"source_type": params.SourceType,
+			})
+		}
+
+		if len(params.SourceIDs) > 0 {
+			query = query.Where(sq.Eq{
+				"source_id": params.SourceIDs,
+			})
+		}
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch prices from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			p := new(models.Price)
+
+			if err = rows.Scan(
+				&p.ID,
+				&p.OrganizationID,
+				&p.SourceType,
+				&p.SourceID,
+				&p.Base,
+				&p.Quote,
+				&p.Rate,
+				&p.Provider,
+				&p.ObservedAt,
+				&p.CreatedAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			prices = append(prices, p)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
@@ -9,11 +9,20 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/money"
+	"github.com/emochka2007/block-accounting/internal/pkg/secrets"
 	sqltools "github.com/emochka2007/block-accounting/internal/pkg/sqlutils"
 	"github.com/emochka2007/block-accounting/internal/usecase/repository/organizations"
 	"github.com/google/uuid"
 )
 
+var (
+	ErrorTransactionStatusConflict = errors.New("transaction status changed concurrently")
+	ErrorPayrollStatusConflict     = errors.New("payroll status changed concurrently")
+	ErrorSalaryStatusConflict      = errors.New("salary status changed concurrently")
+	ErrorConfirmationNotFound      = errors.New("multisig confirmation not found")
+)
+
 type GetTransactionsParams struct {
 	Ids            uuid.UUIDs
 	OrganizationId uuid.UUID
@@ -30,38 +39,94 @@ type ConfirmTransactionParams struct {
 	OrganizationId uuid.UUID
 }
 
+type UpdateTransactionParams struct {
+	TxId           uuid.UUID
+	OrganizationId uuid.UUID
+	Status         models.TransactionStatus
+	FromStatuses   []models.TransactionStatus
+	// TxIndex and SubmittedAt are saved with TransactionStatusSubmitted status
+	TxIndex     int64
+	SubmittedAt time.Time
+	TxHash      string
+	CommitedAt  time.Time
+	UpdatedAt   time.Time
+}
+
 type CancelTransactionParams struct {
 	TxId           uuid.UUID
 	UserId         uuid.UUID
 	OrganizationId uuid.UUID
 }
 
+type RevokeConfirmedTransactionParams struct {
+	TxId           uuid.UUID
+	OrganizationId uuid.UUID
+	MultisigID     uuid.UUID
+	OwnerID        uuid.UUID
+	// ExecuteJobKind is the kind of the job executing confirmed transaction
+	ExecuteJobKind string
+	RevokedAt      time.Time
+}
+
 type Repository interface {
 	GetTransactions(ctx context.Context, params GetTransactionsParams) ([]*models.Transaction, error)
 	CreateTransaction(ctx context.Context, tx models.Transaction) error
-	UpdateTransaction(ctx context.Context, tx models.Transaction) error
+	// UpdateTransaction updates transaction status. If FromStatuses is set, transaction is updated only if its status
+	// is one of them. Otherwise ErrorTransactionStatusConflict is returned
+	UpdateTransaction(ctx context.Context, params UpdateTransactionParams) error
 	DeleteTransaction(ctx context.Context, tx models.Transaction) error
 
+	// ConfirmTransaction moves pending transaction to the TransactionStatusConfirmed status.
+	// Returns ErrorTransactionStatusConflict if transaction is not pending or cancelled
 	ConfirmTransaction(ctx context.Context, params ConfirmTransactionParams) error
+	// CancelTransaction cancels pending transaction.
+	// Returns ErrorTransactionStatusConflict if transaction is not pending or already cancelled
 	CancelTransaction(ctx context.Context, params CancelTransactionParams) error
+	// RevokeConfirmedTransaction removes owner confirmation of the confirmed transaction, fails its queued
+	// execution job and returns transaction to the pending status at once. Returns ErrorTransactionStatusConflict
+	// if transaction is not confirmed or its execution job has already started
+	RevokeConfirmedTransaction(ctx context.Context, params RevokeConfirmedTransactionParams) error
 
 	AddMultisig(ctx context.Context, multisig models.Multisig) error
 	ListMultisig(ctx context.Context, params ListMultisigsParams) ([]models.Multisig, error)
+	// ConfirmMultisig saves owner confirmation or rejection of the entity and updates entity confirmations counter.
+	// Owner decision replaces the previous one
 	ConfirmMultisig(ctx context.Context, params ConfirmMultisigParams) error
+	// RevokeMultisigConfirmation removes owner confirmation or rejection of the entity and updates entity
+	// confirmations counter. Returns ErrorConfirmationNotFound if owner has not confirmed or rejected the entity
+	RevokeMultisigConfirmation(ctx context.Context, params RevokeMultisigConfirmationParams) error
+	MultisigConfirmations(ctx context.Context, params MultisigConfirmationsParams) (map[uuid.UUID]uuid.UUIDs, error)
+	// MultisigConfirmationsCount returns entity confirmations counter value
+	MultisigConfirmationsCount(ctx context.Context, params MultisigConfirmationsCountParams) (int, error)
 
 	AddPayrollContract(ctx context.Context, params AddPayrollContract) error
 	ListPayrolls(ctx context.Context, params ListPayrollsParams) ([]models.Payroll, error)
+	// UpdatePayroll updates payroll status. If FromStatuses is set, payroll is updated only if its status
+	// is one of them. Otherwise ErrorPayrollStatusConflict is returned
+	UpdatePayroll(ctx context.Context, params UpdatePayrollParams) error
+
+	AddSalary(ctx context.Context, salary models.Salary) error
+	// UpdateSalary updates salary status. If FromStatuses is set, salary is updated only if its status
+	// is one of them. Otherwise ErrorSalaryStatusConflict is returned
+	UpdateSalary(ctx context.Context, params UpdateSalaryParams) error
+	ListSalaries(ctx context.Context, params ListSalariesParams) ([]models.Salary, error)
 }
 
 type repositorySQL struct {
 	db      *sql.DB
 	orgRepo organizations.Repository
+	keeper  *secrets.Keeper
 }
 
-func NewRepository(db *sql.DB, orgRepo organizations.Repository) Repository {
+func NewRepository(
+	db *sql.DB,
+	orgRepo organizations.Repository,
+	keeper *secrets.Keeper,
+) Repository {
 	return &repositorySQL{
 		db:      db,
 		orgRepo: orgRepo,
+		keeper:  keeper,
 	}
 }
 
@@ -97,21 +162,35 @@ func (r *repositorySQL) GetTransactions(
 				id             uuid.UUID
 				description    string
 				organizationId uuid.UUID
-				amount         float64
+				amount         money.Decimal
 				toAddr         []byte
-				maxFeeAllowed  float64
+				maxFeeAllowed  money.Decimal
 				deadline       sql.NullTime
+				multisigId     uuid.NullUUID
+				confirmations  int
+				txIndex        sql.NullInt64
+				txHash         sql.NullString
+				status         int
 				createdAt      time.Time
 				updatedAt      time.Time
 				confirmedAt    sql.NullTime
 				cancelledAt    sql.NullTime
 				commitedAt     sql.NullTime
+				submittedAt    sql.NullTime
 
 				createdById          uuid.UUID
 				createdBySeed        []byte
 				createdByCreatedAt   time.Time
 				createdByActivatedAt sql.NullTime
-				createdByIsAdmin     bool
+				createdByRole        int
+
+				assetID              uuid.NullUUID
+				assetSymbol          sql.NullString
+				assetContractAddress []byte
+				assetDecimals        sql.NullInt32
+				assetChainID         sql.NullInt64
+				assetCreatedBy       uuid.NullUUID
+				assetCreatedAt       sql.NullTime
 			)
 
 			if err = rows.Scan(
@@ -123,35 +202,59 @@ func (r *repositorySQL) GetTransactions(
 				&toAddr,
 				&maxFeeAllowed,
 				&deadline,
+				&multisigId,
+				&confirmations,
+				&txIndex,
+				&txHash,
+				&status,
 				&createdAt,
 				&updatedAt,
 				&confirmedAt,
 				&cancelledAt,
 				&commitedAt,
+				&submittedAt,
 
 				&createdBySeed,
 				&createdByCreatedAt,
 				&createdByActivatedAt,
-				&createdByIsAdmin,
+				&createdByRole,
+
+				&assetID,
+				&assetSymbol,
+				&assetContractAddress,
+				&assetDecimals,
+				&assetChainID,
+				&assetCreatedBy,
+				&assetCreatedAt,
 			); err != nil {
 				return fmt.Errorf("error scan row. %w", err)
 			}
 
+			if createdBySeed, err = r.keeper.Decrypt(createdBySeed); err != nil {
+				return fmt.Errorf("error decrypt transaction author seed. %w", err)
+			}
+
 			tx := &models.Transaction{
 				Id:             id,
 				Description:    description,
 				OrganizationId: organizationId,
-				Amount:         amount,
+				Amount:         money.New(amount, money.ETH),
 				ToAddr:         toAddr,
-				MaxFeeAllowed:  maxFeeAllowed,
+				MaxFeeAllowed:  money.New(maxFeeAllowed, money.ETH),
 				CreatedBy: &models.OrganizationUser{
 					User: models.User{
 						ID:        createdById,
 						Bip39Seed: createdBySeed,
 					},
+					OrgRole: models.Role(createdByRole),
 				},
-				CreatedAt: createdAt,
-				UpdatedAt: updatedAt,
+				MultisigID:            multisigId.UUID,
+				ConfirmationsRequired: confirmations,
+				TxIndex:               txIndex.Int64,
+				TxHash:                txHash.String,
+				Status:                models.TransactionStatus(status),
+				CreatedAt:             createdAt,
+				UpdatedAt:             updatedAt,
 			}
 
 			if deadline.Valid {
@@ -170,10 +273,30 @@ func (r *repositorySQL) GetTransactions(
 				tx.CancelledAt = cancelledAt.Time
 			}
 
+			if submittedAt.Valid {
+				tx.SubmittedAt = submittedAt.Time
+			}
+
 			if createdByActivatedAt.Valid {
 				tx.CreatedBy.Activated = true
 			}
 
+			if assetID.Valid {
+				tx.AssetID = assetID.UUID
+				tx.Asset = &models.Asset{
+					ID:              assetID.UUID,
+					OrganizationID:  organizationId,
+					Symbol:          assetSymbol.String,
+					ContractAddress: assetContractAddress,
+					Decimals:        assetDecimals.Int32,
+					ChainID:         assetChainID.Int64,
+					CreatedBy:       assetCreatedBy.UUID,
+					CreatedAt:       assetCreatedAt.Time,
+				}
+
+				tx.Amount = money.New(amount, tx.Asset.Currency())
+			}
+
 			txs = append(txs, tx)
 		}
 
@@ -196,6 +319,9 @@ func (r *repositorySQL) CreateTransaction(ctx context.Context, tx models.Transac
 			"amount",
 			"to_addr",
 			"max_fee_allowed",
+			"multisig_id",
+			"confirmations_required",
+			"status",
 			"created_at",
 			"updated_at",
 		}
@@ -205,9 +331,12 @@ func (r *repositorySQL) CreateTransaction(ctx context.Context, tx models.Transac
 			tx.Description,
 			tx.OrganizationId,
 			tx.CreatedBy.ID,
-			tx.Amount,
+			tx.Amount.Value,
 			tx.ToAddr,
-			tx.MaxFeeAllowed,
+			tx.MaxFeeAllowed.Value,
+			tx.MultisigID,
+			tx.ConfirmationsRequired,
+			tx.Status,
 			tx.CreatedAt,
 			tx.CreatedAt,
 		}
@@ -217,6 +346,11 @@ func (r *repositorySQL) CreateTransaction(ctx context.Context, tx models.Transac
 			values = append(values, tx.Deadline)
 		}
 
+		if tx.AssetID != uuid.Nil {
+			columns = append(columns, "asset_id")
+			values = append(values, tx.AssetID)
+		}
+
 		query := sq.Insert("transactions").
 			Columns(columns...).
 			Values(values...).
@@ -236,8 +370,136 @@ func (r *repositorySQL) CreateTransaction(ctx context.Context, tx models.Transac
 	return nil
 }
 
-func (r *repositorySQL) UpdateTransaction(ctx context.Context, tx models.Transaction) error {
-	return nil
+func (r *repositorySQL) UpdateTransaction(ctx context.Context, params UpdateTransactionParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		updates := sq.Eq{
+			"status":     params.Status,
+			"updated_at": params.UpdatedAt,
+		}
+
+		if params.Status == models.TransactionStatusSubmitted {
+			updates["tx_index"] = params.TxIndex
+
+			if !params.SubmittedAt.IsZero() {
+				updates["submitted_at"] = params.SubmittedAt
+			}
+		}
+
+		if params.TxHash != "" {
+			updates["tx_hash"] = params.TxHash
+		}
+
+		if !params.CommitedAt.IsZero() {
+			updates["commited_at"] = params.CommitedAt
+		}
+
+		query := sq.Update("transactions").
+			SetMap(updates).
+			Where(sq.Eq{
+				"id":              params.TxId,
+				"organization_id": params.OrganizationId,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.FromStatuses) > 0 {
+			query = query.Where(sq.Eq{
+				"status": params.FromStatuses,
+			})
+		}
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error update transaction. %w", err)
+		}
+
+		if len(params.FromStatuses) == 0 {
+			return nil
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorTransactionStatusConflict
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) RevokeConfirmedTransaction(
+	ctx context.Context,
+	params RevokeConfirmedTransactionParams,
+) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Update("transactions").
+			SetMap(sq.Eq{
+				"status":       models.TransactionStatusPending,
+				"confirmed_at": nil,
+				"updated_at":   params.RevokedAt,
+			}).
+			Where(sq.Eq{
+				"id":              params.TxId,
+				"organization_id": params.OrganizationId,
+				"status":          models.TransactionStatusConfirmed,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error return transaction to pending. %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorTransactionStatusConflict
+		}
+
+		// running job may be submitting the transaction right now, so only queued job is cancelled
+		jobsQuery := sq.Update("jobs").
+			SetMap(sq.Eq{
+				"status":       models.JobStatusFailed,
+				"last_error":   "transaction confirmation revoked",
+				"locked_until": nil,
+				"finished_at":  params.RevokedAt,
+				"updated_at":   params.RevokedAt,
+			}).
+			Where(sq.Eq{
+				"organization_id": params.OrganizationId,
+				"kind":            params.ExecuteJobKind,
+				"status":          models.JobStatusQueued,
+			}).
+			Where(sq.Expr("payload->>'tx_id' = ?", params.TxId.String())).
+			PlaceholderFormat(sq.Dollar)
+
+		res, err = jobsQuery.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error cancel transaction execution job. %w", err)
+		}
+
+		affected, err = res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorTransactionStatusConflict
+		}
+
+		return r.RevokeMultisigConfirmation(ctx, RevokeMultisigConfirmationParams{
+			MultisigID: params.MultisigID,
+			OwnerID:    params.OwnerID,
+			RevokedAt:  params.RevokedAt,
+			EntityID:   params.TxId,
+			EntityType: models.MultisigConfirmationEntityTypeTransaction,
+		})
+	})
 }
 
 func (r *repositorySQL) DeleteTransaction(ctx context.Context, tx models.Transaction) error {
@@ -246,20 +508,35 @@ func (r *repositorySQL) DeleteTransaction(ctx context.Context, tx models.Transac
 
 func (r *repositorySQL) ConfirmTransaction(ctx context.Context, params ConfirmTransactionParams) error {
 	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		now := time.Now()
+
 		query := sq.Update("transactions").
 			SetMap(sq.Eq{
-				"confirmed_at": time.Now(),
-				"cancelled_at": nil,
+				"confirmed_at": now,
+				"updated_at":   now,
+				"status":       models.TransactionStatusConfirmed,
 			}).
 			Where(sq.Eq{
 				"id":              params.TxId,
 				"organization_id": params.OrganizationId,
+				"status":          models.TransactionStatusPending,
+				"cancelled_at":    nil,
 			}).PlaceholderFormat(sq.Dollar)
 
-		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
 			return fmt.Errorf("error update confirmed at. %w", err)
 		}
 
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorTransactionStatusConflict
+		}
+
 		return nil
 	}); err != nil {
 		return err
@@ -278,10 +555,22 @@ func (r *repositorySQL) CancelTransaction(ctx context.Context, params CancelTran
 			Where(sq.Eq{
 				"id":              params.TxId,
 				"organization_id": params.OrganizationId,
+				"status":          models.TransactionStatusPending,
+				"cancelled_at":    nil,
 			}).PlaceholderFormat(sq.Dollar)
 
-		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
-			return fmt.Errorf("error update confirmed at. %w", err)
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error update cancelled at. %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorTransactionStatusConflict
 		}
 
 		return nil
@@ -302,20 +591,34 @@ func buildGetTransactionsQuery(params GetTransactionsParams) sq.SelectBuilder {
 		t.to_addr,
 		t.max_fee_allowed,
 		t.deadline,
+		t.multisig_id,
 		t.confirmations_required,
+		t.tx_index,
+		t.tx_hash,
+		t.status,
 		t.created_at,
 		t.updated_at,
 
 		t.confirmed_at,
 		t.cancelled_at,
 		t.commited_at,
+		t.submitted_at,
 		
 		u.seed,
 		u.created_at,
 		u.activated_at,
-		ou.is_admin`,
+		ou.role,
+
+		a.id,
+		a.symbol,
+		a.contract_address,
+		a.decimals,
+		a.chain_id,
+		a.created_by,
+		a.created_at`,
 	).From("transactions as t").
 		InnerJoin("users as u on u.id = t.created_by").
+		LeftJoin("assets as a on a.id = t.asset_id").
 		InnerJoin(
 			`organizations_users as ou on 
 			u.id = ou.user_id and ou.organization_id = t.organization_id`,
@@ -337,9 +640,10 @@ func buildGetTransactionsQuery(params GetTransactionsParams) sq.SelectBuilder {
 	}
 
 	if params.Pending {
-		query = query.InnerJoin("multisig_confirmations as mc on mc.multisig_id = t.multisig_id").Where(
-			sq.Lt{},
-		)
+		query = query.Where(sq.Eq{
+			"t.status":       models.TransactionStatusPending,
+			"t.cancelled_at": nil,
+		})
 	}
 
 	query = query.Limit(uint64(params.Limit))
@@ -414,6 +718,7 @@ func (r *repositorySQL) ListMultisig(
 			"title",
 			"address",
 			"confirmations",
+			"rotation_required_at",
 			"created_at",
 			"updated_at",
 		).From("multisigs").Where(sq.Eq{
@@ -442,6 +747,7 @@ func (r *repositorySQL) ListMultisig(
 				address        []byte
 				title          string
 				confirmations  int
+				rotationAt     sql.NullTime
 				createdAt      time.Time
 				updatedAt      time.Time
 			)
@@ -452,6 +758,7 @@ func (r *repositorySQL) ListMultisig(
 				&title,
 				&address,
 				&confirmations,
+				&rotationAt,
 				&createdAt,
 				&updatedAt,
 			); err != nil {
@@ -464,6 +771,7 @@ func (r *repositorySQL) ListMultisig(
 				Address:               address,
 				OrganizationID:        organizationID,
 				ConfirmationsRequired: confirmations,
+				RotationRequiredAt:    rotationAt.Time,
 				CreatedAt:             createdAt,
 				UpdatedAt:             updatedAt,
 			})
@@ -496,14 +804,21 @@ type ConfirmMultisigParams struct {
 	OrganizationsID uuid.UUID
 	CinfirmedBy     *models.OrganizationUser
 	ConfirmedAt     time.Time
+	// Rejected saves owner rejection instead of confirmation
+	Rejected bool
+
+	EntityID   uuid.UUID
+	EntityType models.MultisigConfirmationEntityType
 }
 
 func (r *repositorySQL) ConfirmMultisig(ctx context.Context, params ConfirmMultisigParams) error {
 	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
 		deleteOldQuery := sq.Delete("multisig_confirmations").
 			Where(sq.Eq{
-				"multisig_id": params.MultisigID,
-				"owner_id":    params.CinfirmedBy.Id(),
+				"multisig_id":           params.MultisigID,
+				"owner_id":              params.CinfirmedBy.Id(),
+				"confirmed_entity_id":   params.EntityID,
+				"confirmed_entity_type": params.EntityType,
 			}).
 			PlaceholderFormat(sq.Dollar)
 
@@ -515,11 +830,17 @@ func (r *repositorySQL) ConfirmMultisig(ctx context.Context, params ConfirmMulti
 			Columns(
 				"multisig_id",
 				"owner_id",
+				"confirmed_entity_id",
+				"confirmed_entity_type",
+				"rejected",
 				"created_at",
 			).
 			Values(
 				params.MultisigID,
 				params.CinfirmedBy.Id(),
+				params.EntityID,
+				params.EntityType,
+				params.Rejected,
 				params.ConfirmedAt,
 			).
 			PlaceholderFormat(sq.Dollar)
@@ -528,10 +849,219 @@ func (r *repositorySQL) ConfirmMultisig(ctx context.Context, params ConfirmMulti
 			return fmt.Errorf("error add multisig confirmation. %w", err)
 		}
 
-		return nil
+		return r.updateConfirmationsCounter(ctx, confirmationsCounterParams{
+			MultisigID: params.MultisigID,
+			EntityID:   params.EntityID,
+			EntityType: params.EntityType,
+			UpdatedAt:  params.ConfirmedAt,
+		})
 	})
 }
 
+type RevokeMultisigConfirmationParams struct {
+	MultisigID uuid.UUID
+	OwnerID    uuid.UUID
+	RevokedAt  time.Time
+
+	EntityID   uuid.UUID
+	EntityType models.MultisigConfirmationEntityType
+}
+
+func (r *repositorySQL) RevokeMultisigConfirmation(
+	ctx context.Context,
+	params RevokeMultisigConfirmationParams,
+) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Delete("multisig_confirmations").
+			Where(sq.Eq{
+				"multisig_id":           params.MultisigID,
+				"owner_id":              params.OwnerID,
+				"confirmed_entity_id":   params.EntityID,
+				"confirmed_entity_type": params.EntityType,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error delete multisig confirmation. %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorConfirmationNotFound
+		}
+
+		return r.updateConfirmationsCounter(ctx, confirmationsCounterParams{
+			MultisigID: params.MultisigID,
+			EntityID:   params.EntityID,
+			EntityType: params.EntityType,
+			UpdatedAt:  params.RevokedAt,
+		})
+	})
+}
+
+type confirmationsCounterParams struct {
+	MultisigID uuid.UUID
+	EntityID   uuid.UUID
+	EntityType models.MultisigConfirmationEntityType
+	UpdatedAt  time.Time
+}
+
+// updateConfirmationsCounter recounts entity confirmations, so counter does not drift on repeated confirmations.
+// Rejections are not counted
+func (r *repositorySQL) updateConfirmationsCounter(ctx context.Context, params confirmationsCounterParams) error {
+	countQuery := sq.Select("count(*)").
+		From("multisig_confirmations").
+		Where(sq.Eq{
+			"multisig_id":           params.MultisigID,
+			"confirmed_entity_id":   params.EntityID,
+			"confirmed_entity_type": params.EntityType,
+			"rejected":              false,
+		}).
+		PlaceholderFormat(sq.Dollar)
+
+	var count int64
+
+	if err := countQuery.RunWith(r.Conn(ctx)).QueryRowContext(ctx).Scan(&count); err != nil {
+		return fmt.Errorf("error count multisig confirmations. %w", err)
+	}
+
+	query := sq.Insert("multisig_confirmations_counter").
+		Columns(
+			"multisig_id",
+			"confirmed_entity_id",
+			"confirmed_entity_type",
+			"count",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			params.MultisigID,
+			params.EntityID,
+			params.EntityType,
+			count,
+			params.UpdatedAt,
+			params.UpdatedAt,
+		).
+		Suffix(
+			"on conflict (multisig_id, confirmed_entity_id, confirmed_entity_type) " +
+				"do update set count = excluded.count, updated_at = excluded.updated_at",
+		).
+		PlaceholderFormat(sq.Dollar)
+
+	if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+		return fmt.Errorf("error update multisig confirmations counter. %w", err)
+	}
+
+	return nil
+}
+
+type MultisigConfirmationsCountParams struct {
+	MultisigID uuid.UUID
+	EntityID   uuid.UUID
+	EntityType models.MultisigConfirmationEntityType
+}
+
+func (r *repositorySQL) MultisigConfirmationsCount(
+	ctx context.Context,
+	params MultisigConfirmationsCountParams,
+) (int, error) {
+	var count int64
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Select("count").
+			From("multisig_confirmations_counter").
+			Where(sq.Eq{
+				"multisig_id":           params.MultisigID,
+				"confirmed_entity_id":   params.EntityID,
+				"confirmed_entity_type": params.EntityType,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		if err := query.RunWith(r.Conn(ctx)).QueryRowContext(ctx).Scan(&count); err != nil {
+			// entity has not been confirmed yet
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+
+			return fmt.Errorf("error fetch multisig confirmations counter. %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+type MultisigConfirmationsParams struct {
+	MultisigID uuid.UUID
+	EntityIDs  uuid.UUIDs
+	EntityType models.MultisigConfirmationEntityType
+	// Rejected returns owners rejected the entities instead
+	Rejected bool
+}
+
+// MultisigConfirmations returns ids of the owners confirmed each entity
+func (r *repositorySQL) MultisigConfirmations(
+	ctx context.Context,
+	params MultisigConfirmationsParams,
+) (map[uuid.UUID]uuid.UUIDs, error) {
+	confirmations := make(map[uuid.UUID]uuid.UUIDs, len(params.EntityIDs))
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"confirmed_entity_id",
+			"owner_id",
+		).From("multisig_confirmations").
+			Where(sq.Eq{
+				"confirmed_entity_id":   params.EntityIDs,
+				"confirmed_entity_type": params.EntityType,
+				"rejected":              params.Rejected,
+			}).
+			OrderBy("created_at").
+			PlaceholderFormat(sq.Dollar)
+
+		if params.MultisigID != uuid.Nil {
+			query = query.Where(sq.Eq{
+				"multisig_id": params.MultisigID,
+			})
+		}
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch multisig confirmations from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var entityID, ownerID uuid.UUID
+
+			if err = rows.Scan(&entityID, &ownerID); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			confirmations[entityID] = append(confirmations[entityID], ownerID)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return confirmations, nil
+}
+
 type AddPayrollContract struct {
 	ID             uuid.UUID
 	Title          string
@@ -540,6 +1070,8 @@ type AddPayrollContract struct {
 	Payload        []byte
 	OrganizationID uuid.UUID
 	MultisigID     uuid.UUID
+	Status         models.PayrollStatus
+	CreatedBy      uuid.UUID
 	CreatedAt      time.Time
 }
 
@@ -557,7 +1089,10 @@ func (r *repositorySQL) AddPayrollContract(
 				"payload",
 				"organization_id",
 				"multisig_id",
+				"status",
+				"created_by",
 				"created_at",
+				"updated_at",
 			).
 			Values(
 				params.ID,
@@ -567,6 +1102,9 @@ func (r *repositorySQL) AddPayrollContract(
 				params.Payload,
 				params.OrganizationID,
 				params.MultisigID,
+				params.Status,
+				uuid.NullUUID{UUID: params.CreatedBy, Valid: params.CreatedBy != uuid.Nil},
+				params.CreatedAt,
 				params.CreatedAt,
 			).
 			PlaceholderFormat(sq.Dollar)
@@ -644,9 +1182,11 @@ func (r *repositorySQL) ListPayrolls(ctx context.Context, params ListPayrollsPar
 			"address",
 			"organization_id",
 			"multisig_id",
+			"status",
+			"created_by",
 			"created_at",
 			"updated_at",
-		).Where(sq.Eq{
+		).From("payrolls").Where(sq.Eq{
 			"organization_id": params.OrganizationID,
 		}).PlaceholderFormat(sq.Dollar)
 
@@ -676,6 +1216,8 @@ func (r *repositorySQL) ListPayrolls(ctx context.Context, params ListPayrollsPar
 				address        []byte
 				organizationId uuid.UUID
 				multisigId     uuid.UUID
+				status         int
+				createdBy      uuid.NullUUID
 				createdAt      time.Time
 				updatedAt      time.Time
 			)
@@ -686,6 +1228,8 @@ func (r *repositorySQL) ListPayrolls(ctx context.Context, params ListPayrollsPar
 				&address,
 				&organizationId,
 				&multisigId,
+				&status,
+				&createdBy,
 				&createdAt,
 				&updatedAt,
 			); err != nil {
@@ -698,6 +1242,8 @@ func (r *repositorySQL) ListPayrolls(ctx context.Context, params ListPayrollsPar
 				Address:        address,
 				OrganizationID: organizationId,
 				MultisigID:     multisigId,
+				Status:         models.PayrollStatus(status),
+				CreatedBy:      createdBy.UUID,
 				CreatedAt:      createdAt,
 				UpdatedAt:      updatedAt,
 			})
@@ -710,3 +1256,278 @@ func (r *repositorySQL) ListPayrolls(ctx context.Context, params ListPayrollsPar
 
 	return payrolls, nil
 }
+
+type UpdatePayrollParams struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Status         models.PayrollStatus
+	FromStatuses   []models.PayrollStatus
+	// Address is saved if set
+	Address   []byte
+	UpdatedAt time.Time
+}
+
+func (r *repositorySQL) UpdatePayroll(ctx context.Context, params UpdatePayrollParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		values := sq.Eq{
+			"status":     params.Status,
+			"updated_at": params.UpdatedAt,
+		}
+
+		if len(params.Address) > 0 {
+			values["address"] = params.Address
+		}
+
+		query := sq.Update("payrolls").
+			SetMap(values).
+			Where(sq.Eq{
+				"id":              params.ID,
+				"organization_id": params.OrganizationID,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.FromStatuses) > 0 {
+			query = query.Where(sq.Eq{
+				"status": params.FromStatuses,
+			})
+		}
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error update payroll. %w", err)
+		}
+
+		if len(params.FromStatuses) == 0 {
+			return nil
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorPayrollStatusConflict
+		}
+
+		return nil
+	})
+}
+
+func (r *repositorySQL) AddSalary(ctx context.Context, salary models.Salary) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		query := sq.Insert("salaries").
+			Columns(
+				"id",
+				"organization_id",
+				"payroll_id",
+				"employee_id",
+				"employee_address",
+				"amount",
+				"status",
+				"created_by",
+				"created_at",
+				"updated_at",
+			).
+			Values(
+				salary.ID,
+				salary.OrganizationID,
+				salary.PayrollID,
+				salary.EmployeeID,
+				salary.EmployeeAddress,
+				salary.Amount.Value,
+				salary.Status,
+				salary.CreatedBy,
+				salary.CreatedAt,
+				salary.UpdatedAt,
+			).
+			PlaceholderFormat(sq.Dollar)
+
+		if _, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx); err != nil {
+			return fmt.Errorf("error add new salary. %w", err)
+		}
+
+		return nil
+	})
+}
+
+type UpdateSalaryParams struct {
+	ID           uuid.UUID
+	Status       models.SalaryStatus
+	FromStatuses []models.SalaryStatus
+	// TxIndex is saved with SalaryStatusSubmitted status
+	TxIndex   int64
+	TxHash    string
+	UpdatedAt time.Time
+}
+
+func (r *repositorySQL) UpdateSalary(ctx context.Context, params UpdateSalaryParams) error {
+	return sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
+		values := sq.Eq{
+			"status":     params.Status,
+			"updated_at": params.UpdatedAt,
+		}
+
+		if params.Status == models.SalaryStatusSubmitted {
+			values["tx_index"] = params.TxIndex
+		}
+
+		if params.TxHash != "" {
+			values["tx_hash"] = params.TxHash
+		}
+
+		query := sq.Update("salaries").
+			SetMap(values).
+			Where(sq.Eq{
+				"id": params.ID,
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		if len(params.FromStatuses) > 0 {
+			query = query.Where(sq.Eq{
+				"status": params.FromStatuses,
+			})
+		}
+
+		res, err := query.RunWith(r.Conn(ctx)).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error update salary. %w", err)
+		}
+
+		if len(params.FromStatuses) == 0 {
+			return nil
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error fetch affected rows. %w", err)
+		}
+
+		if affected == 0 {
+			return ErrorSalaryStatusConflict
+		}
+
+		return nil
+	})
+}
+
+type ListSalariesParams struct {
+	IDs            uuid.UUIDs
+	OrganizationID uuid.UUID
+	PayrollIDs     uuid.UUIDs
+	EmployeeIDs    uuid.UUIDs
+	Limit          int64
+}
+
+func (r *repositorySQL) ListSalaries(ctx context.Context, params ListSalariesParams) ([]models.Salary, error) {
+	salaries := make([]models.Salary, 0, len(params.IDs))
+
+	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		query := sq.Select(
+			"id",
+			"organization_id",
+			"payroll_id",
+			"employee_id",
+			"employee_address",
+			"amount",
+			"status",
+			"tx_index",
+			"tx_hash",
+			"created_by",
+			"created_at",
+			"updated_at",
+		).From("salaries").Where(sq.Eq{
+			"organization_id": params.OrganizationID,
+		}).OrderBy("created_at desc").PlaceholderFormat(sq.Dollar)
+
+		if len(params.IDs) > 0 {
+			query = query.Where(sq.Eq{
+				"id": params.IDs,
+			})
+		}
+
+		if len(params.PayrollIDs) > 0 {
+			query = query.Where(sq.Eq{
+				"payroll_id": params.PayrollIDs,
+			})
+		}
+
+		if len(params.EmployeeIDs) > 0 {
+			query = query.Where(sq.Eq{
+				"employee_id": params.EmployeeIDs,
+			})
+		}
+
+		if params.Limit <= 0 {
+			params.Limit = 100
+		}
+
+		query = query.Limit(uint64(params.Limit))
+
+		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
+		if err != nil {
+			return fmt.Errorf("error fetch salaries from database. %w", err)
+		}
+
+		defer func() {
+			if cErr := rows.Close(); cErr != nil {
+				err = errors.Join(fmt.Errorf("error close database rows. %w", cErr), err)
+			}
+		}()
+
+		for rows.Next() {
+			var (
+				id              uuid.UUID
+				organizationID  uuid.UUID
+				payrollID       uuid.UUID
+				employeeID      uuid.UUID
+				employeeAddress []byte
+				amount          money.Decimal
+				status          int
+				txIndex         sql.NullInt64
+				txHash          sql.NullString
+				createdBy       uuid.UUID
+				createdAt       time.Time
+				updatedAt       time.Time
+			)
+
+			if err = rows.Scan(
+				&id,
+				&organizationID,
+				&payrollID,
+				&employeeID,
+				&employeeAddress,
+				&amount,
+				&status,
+				&txIndex,
+				&txHash,
+				&createdBy,
+				&createdAt,
+				&updatedAt,
+			); err != nil {
+				return fmt.Errorf("error scan row. %w", err)
+			}
+
+			salaries = append(salaries, models.Salary{
+				ID:              id,
+				OrganizationID:  organizationID,
+				PayrollID:       payrollID,
+				EmployeeID:      employeeID,
+				EmployeeAddress: employeeAddress,
+				Amount:          money.New(amount, money.USD),
+				Status:          models.SalaryStatus(status),
+				TxIndex:         txIndex.Int64,
+				TxHash:          txHash.String,
+				CreatedBy:       createdBy,
+				CreatedAt:       createdAt,
+				UpdatedAt:       updatedAt,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return salaries, nil
+}
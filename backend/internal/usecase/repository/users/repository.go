@@ -9,6 +9,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/emochka2007/block-accounting/internal/pkg/models"
+	"github.com/emochka2007/block-accounting/internal/pkg/secrets"
 	sqltools "github.com/emochka2007/block-accounting/internal/pkg/sqlutils"
 	"github.com/google/uuid"
 )
@@ -17,7 +18,8 @@ type GetParams struct {
 	Ids            uuid.UUIDs
 	PKs            [][]byte
 	OrganizationId uuid.UUID
-	Seed           []byte
+	// Seed is matched by its digest, seeds are stored encrypted
+	Seed []byte
 }
 
 // todo implement
@@ -30,12 +32,14 @@ type Repository interface {
 }
 
 type repositorySQL struct {
-	db *sql.DB
+	db     *sql.DB
+	keeper *secrets.Keeper
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *sql.DB, keeper *secrets.Keeper) Repository {
 	return &repositorySQL{
-		db: db,
+		db:     db,
+		keeper: keeper,
 	}
 }
 
@@ -76,11 +80,9 @@ func (r *repositorySQL) Get(ctx context.Context, params GetParams) ([]*models.Us
 		}
 
 		if params.Seed != nil {
-			query = query.Where("u.seed = ?", params.Seed)
-		}
-
-		if params.PKs != nil {
-			fmt.Println(query.ToSql())
+			query = query.Where(sq.Eq{
+				"u.seed_hash": r.keeper.Digests(params.Seed),
+			})
 		}
 
 		rows, err := query.RunWith(r.Conn(ctx)).QueryContext(ctx)
@@ -108,7 +110,7 @@ func (r *repositorySQL) Get(ctx context.Context, params GetParams) ([]*models.Us
 				//isAdmin     bool
 				createdAt   time.Time
 				activatedAt sql.NullTime
-				mnemonic    string
+				mnemonic    []byte
 			)
 
 			if err = rows.Scan(
@@ -126,6 +128,14 @@ func (r *repositorySQL) Get(ctx context.Context, params GetParams) ([]*models.Us
 				return fmt.Errorf("error scan row. %w", err)
 			}
 
+			if seed, err = r.keeper.Decrypt(seed); err != nil {
+				return fmt.Errorf("error decrypt user seed. %w", err)
+			}
+
+			if mnemonic, err = r.keeper.Decrypt(mnemonic); err != nil {
+				return fmt.Errorf("error decrypt user mnemonic. %w", err)
+			}
+
 			users = append(users, &models.User{
 				ID:   id,
 				Name: name,
@@ -136,7 +146,7 @@ func (r *repositorySQL) Get(ctx context.Context, params GetParams) ([]*models.Us
 				},
 				Bip39Seed: seed,
 				PK:        pk,
-				Mnemonic:  mnemonic,
+				Mnemonic:  string(mnemonic),
 				//Admin:     isAdmin,
 				CreatedAt: createdAt,
 				Activated: activatedAt.Valid,
@@ -152,8 +162,23 @@ func (r *repositorySQL) Get(ctx context.Context, params GetParams) ([]*models.Us
 }
 
 func (r *repositorySQL) Create(ctx context.Context, user *models.User) error {
+	seed, err := r.keeper.Encrypt(user.Bip39Seed)
+	if err != nil {
+		return fmt.Errorf("error encrypt user seed. %w", err)
+	}
+
+	mnemonic, err := r.keeper.Encrypt([]byte(user.Mnemonic))
+	if err != nil {
+		return fmt.Errorf("error encrypt user mnemonic. %w", err)
+	}
+
+	var seedHash []byte
+	if len(user.Bip39Seed) > 0 {
+		seedHash = r.keeper.Digest(user.Bip39Seed)
+	}
+
 	if err := sqltools.Transaction(ctx, r.db, func(ctx context.Context) error {
-		columns := []string{"id", "name", "email", "phone", "tg", "seed", "public_key", "mnemonic", "created_at"}
+		columns := []string{"id", "name", "email", "phone", "tg", "seed", "seed_hash", "public_key", "mnemonic", "created_at"}
 
 		values := []any{
 			user.ID,
@@ -161,9 +186,10 @@ func (r *repositorySQL) Create(ctx context.Context, user *models.User) error {
 			user.Credentails.Email,
 			user.Credentails.Phone,
 			user.Credentails.Telegram,
-			user.Bip39Seed,
+			seed,
+			seedHash,
 			user.PK,
-			user.Mnemonic,
+			mnemonic,
 			user.CreatedAt,
 		}
 
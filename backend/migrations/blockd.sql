@@ -5,15 +5,19 @@ create table if not exists users (
         phone varchar(16),
         tg varchar(200),
         public_key bytea not null unique,
-        mnemonic varchar(500) not null,
-        seed bytea not null unique,
+        -- mnemonic and seed are envelope encrypted, null for users joined with wallet signature.
+        -- seed_hash is the keyed digest of the seed used for lookups
+        mnemonic bytea default null,
+        seed bytea default null,
+        seed_hash bytea default null unique,
         created_at timestamp default current_timestamp,
         updated_at timestamp default current_timestamp,
         activated_at  timestamp default null
 );
 
-create index if not exists index_users_seed
-        on users (seed); 
+-- users created before wallet signature login had mnemonic and seed required
+alter table users alter column mnemonic drop not null;
+alter table users alter column seed drop not null;
 
 create index if not exists index_users_public_key
         on users (public_key); 
@@ -21,37 +25,82 @@ create index if not exists index_users_public_key
 create index if not exists index_users_name
         on users using hash (name); 
 
-create index if not exists index_users_seed
-        on users using hash (seed); 
+create index if not exists index_users_seed_hash
+        on users using hash (seed_hash); 
 
 create table if not exists access_tokens (
+        id uuid primary key default gen_random_uuid(),
         user_id uuid not null references users(id),
         token varchar(350) not null, 
         token_expired_at timestamp, 
         refresh_token varchar(350) not null, 
         refresh_token_expired_at timestamp, 
         created_at timestamp default current_timestamp,
-        remote_addr varchar(100)
+        remote_addr varchar(100),
+        user_agent varchar(300) default null,
+        revoked_at timestamp default null
 );
 
+-- access tokens created before sessions had no session id
+alter table access_tokens add column if not exists id uuid default gen_random_uuid();
+alter table access_tokens add column if not exists user_agent varchar(300) default null;
+alter table access_tokens add column if not exists revoked_at timestamp default null;
+
+update access_tokens set id = gen_random_uuid() where id is null;
+
+create unique index if not exists index_access_tokens_id
+        on access_tokens (id);
+
+create index if not exists index_access_tokens_user_id
+        on access_tokens (user_id);
+
 create index if not exists index_access_tokens_token_refresh_token
         on access_tokens (token, refresh_token); 
 
 create index if not exists index_access_tokens_token_refresh_token_exp
         on access_tokens (token, refresh_token, token_expired_at, refresh_token_expired_at); 
 
+create table if not exists auth_nonces (
+        nonce varchar(64) primary key,
+        address bytea not null,
+        message text not null,
+        created_at timestamp default current_timestamp,
+        expired_at timestamp not null,
+        used_at timestamp default null
+);
+
 create table if not exists organizations (
         id uuid primary key unique, 
         name varchar(300) default 'My Organization' not null, 
         address varchar(750) not null, 
+        -- envelope encrypted
         wallet_seed bytea not null,
         created_at timestamp default current_timestamp,
-        updated_at timestamp default current_timestamp
+        updated_at timestamp default current_timestamp,
+        archived_at timestamp default null
 );
 
+alter table organizations add column if not exists archived_at timestamp default null;
+
 create index if not exists index_organizations_id
         on organizations (id); 
 
+create table if not exists ownership_transfers (
+        id uuid primary key,
+        organization_id uuid not null references organizations(id),
+        from_user_id uuid not null references users(id),
+        to_user_id uuid not null references users(id),
+        status smallint default 0,
+        created_by uuid not null references users(id),
+        created_at timestamp default current_timestamp,
+        updated_at timestamp default current_timestamp,
+        confirmed_at timestamp default null,
+        cancelled_at timestamp default null
+);
+
+create unique index if not exists index_ownership_transfers_organization_id_pending
+        on ownership_transfers (organization_id) where status = 0;
+
 create table employees (
         id uuid primary key, 
         name varchar(250) default 'Employee',
@@ -76,13 +125,35 @@ create table organizations_users (
         added_at timestamp default current_timestamp,
         updated_at timestamp default current_timestamp,
         deleted_at timestamp default null,
-        is_admin bool default false,
-        is_owner bool default false,
+        role smallint default 1,
         primary key(organization_id, user_id, employee_id)
 );
 
-create index if not exists index_organizations_users_organization_id_user_id_is_admin
-        on organizations_users (organization_id, user_id, is_admin); 
+-- organizations users created before roles had is_owner and is_admin flags, they are mapped
+-- into owner (5) and admin (4) roles, others get the viewer role
+alter table organizations_users add column if not exists role smallint default 1;
+
+do $$
+begin
+        if exists (
+                select 1 from information_schema.columns
+                where table_name = 'organizations_users' and column_name = 'is_owner'
+        ) then
+                update organizations_users set role = case
+                        when is_owner then 5
+                        when is_admin then 4
+                        else 1
+                end;
+
+                drop index if exists index_organizations_users_organization_id_user_id_is_admin;
+
+                alter table organizations_users drop column is_owner;
+                alter table organizations_users drop column is_admin;
+        end if;
+end $$;
+
+create index if not exists index_organizations_users_organization_id_user_id_role
+        on organizations_users (organization_id, user_id, role); 
 
 create index if not exists index_organizations_users_organization_id_user_id
         on organizations_users (organization_id, user_id); 
@@ -99,10 +170,13 @@ create table multisigs (
         address bytea not null,
         confirmations smallint default 0,
         title varchar(350) default 'New Multi-Sig',
+        rotation_required_at timestamp default null,
         created_at timestamp default current_timestamp,
         updated_at timestamp default current_timestamp
 );
 
+alter table multisigs add column if not exists rotation_required_at timestamp default null;
+
 create table multisig_owners (
         multisig_id uuid references multisigs(id), 
         owner_id uuid references users(id), 
@@ -122,10 +196,13 @@ create table multisig_confirmations (
         owner_id uuid references users(id), 
         confirmed_entity_id uuid not null, 
         confirmed_entity_type smallint default 0,
-        created_at timestamp default current_timestamp
-        primary key (multisig_id, owner_id)
+        rejected boolean default false,
+        created_at timestamp default current_timestamp,
+        primary key (multisig_id, owner_id, confirmed_entity_id)
 );
 
+alter table multisig_confirmations add column if not exists rejected boolean default false;
+
 create index if not exists  idx_multisig_confirmations_owners_multisig_id
         on multisig_confirmations (multisig_id);
 
@@ -144,37 +221,67 @@ create table multisig_confirmations_counter (
         count bigint default 0
 );
 
-create index if not exists  idx_multisig_confirmations_counter_multisig_id_confirmed_entity_id
-        on multisig_confirmations (multisig_id, confirmed_entity_id);
+create unique index if not exists  idx_multisig_confirmations_counter_multisig_id_confirmed_entity_id
+        on multisig_confirmations_counter (multisig_id, confirmed_entity_id, confirmed_entity_type);
+
+create index if not exists  idx_multisig_confirmations_confirmed_entity_id_type
+        on multisig_confirmations (confirmed_entity_id, confirmed_entity_type);
 
 create table invites (
         link_hash varchar(64) primary key, 
         organization_id uuid, 
         created_by uuid not null references users(id),
+        role smallint default 1,
+        position varchar(300) default null,
+        public_key bytea default null,
+        max_uses int default 1,
+        uses int default 0,
         created_at timestamp default current_timestamp,
         expired_at timestamp default null,
-        used_at timestamp default null
+        used_at timestamp default null,
+        revoked_at timestamp default null
 );
 
+alter table invites add column if not exists role smallint default 1;
+alter table invites add column if not exists position varchar(300) default null;
+alter table invites add column if not exists public_key bytea default null;
+alter table invites add column if not exists max_uses int default 1;
+alter table invites add column if not exists uses int default 0;
+alter table invites add column if not exists revoked_at timestamp default null;
+
+create index if not exists index_invites_organization_id
+        on invites (organization_id);
+
+create index if not exists index_invites_public_key
+        on invites (public_key);
+
 create table payrolls (
         id uuid primary key, 
         title varchar(250) default 'New Payroll', 
         description text not null, 
-        address bytea not null, 
+        address bytea default null, 
         payload bytea default null,
         organization_id uuid not null references organizations(id), 
         tx_index bytea default null,
         multisig_id uuid references multisigs(id),
+        status int default 0,
+        created_by uuid default null references users(id),
         created_at timestamp default current_timestamp,
         updated_at timestamp default current_timestamp
 );
 
+-- payrolls created before confirmations were deployed right away, default status 0 marks them deployed
+alter table payrolls alter column address drop not null;
+alter table payrolls add column if not exists status int default 0;
+alter table payrolls add column if not exists created_by uuid default null references users(id);
+
 create table if not exists transactions (
         id uuid primary key,
         description text default 'New Transaction', 
         organization_id uuid not null, 
         created_by uuid  not null, 
         amount decimal default 0,
+        asset_id uuid default null,
 
         to_addr bytea not null,
         tx_index bigint default 0,
@@ -182,8 +289,8 @@ create table if not exists transactions (
         max_fee_allowed decimal default 0, 
         deadline timestamp default null,
         confirmations_required bigint default 1,
-        multisig_id uuid not null, 
         multisig_id uuid default null,
+        tx_hash varchar(66) default null,
 
         status int default 0,
 
@@ -192,10 +299,16 @@ create table if not exists transactions (
 
         confirmed_at timestamp default null,
         cancelled_at timestamp default null,
+        submitted_at timestamp default null,
 
         commited_at timestamp default null
 );
 
+alter table transactions add column if not exists asset_id uuid default null;
+alter table transactions add column if not exists tx_hash varchar(66) default null;
+alter table transactions add column if not exists submitted_at timestamp default null;
+alter table transactions alter column multisig_id drop not null;
+
 create index if not exists index_transactions_id_organization_id
         on transactions (organization_id); 
 
@@ -204,3 +317,258 @@ create index if not exists index_transactions_id_organization_id_created_by
 
 create index if not exists index_transactions_organization_id_deadline
         on transactions (organization_id, deadline); 
+
+create table if not exists jobs (
+        id uuid primary key,
+        organization_id uuid not null references organizations(id),
+        created_by uuid not null references users(id),
+        kind varchar(64) not null,
+        payload jsonb not null default '{}',
+        result jsonb default null,
+
+        status int default 0,
+        attempts int default 0,
+        max_attempts int default 5,
+        last_error text default null,
+
+        run_at timestamp default current_timestamp,
+        lease_owner uuid default null,
+        locked_until timestamp default null,
+
+        created_at timestamp default current_timestamp,
+        updated_at timestamp default current_timestamp,
+        started_at timestamp default null,
+        finished_at timestamp default null
+);
+
+alter table jobs add column if not exists lease_owner uuid default null;
+
+create index if not exists index_jobs_organization_id
+        on jobs (organization_id);
+
+create index if not exists index_jobs_status_run_at
+        on jobs (status, run_at);
+
+create table if not exists salaries (
+        id uuid primary key,
+        organization_id uuid not null references organizations(id),
+        payroll_id uuid not null references payrolls(id),
+        employee_id uuid not null,
+        employee_address bytea not null,
+        amount decimal default 0,
+        status int default 0,
+        tx_index bigint default null,
+        tx_hash varchar(66) default null,
+        created_by uuid not null references users(id),
+        created_at timestamp default current_timestamp,
+        updated_at timestamp default current_timestamp
+);
+
+create index if not exists index_salaries_organization_id_payroll_id
+        on salaries (organization_id, payroll_id);
+
+create index if not exists index_salaries_organization_id_employee_id
+        on salaries (organization_id, employee_id);
+
+create table if not exists payout_runs (
+        id uuid primary key,
+        organization_id uuid not null references organizations(id),
+        payroll_id uuid not null references payrolls(id),
+        multisig_id uuid not null references multisigs(id),
+        status int default 0,
+        deposit_amount decimal default 0,
+        deposit_tx_hash varchar(66) default null,
+        created_by uuid not null references users(id),
+        created_at timestamp default current_timestamp,
+        updated_at timestamp default current_timestamp,
+        executed_at timestamp default null
+);
+
+create index if not exists index_payout_runs_organization_id_payroll_id
+        on payout_runs (organization_id, payroll_id);
+
+create table if not exists payments (
+        id uuid primary key,
+        payout_run_id uuid not null references payout_runs(id),
+        organization_id uuid not null references organizations(id),
+        payroll_id uuid not null references payrolls(id),
+        salary_id uuid not null references salaries(id),
+        employee_id uuid not null,
+        employee_address bytea not null,
+        amount decimal default 0,
+        status int default 0,
+        tx_index bigint default null,
+        tx_hash varchar(66) default null,
+        created_at timestamp default current_timestamp,
+        updated_at timestamp default current_timestamp,
+        paid_at timestamp default null
+);
+
+create index if not exists index_payments_payout_run_id
+        on payments (payout_run_id);
+
+create index if not exists index_payments_organization_id_employee_id
+        on payments (organization_id, employee_id);
+
+create table if not exists payroll_deposits (
+        id uuid primary key,
+        organization_id uuid not null references organizations(id),
+        payroll_id uuid not null references payrolls(id),
+        payout_run_id uuid default null references payout_runs(id),
+        amount decimal default 0,
+        tx_hash varchar(66) not null,
+        created_by uuid not null references users(id),
+        created_at timestamp default current_timestamp
+);
+
+create index if not exists index_payroll_deposits_organization_id_payroll_id
+        on payroll_deposits (organization_id, payroll_id);
+
+create table if not exists licenses (
+        id uuid primary key,
+        organization_id uuid not null references organizations(id),
+        multisig_id uuid not null references multisigs(id),
+        title varchar(250) default 'New License',
+        address bytea default null,
+        status int default 0,
+        oracle_url text default null,
+        payroll_id uuid default null references payrolls(id),
+        created_by uuid not null references users(id),
+        created_at timestamp default current_timestamp,
+        updated_at timestamp default current_timestamp
+);
+
+create index if not exists index_licenses_organization_id
+        on licenses (organization_id);
+
+create table if not exists license_shareholders (
+        license_id uuid not null references licenses(id),
+        participant_id uuid default null,
+        address bytea not null,
+        share int not null,
+        primary key (license_id, address)
+);
+
+create table if not exists license_operations (
+        id uuid primary key,
+        license_id uuid not null references licenses(id),
+        organization_id uuid not null references organizations(id),
+        multisig_id uuid not null references multisigs(id),
+        kind int not null,
+        status int default 0,
+        url text default null,
+        payroll_id uuid default null references payrolls(id),
+        tx_index bigint default null,
+        tx_hash varchar(66) default null,
+        created_by uuid not null references users(id),
+        created_at timestamp default current_timestamp,
+        updated_at timestamp default current_timestamp,
+        executed_at timestamp default null
+);
+
+create index if not exists index_license_operations_organization_id_license_id
+        on license_operations (organization_id, license_id);
+
+create table if not exists agreements (
+        id uuid primary key,
+        organization_id uuid not null references organizations(id),
+        multisig_id uuid not null references multisigs(id),
+        title varchar(250) default 'New Agreement',
+        address bytea default null,
+        status int default 0,
+        request_url text default null,
+        outcome boolean default null,
+        resolved_at timestamp default null,
+        created_by uuid not null references users(id),
+        created_at timestamp default current_timestamp,
+        updated_at timestamp default current_timestamp
+);
+
+create index if not exists index_agreements_organization_id
+        on agreements (organization_id);
+
+create table if not exists agreement_operations (
+        id uuid primary key,
+        agreement_id uuid not null references agreements(id),
+        organization_id uuid not null references organizations(id),
+        multisig_id uuid not null references multisigs(id),
+        kind int not null,
+        status int default 0,
+        url text default null,
+        tx_index bigint default null,
+        tx_hash varchar(66) default null,
+        created_by uuid not null references users(id),
+        created_at timestamp default current_timestamp,
+        updated_at timestamp default current_timestamp,
+        executed_at timestamp default null
+);
+
+create index if not exists index_agreement_operations_organization_id_agreement_id
+        on agreement_operations (organization_id, agreement_id);
+
+create table if not exists ledger_accounts (
+        id uuid primary key,
+        organization_id uuid not null references organizations(id),
+        code varchar(32) not null,
+        name varchar(250) not null,
+        type smallint not null,
+        created_at timestamp default current_timestamp,
+        unique (organization_id, code)
+);
+
+create table if not exists ledger_entries (
+        id uuid primary key,
+        organization_id uuid not null references organizations(id),
+        description text default null,
+        source_type varchar(32) not null,
+        source_id uuid not null,
+        occurred_at timestamp not null,
+        created_by uuid default null references users(id),
+        created_at timestamp default current_timestamp,
+        unique (organization_id, source_type, source_id)
+);
+
+create index if not exists index_ledger_entries_organization_id_occurred_at
+        on ledger_entries (organization_id, occurred_at);
+
+create table if not exists ledger_lines (
+        id uuid primary key,
+        entry_id uuid not null references ledger_entries(id),
+        account_id uuid not null references ledger_accounts(id),
+        currency varchar(16) not null,
+        debit decimal default 0,
+        credit decimal default 0
+);
+
+create index if not exists index_ledger_lines_entry_id
+        on ledger_lines (entry_id);
+
+create index if not exists index_ledger_lines_account_id
+        on ledger_lines (account_id);
+
+create table if not exists assets (
+        id uuid primary key,
+        organization_id uuid not null references organizations(id),
+        symbol varchar(16) not null,
+        contract_address bytea not null,
+        decimals smallint not null,
+        chain_id bigint not null,
+        created_by uuid not null references users(id),
+        created_at timestamp default current_timestamp,
+        unique (organization_id, symbol),
+        unique (organization_id, chain_id, contract_address)
+);
+
+create table if not exists prices (
+        id uuid primary key,
+        organization_id uuid not null references organizations(id),
+        source_type varchar(32) not null,
+        source_id uuid not null,
+        base varchar(16) not null,
+        quote varchar(16) not null,
+        rate decimal not null,
+        provider varchar(32) not null,
+        observed_at timestamp not null,
+        created_at timestamp default current_timestamp,
+        unique (organization_id, source_type, source_id, base, quote)
+);